@@ -488,6 +488,7 @@ type ComponentDefinitionSpec struct {
 	//     This approach aims to minimize downtime and maintain availability in systems with a leader-follower topology,
 	//     such as before planned maintenance or upgrades on the current leader node.
 	//   - `memberJoin`: Defines the procedure to add a new replica to the replication group.
+	//   - `preScaleIn`: Defines the procedure to move the data off a replica before it is removed from the replication group.
 	//   - `memberLeave`: Defines the method to remove a replica from the replication group.
 	//   - `readOnly`: Defines the procedure to switch a replica into the read-only state.
 	//   - `readWrite`: transition a replica from the read-only state back to the read-write state.
@@ -1384,6 +1385,64 @@ type ComponentLifecycleActions struct {
 	// +optional
 	Switchover *Action `json:"switchover,omitempty"`
 
+	// Defines the procedure to query the replication lag of a replica relative to the current leader.
+	//
+	// Use Case:
+	// When a switchover is requested without specifying a candidate, KubeBlocks invokes this action on each
+	// eligible replica and chooses the one with the smallest lag as the new leader candidate.
+	//
+	// The container executing this action has access to following variables:
+	//
+	// - KB_POD_FQDN: The FQDN of the replica pod whose replication lag is being queried.
+	//
+	// Expected action output:
+	// - On Success: A non-negative integer representing the replication lag (e.g., in bytes or seconds),
+	//   where a smaller value indicates a more up-to-date replica.
+	// - On Failure: An error message, if applicable, indicating why the action failed.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	ReplicationLag *Action `json:"replicationLag,omitempty"`
+
+	// Defines the procedure to check whether a replica is healthy and ready to serve, e.g. has caught up with the leader.
+	//
+	// Use Case:
+	// This action is used to gate the rolling operations, such as a "Restart" OpsRequest with a health gate specified,
+	// the next replica will not be touched until the restarted replica passes the check.
+	//
+	// The container executing this action has access to following variables:
+	//
+	// - KB_POD_FQDN: The FQDN of the replica pod being checked.
+	//
+	// Expected action output:
+	// - On Failure: An error message, if applicable, indicating why the replica is not healthy.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	HealthCheck *Action `json:"healthCheck,omitempty"`
+
+	// Defines the procedure to report the disk usage of the volumes of a replica.
+	//
+	// Use Case:
+	// This action is used by the StorageAutoscalingPolicy with the "KBAgent" usage source, for the environments
+	// where the kubelet stats are not accessible or do not reflect the real usage of the volumes.
+	//
+	// The container executing this action has access to following variables:
+	//
+	// - KB_POD_FQDN: The FQDN of the replica pod whose volume usage is being queried.
+	//
+	// Expected action output:
+	// - On Success: A JSON object keyed by the name of the volume claim template, each value reports the used and
+	//   total bytes of the volume, e.g. {"data": {"usedBytes": 1073741824, "capacityBytes": 21474836480}}.
+	// - On Failure: An error message, if applicable, indicating why the action failed.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	VolumeUsage *Action `json:"volumeUsage,omitempty"`
+
 	// Defines the procedure to add a new replica to the replication group.
 	//
 	// This action is initiated after a replica pod becomes ready.
@@ -1417,6 +1476,31 @@ type ComponentLifecycleActions struct {
 	// +optional
 	MemberJoin *Action `json:"memberJoin,omitempty"`
 
+	// Defines the procedure to move the data off a replica before it is removed from the replication group,
+	// e.g. migrating the shards or the regions hosted by the replica to the remaining replicas.
+	//
+	// This action is initiated on each replica to be removed during a scale-in, before the MemberLeave action.
+	// It is executed asynchronously by the kb-agent, the operator polls the action until it completes successfully,
+	// and will not call MemberLeave or release the replica until then.
+	// The action should be idempotent, since it may be executed again if the operator is restarted.
+	//
+	// The action can report its progress by printing the percentage (an integer between 0 and 100) to stdout,
+	// one line per report, and the last line printed is taken as the current progress.
+	// The progress is surfaced in the HorizontalScaling OpsRequest.
+	//
+	// The container executing this action has access to following variables:
+	//
+	// - KB_LEAVE_MEMBER_POD_FQDN: The pod FQDN of the replica being removed from the group.
+	// - KB_LEAVE_MEMBER_POD_NAME: The pod name of the replica being removed from the group.
+	//
+	// Expected action output:
+	// - On Failure: An error message, if applicable, indicating why the action failed.
+	//
+	// Note: This field is immutable once it has been set.
+	//
+	// +optional
+	PreScaleIn *Action `json:"preScaleIn,omitempty"`
+
 	// Defines the procedure to remove a replica from the replication group.
 	//
 	// This action is initiated before remove a replica from the group.
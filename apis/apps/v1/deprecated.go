@@ -245,5 +245,9 @@ func (t *InstanceTemplate) GetReplicas() int32 {
 
 // GetOrdinals TODO(free6om): Remove after resolving the circular dependencies between apps and workloads.
 func (t *InstanceTemplate) GetOrdinals() workloads.Ordinals {
-	return workloads.Ordinals{}
+	return workloads.Ordinals{Discrete: t.Ordinals}
+}
+
+func (t *InstanceTemplate) IsFlatInstanceOrdinal() bool {
+	return len(t.Ordinals) > 0
 }
@@ -650,6 +650,14 @@ type InstanceTemplate struct {
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Specifies the ordinals of the existing instances of the Component to be overridden by this InstanceTemplate.
+	// The instances keep the names of the default template: $(cluster.name)-$(component.name)-$(ordinal),
+	// and are re-created with the overrides of this InstanceTemplate in place, their PVCs are retained.
+	// If specified, the Replicas must be equal to the number of the ordinals.
+	//
+	// +optional
+	Ordinals []int32 `json:"ordinals,omitempty"`
+
 	// Specifies a map of key-value pairs to be merged into the Pod's existing annotations.
 	// Existing keys will have their values overwritten, while new keys will be added to the annotations.
 	//
@@ -667,6 +675,22 @@ type InstanceTemplate struct {
 	// +optional
 	Image *string `json:"image,omitempty"`
 
+	// Specifies the ComponentDefinition of the instances, overriding the one of the Component.
+	// Only the images of the containers are resolved from it, and the rest of the Pod follows the Component.
+	// It must be the exact name of the ComponentDefinition.
+	//
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([a-z0-9\.\-]*[a-z0-9])?$`
+	// +optional
+	CompDef string `json:"compDef,omitempty"`
+
+	// Specifies the ServiceVersion of the instances, overriding the one of the Component.
+	// The images of the containers are resolved with it, and take precedence over the Image.
+	//
+	// +kubebuilder:validation:MaxLength=32
+	// +optional
+	ServiceVersion string `json:"serviceVersion,omitempty"`
+
 	// Specifies the scheduling policy for the Component.
 	//
 	// +optional
@@ -1025,11 +1025,31 @@ func (in *ComponentLifecycleActions) DeepCopyInto(out *ComponentLifecycleActions
 		*out = new(Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReplicationLag != nil {
+		in, out := &in.ReplicationLag, &out.ReplicationLag
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeUsage != nil {
+		in, out := &in.VolumeUsage, &out.VolumeUsage
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MemberJoin != nil {
 		in, out := &in.MemberJoin, &out.MemberJoin
 		*out = new(Action)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PreScaleIn != nil {
+		in, out := &in.PreScaleIn, &out.PreScaleIn
+		*out = new(Action)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MemberLeave != nil {
 		in, out := &in.MemberLeave, &out.MemberLeave
 		*out = new(Action)
@@ -1870,6 +1890,11 @@ func (in *InstanceTemplate) DeepCopyInto(out *InstanceTemplate) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Ordinals != nil {
+		in, out := &in.Ordinals, &out.Ordinals
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
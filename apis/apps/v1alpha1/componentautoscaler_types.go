@@ -0,0 +1,230 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentAutoscalerSpec defines the replicas range of the Component and the metric to scale it.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must be less than or equal to maxReplicas"
+type ComponentAutoscalerSpec struct {
+	// Specifies the name of the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the Component in the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the lower limit of the replicas.
+	// The replicas limit defined in the ComponentDefinition is also respected.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Specifies the upper limit of the replicas.
+	// The replicas limit defined in the ComponentDefinition is also respected.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Specifies the metric to calculate the desired replicas.
+	//
+	// +kubebuilder:validation:Required
+	Metric AutoscalingMetric `json:"metric"`
+
+	// Specifies the window in seconds to stabilize the scaling out.
+	// The replicas are scaled out to the lowest replicas recommended within the window.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	ScaleOutStabilizationWindowSeconds *int32 `json:"scaleOutStabilizationWindowSeconds,omitempty"`
+
+	// Specifies the window in seconds to stabilize the scaling in.
+	// The replicas are scaled in to the highest replicas recommended within the window,
+	// which prevents the replicas from flapping when the metric fluctuates.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=300
+	// +optional
+	ScaleInStabilizationWindowSeconds *int32 `json:"scaleInStabilizationWindowSeconds,omitempty"`
+
+	// Specifies the interval in seconds to collect the metric.
+	//
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:default=30
+	// +optional
+	CheckIntervalSeconds int32 `json:"checkIntervalSeconds,omitempty"`
+
+	// Indicates whether the autoscaling is suspended.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// AutoscalingMetric defines the source and the target value of the metric.
+// The desired replicas are calculated as `ceil(currentReplicas * currentValue / targetValue)`,
+// the scaling is skipped if the ratio of the current value to the target value is within 10% of 1.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.kbAgent) != has(self.prometheus)",message="exactly one of kbAgent and prometheus must be specified"
+type AutoscalingMetric struct {
+	// Collects the metric by calling an action of the kb-agent on each replica of the Component.
+	// The output of the action must be a number, and the metric value is the average of the outputs.
+	//
+	// +optional
+	KBAgent *KBAgentMetricSource `json:"kbAgent,omitempty"`
+
+	// Collects the metric by querying a Prometheus-compatible endpoint.
+	// The query must return a scalar or a vector, and the metric value is the average of the samples.
+	//
+	// +optional
+	Prometheus *PrometheusMetricSource `json:"prometheus,omitempty"`
+
+	// Specifies the target value of the metric per replica.
+	//
+	// +kubebuilder:validation:Required
+	TargetValue resource.Quantity `json:"targetValue"`
+}
+
+// KBAgentMetricSource defines the kb-agent action which reports the metric.
+type KBAgentMetricSource struct {
+	// Specifies the name of the action, e.g. "replicationLag".
+	// The action must be defined in the `lifecycleActions` of the ComponentDefinition.
+	//
+	// +kubebuilder:validation:Required
+	Action string `json:"action"`
+}
+
+// PrometheusMetricSource defines the Prometheus query which reports the metric.
+type PrometheusMetricSource struct {
+	// Specifies the address of the Prometheus-compatible server, e.g. "http://prometheus-server.monitoring:9090".
+	//
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+
+	// Specifies the PromQL query, which is evaluated by the instant query API.
+	//
+	// +kubebuilder:validation:Required
+	Query string `json:"query"`
+}
+
+// ComponentAutoscalerStatus defines the observed state of ComponentAutoscaler.
+type ComponentAutoscalerStatus struct {
+	// Represents the most recent generation observed for the ComponentAutoscaler.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the current replicas of the Component.
+	//
+	// +optional
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+
+	// Represents the desired replicas calculated by the latest metric.
+	//
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// Represents the latest value of the metric.
+	//
+	// +optional
+	CurrentMetricValue *resource.Quantity `json:"currentMetricValue,omitempty"`
+
+	// Records the last time the metric was collected.
+	//
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// Records the last time the Component was scaled.
+	//
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// Records the name of the last HorizontalScaling OpsRequest created by the autoscaler.
+	//
+	// +optional
+	LastOpsRequest string `json:"lastOpsRequest,omitempty"`
+
+	// Records the replicas recommended within the stabilization windows.
+	//
+	// +optional
+	Recommendations []ReplicasRecommendation `json:"recommendations,omitempty"`
+
+	// Provides a human-readable message, e.g. why the metric can not be collected.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ReplicasRecommendation records the replicas recommended at a time.
+type ReplicasRecommendation struct {
+	// Specifies the recommended replicas.
+	Replicas int32 `json:"replicas"`
+
+	// Specifies the time of the recommendation.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=cas
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName"
+// +kubebuilder:printcolumn:name="MIN",type="integer",JSONPath=".spec.minReplicas"
+// +kubebuilder:printcolumn:name="MAX",type="integer",JSONPath=".spec.maxReplicas"
+// +kubebuilder:printcolumn:name="REPLICAS",type="integer",JSONPath=".status.currentReplicas"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ComponentAutoscaler is the Schema for the ComponentAutoscalers API.
+//
+// ComponentAutoscaler scales the replicas of a Component, e.g. the read replicas, according to a custom metric.
+// The Component is scaled by HorizontalScaling OpsRequests, so that the queueing of the OpsRequests and
+// the member join/leave actions are applied as usual.
+type ComponentAutoscaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentAutoscalerSpec   `json:"spec,omitempty"`
+	Status ComponentAutoscalerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ComponentAutoscalerList contains a list of ComponentAutoscaler.
+type ComponentAutoscalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentAutoscaler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ComponentAutoscaler{}, &ComponentAutoscalerList{})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpsApprovalPolicySpec defines which OpsRequests require approval and who can approve them.
+type OpsApprovalPolicySpec struct {
+	// Specifies the types of the OpsRequests which require approval.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	OpsTypes []OpsType `json:"opsTypes"`
+
+	// Specifies the label selector of the namespaces where the policy takes effect.
+	// If not specified, the policy takes effect in all namespaces.
+	//
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Specifies the label selector of the Clusters to which the policy applies.
+	// If not specified, the policy applies to all Clusters.
+	//
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// Specifies the users and groups who are allowed to approve the OpsRequests.
+	//
+	// +kubebuilder:validation:Required
+	Approvers OpsApprovers `json:"approvers"`
+
+	// Specifies the maximum time in seconds that an OpsRequest can stay in the "PendingApproval" phase.
+	// The OpsRequest will be aborted if it is not approved in time.
+	// If not specified or set to 0, the OpsRequest waits for approval indefinitely.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ExpirationSeconds int32 `json:"expirationSeconds,omitempty"`
+}
+
+// OpsApprovers defines the users and groups who are allowed to approve the OpsRequests.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.users) || has(self.groups)",message="at least one of users or groups must be specified"
+type OpsApprovers struct {
+	// Specifies the names of the users who are allowed to approve.
+	//
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Specifies the names of the groups whose members are allowed to approve.
+	//
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// OpsApprovalPolicyStatus defines the observed state of OpsApprovalPolicy.
+type OpsApprovalPolicyStatus struct {
+	// Refers to the most recent generation that has been observed for the OpsApprovalPolicy.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},scope=Cluster,shortName=oap
+// +kubebuilder:printcolumn:name="OPS-TYPES",type="string",JSONPath=".spec.opsTypes",description="The types of OpsRequests which require approval."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpsApprovalPolicy is the Schema for the OpsApprovalPolicies API.
+//
+// An OpsRequest matching an OpsApprovalPolicy stays in the "PendingApproval" phase until it is approved.
+// To approve an OpsRequest, an allowed approver adds the annotation "ops.kubeblocks.io/approved-by" to the OpsRequest.
+// The admission webhook of OpsRequest records the user name and groups of the approver from the request into the annotations
+// "ops.kubeblocks.io/approved-by" and "ops.kubeblocks.io/approved-by-groups", and rejects the approval from the requester
+// of the OpsRequest. The approval requires the admission webhooks to be enabled.
+type OpsApprovalPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpsApprovalPolicySpec   `json:"spec,omitempty"`
+	Status OpsApprovalPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpsApprovalPolicyList contains a list of OpsApprovalPolicy.
+type OpsApprovalPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsApprovalPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsApprovalPolicy{}, &OpsApprovalPolicyList{})
+}
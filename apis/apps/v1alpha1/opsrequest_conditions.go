@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,7 +44,13 @@ const (
 	ConditionTypeExpose             = "Exposing"
 	ConditionTypeBackup             = "Backup"
 	ConditionTypeInstanceRebuilding = "InstancesRebuilding"
+	ConditionTypeInstanceMigrating  = "InstancesMigrating"
+	ConditionTypeDataExporting      = "DataExporting"
+	ConditionTypeDataImporting      = "DataImporting"
 	ConditionTypeCustomOperation    = "CustomOperation"
+	ConditionTypePaused             = "Paused"
+	ConditionTypeApproved           = "Approved"
+	ConditionTypeRetrying           = "Retrying"
 
 	// condition and event reasons
 
@@ -64,6 +71,14 @@ const (
 	ReasonOpsCancelFailed          = "CancelFailed"
 	ReasonOpsCancelSucceed         = "CancelSucceed"
 	ReasonOpsCancelByController    = "CancelByController"
+	ReasonHealthGateFailed         = "HealthGateFailed"
+	ReasonOpsResumed               = "Resumed"
+	ReasonWaitForApproval          = "WaitForApproval"
+	ReasonApprovalRejected         = "ApprovalRejected"
+	ReasonOpsApproved              = "Approved"
+	ReasonApprovalExpired          = "ApprovalExpired"
+	ReasonOpsRetrying              = "Retrying"
+	ReasonWaitForConflictingOps    = "WaitForConflictingOps"
 )
 
 func (r *OpsRequest) SetStatusCondition(condition metav1.Condition) {
@@ -82,6 +97,19 @@ func NewWaitForProcessingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewWaitForConflictingOpsCondition creates a condition that the OpsRequest is queued
+// until the conflicting OpsRequest is completed.
+func NewWaitForConflictingOpsCondition(ops *OpsRequest, conflictingOps OpsRecorder, reason string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeWaitForProgressing,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonWaitForConflictingOps,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`The OpsRequest "%s" is waiting for the %s OpsRequest "%s" to complete, because %s`,
+			ops.Name, conflictingOps.Type, conflictingOps.Name, reason),
+	}
+}
+
 // NewCancelingCondition the controller is canceling the OpsRequest
 func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	return &metav1.Condition{
@@ -94,6 +122,84 @@ func NewCancelingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewHealthGatePausedCondition creates a condition that the OpsRequest is paused because the instance failed the health gate.
+func NewHealthGatePausedCondition(instanceName, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypePaused,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonHealthGateFailed,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`Paused because the instance "%s" failed the health gate: %s`, instanceName, message),
+	}
+}
+
+// NewResumedCondition creates a condition that the paused OpsRequest is resumed.
+func NewResumedCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypePaused,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonOpsResumed,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`The OpsRequest "%s" is resumed`, ops.Name),
+	}
+}
+
+// NewRetryingCondition creates a condition that the failed OpsRequest will be retried.
+func NewRetryingCondition(attempt int32, backoff time.Duration, message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeRetrying,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonOpsRetrying,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Attempt %d failed: %s, retry after %s", attempt, message, backoff),
+	}
+}
+
+// NewWaitForApprovalCondition creates a condition that the OpsRequest is waiting for approval.
+func NewWaitForApprovalCondition(ops *OpsRequest, policyName string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproved,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonWaitForApproval,
+		LastTransitionTime: metav1.Now(),
+		Message: fmt.Sprintf(`The OpsRequest "%s" is waiting for approval required by OpsApprovalPolicy "%s"`,
+			ops.Name, policyName),
+	}
+}
+
+// NewApprovalRejectedCondition creates a condition that the approval record of the OpsRequest is not allowed.
+func NewApprovalRejectedCondition(message string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproved,
+		Status:             metav1.ConditionFalse,
+		Reason:             ReasonApprovalRejected,
+		LastTransitionTime: metav1.Now(),
+		Message:            message,
+	}
+}
+
+// NewApprovedCondition creates a condition that the OpsRequest has been approved.
+func NewApprovedCondition(ops *OpsRequest, approver string) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeApproved,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonOpsApproved,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`The OpsRequest "%s" is approved by "%s"`, ops.Name, approver),
+	}
+}
+
+// NewApprovalExpiredCondition creates a condition that the OpsRequest is aborted because it is not approved in time.
+func NewApprovalExpiredCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeAborted,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonApprovalExpired,
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf(`Aborted because the OpsRequest "%s" is not approved before it expires`, ops.Name),
+	}
+}
+
 // NewCancelFailedCondition creates a condition for canceling failed.
 func NewCancelFailedCondition(ops *OpsRequest, err error) *metav1.Condition {
 	msg := fmt.Sprintf(`Failed to cancel OpsRequest "%s"`, ops.Name)
@@ -202,6 +308,39 @@ func NewInstancesRebuildingCondition(ops *OpsRequest) *metav1.Condition {
 	}
 }
 
+// NewInstancesMigratingCondition creates a condition that the operation starts to migrate the instances.
+func NewInstancesMigratingCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeInstanceMigrating,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToMigrateInstances",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to migrate the instances in Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
+// NewDataExportingCondition creates a condition that the operation starts to export the data.
+func NewDataExportingCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeDataExporting,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToExportData",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to export the data of Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
+// NewDataImportingCondition creates a condition that the operation starts to import the data.
+func NewDataImportingCondition(ops *OpsRequest) *metav1.Condition {
+	return &metav1.Condition{
+		Type:               ConditionTypeDataImporting,
+		Status:             metav1.ConditionTrue,
+		Reason:             "StartToImportData",
+		LastTransitionTime: metav1.Now(),
+		Message:            fmt.Sprintf("Start to import the data into Cluster: %s", ops.Spec.GetClusterName()),
+	}
+}
+
 // NewSwitchoveringCondition creates a condition that the operation starts to switchover components
 func NewSwitchoveringCondition(generation int64, message string) *metav1.Condition {
 	return &metav1.Condition{
@@ -0,0 +1,157 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	jsonpatch "github.com/evanphx/json-patch"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/apecloud/kubeblocks/pkg/common"
+)
+
+// opsTemplateApplier renders the OpsTemplate referenced by a new OpsRequest and merges it into the spec
+// before the OpsRequest is persisted, so the spec is never changed after it is admitted.
+type opsTemplateApplier struct {
+	client client.Reader
+}
+
+func (r *opsTemplateApplier) apply(ctx context.Context, opsRequest *OpsRequest) error {
+	if opsRequest.Spec.TemplateRef == nil {
+		return nil
+	}
+	opsTemplate := &OpsTemplate{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: opsRequest.Spec.TemplateRef.Name,
+		Namespace: opsRequest.Namespace}, opsTemplate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf(`OpsTemplate "%s" not found`, opsRequest.Spec.TemplateRef.Name)
+		}
+		return err
+	}
+	spec, err := renderOpsTemplate(opsRequest, opsTemplate)
+	if err != nil {
+		return fmt.Errorf(`failed to apply the OpsTemplate "%s": %s`, opsTemplate.Name, err.Error())
+	}
+	opsRequest.Spec = *spec
+	return nil
+}
+
+// renderOpsTemplate renders the template with the typed parameters, and returns the spec of the opsRequest
+// merged on top of the rendered one.
+func renderOpsTemplate(opsRequest *OpsRequest, opsTemplate *OpsTemplate) (*OpsRequestSpec, error) {
+	if opsRequest.Spec.Type != opsTemplate.Spec.Type {
+		return nil, fmt.Errorf(`the type of the OpsTemplate is "%s", but the type of the opsRequest is "%s"`,
+			opsTemplate.Spec.Type, opsRequest.Spec.Type)
+	}
+	params, err := buildOpsTemplateParameters(opsTemplate.Spec.ParametersSchema, opsRequest.Spec.TemplateRef.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	// the parameters are expected to be quoted by "toJson", so the values can not change the structure of the spec.
+	tpl, err := template.New("opsTemplate").Option("missingkey=error").Funcs(sprig.TxtFuncMap()).Parse(opsTemplate.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = tpl.Execute(&buf, map[string]interface{}{
+		"parameters": params,
+		"opsRequest": map[string]interface{}{
+			"name":      opsRequest.Name,
+			"namespace": opsRequest.Namespace,
+		},
+	}); err != nil {
+		return nil, err
+	}
+	rendered, err := yaml.YAMLToJSON(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	renderedSpec := &OpsRequestSpec{}
+	if err = yaml.UnmarshalStrict(buf.Bytes(), renderedSpec); err != nil {
+		return nil, fmt.Errorf("the rendered template is not a valid spec of the opsRequest: %s", err.Error())
+	}
+	if renderedSpec.Type != "" && renderedSpec.Type != opsTemplate.Spec.Type {
+		return nil, fmt.Errorf(`the rendered type "%s" is different from the type of the OpsTemplate`, renderedSpec.Type)
+	}
+	if renderedSpec.TemplateRef != nil {
+		return nil, fmt.Errorf("the templateRef can not be specified in the template")
+	}
+	if string(rendered) == "null" {
+		rendered = []byte("{}")
+	}
+	specJSON, err := json.Marshal(opsRequest.Spec)
+	if err != nil {
+		return nil, err
+	}
+	mergedJSON, err := jsonpatch.MergePatch(rendered, specJSON)
+	if err != nil {
+		return nil, err
+	}
+	spec := &OpsRequestSpec{}
+	if err = json.Unmarshal(mergedJSON, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// buildOpsTemplateParameters converts the parameters to the types declared in the schema,
+// fills the default values and validates them against the schema.
+func buildOpsTemplateParameters(parametersSchema *ParametersSchema, parameters []Parameter) (map[string]interface{}, error) {
+	values := map[string]string{}
+	for _, v := range parameters {
+		values[v.Name] = v.Value
+	}
+	if parametersSchema == nil || parametersSchema.OpenAPIV3Schema == nil {
+		params := map[string]interface{}{}
+		for k, v := range values {
+			params[k] = v
+		}
+		return params, nil
+	}
+	openAPIV3Schema := parametersSchema.OpenAPIV3Schema
+	for _, v := range parameters {
+		if _, ok := openAPIV3Schema.Properties[v.Name]; !ok {
+			return nil, fmt.Errorf(`parameter "%s" is not defined in the parametersSchema`, v.Name)
+		}
+	}
+	params, err := common.CoverStringToInterfaceBySchemaType(openAPIV3Schema, values)
+	if err != nil {
+		return nil, err
+	}
+	for k, p := range openAPIV3Schema.Properties {
+		if _, ok := params[k]; ok || p.Default == nil {
+			continue
+		}
+		var defaultValue interface{}
+		if err = json.Unmarshal(p.Default.Raw, &defaultValue); err != nil {
+			return nil, fmt.Errorf(`invalid default value of the parameter "%s": %s`, k, err.Error())
+		}
+		params[k] = defaultValue
+	}
+	if err = common.ValidateDataWithSchema(openAPIV3Schema, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
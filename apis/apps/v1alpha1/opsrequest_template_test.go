@@ -0,0 +1,127 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestOpsTemplateApplier(t *testing.T) {
+	minReplicas := float64(1)
+	opsTemplate := &OpsTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hscale-template",
+			Namespace: "default",
+		},
+		Spec: OpsTemplateSpec{
+			Type: HorizontalScalingType,
+			ParametersSchema: &ParametersSchema{
+				OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+					Type:     "object",
+					Required: []string{"component"},
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"component": {Type: "string"},
+						"replicas": {
+							Type:    "integer",
+							Minimum: &minReplicas,
+							Default: &apiextensionsv1.JSON{Raw: []byte("1")},
+						},
+					},
+				},
+			},
+			Template: `
+ttlSecondsAfterSucceed: 60
+horizontalScaling:
+- componentName: {{ .parameters.component | toJson }}
+  scaleOut:
+    replicaChanges: {{ .parameters.replicas | toJson }}
+`,
+		},
+	}
+	scheme := runtime.NewScheme()
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	defaulter := &opsRequestDefaulter{
+		templateApplier: &opsTemplateApplier{client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(opsTemplate).Build()},
+	}
+	admit := func(opsType OpsType, templateName string, params ...Parameter) (*OpsRequest, error) {
+		ops := createTestOpsRequest("mysql-test", "mysql-hscale", opsType)
+		ops.Spec.TTLSecondsAfterSucceed = 10
+		ops.Spec.TemplateRef = &OpsTemplateRef{Name: templateName, Parameters: params}
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+		}}
+		return ops, defaulter.Default(admission.NewContextWithRequest(context.Background(), req), ops)
+	}
+
+	ops, err := admit(HorizontalScalingType, opsTemplate.Name, Parameter{Name: "component", Value: "mysql"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops.Spec.HorizontalScalingList) != 1 || ops.Spec.HorizontalScalingList[0].ComponentName != "mysql" {
+		t.Fatalf("expect the template is rendered with the parameters, but got %v", ops.Spec.HorizontalScalingList)
+	}
+	if scaleOut := ops.Spec.HorizontalScalingList[0].ScaleOut; scaleOut == nil || *scaleOut.ReplicaChanges != 1 {
+		t.Error("expect the default value of the parameter is used")
+	}
+	if ops.Spec.TTLSecondsAfterSucceed != 10 {
+		t.Error("expect the fields specified by the opsRequest take precedence over the template")
+	}
+	if ops.Annotations[constant.OpsRequestedByAnnotationKey] != "alice" {
+		t.Error("expect the requester is recorded")
+	}
+
+	// the parameters can not change the structure of the rendered spec.
+	injected := "mysql\n  scaleIn:\n    replicaChanges: 2"
+	ops, err = admit(HorizontalScalingType, opsTemplate.Name, Parameter{Name: "component", Value: injected})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ops.Spec.HorizontalScalingList[0].ComponentName != injected || ops.Spec.HorizontalScalingList[0].ScaleIn != nil {
+		t.Error("expect the parameter is quoted in the rendered spec")
+	}
+
+	for _, c := range []struct {
+		opsType      OpsType
+		templateName string
+		params       []Parameter
+		expected     string
+	}{
+		{HorizontalScalingType, opsTemplate.Name, []Parameter{{Name: "component", Value: "mysql"}, {Name: "replicas", Value: "0"}}, "replicas"},
+		{HorizontalScalingType, opsTemplate.Name, []Parameter{{Name: "component", Value: "mysql"}, {Name: "unknown", Value: "1"}}, `parameter "unknown" is not defined`},
+		{RestartType, opsTemplate.Name, nil, "the type of the OpsTemplate"},
+		{HorizontalScalingType, "not-found", nil, `OpsTemplate "not-found" not found`},
+	} {
+		if _, err = admit(c.opsType, c.templateName, c.params...); err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("expect the opsRequest is rejected with %q, but got %v", c.expected, err)
+		}
+	}
+}
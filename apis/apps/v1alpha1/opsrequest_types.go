@@ -29,7 +29,8 @@ import (
 
 // OpsRequestSpec defines the desired state of OpsRequest
 //
-// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling']"
+// +kubebuilder:validation:XValidation:rule="has(self.healthGate) ? self.type in ['Restart','VerticalScaling','Upgrade'] : true",message="healthGate is only supported by the Restart, VerticalScaling and Upgrade opsRequest"
+// +kubebuilder:validation:XValidation:rule="has(self.cancel) && self.cancel ? (self.type in ['VerticalScaling', 'HorizontalScaling', 'Restart', 'Upgrade', 'Reconfiguring', 'RebuildInstance', 'Migrate']) : true",message="forbidden to cancel the opsRequest which type not in ['VerticalScaling','HorizontalScaling','Restart','Upgrade','Reconfiguring','RebuildInstance','Migrate']"
 type OpsRequestSpec struct {
 	// Specifies the name of the Cluster resource that this operation is targeting.
 	//
@@ -45,7 +46,22 @@ type OpsRequestSpec struct {
 	// Indicates whether the current operation should be canceled and terminated gracefully if it's in the
 	// "Pending", "Creating", or "Running" state.
 	//
-	// This field applies only to "VerticalScaling" and "HorizontalScaling" opsRequests.
+	// This field applies only to "VerticalScaling", "HorizontalScaling", "Restart", "Upgrade", "Reconfiguring",
+	// "RebuildInstance" and "Migrate" opsRequests. The effect of the cancellation depends on the type of the opsRequest:
+	//
+	// - "VerticalScaling" and "HorizontalScaling": the Component is rolled back to the configuration recorded
+	//   in `status.lastConfiguration`.
+	// - "Restart": the Pods that have not been restarted yet are skipped, the restarted Pods are kept as they are.
+	// - "Upgrade": the `serviceVersion` and `componentDefinitionName` of the Component are rolled back,
+	//   which reverts the instances that have already been upgraded and leaves the others untouched.
+	// - "Reconfiguring": the previous configuration is restored, so the replicas that have not been updated yet
+	//   are no longer rolled and the updated ones are rolled back.
+	// - "RebuildInstance": the instances that have not started to replace their volumes are not rebuilt,
+	//   and the temporary PVCs, Pods and Restores created for the rebuilding are cleaned up.
+	// - "Migrate": the instances that have not been taken offline are not migrated and the scaled out instances are removed.
+	//   The switchover that has been triggered is not reverted.
+	//
+	// The details of what has been reverted and what has not are recorded in `status.cancelResult`.
 	//
 	// Note: Setting `cancel` to true is irreversible; further modifications to this field are ineffective.
 	//
@@ -108,6 +124,40 @@ type OpsRequestSpec struct {
 	// +kubebuilder:Minimum=0
 	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 
+	// Specifies the health gate for the instances restarted by this opsRequest.
+	// Only supported by the "Restart", "VerticalScaling" and "Upgrade" opsRequest.
+	//
+	// The instances of each Component are restarted one by one. When specified, a restarted instance must pass the
+	// gate in addition to being ready before the next one is restarted.
+	// For "VerticalScaling" and "Upgrade", the rollout of the InstanceSet is advanced through its partition
+	// one instance at a time.
+	// If an instance fails the gate, the opsRequest is paused until the annotation
+	// "ops.kubeblocks.io/resume" is added to the opsRequest.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.healthGate"
+	HealthGate *InstanceHealthGate `json:"healthGate,omitempty"`
+
+	// Specifies the policy for retrying the opsRequest when it fails.
+	//
+	// When specified, a failed attempt whose reason is retryable re-enters the "Creating" phase after a backoff,
+	// and the action of the opsRequest is performed again until the maximum number of attempts is reached.
+	// Each attempt is recorded in `status.attempts`.
+	// Note that `timeoutSeconds` applies to all attempts as a whole.
+	//
+	// +optional
+	RetryPolicy *OpsRetryPolicy `json:"retryPolicy,omitempty"`
+
+	// Specifies the OpsTemplate to create the opsRequest from, and the values of its parameters.
+	//
+	// The template is rendered with the parameters and merged into this spec by the admission webhook
+	// when the opsRequest is created, and the creation is rejected if the template can not be applied.
+	// The fields specified in this spec take precedence over the rendered ones.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.templateRef"
+	TemplateRef *OpsTemplateRef `json:"templateRef,omitempty"`
+
 	// Exactly one of its members must be set.
 	SpecificOpsRequest `json:",inline"`
 }
@@ -232,6 +282,33 @@ type SpecificOpsRequest struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.rebuildFrom"
 	RebuildFrom []RebuildInstance `json:"rebuildFrom,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
 
+	// Specifies the instances to be migrated to other nodes or zones.
+	// If the instance holds the primary role, a switchover is performed away from it first.
+	// Then it is rebuilt on the target node by scaling out a new instance, and the PVCs of the old instance
+	// are removed after the old instance is taken offline.
+	//
+	// +optional
+	// +patchMergeKey=componentName
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=componentName
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.migrate"
+	MigrateList []Migrate `json:"migrate,omitempty"  patchStrategy:"merge,retainKeys" patchMergeKey:"componentName"`
+
+	// Specifies the parameters to export the logical data of a Component to a BackupRepo.
+	// The data is produced by the `dataDump` lifecycle action of the Component.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.dataExport"
+	DataExport *DataExport `json:"dataExport,omitempty"`
+
+	// Specifies the parameters to import the logical data stored in a BackupRepo into a Component.
+	// The data is consumed by the `dataLoad` lifecycle action of the Component.
+	//
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.dataImport"
+	DataImport *DataImport `json:"dataImport,omitempty"`
+
 	// Specifies a custom operation defined by OpsDefinition.
 	//
 	// +optional
@@ -299,6 +376,107 @@ type Instance struct {
 	TargetNodeName string `json:"targetNodeName,omitempty"`
 }
 
+type Migrate struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the instances (Pods) that need to be migrated.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:Required
+	Instances []MigrateInstance `json:"instances"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.targetNodeName) || has(self.targetNodeSelector) || has(self.targetZone)",message="at least one of targetNodeName, targetNodeSelector or targetZone must be specified"
+
+type MigrateInstance struct {
+	// Pod name of the instance.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// The instance will be migrated to the specified node.
+	// +optional
+	TargetNodeName string `json:"targetNodeName,omitempty"`
+
+	// The instance will be migrated to a node matching the node selector.
+	// It is ignored if `targetNodeName` is specified.
+	// +optional
+	TargetNodeSelector map[string]string `json:"targetNodeSelector,omitempty"`
+
+	// The instance will be migrated to a node in the specified zone.
+	// It is ignored if `targetNodeName` is specified.
+	// +optional
+	TargetZone string `json:"targetZone,omitempty"`
+}
+
+// OpsTemplateRef references an OpsTemplate with the values of its parameters.
+type OpsTemplateRef struct {
+	// Specifies the name of the OpsTemplate in the namespace of the opsRequest.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the values of the parameters defined in the `parametersSchema` of the OpsTemplate.
+	// If the parameter type is an array, the format should be "v1,v2,v3".
+	//
+	// +optional
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	Parameters []Parameter `json:"parameters,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// DataRepoLocation specifies a path in a BackupRepo.
+type DataRepoLocation struct {
+	// Specifies the name of the BackupRepo.
+	// If not set, the default BackupRepo will be used.
+	//
+	// +optional
+	BackupRepoName string `json:"backupRepoName,omitempty"`
+
+	// Specifies the path of the data file relative to the root of the BackupRepo.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[^/].*[^/]$`
+	Path string `json:"path"`
+}
+
+type DataExport struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the instance (Pod) to dump the data from.
+	// If not set, the first instance of the Component is used.
+	//
+	// +optional
+	InstanceName string `json:"instanceName,omitempty"`
+
+	// Specifies the location in the BackupRepo to write the exported data to.
+	DataRepoLocation `json:",inline"`
+}
+
+type DataImport struct {
+	// Specifies the name of the Component.
+	ComponentOps `json:",inline"`
+
+	// Specifies the instance (Pod) to load the data into.
+	// If not set, the first instance of the Component is used.
+	//
+	// +optional
+	InstanceName string `json:"instanceName,omitempty"`
+
+	// Specifies the location in the BackupRepo to read the data from.
+	DataRepoLocation `json:",inline"`
+
+	// Specifies whether to skip verifying the data against the checksum recorded by the DataExport.
+	// By default, the data is verified before it is loaded, and the import fails if the checksum is missing or mismatched.
+	// Set it to true to import the data which is not exported by the DataExport.
+	//
+	// +optional
+	SkipChecksumVerification bool `json:"skipChecksumVerification,omitempty"`
+}
+
 type Switchover struct {
 	// Specifies the name of the Component.
 	ComponentOps `json:",inline"`
@@ -320,6 +498,98 @@ type Switchover struct {
 	//
 	// +kubebuilder:validation:Required
 	InstanceName string `json:"instanceName"`
+
+	// Specifies the constraints used to choose the new primary or leader automatically
+	// when `instanceName` is "*".
+	//
+	// Only the ready replicas matching the constraints are considered as candidates.
+	// If the `replicationLag` lifecycle action is defined in the ComponentDefinition,
+	// the candidate with the smallest replication lag is chosen.
+	// The scoring of each replica is recorded in the progress details of the OpsRequest.
+	//
+	// +optional
+	CandidateSelector *SwitchoverCandidateSelector `json:"candidateSelector,omitempty"`
+}
+
+// InstanceHealthGate defines the checks that a restarted instance must pass before the next instance is restarted.
+type InstanceHealthGate struct {
+	// Specifies the number of consecutive successful executions of the `healthCheck` lifecycle action
+	// required for an instance to pass the gate.
+	// The action is skipped if it is not defined in the ComponentDefinition.
+	//
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SuccessThreshold int32 `json:"successThreshold,omitempty"`
+
+	// Specifies the number of consecutive failed executions of the `healthCheck` lifecycle action
+	// after which the instance fails the gate and the opsRequest is paused.
+	//
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+
+	// Specifies the interval in seconds between two executions of the `healthCheck` lifecycle action.
+	//
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// Specifies the minimum time in seconds that a restarted instance must stay ready
+	// before the `healthCheck` lifecycle action is executed.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	SoakSeconds int32 `json:"soakSeconds,omitempty"`
+}
+
+// OpsRetryPolicy defines how a failed opsRequest is retried.
+type OpsRetryPolicy struct {
+	// Specifies the maximum number of attempts, including the first one.
+	//
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+
+	// Specifies the backoff in seconds before the first retry.
+	// The backoff is doubled for each subsequent retry.
+	//
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+
+	// Specifies the maximum backoff in seconds between two attempts.
+	//
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBackoffSeconds int32 `json:"maxBackoffSeconds,omitempty"`
+
+	// Specifies the failure reasons that are retryable.
+	// Possible values include "ActionFailed" and "ProgressFailed".
+	// If not specified, all failures are retryable.
+	//
+	// +optional
+	RetryableReasons []OpsFailureReason `json:"retryableReasons,omitempty"`
+}
+
+// SwitchoverCandidateSelector defines the constraints for choosing a switchover candidate.
+type SwitchoverCandidateSelector struct {
+	// Specifies the zones in which the candidate must be located.
+	// The zone of a replica is determined by the "topology.kubernetes.io/zone" label of the node it runs on.
+	//
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Specifies the names of the instance templates the candidate must belong to.
+	// An empty string represents the default template of the Component.
+	//
+	// +optional
+	InstanceTemplates []string `json:"instanceTemplates,omitempty"`
 }
 
 // Upgrade defines the parameters for an upgrade operation.
@@ -363,6 +633,42 @@ type UpgradeComponent struct {
 	// +kubebuilder:validation:MaxLength=32
 	// +optional
 	ServiceVersion *string `json:"serviceVersion,omitempty"`
+
+	// Specifies the canary policy of the upgrade.
+	// If specified, only the chosen subset of instances is upgraded to the target serviceVersion and
+	// componentDefinition first, through a temporary instance template named "canary" which overrides
+	// the images of these instances in place. After the canary instances are verified, the target is folded back
+	// into the Component and the rest instances are upgraded (promoted), otherwise the temporary instance template
+	// is removed and the canary instances are rolled back.
+	// Only the images of the target componentDefinition are applied to the canary instances.
+	// +optional
+	Canary *CanaryUpgrade `json:"canary,omitempty"`
+}
+
+// CanaryUpgrade defines the canary instances of the upgrade and how they are verified.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.replicas) != has(self.ordinals)",message="exactly one of replicas or ordinals must be specified"
+type CanaryUpgrade struct {
+	// Specifies the number of canary instances.
+	// The first instances of the default instance template in the update order of the InstanceSet are upgraded
+	// as the canary instances.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Specifies the ordinals of the instances of the default instance template to be upgraded as the canary instances.
+	// The instances are upgraded in place, and their PVCs are retained.
+	// +kubebuilder:validation:MaxItems=1024
+	// +optional
+	Ordinals []int32 `json:"ordinals,omitempty"`
+
+	// Specifies the health gate used to verify the canary instances.
+	// If specified, the canary is promoted once all canary instances pass the gate,
+	// and rolled back once any of them fails it.
+	// If not specified, the canary is promoted or rolled back by the annotation "ops.kubeblocks.io/canary-decision"
+	// with the value "Promote" or "Rollback".
+	// +optional
+	HealthGate *InstanceHealthGate `json:"healthGate,omitempty"`
 }
 
 // VerticalScaling refers to the process of adjusting compute resources (e.g., CPU, memory) allocated to a Component.
@@ -959,7 +1265,7 @@ type OpsRequestStatus struct {
 	ClusterGeneration int64 `json:"clusterGeneration,omitempty"`
 
 	// Represents the phase of the OpsRequest.
-	// Possible values include "Pending", "Creating", "Running", "Cancelling", "Cancelled", "Failed", "Succeed".
+	// Possible values include "PendingApproval", "Pending", "Creating", "Running", "Cancelling", "Cancelled", "Failed", "Succeed".
 	Phase OpsPhase `json:"phase,omitempty"`
 
 	// Represents the progress of the OpsRequest.
@@ -971,6 +1277,11 @@ type OpsRequestStatus struct {
 	// +optional
 	LastConfiguration LastConfiguration `json:"lastConfiguration,omitempty"`
 
+	// Records the status of the data transferred by the `DataExport` or `DataImport` OpsRequest.
+	//
+	// +optional
+	DataTransfer *DataTransferStatus `json:"dataTransfer,omitempty"`
+
 	// Records the status information of Components changed due to the OpsRequest.
 	// +optional
 	Components map[string]OpsRequestComponentStatus `json:"components,omitempty"`
@@ -990,6 +1301,18 @@ type OpsRequestStatus struct {
 	// +optional
 	CancelTimestamp metav1.Time `json:"cancelTimestamp,omitempty"`
 
+	// Records what the cancellation has reverted and what it has kept as it is.
+	// +optional
+	CancelResult *CancelResult `json:"cancelResult,omitempty"`
+
+	// Records the approval of the OpsRequest if it is required by an OpsApprovalPolicy.
+	// +optional
+	Approval *OpsApprovalStatus `json:"approval,omitempty"`
+
+	// Records the failed attempts of the OpsRequest if `spec.retryPolicy` is specified.
+	// +optional
+	Attempts []OpsAttemptStatus `json:"attempts,omitempty"`
+
 	// Deprecated: Replaced by ReconfiguringStatusAsComponent.
 	// Defines the status information of reconfiguring.
 	// +optional
@@ -1011,6 +1334,87 @@ type OpsRequestStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// DataTransferStatus describes the data transferred between a Component and a BackupRepo.
+type DataTransferStatus struct {
+	// Specifies the name of the BackupRepo.
+	//
+	// +optional
+	BackupRepoName string `json:"backupRepoName,omitempty"`
+
+	// Specifies the path of the data file in the BackupRepo.
+	//
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Records the size in bytes of the transferred data.
+	//
+	// +optional
+	Size int64 `json:"size,omitempty"`
+
+	// Records the checksum of the transferred data, in the format of `sha256:<hex>`.
+	//
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// CancelResult describes the effect of cancelling an OpsRequest.
+type CancelResult struct {
+	// Lists the changes made by the OpsRequest that are reverted or not applied anymore due to the cancellation.
+	// +optional
+	Reverted []string `json:"reverted,omitempty"`
+
+	// Lists the changes made by the OpsRequest that have been applied and are not reverted by the cancellation.
+	// +optional
+	NotReverted []string `json:"notReverted,omitempty"`
+}
+
+// OpsApprovalStatus records the approval of an OpsRequest.
+type OpsApprovalStatus struct {
+	// Specifies the name of the OpsApprovalPolicy which requires the approval.
+	// +optional
+	PolicyName string `json:"policyName,omitempty"`
+
+	// Specifies the user who approved the OpsRequest.
+	// +optional
+	Approver string `json:"approver,omitempty"`
+
+	// Specifies the groups of the approver that are allowed by the OpsApprovalPolicy.
+	// +optional
+	ApproverGroups []string `json:"approverGroups,omitempty"`
+
+	// Records the time when the OpsRequest was approved.
+	// +optional
+	ApprovalTimestamp *metav1.Time `json:"approvalTimestamp,omitempty"`
+}
+
+// OpsAttemptStatus records a failed attempt of an OpsRequest.
+type OpsAttemptStatus struct {
+	// Indicates the sequence number of the attempt, starting from 1.
+	// +kubebuilder:validation:Required
+	Attempt int32 `json:"attempt"`
+
+	// Records the time when the attempt started.
+	// +optional
+	StartTimestamp metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the attempt failed.
+	// +optional
+	CompletionTimestamp metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the time after which the next attempt starts.
+	// It is not set if the attempt is not retried.
+	// +optional
+	NextRetryTimestamp *metav1.Time `json:"nextRetryTimestamp,omitempty"`
+
+	// Indicates the reason why the attempt failed.
+	// +optional
+	Reason OpsFailureReason `json:"reason,omitempty"`
+
+	// Provides the error message of the attempt.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +kubebuilder:validation:XValidation:rule="has(self.objectKey) || has(self.actionName)", message="at least one objectKey or actionName."
 
 type ProgressStatusDetail struct {
@@ -1142,6 +1546,14 @@ type OpsRequestComponentStatus struct {
 	// +optional
 	ProgressDetails []ProgressStatusDetail `json:"progressDetails,omitempty"`
 
+	// Records the health gate status of the instances restarted by this opsRequest.
+	// +optional
+	HealthGates []InstanceHealthGateStatus `json:"healthGates,omitempty"`
+
+	// Records the status of the canary upgrade of the Component.
+	// +optional
+	Canary *CanaryUpgradeStatus `json:"canary,omitempty"`
+
 	// Provides an explanation for the Component being in its current state.
 	// +kubebuilder:validation:MaxLength=1024
 	// +optional
@@ -1153,6 +1565,46 @@ type OpsRequestComponentStatus struct {
 	Message string `json:"message,omitempty" protobuf:"bytes,6,opt,name=message"`
 }
 
+type CanaryUpgradeStatus struct {
+	// Records the phase of the canary upgrade.
+	// +optional
+	Phase CanaryUpgradePhase `json:"phase,omitempty"`
+
+	// Records the canary instances that run the target serviceVersion.
+	// +optional
+	CanaryInstances []string `json:"canaryInstances,omitempty"`
+
+	// Records the baseline instances that run the original serviceVersion.
+	// +optional
+	BaselineInstances []string `json:"baselineInstances,omitempty"`
+
+	// Provides a human-readable message of the canary upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type InstanceHealthGateStatus struct {
+	// Specifies the name of the instance.
+	// +kubebuilder:validation:Required
+	InstanceName string `json:"instanceName"`
+
+	// Records the number of consecutive successful executions of the `healthCheck` lifecycle action.
+	// +optional
+	SucceededCount int32 `json:"succeededCount,omitempty"`
+
+	// Records the number of consecutive failed executions of the `healthCheck` lifecycle action.
+	// +optional
+	FailedCount int32 `json:"failedCount,omitempty"`
+
+	// Records the timestamp of the last execution of the `healthCheck` lifecycle action.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// Provides the output or error message of the last execution.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 type OverrideBy struct {
 	// Indicates the name of the OpsRequest.
 	// +optional
@@ -1237,6 +1689,12 @@ type ConfigurationItemStatus struct {
 	// +optional
 	LastAppliedConfiguration map[string]string `json:"lastAppliedConfiguration,omitempty"`
 
+	// Records the values of the updated parameters and the contents of the updated files before reconfiguring.
+	// The configuration is restored to them if the opsRequest is cancelled.
+	// A parameter without value did not exist before reconfiguring, and is removed by the restoration.
+	// +optional
+	PreviousConfiguration []ParameterConfig `json:"previousConfiguration,omitempty"`
+
 	// Contains the updated parameters.
 	// +optional
 	UpdatedParameters UpdatedParameters `json:"updatedParameters"`
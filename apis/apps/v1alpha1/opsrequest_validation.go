@@ -79,7 +79,10 @@ func (r *OpsRequest) Validate(ctx context.Context,
 			return err
 		}
 	}
-	return r.validateOps(ctx, k8sClient, cluster)
+	if err := r.validateOps(ctx, k8sClient, cluster); err != nil {
+		return err
+	}
+	return r.validateHealthGate(ctx, k8sClient, cluster)
 }
 
 // validateClusterPhase validates whether the current cluster state supports the OpsRequest
@@ -140,10 +143,92 @@ func (r *OpsRequest) validateOps(ctx context.Context,
 		return r.validateExpose(ctx, cluster)
 	case RebuildInstanceType:
 		return r.validateRebuildInstance(cluster)
+	case MigrateType:
+		return r.validateMigrate(cluster)
+	case DataExportType:
+		if r.Spec.DataExport == nil {
+			return notEmptyError("spec.dataExport")
+		}
+		return r.validateDataTransfer(cluster, r.Spec.DataExport.ComponentOps, r.Spec.DataExport.DataRepoLocation)
+	case DataImportType:
+		if r.Spec.DataImport == nil {
+			return notEmptyError("spec.dataImport")
+		}
+		return r.validateDataTransfer(cluster, r.Spec.DataImport.ComponentOps, r.Spec.DataImport.DataRepoLocation)
+	}
+	return nil
+}
+
+// validateHealthGate validates that the components gated by the health gate define the healthCheck action.
+func (r *OpsRequest) validateHealthGate(ctx context.Context, k8sClient client.Client, cluster *appsv1.Cluster) error {
+	var compNames []string
+	switch r.Spec.Type {
+	case RestartType:
+		if r.Spec.HealthGate != nil {
+			for _, compOps := range r.Spec.RestartList {
+				compNames = append(compNames, compOps.ComponentName)
+			}
+		}
+	case VerticalScalingType:
+		if r.Spec.HealthGate != nil {
+			for _, vs := range r.Spec.VerticalScalingList {
+				compNames = append(compNames, vs.ComponentName)
+			}
+		}
+	case UpgradeType:
+		if r.Spec.Upgrade == nil {
+			return nil
+		}
+		for _, upgradeComp := range r.Spec.Upgrade.Components {
+			if r.Spec.HealthGate != nil || (upgradeComp.Canary != nil && upgradeComp.Canary.HealthGate != nil) {
+				compNames = append(compNames, upgradeComp.ComponentName)
+			}
+		}
+	default:
+		if r.Spec.HealthGate != nil {
+			return fmt.Errorf(`spec.healthGate is not supported by the OpsRequest type "%s"`, r.Spec.Type)
+		}
+	}
+	for _, compName := range compNames {
+		compDefName, err := getComponentDefNameOfComponent(ctx, k8sClient, cluster, compName)
+		if err != nil {
+			return err
+		}
+		compDef := &appsv1.ComponentDefinition{}
+		if err = k8sClient.Get(ctx, types.NamespacedName{Name: compDefName}, compDef); err != nil {
+			return err
+		}
+		if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.HealthCheck == nil {
+			return fmt.Errorf(`the health gate is not supported by the component "%s", as its componentDefinition "%s" does not define the healthCheck action`,
+				compName, compDefName)
+		}
 	}
 	return nil
 }
 
+// getComponentDefNameOfComponent gets the name of the ComponentDefinition resolved by the component or the sharding.
+func getComponentDefNameOfComponent(ctx context.Context, k8sClient client.Client, cluster *appsv1.Cluster, compName string) (string, error) {
+	if cluster.Spec.GetShardingByName(compName) == nil {
+		comp := &appsv1.Component{}
+		compKey := types.NamespacedName{Namespace: cluster.Namespace, Name: constant.GenerateClusterComponentName(cluster.Name, compName)}
+		if err := k8sClient.Get(ctx, compKey, comp); err != nil {
+			return "", err
+		}
+		return comp.Spec.CompDef, nil
+	}
+	compList := &appsv1.ComponentList{}
+	if err := k8sClient.List(ctx, compList, client.InNamespace(cluster.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:       cluster.Name,
+		constant.KBAppShardingNameLabelKey: compName,
+	}); err != nil {
+		return "", err
+	}
+	if len(compList.Items) == 0 {
+		return "", fmt.Errorf(`no component of the sharding "%s" is found`, compName)
+	}
+	return compList.Items[0].Spec.CompDef, nil
+}
+
 // validateExpose validates expose api when spec.type is Expose
 func (r *OpsRequest) validateExpose(_ context.Context, cluster *appsv1.Cluster) error {
 	exposeList := r.Spec.ExposeList
@@ -186,6 +271,48 @@ func (r *OpsRequest) validateRebuildInstance(cluster *appsv1.Cluster) error {
 	return r.checkComponentExistence(cluster, compOpsList)
 }
 
+func (r *OpsRequest) validateMigrate(cluster *appsv1.Cluster) error {
+	migrateList := r.Spec.MigrateList
+	if len(migrateList) == 0 {
+		return notEmptyError("spec.migrate")
+	}
+	var compOpsList []ComponentOps
+	for _, v := range migrateList {
+		compOpsList = append(compOpsList, v.ComponentOps)
+	}
+	if err := r.checkComponentExistence(cluster, compOpsList); err != nil {
+		return err
+	}
+	for _, v := range migrateList {
+		if cluster.Spec.GetComponentByName(v.ComponentName) == nil {
+			return fmt.Errorf(`the migrate operation only supports the component, but "%s" is a sharding`, v.ComponentName)
+		}
+		for _, ins := range v.Instances {
+			if ins.TargetNodeName == "" && len(ins.TargetNodeSelector) == 0 && ins.TargetZone == "" {
+				return fmt.Errorf(`the target node or zone of the instance "%s" must be specified`, ins.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateDataTransfer validates spec.dataExport and spec.dataImport
+func (r *OpsRequest) validateDataTransfer(cluster *appsv1.Cluster, compOps ComponentOps, location DataRepoLocation) error {
+	if err := r.checkComponentExistence(cluster, []ComponentOps{compOps}); err != nil {
+		return err
+	}
+	if cluster.Spec.GetComponentByName(compOps.ComponentName) == nil {
+		return fmt.Errorf(`the %s operation only supports the component, but "%s" is a sharding`, r.Spec.Type, compOps.ComponentName)
+	}
+	if location.Path == "" {
+		return fmt.Errorf("the path of the data in the BackupRepo must be specified")
+	}
+	if slices.Contains(strings.Split(location.Path, "/"), "..") {
+		return fmt.Errorf(`the path "%s" must not contain ".."`, location.Path)
+	}
+	return nil
+}
+
 // validateUpgrade validates spec.restart
 func (r *OpsRequest) validateRestart(cluster *appsv1.Cluster) error {
 	restartList := r.Spec.RestartList
@@ -207,6 +334,64 @@ func (r *OpsRequest) validateUpgrade(ctx context.Context, k8sClient client.Clien
 	if len(r.Spec.Upgrade.Components) == 0 {
 		return notEmptyError("spec.upgrade.components")
 	}
+	for _, v := range upgrade.Components {
+		if v.Canary == nil {
+			continue
+		}
+		if err := r.validateCanaryUpgrade(cluster, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCanaryUpgrade validates the canary of the upgrade component.
+func (r *OpsRequest) validateCanaryUpgrade(cluster *appsv1.Cluster, upgradeComp UpgradeComponent) error {
+	compName := upgradeComp.ComponentName
+	if (upgradeComp.ServiceVersion == nil || *upgradeComp.ServiceVersion == "") &&
+		(upgradeComp.ComponentDefinitionName == nil || *upgradeComp.ComponentDefinitionName == "") {
+		return fmt.Errorf(`serviceVersion or componentDefinitionName is required for the canary upgrade of the component "%s"`, compName)
+	}
+	compSpec := cluster.Spec.GetComponentByName(compName)
+	if compSpec == nil {
+		return fmt.Errorf(`the canary upgrade only supports the component, but "%s" is not a component`, compName)
+	}
+	// the canary instances are upgraded through a temporary instance template which is owned by the opsRequest.
+	templateReplicas := int32(0)
+	for _, ins := range compSpec.Instances {
+		if ins.Name == constant.CanaryInsTemplateName {
+			return fmt.Errorf(`the instance template name "%s" of the component "%s" is reserved for the canary upgrade`,
+				constant.CanaryInsTemplateName, compName)
+		}
+		templateReplicas += ins.GetReplicas()
+	}
+	// the instances of the default template can be upgraded as the canary instances.
+	defaultReplicas := compSpec.Replicas - templateReplicas
+	defaultInstances := map[int32]struct{}{}
+	for ordinal := int32(0); int32(len(defaultInstances)) < defaultReplicas; ordinal++ {
+		if !slices.Contains(compSpec.OfflineInstances, constant.GeneratePodName(cluster.Name, compName, int(ordinal))) {
+			defaultInstances[ordinal] = struct{}{}
+		}
+	}
+	canary := upgradeComp.Canary
+	canaryReplicas := int32(len(canary.Ordinals))
+	if canary.Replicas != nil {
+		canaryReplicas = *canary.Replicas
+	}
+	ordinalSet := map[int32]struct{}{}
+	for _, ordinal := range canary.Ordinals {
+		if _, ok := defaultInstances[ordinal]; !ok {
+			return fmt.Errorf(`the canary ordinal %d of the component "%s" does not belong to the default instance template or is offline`, ordinal, compName)
+		}
+		if _, ok := ordinalSet[ordinal]; ok {
+			return fmt.Errorf(`the canary ordinal %d of the component "%s" is duplicated`, ordinal, compName)
+		}
+		ordinalSet[ordinal] = struct{}{}
+	}
+	if canaryReplicas <= 0 || canaryReplicas >= compSpec.Replicas || canaryReplicas > defaultReplicas {
+		return fmt.Errorf(`the canary replicas of the component "%s" must be greater than 0, less than the replicas %d and not greater than the replicas %d of the default instance template`,
+			compName, compSpec.Replicas, defaultReplicas)
+	}
 	return nil
 }
 
@@ -0,0 +1,140 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+// +kubebuilder:webhook:path=/mutate-apps-kubeblocks-io-v1alpha1-opsrequest,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.kubeblocks.io,resources=opsrequests,verbs=create;update,versions=v1alpha1,name=mopsrequest.kb.io,admissionReviewVersions=v1
+
+func (r *OpsRequest) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(&opsRequestDefaulter{
+			templateApplier: &opsTemplateApplier{client: mgr.GetAPIReader()},
+		}).
+		Complete()
+}
+
+// opsRequestDefaulter applies the OpsTemplate to a new OpsRequest, and records its requester and approver.
+type opsRequestDefaulter struct {
+	opsRequestApprovalRecorder
+	templateApplier *opsTemplateApplier
+}
+
+var _ admission.CustomDefaulter = &opsRequestDefaulter{}
+
+func (r *opsRequestDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	opsRequest, ok := obj.(*OpsRequest)
+	if !ok {
+		return fmt.Errorf("expected an OpsRequest but got a %T", obj)
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if req.Operation == admissionv1.Create {
+		if err = r.templateApplier.apply(ctx, opsRequest); err != nil {
+			return err
+		}
+	}
+	return r.opsRequestApprovalRecorder.Default(ctx, obj)
+}
+
+// opsRequestApprovalRecorder records the requester and the approver of the OpsRequest from the user info
+// of the admission request, so the approval annotations can not be forged by the requester.
+type opsRequestApprovalRecorder struct{}
+
+var _ admission.CustomDefaulter = &opsRequestApprovalRecorder{}
+
+func (r *opsRequestApprovalRecorder) Default(ctx context.Context, obj runtime.Object) error {
+	opsRequest, ok := obj.(*OpsRequest)
+	if !ok {
+		return fmt.Errorf("expected an OpsRequest but got a %T", obj)
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	switch req.Operation {
+	case admissionv1.Create:
+		return r.recordRequester(opsRequest, req)
+	case admissionv1.Update:
+		oldOpsRequest := &OpsRequest{}
+		if err = json.Unmarshal(req.OldObject.Raw, oldOpsRequest); err != nil {
+			return err
+		}
+		return r.recordApprover(oldOpsRequest, opsRequest, req)
+	}
+	return nil
+}
+
+func (r *opsRequestApprovalRecorder) recordRequester(opsRequest *OpsRequest, req admission.Request) error {
+	if _, ok := opsRequest.Annotations[constant.OpsApprovedByAnnotationKey]; ok {
+		return fmt.Errorf(`forbidden to approve the OpsRequest "%s" by its requester`, opsRequest.Name)
+	}
+	if _, ok := opsRequest.Annotations[constant.OpsApprovedByGroupsAnnotationKey]; ok {
+		return fmt.Errorf(`forbidden to approve the OpsRequest "%s" by its requester`, opsRequest.Name)
+	}
+	if opsRequest.Annotations == nil {
+		opsRequest.Annotations = map[string]string{}
+	}
+	opsRequest.Annotations[constant.OpsRequestedByAnnotationKey] = req.UserInfo.Username
+	return nil
+}
+
+func (r *opsRequestApprovalRecorder) recordApprover(oldOpsRequest, opsRequest *OpsRequest, req admission.Request) error {
+	requester := oldOpsRequest.Annotations[constant.OpsRequestedByAnnotationKey]
+	if opsRequest.Annotations[constant.OpsRequestedByAnnotationKey] != requester {
+		return fmt.Errorf(`forbidden to update the annotation "%s"`, constant.OpsRequestedByAnnotationKey)
+	}
+	approvalChanged := func(key string) bool {
+		oldValue, oldOK := oldOpsRequest.Annotations[key]
+		value, ok := opsRequest.Annotations[key]
+		return oldOK != ok || oldValue != value
+	}
+	if !approvalChanged(constant.OpsApprovedByAnnotationKey) && !approvalChanged(constant.OpsApprovedByGroupsAnnotationKey) {
+		return nil
+	}
+	_, approved := opsRequest.Annotations[constant.OpsApprovedByAnnotationKey]
+	_, approvedByGroups := opsRequest.Annotations[constant.OpsApprovedByGroupsAnnotationKey]
+	if !approved && !approvedByGroups {
+		// the approval is revoked.
+		return nil
+	}
+	if requester == "" {
+		return fmt.Errorf(`the requester of the OpsRequest "%s" is unknown, please recreate it before approving`, opsRequest.Name)
+	}
+	if req.UserInfo.Username == requester {
+		return fmt.Errorf(`forbidden to approve the OpsRequest "%s" by its requester`, opsRequest.Name)
+	}
+	// the approval is always recorded as the user who sends the request.
+	opsRequest.Annotations[constant.OpsApprovedByAnnotationKey] = req.UserInfo.Username
+	opsRequest.Annotations[constant.OpsApprovedByGroupsAnnotationKey] = strings.Join(req.UserInfo.Groups, ",")
+	return nil
+}
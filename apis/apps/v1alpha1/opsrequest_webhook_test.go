@@ -0,0 +1,96 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func TestOpsRequestApprovalRecorder(t *testing.T) {
+	recorder := &opsRequestApprovalRecorder{}
+	admit := func(operation admissionv1.Operation, username string, oldOps, ops *OpsRequest) error {
+		req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: operation,
+			UserInfo:  authenticationv1.UserInfo{Username: username, Groups: []string{"dba", "system:authenticated"}},
+		}}
+		if oldOps != nil {
+			raw, err := json.Marshal(oldOps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.OldObject = runtime.RawExtension{Raw: raw}
+		}
+		return recorder.Default(admission.NewContextWithRequest(context.Background(), req), ops)
+	}
+
+	ops := createTestOpsRequest("mysql-test", "mysql-restart", RestartType)
+	ops.Annotations = map[string]string{constant.OpsApprovedByAnnotationKey: "alice"}
+	if err := admit(admissionv1.Create, "alice", nil, ops); err == nil {
+		t.Error("expect the requester can not approve the OpsRequest on creation")
+	}
+
+	ops.Annotations = map[string]string{constant.OpsRequestedByAnnotationKey: "bob"}
+	if err := admit(admissionv1.Create, "alice", nil, ops); err != nil {
+		t.Fatal(err)
+	}
+	if ops.Annotations[constant.OpsRequestedByAnnotationKey] != "alice" {
+		t.Errorf("expect the requester is recorded as alice, but got %s", ops.Annotations[constant.OpsRequestedByAnnotationKey])
+	}
+
+	oldOps := ops.DeepCopy()
+	ops.Annotations[constant.OpsRequestedByAnnotationKey] = "bob"
+	if err := admit(admissionv1.Update, "alice", oldOps, ops); err == nil {
+		t.Error("expect the requester annotation can not be updated")
+	}
+
+	ops = oldOps.DeepCopy()
+	ops.Annotations[constant.OpsApprovedByAnnotationKey] = "bob"
+	if err := admit(admissionv1.Update, "alice", oldOps, ops); err == nil {
+		t.Error("expect the requester can not approve the OpsRequest")
+	}
+
+	ops = oldOps.DeepCopy()
+	ops.Annotations[constant.OpsApprovedByAnnotationKey] = "admin"
+	ops.Annotations[constant.OpsApprovedByGroupsAnnotationKey] = "admins"
+	if err := admit(admissionv1.Update, "bob", oldOps, ops); err != nil {
+		t.Fatal(err)
+	}
+	if ops.Annotations[constant.OpsApprovedByAnnotationKey] != "bob" {
+		t.Errorf("expect the approver is recorded as bob, but got %s", ops.Annotations[constant.OpsApprovedByAnnotationKey])
+	}
+	if ops.Annotations[constant.OpsApprovedByGroupsAnnotationKey] != "dba,system:authenticated" {
+		t.Errorf("expect the approver groups are recorded from the user info, but got %s", ops.Annotations[constant.OpsApprovedByGroupsAnnotationKey])
+	}
+
+	oldOps = ops.DeepCopy()
+	ops.Labels = map[string]string{"foo": "bar"}
+	if err := admit(admissionv1.Update, "alice", oldOps, ops); err != nil {
+		t.Fatal(err)
+	}
+	if ops.Annotations[constant.OpsApprovedByAnnotationKey] != "bob" {
+		t.Error("expect the approval is not changed by the other updates")
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpsScheduleSpec defines the schedule and the template of the OpsRequests to create.
+type OpsScheduleSpec struct {
+	// Specifies the schedule in the standard cron format, e.g. "0 2 * * 0".
+	// The time zone is UTC, unless the expression is prefixed by "CRON_TZ=<zone>",
+	// e.g. "CRON_TZ=Asia/Shanghai 0 2 * * 0".
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Specifies how to treat the concurrent executions of the OpsRequests created by the schedule.
+	//
+	// - "Allow": allows the OpsRequests to run concurrently.
+	// - "Forbid": skips the new OpsRequest if the previous one has not completed yet.
+	// - "Replace": cancels the previous OpsRequest which has not completed yet, and creates the new one.
+	//   If the previous OpsRequest can not be cancelled, it is deleted if it has not started yet,
+	//   otherwise the new OpsRequest is skipped.
+	//
+	// +kubebuilder:default=Allow
+	// +optional
+	ConcurrencyPolicy OpsConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// Specifies the deadline in seconds for starting the OpsRequest if it misses the scheduled time for any reason.
+	// The missed OpsRequest is skipped if the deadline is exceeded.
+	// If not specified, there is no deadline.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+
+	// Indicates whether the schedule is suspended.
+	// The OpsRequests which have been created are not affected.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Specifies the number of the succeeded OpsRequests to retain.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// +optional
+	SuccessfulOpsHistoryLimit *int32 `json:"successfulOpsHistoryLimit,omitempty"`
+
+	// Specifies the number of the unsuccessfully completed OpsRequests (e.g. "Failed", "Aborted", "Cancelled")
+	// to retain.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	FailedOpsHistoryLimit *int32 `json:"failedOpsHistoryLimit,omitempty"`
+
+	// Specifies the template of the OpsRequests created by the schedule.
+	//
+	// +kubebuilder:validation:Required
+	OpsRequestTemplate OpsRequestTemplateSpec `json:"opsRequestTemplate"`
+}
+
+// OpsRequestTemplateSpec describes the OpsRequests created from the template.
+type OpsRequestTemplateSpec struct {
+	// Specifies the labels and annotations of the OpsRequests.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specifies the spec of the OpsRequests.
+	// It is validated when the OpsRequest is created.
+	//
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Required
+	Spec OpsRequestSpec `json:"spec"`
+}
+
+// OpsConcurrencyPolicy describes how the OpsRequests created by an OpsSchedule are executed concurrently.
+//
+// +enum
+// +kubebuilder:validation:Enum={Allow,Forbid,Replace}
+type OpsConcurrencyPolicy string
+
+const (
+	AllowOpsConcurrent   OpsConcurrencyPolicy = "Allow"
+	ForbidOpsConcurrent  OpsConcurrencyPolicy = "Forbid"
+	ReplaceOpsConcurrent OpsConcurrencyPolicy = "Replace"
+)
+
+// OpsSchedulePhase defines the phase of the OpsSchedule.
+//
+// +enum
+// +kubebuilder:validation:Enum={Available,Failed}
+type OpsSchedulePhase string
+
+const (
+	// OpsSchedulePhaseAvailable indicates the OpsSchedule is available.
+	OpsSchedulePhaseAvailable OpsSchedulePhase = "Available"
+
+	// OpsSchedulePhaseFailed indicates the OpsSchedule is invalid, e.g. the schedule can not be parsed.
+	OpsSchedulePhaseFailed OpsSchedulePhase = "Failed"
+)
+
+// OpsScheduleStatus defines the observed state of OpsSchedule.
+type OpsScheduleStatus struct {
+	// Describes the phase of the OpsSchedule.
+	//
+	// +optional
+	Phase OpsSchedulePhase `json:"phase,omitempty"`
+
+	// Represents the most recent generation observed for the OpsSchedule.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Represents the reason why the OpsSchedule is failed.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// Lists the OpsRequests created by the schedule which have not completed yet.
+	//
+	// +optional
+	Active []corev1.ObjectReference `json:"active,omitempty"`
+
+	// Records the last time an OpsRequest was scheduled.
+	//
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Records the last time an OpsRequest created by the schedule succeeded.
+	//
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// Records the next time an OpsRequest will be scheduled.
+	//
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=opss
+// +kubebuilder:printcolumn:name="SCHEDULE",type="string",JSONPath=".spec.schedule",description="The schedule in the cron format."
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.opsRequestTemplate.spec.type",description="The type of the OpsRequests."
+// +kubebuilder:printcolumn:name="SUSPEND",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.phase",description="The phase of the OpsSchedule."
+// +kubebuilder:printcolumn:name="LAST-SCHEDULE",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpsSchedule is the Schema for the OpsSchedules API.
+//
+// OpsSchedule creates OpsRequests from a template periodically according to a cron expression,
+// e.g. for recurring restarts, switchover drills or reconfigurations.
+// The OpsRequests are created by the operator itself, and are owned by the OpsSchedule.
+type OpsSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpsScheduleSpec   `json:"spec,omitempty"`
+	Status OpsScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpsScheduleList contains a list of OpsSchedule.
+type OpsScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsSchedule{}, &OpsScheduleList{})
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpsTemplateSpec defines a reusable and parameterized OpsRequestSpec.
+type OpsTemplateSpec struct {
+	// Specifies the type of the OpsRequests created from the template.
+	// An OpsRequest which references the template must have the same type.
+	//
+	// +kubebuilder:validation:Required
+	Type OpsType `json:"type"`
+
+	// Specifies the schema of the parameters of the template.
+	// The parameter values provided by an OpsRequest are converted to the types declared in the schema,
+	// and validated against the schema before the template is rendered.
+	// The default values declared in the schema are used for the parameters not provided.
+	//
+	// +optional
+	ParametersSchema *ParametersSchema `json:"parametersSchema,omitempty"`
+
+	// Specifies a partial OpsRequestSpec in YAML format, as a Go template.
+	// Available built-in objects that can be referenced in the template include:
+	//
+	// - `parameters`: The parameters provided by the OpsRequest, typed as declared in the `parametersSchema`.
+	// - `opsRequest`: The name and namespace of the OpsRequest.
+	//
+	// The functions of the sprig library are available. The parameters should be quoted with `toJson`,
+	// so that their values can not change the structure of the rendered spec. For example:
+	//
+	// ```yaml
+	// template: |
+	//   horizontalScaling:
+	//   - componentName: {{ .parameters.component | toJson }}
+	//     scaleOut:
+	//       replicaChanges: {{ .parameters.replicas | toJson }}
+	// ```
+	//
+	// The rendered spec is merged into the spec of the OpsRequest, and the fields explicitly specified by the
+	// OpsRequest take precedence over the rendered ones.
+	//
+	// +kubebuilder:validation:Required
+	Template string `json:"template"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories={kubeblocks},shortName=ot
+// +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".spec.type",description="The type of the OpsRequests created from the template."
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpsTemplate is the Schema for the OpsTemplates API.
+//
+// OpsTemplate holds a partial OpsRequestSpec with typed parameters, which can be referenced by the OpsRequests
+// in the same namespace through `spec.templateRef`, to avoid creating near-identical OpsRequests repeatedly.
+type OpsTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec OpsTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpsTemplateList contains a list of OpsTemplate.
+type OpsTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpsTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpsTemplate{}, &OpsTemplateList{})
+}
@@ -0,0 +1,324 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceRecommenderSpec defines the Component to recommend the compute resources for,
+// and how the recommendations are computed and applied.
+type ResourceRecommenderSpec struct {
+	// Specifies the name of the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the Component in the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the source of the resource usage of the containers.
+	//
+	// +kubebuilder:default={metricsAPI: {}}
+	// +optional
+	Source ResourceUsageSource `json:"source,omitempty"`
+
+	// Specifies the percentile of the usage histogram used as the recommended requests.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=90
+	// +optional
+	TargetPercentile int32 `json:"targetPercentile,omitempty"`
+
+	// Specifies the percentage added to the recommended requests as a safety margin.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=15
+	// +optional
+	SafetyMarginPercent int32 `json:"safetyMarginPercent,omitempty"`
+
+	// Specifies the half-life in hours of the samples in the usage histograms.
+	// The weight of a sample is halved every half-life, so the recent usage contributes more to the recommendations.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=24
+	// +optional
+	HistoryHalfLifeHours int32 `json:"historyHalfLifeHours,omitempty"`
+
+	// Specifies the minimum and maximum resources which can be recommended.
+	// If not specified, the requests and the limits of the main container declared in the ComponentDefinition
+	// are used as the minimum and the maximum resources respectively, i.e. the ComponentDefinition specified by
+	// the InstanceTemplate for the instances of the template, or the one of the Component for the others.
+	//
+	// +optional
+	ResourcePolicy ResourceRecommendationPolicy `json:"resourcePolicy,omitempty"`
+
+	// Specifies whether and when to apply the recommendations.
+	//
+	// +optional
+	UpdatePolicy *ResourceRecommendationUpdatePolicy `json:"updatePolicy,omitempty"`
+
+	// Specifies the interval in seconds to sample the resource usage.
+	//
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:default=60
+	// +optional
+	CheckIntervalSeconds int32 `json:"checkIntervalSeconds,omitempty"`
+}
+
+// ResourceUsageSource defines where the resource usage of the containers is collected from.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.metricsAPI) != has(self.prometheus)",message="exactly one of metricsAPI and prometheus must be specified"
+type ResourceUsageSource struct {
+	// Collects the usage from the resource metrics API, i.e. metrics.k8s.io, which is served by the metrics-server.
+	//
+	// +optional
+	MetricsAPI *MetricsAPIUsageSource `json:"metricsAPI,omitempty"`
+
+	// Collects the usage from the cAdvisor metrics stored in a Prometheus-compatible server,
+	// i.e. `container_cpu_usage_seconds_total` and `container_memory_working_set_bytes`.
+	//
+	// +optional
+	Prometheus *PrometheusUsageSource `json:"prometheus,omitempty"`
+}
+
+// MetricsAPIUsageSource defines the resource metrics API as the usage source.
+type MetricsAPIUsageSource struct {
+}
+
+// PrometheusUsageSource defines a Prometheus-compatible server as the usage source.
+type PrometheusUsageSource struct {
+	// Specifies the address of the Prometheus-compatible server, e.g. "http://prometheus-server.monitoring:9090".
+	//
+	// +kubebuilder:validation:Required
+	Endpoint string `json:"endpoint"`
+}
+
+// ResourceRecommendationPolicy defines the range of the recommended resources.
+type ResourceRecommendationPolicy struct {
+	// Specifies the minimum requests which can be recommended.
+	//
+	// +optional
+	MinAllowed corev1.ResourceList `json:"minAllowed,omitempty"`
+
+	// Specifies the maximum requests which can be recommended.
+	//
+	// +optional
+	MaxAllowed corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// ResourceRecommendationUpdateMode defines whether the recommendations are applied.
+//
+// +enum
+// +kubebuilder:validation:Enum={Off,Auto}
+type ResourceRecommendationUpdateMode string
+
+const (
+	// ResourceRecommendationUpdateModeOff indicates the recommendations are only published in the status.
+	ResourceRecommendationUpdateModeOff ResourceRecommendationUpdateMode = "Off"
+
+	// ResourceRecommendationUpdateModeAuto indicates the recommendations are applied by VerticalScaling OpsRequests
+	// within the maintenance window.
+	ResourceRecommendationUpdateModeAuto ResourceRecommendationUpdateMode = "Auto"
+)
+
+// ResourceRecommendationUpdatePolicy defines how to apply the recommendations.
+type ResourceRecommendationUpdatePolicy struct {
+	// Specifies whether the recommendations are applied.
+	//
+	// +kubebuilder:default=Off
+	// +optional
+	Mode ResourceRecommendationUpdateMode `json:"mode,omitempty"`
+
+	// Specifies the maintenance window in which the recommendations are applied.
+	// If not specified, the recommendations are applied at any time.
+	//
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+
+	// Specifies the minimum percentage of the difference between the recommended and the current requests
+	// to apply the recommendations, which avoids restarting the instances for trivial changes.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=10
+	// +optional
+	MinChangePercent int32 `json:"minChangePercent,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring time window.
+type MaintenanceWindow struct {
+	// Specifies the start time of the window in the cron format, e.g. "0 2 * * 6".
+	//
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// Specifies the duration in minutes of the window.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=60
+	// +optional
+	DurationMinutes int32 `json:"durationMinutes,omitempty"`
+}
+
+// ResourceRecommenderStatus defines the observed state of ResourceRecommender.
+type ResourceRecommenderStatus struct {
+	// Represents the most recent generation observed for the ResourceRecommender.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Records the last time the resource usage was sampled.
+	//
+	// +optional
+	LastSampleTime *metav1.Time `json:"lastSampleTime,omitempty"`
+
+	// Represents the recommended resources of the main containers.
+	//
+	// +optional
+	Recommendations []ContainerResourceRecommendation `json:"recommendations,omitempty"`
+
+	// Records the usage histograms of the main containers, from which the recommendations are computed.
+	//
+	// +optional
+	Histograms []ContainerUsageHistogram `json:"histograms,omitempty"`
+
+	// Records the last time the recommendations were applied.
+	//
+	// +optional
+	LastApplyTime *metav1.Time `json:"lastApplyTime,omitempty"`
+
+	// Records the name of the last VerticalScaling OpsRequest created by the recommender.
+	//
+	// +optional
+	LastOpsRequest string `json:"lastOpsRequest,omitempty"`
+
+	// Provides a human-readable message, e.g. why the usage can not be collected.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ContainerResourceRecommendation represents the recommended resources of a container.
+type ContainerResourceRecommendation struct {
+	// Specifies the name of the instance template, empty for the instances not created by any template.
+	//
+	// +optional
+	InstanceTemplate string `json:"instanceTemplate,omitempty"`
+
+	// Specifies the name of the container.
+	ContainerName string `json:"containerName"`
+
+	// Represents the recommended requests and limits.
+	// The limits are scaled proportionally to the current ratio of the limits to the requests.
+	Resources corev1.ResourceRequirements `json:"resources"`
+
+	// Represents the recommended requests before they are bounded by the resource policy.
+	//
+	// +optional
+	UncappedRequests corev1.ResourceList `json:"uncappedRequests,omitempty"`
+
+	// Represents the number of the samples the recommendation is computed from.
+	//
+	// +optional
+	SampleCount int32 `json:"sampleCount,omitempty"`
+}
+
+// ContainerUsageHistogram records the usage histograms of a container.
+type ContainerUsageHistogram struct {
+	// Specifies the name of the instance template, empty for the instances not created by any template.
+	//
+	// +optional
+	InstanceTemplate string `json:"instanceTemplate,omitempty"`
+
+	// Specifies the name of the container.
+	ContainerName string `json:"containerName"`
+
+	// Represents the histogram of the CPU usage.
+	//
+	// +optional
+	CPU UsageHistogram `json:"cpu,omitempty"`
+
+	// Represents the histogram of the memory usage.
+	//
+	// +optional
+	Memory UsageHistogram `json:"memory,omitempty"`
+
+	// Represents the number of the samples in the histograms.
+	//
+	// +optional
+	SampleCount int32 `json:"sampleCount,omitempty"`
+}
+
+// UsageHistogram is a histogram with exponentially growing buckets, whose weights decay over time.
+type UsageHistogram struct {
+	// Represents the non-empty buckets of the histogram.
+	//
+	// +optional
+	Buckets []HistogramBucket `json:"buckets,omitempty"`
+}
+
+// HistogramBucket represents a bucket of the histogram.
+type HistogramBucket struct {
+	// Specifies the index of the bucket.
+	Index int32 `json:"index"`
+
+	// Specifies the weight of the bucket, in the unit of one millionth of a sample.
+	Weight int64 `json:"weight"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=rr
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName"
+// +kubebuilder:printcolumn:name="MODE",type="string",JSONPath=".spec.updatePolicy.mode"
+// +kubebuilder:printcolumn:name="LAST-SAMPLE",type="date",JSONPath=".status.lastSampleTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ResourceRecommender is the Schema for the ResourceRecommenders API.
+//
+// ResourceRecommender records the CPU and memory usage histograms of the main containers of a Component,
+// and recommends the requests and limits of the main containers from the histograms.
+// The recommendations can be applied by VerticalScaling OpsRequests within a maintenance window.
+type ResourceRecommender struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceRecommenderSpec   `json:"spec,omitempty"`
+	Status ResourceRecommenderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourceRecommenderList contains a list of ResourceRecommender.
+type ResourceRecommenderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceRecommender `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceRecommender{}, &ResourceRecommenderList{})
+}
@@ -0,0 +1,216 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StorageAutoscalingPolicySpec defines the volumes to expand automatically and the thresholds to expand them.
+type StorageAutoscalingPolicySpec struct {
+	// Specifies the name of the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.clusterName"
+	ClusterName string `json:"clusterName"`
+
+	// Specifies the name of the Component or the sharding in the Cluster.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="forbidden to update spec.componentName"
+	ComponentName string `json:"componentName"`
+
+	// Specifies the autoscaling policies of the volumeClaimTemplates of the Component.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	VolumeClaimTemplates []StorageAutoscalingVolumeClaimTemplate `json:"volumeClaimTemplates" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+
+	// Specifies the interval in seconds to check the usage of the volumes.
+	//
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:default=60
+	// +optional
+	CheckIntervalSeconds int32 `json:"checkIntervalSeconds,omitempty"`
+
+	// Specifies where the usage of the volumes is collected from.
+	//
+	// +kubebuilder:default={kbAgent: {}}
+	// +optional
+	UsageSource StorageUsageSource `json:"usageSource,omitempty"`
+
+	// Indicates whether the autoscaling is suspended.
+	//
+	// +kubebuilder:default=false
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// StorageUsageSource defines where the usage of the volumes is collected from.
+// If neither is specified, the usage is collected through kb-agent.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.kbAgent) && has(self.prometheus))",message="at most one of kbAgent and prometheus can be specified"
+type StorageUsageSource struct {
+	// Collects the usage by calling the `volumeUsage` lifecycle action defined in the ComponentDefinition through kb-agent.
+	//
+	// +optional
+	KBAgent *KBAgentStorageUsageSource `json:"kbAgent,omitempty"`
+
+	// Collects the usage from the kubelet volume metrics stored in a Prometheus-compatible server,
+	// i.e. `kubelet_volume_stats_used_bytes` and `kubelet_volume_stats_capacity_bytes`.
+	//
+	// +optional
+	Prometheus *PrometheusUsageSource `json:"prometheus,omitempty"`
+}
+
+// KBAgentStorageUsageSource defines the `volumeUsage` lifecycle action as the usage source.
+type KBAgentStorageUsageSource struct {
+}
+
+// StorageAutoscalingVolumeClaimTemplate defines how to expand the volumes of a volumeClaimTemplate.
+type StorageAutoscalingVolumeClaimTemplate struct {
+	// Specifies the name of the volumeClaimTemplate in the Component.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Specifies the percentage of the used space of a volume which triggers the expansion.
+	// The volumes of the volumeClaimTemplate are expanded if the usage of any of them exceeds the threshold.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	// +kubebuilder:default=80
+	// +optional
+	ThresholdPercent int32 `json:"thresholdPercent,omitempty"`
+
+	// Specifies the size to increase for each expansion,
+	// either a percentage of the current size (e.g. "20%") or an absolute quantity (e.g. "10Gi").
+	//
+	// +kubebuilder:validation:Pattern=`^(\d+%|[0-9.]+([KMGTPE]i?)?)$`
+	// +kubebuilder:default="20%"
+	// +optional
+	Increment string `json:"increment,omitempty"`
+
+	// Specifies the maximum size of the volumes. The volumes are not expanded beyond it.
+	//
+	// +kubebuilder:validation:Required
+	MaxSize resource.Quantity `json:"maxSize"`
+
+	// Specifies the minimum interval in seconds between two expansions,
+	// for the storage providers which limit the frequency of the expansion.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3600
+	// +optional
+	CooldownSeconds int32 `json:"cooldownSeconds,omitempty"`
+}
+
+// StorageAutoscalingPolicyStatus defines the observed state of StorageAutoscalingPolicy.
+type StorageAutoscalingPolicyStatus struct {
+	// Represents the most recent generation observed for the StorageAutoscalingPolicy.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Records the last time the usage of the volumes was checked.
+	//
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// Records the status of the volumeClaimTemplates.
+	//
+	// +patchMergeKey=name
+	// +patchStrategy=merge,retainKeys
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	VolumeClaimTemplates []StorageAutoscalingVolumeStatus `json:"volumeClaimTemplates,omitempty" patchStrategy:"merge,retainKeys" patchMergeKey:"name"`
+}
+
+// StorageAutoscalingVolumeStatus records the usage and the expansions of the volumes of a volumeClaimTemplate.
+type StorageAutoscalingVolumeStatus struct {
+	// Specifies the name of the volumeClaimTemplate.
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Represents the current size of the volumes.
+	//
+	// +optional
+	CurrentSize *resource.Quantity `json:"currentSize,omitempty"`
+
+	// Represents the highest percentage of the used space among the volumes.
+	//
+	// +optional
+	UsedPercent int32 `json:"usedPercent,omitempty"`
+
+	// Records the last time the volumes were expanded.
+	//
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// Records the name of the last VolumeExpansion OpsRequest created for the volumes.
+	//
+	// +optional
+	LastOpsRequest string `json:"lastOpsRequest,omitempty"`
+
+	// Provides a human-readable message, e.g. why the volumes can not be expanded.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},shortName=sap
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="COMPONENT",type="string",JSONPath=".spec.componentName"
+// +kubebuilder:printcolumn:name="SUSPEND",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="LAST-CHECK",type="date",JSONPath=".status.lastCheckTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// StorageAutoscalingPolicy is the Schema for the StorageAutoscalingPolicies API.
+//
+// StorageAutoscalingPolicy expands the volumes of a Component automatically by creating VolumeExpansion OpsRequests,
+// when the used space of the volumes, reported by the usage source, exceeds the threshold.
+type StorageAutoscalingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StorageAutoscalingPolicySpec   `json:"spec,omitempty"`
+	Status StorageAutoscalingPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StorageAutoscalingPolicyList contains a list of StorageAutoscalingPolicy.
+type StorageAutoscalingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageAutoscalingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StorageAutoscalingPolicy{}, &StorageAutoscalingPolicyList{})
+}
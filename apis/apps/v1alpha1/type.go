@@ -327,18 +327,55 @@ const (
 
 // OpsPhase defines opsRequest phase.
 // +enum
-// +kubebuilder:validation:Enum={Pending,Creating,Running,Cancelling,Cancelled,Aborted,Failed,Succeed}
+// +kubebuilder:validation:Enum={PendingApproval,Pending,Creating,Running,Cancelling,Cancelled,Aborted,Failed,Succeed}
 type OpsPhase string
 
 const (
-	OpsPendingPhase    OpsPhase = "Pending"
-	OpsCreatingPhase   OpsPhase = "Creating"
-	OpsRunningPhase    OpsPhase = "Running"
-	OpsCancellingPhase OpsPhase = "Cancelling"
-	OpsSucceedPhase    OpsPhase = "Succeed"
-	OpsCancelledPhase  OpsPhase = "Cancelled"
-	OpsFailedPhase     OpsPhase = "Failed"
-	OpsAbortedPhase    OpsPhase = "Aborted"
+	OpsPendingApprovalPhase OpsPhase = "PendingApproval"
+	OpsPendingPhase         OpsPhase = "Pending"
+	OpsCreatingPhase        OpsPhase = "Creating"
+	OpsRunningPhase         OpsPhase = "Running"
+	OpsCancellingPhase      OpsPhase = "Cancelling"
+	OpsSucceedPhase         OpsPhase = "Succeed"
+	OpsCancelledPhase       OpsPhase = "Cancelled"
+	OpsFailedPhase          OpsPhase = "Failed"
+	OpsAbortedPhase         OpsPhase = "Aborted"
+)
+
+// OpsFailureReason defines the reason why an attempt of the opsRequest failed.
+// +enum
+// +kubebuilder:validation:Enum={ActionFailed,ProgressFailed}
+type OpsFailureReason string
+
+const (
+	// ActionFailedReason indicates that the action of the opsRequest failed before it started running.
+	ActionFailedReason OpsFailureReason = "ActionFailed"
+	// ProgressFailedReason indicates that the opsRequest failed while running, e.g. an instance failed to be rebuilt.
+	ProgressFailedReason OpsFailureReason = "ProgressFailed"
+)
+
+// CanaryUpgradePhase defines the phase of the canary upgrade.
+// +enum
+// +kubebuilder:validation:Enum={Deploying,Verifying,Promoted,RolledBack}
+type CanaryUpgradePhase string
+
+const (
+	// CanaryDeployingPhase indicates that the canary instances are being deployed.
+	CanaryDeployingPhase CanaryUpgradePhase = "Deploying"
+	// CanaryVerifyingPhase indicates that the canary instances are waiting for the verification.
+	CanaryVerifyingPhase CanaryUpgradePhase = "Verifying"
+	// CanaryPromotedPhase indicates that the canary is promoted and the rest instances are being upgraded.
+	CanaryPromotedPhase CanaryUpgradePhase = "Promoted"
+	// CanaryRolledBackPhase indicates that the canary instances are rolled back.
+	CanaryRolledBackPhase CanaryUpgradePhase = "RolledBack"
+)
+
+// CanaryDecision defines the manual decision of the canary upgrade.
+type CanaryDecision string
+
+const (
+	CanaryPromoteDecision  CanaryDecision = "Promote"
+	CanaryRollbackDecision CanaryDecision = "Rollback"
 )
 
 // PodSelectionPolicy pod selection strategy.
@@ -374,7 +411,7 @@ const (
 
 // OpsType defines operation types.
 // +enum
-// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Custom}
+// +kubebuilder:validation:Enum={Upgrade,VerticalScaling,VolumeExpansion,HorizontalScaling,Restart,Reconfiguring,Start,Stop,Expose,Switchover,Backup,Restore,RebuildInstance,Migrate,DataExport,DataImport,Custom}
 type OpsType string
 
 const (
@@ -391,6 +428,9 @@ const (
 	BackupType            OpsType = "Backup"
 	RestoreType           OpsType = "Restore"
 	RebuildInstanceType   OpsType = "RebuildInstance" // RebuildInstance rebuilding an instance is very useful when a node is offline or an instance is unrecoverable.
+	MigrateType           OpsType = "Migrate"         // MigrateType migrates the instances to other nodes or zones.
+	DataExportType        OpsType = "DataExport"      // DataExportType exports the logical data of a component to a BackupRepo.
+	DataImportType        OpsType = "DataImport"      // DataImportType imports the logical data from a BackupRepo into a component.
 	CustomType            OpsType = "Custom"          // use opsDefinition
 )
 
@@ -583,6 +623,11 @@ type OpsRecorder struct {
 	Type OpsType `json:"type"`
 	// indicates whether the current opsRequest is in the queue
 	InQueue bool `json:"inQueue,omitempty"`
+	// the components that the opsRequest operates on, empty means all components of the cluster.
+	Components []string `json:"components,omitempty"`
+	// the instances that the opsRequest operates on, empty means all instances of the components.
+	Instances []string `json:"instances,omitempty"`
+	// Deprecated: replaced by the conflict rules of the ops types, it is kept to decode the legacy records.
 	// indicates that the operation is queued for execution within its own-type scope.
 	QueueBySelf bool `json:"queueBySelf,omitempty"`
 }
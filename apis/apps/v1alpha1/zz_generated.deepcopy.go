@@ -120,6 +120,32 @@ func (in *Affinity) DeepCopy() *Affinity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingMetric) DeepCopyInto(out *AutoscalingMetric) {
+	*out = *in
+	if in.KBAgent != nil {
+		in, out := &in.KBAgent, &out.KBAgent
+		*out = new(KBAgentMetricSource)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusMetricSource)
+		**out = **in
+	}
+	out.TargetValue = in.TargetValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingMetric.
+func (in *AutoscalingMetric) DeepCopy() *AutoscalingMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backup) DeepCopyInto(out *Backup) {
 	*out = *in
@@ -315,6 +341,86 @@ func (in *BackupRefSpec) DeepCopy() *BackupRefSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpgrade) DeepCopyInto(out *CanaryUpgrade) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Ordinals != nil {
+		in, out := &in.Ordinals, &out.Ordinals
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(InstanceHealthGate)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpgrade.
+func (in *CanaryUpgrade) DeepCopy() *CanaryUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpgradeStatus) DeepCopyInto(out *CanaryUpgradeStatus) {
+	*out = *in
+	if in.CanaryInstances != nil {
+		in, out := &in.CanaryInstances, &out.CanaryInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BaselineInstances != nil {
+		in, out := &in.BaselineInstances, &out.BaselineInstances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpgradeStatus.
+func (in *CanaryUpgradeStatus) DeepCopy() *CanaryUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CancelResult) DeepCopyInto(out *CancelResult) {
+	*out = *in
+	if in.Reverted != nil {
+		in, out := &in.Reverted, &out.Reverted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotReverted != nil {
+		in, out := &in.NotReverted, &out.NotReverted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CancelResult.
+func (in *CancelResult) DeepCopy() *CancelResult {
+	if in == nil {
+		return nil
+	}
+	out := new(CancelResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClassDefRef) DeepCopyInto(out *ClassDefRef) {
 	*out = *in
@@ -1414,6 +1520,131 @@ func (in *Component) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscaler) DeepCopyInto(out *ComponentAutoscaler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscaler.
+func (in *ComponentAutoscaler) DeepCopy() *ComponentAutoscaler {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentAutoscaler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscalerList) DeepCopyInto(out *ComponentAutoscalerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComponentAutoscaler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscalerList.
+func (in *ComponentAutoscalerList) DeepCopy() *ComponentAutoscalerList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscalerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentAutoscalerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscalerSpec) DeepCopyInto(out *ComponentAutoscalerSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.Metric.DeepCopyInto(&out.Metric)
+	if in.ScaleOutStabilizationWindowSeconds != nil {
+		in, out := &in.ScaleOutStabilizationWindowSeconds, &out.ScaleOutStabilizationWindowSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleInStabilizationWindowSeconds != nil {
+		in, out := &in.ScaleInStabilizationWindowSeconds, &out.ScaleInStabilizationWindowSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscalerSpec.
+func (in *ComponentAutoscalerSpec) DeepCopy() *ComponentAutoscalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentAutoscalerStatus) DeepCopyInto(out *ComponentAutoscalerStatus) {
+	*out = *in
+	if in.CurrentMetricValue != nil {
+		in, out := &in.CurrentMetricValue, &out.CurrentMetricValue
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = make([]ReplicasRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentAutoscalerStatus.
+func (in *ComponentAutoscalerStatus) DeepCopy() *ComponentAutoscalerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentAutoscalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentConfigSpec) DeepCopyInto(out *ComponentConfigSpec) {
 	*out = *in
@@ -2719,6 +2950,13 @@ func (in *ConfigurationItemStatus) DeepCopyInto(out *ConfigurationItemStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.PreviousConfiguration != nil {
+		in, out := &in.PreviousConfiguration, &out.PreviousConfiguration
+		*out = make([]ParameterConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.UpdatedParameters.DeepCopyInto(&out.UpdatedParameters)
 }
 
@@ -2889,6 +3127,46 @@ func (in *ConsensusSetSpec) DeepCopy() *ConsensusSetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerResourceRecommendation) DeepCopyInto(out *ContainerResourceRecommendation) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.UncappedRequests != nil {
+		in, out := &in.UncappedRequests, &out.UncappedRequests
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResourceRecommendation.
+func (in *ContainerResourceRecommendation) DeepCopy() *ContainerResourceRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerResourceRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerUsageHistogram) DeepCopyInto(out *ContainerUsageHistogram) {
+	*out = *in
+	in.CPU.DeepCopyInto(&out.CPU)
+	in.Memory.DeepCopyInto(&out.Memory)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerUsageHistogram.
+func (in *ContainerUsageHistogram) DeepCopy() *ContainerUsageHistogram {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerUsageHistogram)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerVars) DeepCopyInto(out *ContainerVars) {
 	*out = *in
@@ -3061,6 +3339,70 @@ func (in *CustomParametersValidation) DeepCopy() *CustomParametersValidation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataExport) DeepCopyInto(out *DataExport) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	out.DataRepoLocation = in.DataRepoLocation
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataExport.
+func (in *DataExport) DeepCopy() *DataExport {
+	if in == nil {
+		return nil
+	}
+	out := new(DataExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataImport) DeepCopyInto(out *DataImport) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	out.DataRepoLocation = in.DataRepoLocation
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataImport.
+func (in *DataImport) DeepCopy() *DataImport {
+	if in == nil {
+		return nil
+	}
+	out := new(DataImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataRepoLocation) DeepCopyInto(out *DataRepoLocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataRepoLocation.
+func (in *DataRepoLocation) DeepCopy() *DataRepoLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(DataRepoLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataTransferStatus) DeepCopyInto(out *DataTransferStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataTransferStatus.
+func (in *DataTransferStatus) DeepCopy() *DataTransferStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataTransferStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvMappingVar) DeepCopyInto(out *EnvMappingVar) {
 	*out = *in
@@ -3238,6 +3580,21 @@ func (in *HTTPAction) DeepCopy() *HTTPAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HistogramBucket) DeepCopyInto(out *HistogramBucket) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HistogramBucket.
+func (in *HistogramBucket) DeepCopy() *HistogramBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(HistogramBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizontalScalePolicy) DeepCopyInto(out *HorizontalScalePolicy) {
 	*out = *in
@@ -3379,32 +3736,66 @@ func (in *Instance) DeepCopy() *Instance {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstanceReplicasTemplate) DeepCopyInto(out *InstanceReplicasTemplate) {
+func (in *InstanceHealthGate) DeepCopyInto(out *InstanceHealthGate) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceReplicasTemplate.
-func (in *InstanceReplicasTemplate) DeepCopy() *InstanceReplicasTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceHealthGate.
+func (in *InstanceHealthGate) DeepCopy() *InstanceHealthGate {
 	if in == nil {
 		return nil
 	}
-	out := new(InstanceReplicasTemplate)
+	out := new(InstanceHealthGate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstanceResourceTemplate) DeepCopyInto(out *InstanceResourceTemplate) {
+func (in *InstanceHealthGateStatus) DeepCopyInto(out *InstanceHealthGateStatus) {
 	*out = *in
-	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceResourceTemplate.
-func (in *InstanceResourceTemplate) DeepCopy() *InstanceResourceTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceHealthGateStatus.
+func (in *InstanceHealthGateStatus) DeepCopy() *InstanceHealthGateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InstanceResourceTemplate)
+	out := new(InstanceHealthGateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceReplicasTemplate) DeepCopyInto(out *InstanceReplicasTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceReplicasTemplate.
+func (in *InstanceReplicasTemplate) DeepCopy() *InstanceReplicasTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceReplicasTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceResourceTemplate) DeepCopyInto(out *InstanceResourceTemplate) {
+	*out = *in
+	in.ResourceRequirements.DeepCopyInto(&out.ResourceRequirements)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceResourceTemplate.
+func (in *InstanceResourceTemplate) DeepCopy() *InstanceResourceTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceResourceTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3568,6 +3959,36 @@ func (in *JSONPatchOperation) DeepCopy() *JSONPatchOperation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KBAgentMetricSource) DeepCopyInto(out *KBAgentMetricSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KBAgentMetricSource.
+func (in *KBAgentMetricSource) DeepCopy() *KBAgentMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(KBAgentMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KBAgentStorageUsageSource) DeepCopyInto(out *KBAgentStorageUsageSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KBAgentStorageUsageSource.
+func (in *KBAgentStorageUsageSource) DeepCopy() *KBAgentStorageUsageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(KBAgentStorageUsageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LastComponentConfiguration) DeepCopyInto(out *LastComponentConfiguration) {
 	*out = *in
@@ -3709,6 +4130,21 @@ func (in *LogConfig) DeepCopy() *LogConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchExpressions) DeepCopyInto(out *MatchExpressions) {
 	*out = *in
@@ -3724,6 +4160,66 @@ func (in *MatchExpressions) DeepCopy() *MatchExpressions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsAPIUsageSource) DeepCopyInto(out *MetricsAPIUsageSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsAPIUsageSource.
+func (in *MetricsAPIUsageSource) DeepCopy() *MetricsAPIUsageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsAPIUsageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Migrate) DeepCopyInto(out *Migrate) {
+	*out = *in
+	out.ComponentOps = in.ComponentOps
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]MigrateInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Migrate.
+func (in *Migrate) DeepCopy() *Migrate {
+	if in == nil {
+		return nil
+	}
+	out := new(Migrate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrateInstance) DeepCopyInto(out *MigrateInstance) {
+	*out = *in
+	if in.TargetNodeSelector != nil {
+		in, out := &in.TargetNodeSelector, &out.TargetNodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrateInstance.
+func (in *MigrateInstance) DeepCopy() *MigrateInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrateInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitorConfig) DeepCopyInto(out *MonitorConfig) {
 	*out = *in
@@ -3859,6 +4355,181 @@ func (in *OpsAction) DeepCopy() *OpsAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsApprovalPolicy) DeepCopyInto(out *OpsApprovalPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsApprovalPolicy.
+func (in *OpsApprovalPolicy) DeepCopy() *OpsApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsApprovalPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsApprovalPolicyList) DeepCopyInto(out *OpsApprovalPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpsApprovalPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsApprovalPolicyList.
+func (in *OpsApprovalPolicyList) DeepCopy() *OpsApprovalPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsApprovalPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsApprovalPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsApprovalPolicySpec) DeepCopyInto(out *OpsApprovalPolicySpec) {
+	*out = *in
+	if in.OpsTypes != nil {
+		in, out := &in.OpsTypes, &out.OpsTypes
+		*out = make([]OpsType, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Approvers.DeepCopyInto(&out.Approvers)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsApprovalPolicySpec.
+func (in *OpsApprovalPolicySpec) DeepCopy() *OpsApprovalPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsApprovalPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsApprovalPolicyStatus) DeepCopyInto(out *OpsApprovalPolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsApprovalPolicyStatus.
+func (in *OpsApprovalPolicyStatus) DeepCopy() *OpsApprovalPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsApprovalPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsApprovalStatus) DeepCopyInto(out *OpsApprovalStatus) {
+	*out = *in
+	if in.ApproverGroups != nil {
+		in, out := &in.ApproverGroups, &out.ApproverGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApprovalTimestamp != nil {
+		in, out := &in.ApprovalTimestamp, &out.ApprovalTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsApprovalStatus.
+func (in *OpsApprovalStatus) DeepCopy() *OpsApprovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsApprovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsApprovers) DeepCopyInto(out *OpsApprovers) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsApprovers.
+func (in *OpsApprovers) DeepCopy() *OpsApprovers {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsApprovers)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsAttemptStatus) DeepCopyInto(out *OpsAttemptStatus) {
+	*out = *in
+	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
+	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
+	if in.NextRetryTimestamp != nil {
+		in, out := &in.NextRetryTimestamp, &out.NextRetryTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsAttemptStatus.
+func (in *OpsAttemptStatus) DeepCopy() *OpsAttemptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsAttemptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsDefinition) DeepCopyInto(out *OpsDefinition) {
 	*out = *in
@@ -4022,6 +4693,16 @@ func (in *OpsExecAction) DeepCopy() *OpsExecAction {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRecorder) DeepCopyInto(out *OpsRecorder) {
 	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRecorder.
@@ -4097,6 +4778,18 @@ func (in *OpsRequestComponentStatus) DeepCopyInto(out *OpsRequestComponentStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HealthGates != nil {
+		in, out := &in.HealthGates, &out.HealthGates
+		*out = make([]InstanceHealthGateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestComponentStatus.
@@ -4154,11 +4847,26 @@ func (in *OpsRequestSpec) DeepCopyInto(out *OpsRequestSpec) {
 		*out = new(int32)
 		**out = **in
 	}
-	in.SpecificOpsRequest.DeepCopyInto(&out.SpecificOpsRequest)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestSpec.
-func (in *OpsRequestSpec) DeepCopy() *OpsRequestSpec {
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(InstanceHealthGate)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(OpsRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(OpsTemplateRef)
+		(*in).DeepCopyInto(*out)
+	}
+	in.SpecificOpsRequest.DeepCopyInto(&out.SpecificOpsRequest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestSpec.
+func (in *OpsRequestSpec) DeepCopy() *OpsRequestSpec {
 	if in == nil {
 		return nil
 	}
@@ -4171,6 +4879,11 @@ func (in *OpsRequestSpec) DeepCopy() *OpsRequestSpec {
 func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 	*out = *in
 	in.LastConfiguration.DeepCopyInto(&out.LastConfiguration)
+	if in.DataTransfer != nil {
+		in, out := &in.DataTransfer, &out.DataTransfer
+		*out = new(DataTransferStatus)
+		**out = **in
+	}
 	if in.Components != nil {
 		in, out := &in.Components, &out.Components
 		*out = make(map[string]OpsRequestComponentStatus, len(*in))
@@ -4194,6 +4907,23 @@ func (in *OpsRequestStatus) DeepCopyInto(out *OpsRequestStatus) {
 	in.StartTimestamp.DeepCopyInto(&out.StartTimestamp)
 	in.CompletionTimestamp.DeepCopyInto(&out.CompletionTimestamp)
 	in.CancelTimestamp.DeepCopyInto(&out.CancelTimestamp)
+	if in.CancelResult != nil {
+		in, out := &in.CancelResult, &out.CancelResult
+		*out = new(CancelResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(OpsApprovalStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]OpsAttemptStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ReconfiguringStatus != nil {
 		in, out := &in.ReconfiguringStatus, &out.ReconfiguringStatus
 		*out = new(ReconfiguringStatus)
@@ -4234,6 +4964,23 @@ func (in *OpsRequestStatus) DeepCopy() *OpsRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRequestTemplateSpec) DeepCopyInto(out *OpsRequestTemplateSpec) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRequestTemplateSpec.
+func (in *OpsRequestTemplateSpec) DeepCopy() *OpsRequestTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRequestTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsRequestVolumeClaimTemplate) DeepCopyInto(out *OpsRequestVolumeClaimTemplate) {
 	*out = *in
@@ -4272,6 +5019,148 @@ func (in *OpsResourceModifierAction) DeepCopy() *OpsResourceModifierAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsRetryPolicy) DeepCopyInto(out *OpsRetryPolicy) {
+	*out = *in
+	if in.RetryableReasons != nil {
+		in, out := &in.RetryableReasons, &out.RetryableReasons
+		*out = make([]OpsFailureReason, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsRetryPolicy.
+func (in *OpsRetryPolicy) DeepCopy() *OpsRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsSchedule) DeepCopyInto(out *OpsSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsSchedule.
+func (in *OpsSchedule) DeepCopy() *OpsSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsScheduleList) DeepCopyInto(out *OpsScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpsSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsScheduleList.
+func (in *OpsScheduleList) DeepCopy() *OpsScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsScheduleSpec) DeepCopyInto(out *OpsScheduleSpec) {
+	*out = *in
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SuccessfulOpsHistoryLimit != nil {
+		in, out := &in.SuccessfulOpsHistoryLimit, &out.SuccessfulOpsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedOpsHistoryLimit != nil {
+		in, out := &in.FailedOpsHistoryLimit, &out.FailedOpsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.OpsRequestTemplate.DeepCopyInto(&out.OpsRequestTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsScheduleSpec.
+func (in *OpsScheduleSpec) DeepCopy() *OpsScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsScheduleStatus) DeepCopyInto(out *OpsScheduleStatus) {
+	*out = *in
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsScheduleStatus.
+func (in *OpsScheduleStatus) DeepCopy() *OpsScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsService) DeepCopyInto(out *OpsService) {
 	*out = *in
@@ -4318,6 +5207,104 @@ func (in *OpsService) DeepCopy() *OpsService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsTemplate) DeepCopyInto(out *OpsTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsTemplate.
+func (in *OpsTemplate) DeepCopy() *OpsTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsTemplateList) DeepCopyInto(out *OpsTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpsTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsTemplateList.
+func (in *OpsTemplateList) DeepCopy() *OpsTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpsTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsTemplateRef) DeepCopyInto(out *OpsTemplateRef) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]Parameter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsTemplateRef.
+func (in *OpsTemplateRef) DeepCopy() *OpsTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsTemplateSpec) DeepCopyInto(out *OpsTemplateSpec) {
+	*out = *in
+	if in.ParametersSchema != nil {
+		in, out := &in.ParametersSchema, &out.ParametersSchema
+		*out = new(ParametersSchema)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsTemplateSpec.
+func (in *OpsTemplateSpec) DeepCopy() *OpsTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpsVarSource) DeepCopyInto(out *OpsVarSource) {
 	*out = *in
@@ -4698,6 +5685,36 @@ func (in *ProgressStatusDetail) DeepCopy() *ProgressStatusDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusMetricSource) DeepCopyInto(out *PrometheusMetricSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusMetricSource.
+func (in *PrometheusMetricSource) DeepCopy() *PrometheusMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusUsageSource) DeepCopyInto(out *PrometheusUsageSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusUsageSource.
+func (in *PrometheusUsageSource) DeepCopy() *PrometheusUsageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusUsageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProtectedVolume) DeepCopyInto(out *ProtectedVolume) {
 	*out = *in
@@ -4943,103 +5960,311 @@ func (in *ReloadOptions) DeepCopyInto(out *ReloadOptions) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloadOptions.
-func (in *ReloadOptions) DeepCopy() *ReloadOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReloadOptions.
+func (in *ReloadOptions) DeepCopy() *ReloadOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ReloadOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaChanger) DeepCopyInto(out *ReplicaChanger) {
+	*out = *in
+	if in.ReplicaChanges != nil {
+		in, out := &in.ReplicaChanges, &out.ReplicaChanges
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]InstanceReplicasTemplate, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaChanger.
+func (in *ReplicaChanger) DeepCopy() *ReplicaChanger {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaChanger)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaRole) DeepCopyInto(out *ReplicaRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaRole.
+func (in *ReplicaRole) DeepCopy() *ReplicaRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicasLimit) DeepCopyInto(out *ReplicasLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasLimit.
+func (in *ReplicasLimit) DeepCopy() *ReplicasLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicasRecommendation) DeepCopyInto(out *ReplicasRecommendation) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasRecommendation.
+func (in *ReplicasRecommendation) DeepCopy() *ReplicasRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSetSpec) DeepCopyInto(out *ReplicationSetSpec) {
+	*out = *in
+	in.StatefulSetSpec.DeepCopyInto(&out.StatefulSetSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSetSpec.
+func (in *ReplicationSetSpec) DeepCopy() *ReplicationSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMeta) DeepCopyInto(out *ResourceMeta) {
+	*out = *in
+	if in.AsVolumeFrom != nil {
+		in, out := &in.AsVolumeFrom, &out.AsVolumeFrom
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMeta.
+func (in *ResourceMeta) DeepCopy() *ResourceMeta {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceMeta)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationPolicy) DeepCopyInto(out *ResourceRecommendationPolicy) {
+	*out = *in
+	if in.MinAllowed != nil {
+		in, out := &in.MinAllowed, &out.MinAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.MaxAllowed != nil {
+		in, out := &in.MaxAllowed, &out.MaxAllowed
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationPolicy.
+func (in *ResourceRecommendationPolicy) DeepCopy() *ResourceRecommendationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationUpdatePolicy) DeepCopyInto(out *ResourceRecommendationUpdatePolicy) {
+	*out = *in
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationUpdatePolicy.
+func (in *ResourceRecommendationUpdatePolicy) DeepCopy() *ResourceRecommendationUpdatePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ReloadOptions)
+	out := new(ResourceRecommendationUpdatePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicaChanger) DeepCopyInto(out *ReplicaChanger) {
+func (in *ResourceRecommender) DeepCopyInto(out *ResourceRecommender) {
 	*out = *in
-	if in.ReplicaChanges != nil {
-		in, out := &in.ReplicaChanges, &out.ReplicaChanges
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Instances != nil {
-		in, out := &in.Instances, &out.Instances
-		*out = make([]InstanceReplicasTemplate, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaChanger.
-func (in *ReplicaChanger) DeepCopy() *ReplicaChanger {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommender.
+func (in *ResourceRecommender) DeepCopy() *ResourceRecommender {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicaChanger)
+	out := new(ResourceRecommender)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceRecommender) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicaRole) DeepCopyInto(out *ReplicaRole) {
+func (in *ResourceRecommenderList) DeepCopyInto(out *ResourceRecommenderList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceRecommender, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaRole.
-func (in *ReplicaRole) DeepCopy() *ReplicaRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommenderList.
+func (in *ResourceRecommenderList) DeepCopy() *ResourceRecommenderList {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicaRole)
+	out := new(ResourceRecommenderList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceRecommenderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicasLimit) DeepCopyInto(out *ReplicasLimit) {
+func (in *ResourceRecommenderSpec) DeepCopyInto(out *ResourceRecommenderSpec) {
 	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	in.ResourcePolicy.DeepCopyInto(&out.ResourcePolicy)
+	if in.UpdatePolicy != nil {
+		in, out := &in.UpdatePolicy, &out.UpdatePolicy
+		*out = new(ResourceRecommendationUpdatePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasLimit.
-func (in *ReplicasLimit) DeepCopy() *ReplicasLimit {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommenderSpec.
+func (in *ResourceRecommenderSpec) DeepCopy() *ResourceRecommenderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicasLimit)
+	out := new(ResourceRecommenderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationSetSpec) DeepCopyInto(out *ReplicationSetSpec) {
+func (in *ResourceRecommenderStatus) DeepCopyInto(out *ResourceRecommenderStatus) {
 	*out = *in
-	in.StatefulSetSpec.DeepCopyInto(&out.StatefulSetSpec)
+	if in.LastSampleTime != nil {
+		in, out := &in.LastSampleTime, &out.LastSampleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = make([]ContainerResourceRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Histograms != nil {
+		in, out := &in.Histograms, &out.Histograms
+		*out = make([]ContainerUsageHistogram, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastApplyTime != nil {
+		in, out := &in.LastApplyTime, &out.LastApplyTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSetSpec.
-func (in *ReplicationSetSpec) DeepCopy() *ReplicationSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommenderStatus.
+func (in *ResourceRecommenderStatus) DeepCopy() *ResourceRecommenderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationSetSpec)
+	out := new(ResourceRecommenderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceMeta) DeepCopyInto(out *ResourceMeta) {
+func (in *ResourceUsageSource) DeepCopyInto(out *ResourceUsageSource) {
 	*out = *in
-	if in.AsVolumeFrom != nil {
-		in, out := &in.AsVolumeFrom, &out.AsVolumeFrom
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MetricsAPI != nil {
+		in, out := &in.MetricsAPI, &out.MetricsAPI
+		*out = new(MetricsAPIUsageSource)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusUsageSource)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceMeta.
-func (in *ResourceMeta) DeepCopy() *ResourceMeta {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageSource.
+func (in *ResourceUsageSource) DeepCopy() *ResourceUsageSource {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceMeta)
+	out := new(ResourceUsageSource)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5678,7 +6903,9 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 	if in.SwitchoverList != nil {
 		in, out := &in.SwitchoverList, &out.SwitchoverList
 		*out = make([]Switchover, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.VerticalScalingList != nil {
 		in, out := &in.VerticalScalingList, &out.VerticalScalingList
@@ -5733,6 +6960,23 @@ func (in *SpecificOpsRequest) DeepCopyInto(out *SpecificOpsRequest) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MigrateList != nil {
+		in, out := &in.MigrateList, &out.MigrateList
+		*out = make([]Migrate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DataExport != nil {
+		in, out := &in.DataExport, &out.DataExport
+		*out = new(DataExport)
+		**out = **in
+	}
+	if in.DataImport != nil {
+		in, out := &in.DataImport, &out.DataImport
+		*out = new(DataImport)
+		**out = **in
+	}
 	if in.CustomOps != nil {
 		in, out := &in.CustomOps, &out.CustomOps
 		*out = new(CustomOps)
@@ -5786,10 +7030,188 @@ func (in *StatelessSetSpec) DeepCopy() *StatelessSetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingPolicy) DeepCopyInto(out *StorageAutoscalingPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingPolicy.
+func (in *StorageAutoscalingPolicy) DeepCopy() *StorageAutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageAutoscalingPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingPolicyList) DeepCopyInto(out *StorageAutoscalingPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StorageAutoscalingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingPolicyList.
+func (in *StorageAutoscalingPolicyList) DeepCopy() *StorageAutoscalingPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageAutoscalingPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingPolicySpec) DeepCopyInto(out *StorageAutoscalingPolicySpec) {
+	*out = *in
+	in.UsageSource.DeepCopyInto(&out.UsageSource)
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]StorageAutoscalingVolumeClaimTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingPolicySpec.
+func (in *StorageAutoscalingPolicySpec) DeepCopy() *StorageAutoscalingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingPolicyStatus) DeepCopyInto(out *StorageAutoscalingPolicyStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]StorageAutoscalingVolumeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingPolicyStatus.
+func (in *StorageAutoscalingPolicyStatus) DeepCopy() *StorageAutoscalingPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingVolumeClaimTemplate) DeepCopyInto(out *StorageAutoscalingVolumeClaimTemplate) {
+	*out = *in
+	out.MaxSize = in.MaxSize.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingVolumeClaimTemplate.
+func (in *StorageAutoscalingVolumeClaimTemplate) DeepCopy() *StorageAutoscalingVolumeClaimTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingVolumeClaimTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageAutoscalingVolumeStatus) DeepCopyInto(out *StorageAutoscalingVolumeStatus) {
+	*out = *in
+	if in.CurrentSize != nil {
+		in, out := &in.CurrentSize, &out.CurrentSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageAutoscalingVolumeStatus.
+func (in *StorageAutoscalingVolumeStatus) DeepCopy() *StorageAutoscalingVolumeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageAutoscalingVolumeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageUsageSource) DeepCopyInto(out *StorageUsageSource) {
+	*out = *in
+	if in.KBAgent != nil {
+		in, out := &in.KBAgent, &out.KBAgent
+		*out = new(KBAgentStorageUsageSource)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusUsageSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageUsageSource.
+func (in *StorageUsageSource) DeepCopy() *StorageUsageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageUsageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Switchover) DeepCopyInto(out *Switchover) {
 	*out = *in
 	out.ComponentOps = in.ComponentOps
+	if in.CandidateSelector != nil {
+		in, out := &in.CandidateSelector, &out.CandidateSelector
+		*out = new(SwitchoverCandidateSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Switchover.
@@ -5827,6 +7249,31 @@ func (in *SwitchoverAction) DeepCopy() *SwitchoverAction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchoverCandidateSelector) DeepCopyInto(out *SwitchoverCandidateSelector) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceTemplates != nil {
+		in, out := &in.InstanceTemplates, &out.InstanceTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchoverCandidateSelector.
+func (in *SwitchoverCandidateSelector) DeepCopy() *SwitchoverCandidateSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchoverCandidateSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SwitchoverSpec) DeepCopyInto(out *SwitchoverSpec) {
 	*out = *in
@@ -6069,6 +7516,11 @@ func (in *UpgradeComponent) DeepCopyInto(out *UpgradeComponent) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeComponent.
@@ -6081,6 +7533,26 @@ func (in *UpgradeComponent) DeepCopy() *UpgradeComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageHistogram) DeepCopyInto(out *UsageHistogram) {
+	*out = *in
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]HistogramBucket, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageHistogram.
+func (in *UsageHistogram) DeepCopy() *UsageHistogram {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageHistogram)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserResourceRefs) DeepCopyInto(out *UserResourceRefs) {
 	*out = *in
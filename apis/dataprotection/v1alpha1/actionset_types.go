@@ -117,6 +117,19 @@ type BackupActionSpec struct {
 	//
 	// +optional
 	PreDeleteBackup *BaseJobActionSpec `json:"preDelete,omitempty"`
+
+	// Represents the action to consolidate an incremental backup chain into a synthetic full backup.
+	// It is only applicable to the Incremental ActionSet, and is executed by the backup created for
+	// the `consolidation` of a backup schedule, instead of the backupData action.
+	//
+	// The backups of the chain are passed by the environment variables `DP_CONSOLIDATE_BACKUP_NAMES`
+	// and `DP_CONSOLIDATE_BACKUP_PATHS`, which are space-separated and ordered from the full backup
+	// to the latest incremental backup. The synthetic full backup should be written into `DP_BACKUP_BASE_PATH`
+	// in the format that the restore action of this ActionSet accepts, and the backup info should be written
+	// into `DP_BACKUP_INFO_FILE` in the same way as the backupData action.
+	//
+	// +optional
+	Consolidate *JobActionSpec `json:"consolidate,omitempty"`
 }
 
 // BackupDataActionSpec defines how to back up data.
@@ -173,6 +173,18 @@ type BackupStatus struct {
 	// +optional
 	EncryptionConfig *EncryptionConfig `json:"encryptionConfig,omitempty"`
 
+	// Records the name of the synthetic full backup which the parent backup is consolidated into.
+	// It is set by the controller when the incremental backup is re-parented, and takes precedence over
+	// `spec.parentBackupName`.
+	//
+	// +optional
+	ParentBackupName string `json:"parentBackupName,omitempty"`
+
+	// Records the integrity manifests of the backup data and the result of the last verification.
+	//
+	// +optional
+	Integrity *BackupIntegrity `json:"integrity,omitempty"`
+
 	// Records the actions status for this backup.
 	//
 	// +optional
@@ -207,8 +219,94 @@ type BackupTimeRange struct {
 	//
 	// +optional
 	End *metav1.Time `json:"end,omitempty"`
+
+	// Records the time ranges within the time range whose data is missing, e.g. the logs failed to be archived
+	// by the continuous backup. The data can not be restored to any point in time within the gaps.
+	//
+	// +optional
+	Gaps []BackupTimeGap `json:"gaps,omitempty"`
+}
+
+// BackupTimeGap records a time range whose data is missing.
+type BackupTimeGap struct {
+	// Records the start time of the gap, in Coordinated Universal Time (UTC).
+	Start metav1.Time `json:"start"`
+
+	// Records the end time of the gap, in Coordinated Universal Time (UTC).
+	End metav1.Time `json:"end"`
 }
 
+// BackupIntegrity records the integrity manifests of the backup data and the result of the last check.
+type BackupIntegrity struct {
+	// Records the integrity manifests written into the backup paths of the targets.
+	// Each manifest lists the size and the SHA-256 checksum of each file under the backup path,
+	// it is verified before the data is restored.
+	//
+	// +optional
+	Manifests []BackupIntegrityManifest `json:"manifests,omitempty"`
+
+	// Records the result of the last integrity check requested by the annotation
+	// `dataprotection.kubeblocks.io/verify-integrity`, which re-checks the backup data
+	// in the backup repo against the manifests without restoring it.
+	//
+	// +optional
+	LastCheck *BackupIntegrityCheck `json:"lastCheck,omitempty"`
+}
+
+// BackupIntegrityManifest records the integrity manifest of a backup path.
+type BackupIntegrityManifest struct {
+	// Specifies the backup path covered by the manifest, the manifest is stored under this path.
+	//
+	// +kubebuilder:validation:Required
+	Path string `json:"path"`
+
+	// Specifies the SHA-256 digest of the manifest, in the format of "sha256:<hex>".
+	//
+	// +kubebuilder:validation:Required
+	Digest string `json:"digest"`
+}
+
+// BackupIntegrityCheck records the result of checking the backup data in the backup repo
+// against the integrity manifests.
+type BackupIntegrityCheck struct {
+	// The current phase of the integrity check.
+	//
+	// +optional
+	Phase BackupIntegrityCheckPhase `json:"phase,omitempty"`
+
+	// Records the time the integrity check was started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time the integrity check was completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the reason why the integrity check failed.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// BackupIntegrityCheckPhase describes the phase of the backup integrity check.
+// +enum
+// +kubebuilder:validation:Enum={Running,Passed,Failed}
+type BackupIntegrityCheckPhase string
+
+const (
+	// BackupIntegrityCheckPhaseRunning means the backup data is being checked.
+	BackupIntegrityCheckPhaseRunning BackupIntegrityCheckPhase = "Running"
+
+	// BackupIntegrityCheckPhasePassed means the backup data matches the integrity manifests.
+	BackupIntegrityCheckPhasePassed BackupIntegrityCheckPhase = "Passed"
+
+	// BackupIntegrityCheckPhaseFailed means the backup data is missing or corrupted, or the
+	// integrity check can not be performed.
+	BackupIntegrityCheckPhaseFailed BackupIntegrityCheckPhase = "Failed"
+)
+
 // BackupDeletionPolicy describes the policy for end-of-life maintenance of backup content.
 // +enum
 // +kubebuilder:validation:Enum={Delete,Retain}
@@ -221,7 +319,7 @@ const (
 
 // BackupPhase describes the lifecycle phase of a Backup.
 // +enum
-// +kubebuilder:validation:Enum={New,InProgress,Running,Completed,Failed,Deleting}
+// +kubebuilder:validation:Enum={New,Pending,InProgress,Running,Completed,Failed,Deleting}
 type BackupPhase string
 
 const (
@@ -229,6 +327,10 @@ const (
 	// the BackupController.
 	BackupPhaseNew BackupPhase = "New"
 
+	// BackupPhasePending means the backup is waiting for the other backups and restores to finish,
+	// as the number of the concurrent backup and restore jobs reaches the limit.
+	BackupPhasePending BackupPhase = "Pending"
+
 	// BackupPhaseRunning means the backup is currently executing.
 	BackupPhaseRunning BackupPhase = "Running"
 
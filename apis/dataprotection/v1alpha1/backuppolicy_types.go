@@ -79,6 +79,39 @@ type BackupPolicySpec struct {
 	//
 	// +optional
 	EncryptionConfig *EncryptionConfig `json:"encryptionConfig,omitempty"`
+
+	// Specifies the policy for copying the completed backups to a secondary backup repository,
+	// for example, a bucket in another region for disaster recovery.
+	//
+	// +optional
+	CopyPolicy *BackupCopyPolicy `json:"copyPolicy,omitempty"`
+}
+
+// BackupCopyPolicy defines how the completed backups are copied to a secondary backup repository.
+//
+// For each completed backup, a linked Backup object is created in the same namespace, which refers to
+// the copy of the backup data in the secondary repository. The linked Backup can be used to restore
+// when the primary backup repository is unavailable.
+type BackupCopyPolicy struct {
+	// Specifies the name of the BackupRepo that the backups are copied to.
+	// It must be different from the BackupRepo that the backups are stored in.
+	//
+	// +kubebuilder:validation:Required
+	BackupRepoName string `json:"backupRepoName"`
+
+	// Specifies the names of the backup methods whose backups are copied.
+	// If not specified, the backups of all the backup methods are copied.
+	//
+	// The backups taking volume snapshots and the continuous backups are never copied.
+	//
+	// +optional
+	BackupMethods []string `json:"backupMethods,omitempty"`
+
+	// Specifies the maximum tolerated delay between the completion of a backup and the completion of its copy,
+	// for example, "1h". A warning event is emitted for the backup if its copy is not completed in time.
+	//
+	// +optional
+	LagTolerance *metav1.Duration `json:"lagTolerance,omitempty"`
 }
 
 type BackupTarget struct {
@@ -152,7 +185,9 @@ type PodSelectionStrategy string
 
 const (
 	// PodSelectionStrategyAll selects all pods that match the labelsSelector.
-	// TODO: support PodSelectionStrategyAll
+	// Each selected pod is backed up by its own actions into a subdirectory named after the pod,
+	// and the selected pods are recorded in the backup status in the order of their ordinals,
+	// so that they can be restored to the instances with the same ordinals.
 	PodSelectionStrategyAll PodSelectionStrategy = "All"
 
 	// PodSelectionStrategyAny selects any one pod that match the labelsSelector.
@@ -192,6 +227,10 @@ type ConnectionCredential struct {
 }
 
 // KubeResources defines the kubernetes resources to back up.
+// The selected resources are serialized into the file `kube-resources.json` under the backup path of the target,
+// so that they can be restored along with the data.
+// If the `clusters.apps.kubeblocks.io` is included, the Cluster named by the `app.kubernetes.io/instance`
+// label of the selector is backed up as well.
 type KubeResources struct {
 	// A metav1.LabelSelector to filter the target kubernetes resources that need
 	// to be backed up. If not set, will do not back up any kubernetes resources.
@@ -200,8 +239,8 @@ type KubeResources struct {
 	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 
 	// included is a slice of namespaced-scoped resource type names to include in
-	// the kubernetes resources.
-	// The default value is empty.
+	// the kubernetes resources, e.g. `secrets` and `clusters.apps.kubeblocks.io`.
+	// If not specified, the clusters, secrets and configmaps are included.
 	//
 	// +optional
 	Included []string `json:"included,omitempty"`
@@ -303,6 +342,40 @@ type BackupPolicyStatus struct {
 	//
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Records the time windows within which the data can be restored to any point in time,
+	// in ascending order of the start time.
+	//
+	// The time range of each continuous backup of the BackupPolicy is split into the log ranges by its gaps.
+	// A window starts at the end time of the earliest full backup which stops within the log range,
+	// and ends at the end time of the log range. If no such full backup exists, the log range has no
+	// recoverable window. The time between the windows is not recoverable.
+	//
+	// +optional
+	RecoverableWindows []RecoverableWindow `json:"recoverableWindows,omitempty"`
+}
+
+// RecoverableWindow records a time window within which the data can be restored to any point in time.
+type RecoverableWindow struct {
+	// Specifies the name of the continuous backup which provides the logs of the window.
+	ContinuousBackupName string `json:"continuousBackupName"`
+
+	// Specifies the name of the earliest full backup which the logs can be applied to.
+	// It is empty if the continuous backup can be restored without a full backup,
+	// and the window starts at the start time of the log range.
+	//
+	// +optional
+	BaseBackupName string `json:"baseBackupName,omitempty"`
+
+	// Records the start time of the window, in Coordinated Universal Time (UTC).
+	//
+	// +optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// Records the end time of the window, in Coordinated Universal Time (UTC).
+	//
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
 }
 
 // BackupPolicyPhase defines phases for BackupPolicy.
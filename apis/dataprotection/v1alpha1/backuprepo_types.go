@@ -76,6 +76,38 @@ type BackupRepoSpec struct {
 	// +kubebuilder:validation:Pattern=`^([a-zA-Z0-9-_]+/?)*$`
 	// +optional
 	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// Specifies how to import the backups stored in the backup repository as read-only `Backup` objects.
+	// It is used to rebuild the Backup catalog when the `Backup` objects are lost, e.g. the Kubernetes
+	// cluster hosting them is lost.
+	//
+	// The backups are found by the metadata file written into the path of each backup when it is completed.
+	// The backups whose `Backup` objects already exist, or which have expired, are skipped.
+	// The backups are imported with the `Retain` deletion policy, deleting them does not delete the backup data.
+	//
+	// +optional
+	Sync *BackupRepoSync `json:"sync,omitempty"`
+}
+
+// BackupRepoSync defines how to import the backups stored in the backup repository.
+type BackupRepoSync struct {
+	// Specifies the namespace where the imported `Backup` objects are created.
+	// If not specified, the backups are imported into the namespaces where they were taken.
+	//
+	// +optional
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// Specifies the namespaces where the backups to import were taken.
+	// If not specified, the backups taken in all namespaces are imported.
+	//
+	// +optional
+	SourceNamespaces []string `json:"sourceNamespaces,omitempty"`
+
+	// Specifies the interval to sync the backups periodically, e.g. `1h`.
+	// If not specified, the backups are synced only when the backup repository is changed.
+	//
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 // BackupRepoStatus defines the observed state of `BackupRepo`.
@@ -121,6 +153,34 @@ type BackupRepoStatus struct {
 	//
 	// +optional
 	IsDefault bool `json:"isDefault,omitempty"`
+
+	// Records the status of the last backup sync.
+	//
+	// +optional
+	Sync *BackupRepoSyncStatus `json:"sync,omitempty"`
+}
+
+// BackupRepoSyncStatus defines the status of the last backup sync.
+type BackupRepoSyncStatus struct {
+	// Represents the generation of the backup repository when it was last synced.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Records the time when the last sync was finished.
+	//
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Records the number of `Backup` objects imported by the last sync.
+	//
+	// +optional
+	ImportedBackups int32 `json:"importedBackups,omitempty"`
+
+	// Describes why the last sync failed.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
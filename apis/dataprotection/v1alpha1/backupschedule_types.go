@@ -76,6 +76,80 @@ type SchedulePolicy struct {
 	// +optional
 	// +kubebuilder:default="7d"
 	RetentionPeriod RetentionPeriod `json:"retentionPeriod,omitempty"`
+
+	// Specifies the count-based retention of the backups created by this schedule,
+	// for example, keeping the last 7 daily, 4 weekly and 12 monthly backups.
+	//
+	// When specified, the `retentionPeriod` is ignored for the backups created afterward,
+	// and the garbage collection controller deletes the completed backups that are not retained
+	// by any of the rules.
+	// A backup is never deleted while an incremental backup, a continuous backup or a running restore
+	// still depends on it.
+	//
+	// It takes no effect on the continuous backup method.
+	//
+	// +optional
+	RetentionPolicy *BackupRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// Specifies the periodic consolidation of the incremental backups of this backup method.
+	// Each time the consolidation is triggered, the latest incremental backup chain is merged into
+	// a new synthetic full backup in the backup repo, the subsequent incremental backups are re-parented
+	// to the synthetic full backup, so that the backups of the old chain can be released by the garbage collection.
+	//
+	// It only takes effect on the backup method whose ActionSet is of the Incremental type and defines
+	// the `consolidate` action.
+	//
+	// +optional
+	Consolidation *BackupConsolidation `json:"consolidation,omitempty"`
+}
+
+// BackupConsolidation defines the periodic consolidation of the incremental backups.
+type BackupConsolidation struct {
+	// Specifies the cron expression for the consolidation. The timezone is in UTC.
+	// see https://en.wikipedia.org/wiki/Cron.
+	//
+	// +kubebuilder:validation:Required
+	CronExpression string `json:"cronExpression"`
+}
+
+// BackupRetentionPolicy defines the count-based retention of the backups, also known as
+// Grandfather-Father-Son (GFS) retention.
+//
+// The completed backups are sorted by their stop time in descending order, and each rule retains
+// the latest backup of each time period until the specified count of periods is reached.
+// A backup is retained if any of the rules retains it.
+// The time periods are calculated in UTC.
+type BackupRetentionPolicy struct {
+	// Specifies the number of the latest backups to retain.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// Specifies the number of days for which the latest backup of each day is retained.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+
+	// Specifies the number of weeks for which the latest backup of each week is retained.
+	// The week is the ISO 8601 week, which starts on Monday.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+
+	// Specifies the number of months for which the latest backup of each month is retained.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepMonthly *int32 `json:"keepMonthly,omitempty"`
+
+	// Specifies the number of years for which the latest backup of each year is retained.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepYearly *int32 `json:"keepYearly,omitempty"`
 }
 
 // BackupScheduleStatus defines the observed state of BackupSchedule.
@@ -0,0 +1,226 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BackupVerificationSpec defines the desired state of BackupVerification.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.backupName) || has(self.backupPolicyName)",message="either backupName or backupPolicyName must be specified"
+type BackupVerificationSpec struct {
+	// Specifies the name of the backup to be verified.
+	//
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// Specifies the backup policy, the latest completed backup of which is verified.
+	// It is ignored if `backupName` is specified.
+	//
+	// +optional
+	BackupPolicyName string `json:"backupPolicyName,omitempty"`
+
+	// Specifies the backup method to filter the backups of the backup policy.
+	// If not specified, the backups of all the methods are taken into account.
+	//
+	// +optional
+	BackupMethod string `json:"backupMethod,omitempty"`
+
+	// Specifies the cron expression to verify the latest completed backup of the backup policy periodically.
+	// The timezone is in UTC. see https://en.wikipedia.org/wiki/Cron.
+	//
+	// If not specified, the verification runs only once.
+	//
+	// +optional
+	CronExpression string `json:"cronExpression,omitempty"`
+
+	// Defines the action to verify the restored data.
+	//
+	// +kubebuilder:validation:Required
+	VerifyAction VerifyAction `json:"verifyAction"`
+
+	// Specifies the maximum duration in seconds of a verification run, from restoring the backup into
+	// the temporary Cluster to the completion of the verification job.
+	// The run fails and its scratch namespace is deleted if it does not complete within the duration.
+	//
+	// If not specified, it defaults to 7200 seconds.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RunTimeoutSeconds *int64 `json:"runTimeoutSeconds,omitempty"`
+}
+
+// VerifyAction defines the job to verify the restored data, for example, running a checksum query against it.
+//
+// The job runs in the scratch namespace of the verification run once the temporary Cluster restored from the backup
+// is running. The following environment variables are injected into the container:
+//
+// - DP_BACKUP_NAME: the name of the verified backup.
+// - DP_BACKUP_NAMESPACE: the namespace of the verified backup.
+// - DP_DB_HOST: the host of a replica of the temporary Cluster.
+// - DP_DB_PORT: the port of the database.
+// - DP_DB_USER: the user to connect to the database, if the connection credential of the backup target is specified.
+// - DP_DB_PASSWORD: the password to connect to the database, if the connection credential of the backup target is specified.
+//
+// The verification passes if the container exits with code 0.
+type VerifyAction struct {
+	// Specifies the image of the verification container.
+	//
+	// +kubebuilder:validation:Required
+	Image string `json:"image"`
+
+	// Defines the commands to verify the restored data.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Specifies the environment variables of the verification container.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Specifies the maximum duration in seconds of the verification job.
+	// The verification fails if the job does not complete within the duration.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// BackupVerificationStatus defines the observed state of BackupVerification.
+type BackupVerificationStatus struct {
+	// Describes the phase of the BackupVerification.
+	//
+	// +optional
+	Phase BackupVerificationPhase `json:"phase,omitempty"`
+
+	// Represents the most recent generation observed for this BackupVerification.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Records the name of the backup that is being or was last verified.
+	//
+	// +optional
+	BackupName string `json:"backupName,omitempty"`
+
+	// Records the name of the running verification, which is also the name of the verification job.
+	//
+	// +optional
+	RunName string `json:"runName,omitempty"`
+
+	// Records the temporary namespace of the running verification, where the backup is restored into
+	// a temporary Cluster and verified. The namespace is deleted once the verification completes.
+	//
+	// +optional
+	ScratchNamespace string `json:"scratchNamespace,omitempty"`
+
+	// Records the name of the temporary Cluster of the running verification.
+	//
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Records the time when the last verification was started.
+	//
+	// +optional
+	StartTimestamp *metav1.Time `json:"startTimestamp,omitempty"`
+
+	// Records the time when the last verification was completed.
+	//
+	// +optional
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+
+	// Records the duration of the last verification, including restoring the backup.
+	//
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Records the last time the verification was scheduled.
+	//
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Records the next time the verification is scheduled.
+	//
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// Represents the reason why the last verification failed.
+	//
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
+// BackupVerificationPhase defines the phase of BackupVerification.
+//
+// +enum
+// +kubebuilder:validation:Enum={Pending,Running,Passed,Failed}
+type BackupVerificationPhase string
+
+const (
+	// BackupVerificationPhasePending indicates that the verification is waiting for a completed backup or
+	// the scheduled time.
+	BackupVerificationPhasePending BackupVerificationPhase = "Pending"
+
+	// BackupVerificationPhaseRunning indicates that the backup is being restored into the temporary Cluster and verified.
+	BackupVerificationPhaseRunning BackupVerificationPhase = "Running"
+
+	// BackupVerificationPhasePassed indicates that the last verification passed.
+	BackupVerificationPhasePassed BackupVerificationPhase = "Passed"
+
+	// BackupVerificationPhaseFailed indicates that the last verification failed.
+	BackupVerificationPhaseFailed BackupVerificationPhase = "Failed"
+)
+
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:categories={kubeblocks},scope=Namespaced,shortName=bv
+// +kubebuilder:printcolumn:name="BACKUP",type=string,JSONPath=`.status.backupName`
+// +kubebuilder:printcolumn:name="STATUS",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="DURATION",type=string,JSONPath=`.status.duration`
+// +kubebuilder:printcolumn:name="COMPLETION-TIME",type=string,JSONPath=`.status.completionTimestamp`
+// +kubebuilder:printcolumn:name="AGE",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BackupVerification is the Schema for the backupverifications API.
+// It verifies a backup by restoring it into a temporary Cluster in a scratch namespace and
+// running a user-defined verification job against the restored Cluster.
+type BackupVerification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupVerificationSpec   `json:"spec,omitempty"`
+	Status BackupVerificationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupVerificationList contains a list of BackupVerification.
+type BackupVerificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupVerification `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupVerification{}, &BackupVerificationList{})
+}
@@ -102,15 +102,38 @@ type BackupRef struct {
 	SourceTargetName string `json:"sourceTargetName,omitempty"`
 }
 
+// RestoreKubeResources defines the kubernetes resources to restore from the backup.
+// Only the Clusters and the Secrets and ConfigMaps referenced by them are restored, the Secrets and ConfigMaps
+// are restored before the data is prepared, and the Clusters are restored after the data is prepared.
+// If no resources are included, all of them are restored. The resources that already exist are skipped.
 type RestoreKubeResources struct {
 	// Restores the specified resources.
 	//
 	// +optional
 	IncludeResources []IncludeResource `json:"included,omitempty"`
 
-	// TODO: supports exclude resources for recovery
+	// Excludes the specified resources from restoring, which takes precedence over the included resources.
+	//
+	// +optional
+	ExcludeResources []IncludeResource `json:"excluded,omitempty"`
+
+	// Maps the namespaces of the backed up resources to the namespaces where they are restored.
+	// The resources whose namespaces are not mapped are restored into the namespace of the Restore.
+	//
+	// +optional
+	NamespaceMapping map[string]string `json:"namespaceMapping,omitempty"`
+
+	// Maps the names of the backed up resources to the new names.
+	// A resource whose name equals a key or starts with the key followed by a hyphen is renamed
+	// by replacing the key with the value, e.g. mapping `mycluster` to `newcluster` renames the
+	// secret `mycluster-conn-credential` to `newcluster-conn-credential`.
+	// The label values equal to a key are replaced as well.
+	//
+	// +optional
+	NameMapping map[string]string `json:"nameMapping,omitempty"`
 }
 
+// IncludeResource selects the resources by the resource type and the labels.
 type IncludeResource struct {
 	// +kubebuilder:validation:Required
 	GroupResource string `json:"groupResource"`
@@ -368,6 +368,14 @@ type InstanceTemplate struct {
 	// $(cluster.name)-$(component.name)-$(template.name)-7
 	Ordinals Ordinals `json:"ordinals,omitempty"`
 
+	// Specifies whether the instances of this InstanceTemplate are named with the pattern of the default template:
+	// $(cluster.name)-$(component.name)-$(ordinal), instead of including the template's name.
+	// The Ordinals must be specified, and the instances with these ordinals are excluded from the default template.
+	// It allows to override the existing instances of the default template in place, and their PVCs are retained.
+	//
+	// +optional
+	FlatInstanceOrdinal bool `json:"flatInstanceOrdinal,omitempty"`
+
 	// Specifies a map of key-value pairs to be merged into the Pod's existing annotations.
 	// Existing keys will have their values overwritten, while new keys will be added to the annotations.
 	//
@@ -385,6 +393,12 @@ type InstanceTemplate struct {
 	// +optional
 	Image *string `json:"image,omitempty"`
 
+	// Specifies the overrides for the images of the containers in the pod, keyed by the container name.
+	// Both the init containers and the containers are overridden, and it takes precedence over the Image.
+	//
+	// +optional
+	Images map[string]string `json:"images,omitempty"`
+
 	// Specifies the scheduling policy for the Component.
 	//
 	// +optional
@@ -775,3 +789,7 @@ func (t *InstanceTemplate) GetReplicas() int32 {
 func (t *InstanceTemplate) GetOrdinals() Ordinals {
 	return t.Ordinals
 }
+
+func (t *InstanceTemplate) IsFlatInstanceOrdinal() bool {
+	return t.FlatInstanceOrdinal
+}
@@ -293,11 +293,30 @@ func main() {
 	}
 
 	if err = (&dpcontrollers.RestoreReconciler{
+		Client:     mgr.GetClient(),
+		Scheme:     mgr.GetScheme(),
+		Recorder:   mgr.GetEventRecorderFor("restore-controller"),
+		RestConfig: mgr.GetConfig(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Restore")
+		os.Exit(1)
+	}
+
+	if err = (&dpcontrollers.BackupCopyReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("restore-controller"),
+		Recorder: mgr.GetEventRecorderFor("backup-copy-controller"),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Restore")
+		setupLog.Error(err, "unable to create controller", "controller", "BackupCopy")
+		os.Exit(1)
+	}
+
+	if err = (&dpcontrollers.BackupVerificationReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("backup-verification-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "BackupVerification")
 		os.Exit(1)
 	}
 
@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+const datasafedBin = "datasafed"
+
+// datasafedCommand returns the datasafed command, which is the one installed in the datasafed bin path if it is set.
+func datasafedCommand() string {
+	if binPath := os.Getenv(dptypes.DPDatasafedBinPath); binPath != "" {
+		return filepath.Join(binPath, datasafedBin)
+	}
+	return datasafedBin
+}
+
+// transferResult is the result of the data transfer written to the result file.
+type transferResult struct {
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+func writeResult(path string, w *utils.ChecksumWriter) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.Marshal(transferResult{Size: w.Size(), Checksum: w.Digest()})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// startCommand starts the command whose stdout or stdin is streamed with the backup repo.
+func startCommand(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+// exited checks whether the command exited by itself rather than being killed.
+func exited(cmd *exec.Cmd) bool {
+	return cmd.ProcessState != nil && cmd.ProcessState.Exited()
+}
+
+// pushWithChecksum pushes the data read from src to the file of the backup repo, and returns the checksum of it.
+func pushWithChecksum(ctx context.Context, src io.Reader, file string) (*utils.ChecksumWriter, error) {
+	// the stdout of datasafed is not mixed with the output of the tool.
+	push := startCommand(ctx, []string{datasafedCommand(), "push", "-", file}, nil, os.Stderr)
+	stdin, err := push.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = push.Start(); err != nil {
+		return nil, err
+	}
+	w, copyErr := utils.CopyWithChecksum(stdin, src)
+	_ = stdin.Close()
+	if err = push.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to push the file %s: %w", file, err)
+	}
+	if copyErr != nil {
+		return nil, fmt.Errorf("failed to push the file %s: %w", file, copyErr)
+	}
+	return w, nil
+}
+
+// pullWithChecksum pulls the file of the backup repo and writes it to dst, and returns the checksum of it.
+// If dst is nil, the file is only checksummed.
+func pullWithChecksum(ctx context.Context, file string, dst io.Writer) (*utils.ChecksumWriter, error) {
+	pull := startCommand(ctx, []string{datasafedCommand(), "pull", file, "-"}, nil, nil)
+	stdout, err := pull.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = pull.Start(); err != nil {
+		return nil, err
+	}
+	w, copyErr := utils.CopyWithChecksum(dst, stdout)
+	if copyErr != nil {
+		// the consumer exits, stop pulling the rest data.
+		_ = pull.Process.Kill()
+	}
+	if err = pull.Wait(); err != nil && copyErr == nil {
+		return nil, fmt.Errorf("failed to pull the file %s: %w", file, err)
+	}
+	if copyErr != nil {
+		return nil, fmt.Errorf("failed to pull the file %s: %w", file, copyErr)
+	}
+	return w, nil
+}
+
+// pullFile pulls the small file of the backup repo into the memory.
+func pullFile(ctx context.Context, file string) (string, error) {
+	out := &strings.Builder{}
+	if _, err := pullWithChecksum(ctx, file, out); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// listFiles lists the files under the base path of the backup repo recursively.
+func listFiles(ctx context.Context) ([]string, error) {
+	out := &strings.Builder{}
+	list := startCommand(ctx, []string{datasafedCommand(), "list", "-r", "-f", "/"}, nil, out)
+	if err := list.Run(); err != nil {
+		return nil, fmt.Errorf("failed to list the files: %w", err)
+	}
+	var files []string
+	for _, file := range strings.Split(out.String(), "\n") {
+		if file = strings.TrimSpace(file); file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
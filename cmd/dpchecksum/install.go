@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// checksumToolBin is the name of the dpchecksum binary.
+const checksumToolBin = "dpchecksum"
+
+func newInstallCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "install <datasafed-bin-path>",
+		Short: "Installs dpchecksum into the datasafed bin path, and installs it as the proxy of datasafed.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return install(args[0])
+		},
+	}
+}
+
+// install copies dpchecksum into the dir, and links datasafed to it, the original datasafed
+// is renamed to datasafed-origin, which is run by the proxy.
+func install(dir string) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	tool := filepath.Join(dir, checksumToolBin)
+	proxy := filepath.Join(dir, datasafedBin)
+	proxyInfo, err := os.Stat(proxy)
+	if err != nil {
+		return fmt.Errorf("datasafed is not installed in %s: %w", dir, err)
+	}
+	// the proxy is installed if the init container is restarted.
+	toolInfo, err := os.Stat(tool)
+	installed := err == nil && os.SameFile(proxyInfo, toolInfo)
+	if err = copyFile(exe, tool); err != nil {
+		return err
+	}
+	if installed {
+		return nil
+	}
+	if err = os.Rename(proxy, filepath.Join(dir, datasafedOriginBin)); err != nil {
+		return err
+	}
+	return os.Link(tool, proxy)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
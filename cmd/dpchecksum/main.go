@@ -0,0 +1,69 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// terminationLogPath is the file the reason of the failure is written to, so that it is
+// shown in the status of the container.
+const terminationLogPath = "/dev/termination-log"
+
+// dpchecksum streams the data between the backup repo and the local processes by datasafed,
+// and calculates the size and the SHA-256 checksum of the data as it is streamed.
+// It is shipped in the KubeBlocks tools image, and installed into the pods of the data protection,
+// where it is also installed as the proxy of datasafed to throttle the data streamed by datasafed and
+// to record the checksums of the backup data, which are written into the integrity manifest.
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if filepath.Base(os.Args[0]) == datasafedBin {
+		// it is installed as the proxy of datasafed.
+		code, err := runDatasafedProxy(ctx, os.Args[1:])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+		}
+		cancel()
+		os.Exit(code)
+	}
+
+	cmd := &cobra.Command{
+		Use:           "dpchecksum",
+		Short:         "Streams the data of the backup repo by datasafed, and checksums the data as it is streamed.",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.AddCommand(newPushCommand(), newPullCommand(), newInstallCommand(), newManifestCommand(), newVerifyCommand())
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		// the termination log does not exist out of the pod.
+		_ = os.WriteFile(terminationLogPath, []byte(err.Error()), 0644)
+		os.Exit(1)
+	}
+}
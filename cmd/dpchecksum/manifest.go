@@ -0,0 +1,128 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func newManifestCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "manifest",
+		Short: "Writes the integrity manifest of the backup path, and prints the integrity status of the backup.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return writeManifest(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+}
+
+// writeManifest writes the integrity manifest of the backup path specified by DP_BACKUP_BASE_PATH,
+// each line of the manifest is in the format of "<sha256> <size> <file>". The checksums are read from
+// the records of the datasafed proxy. The files which are not pushed through the proxy, e.g. written by
+// the backup tool directly, are not pulled again to calculate their checksums, they are left out of
+// the manifest and not verified.
+// It prints the JSON of the backup status recording the manifest,
+// e.g. {"integrity":{"manifests":[{"path":"/path","digest":"sha256:<hex>"}]}}.
+func writeManifest(ctx context.Context, out io.Writer) error {
+	basePath := os.Getenv(dptypes.DPBackupBasePath)
+	if basePath == "" {
+		return fmt.Errorf("the backup path is not specified by %s", dptypes.DPBackupBasePath)
+	}
+	if err := os.Setenv(dptypes.DPDatasafedBackendBasePath, basePath); err != nil {
+		return err
+	}
+	records, err := readChecksumRecords(os.Getenv(dptypes.DPIntegrityChecksumsFile))
+	if err != nil {
+		return err
+	}
+	files, err := listFiles(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	manifestFile := "/" + dptypes.IntegrityManifestFileName
+	manifest := &strings.Builder{}
+	for _, file := range files {
+		file = path.Join("/", file)
+		if file == manifestFile {
+			continue
+		}
+		record, ok := records[remotePath(basePath, file)]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "the checksum of the file %s is not recorded, it is not verified\n", file)
+			continue
+		}
+		fmt.Fprintf(manifest, "%s %s\n", record, file)
+	}
+	w, err := pushWithChecksum(ctx, strings.NewReader(manifest.String()), manifestFile)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(map[string]any{
+		"integrity": dpv1alpha1.BackupIntegrity{
+			Manifests: []dpv1alpha1.BackupIntegrityManifest{{Path: basePath, Digest: w.Digest()}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// readChecksumRecords reads the checksums recorded by the datasafed proxy, and returns the map
+// from the full path of each file to its "<sha256> <size>".
+func readChecksumRecords(file string) (map[string]string, error) {
+	records := map[string]string{}
+	if file == "" {
+		return records, nil
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return records, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), " ", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		records[fields[2]] = fields[0] + " " + fields[1]
+	}
+	return records, scanner.Err()
+}
@@ -0,0 +1,210 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+// datasafedOriginBin is the name of the datasafed binary which is replaced by the proxy.
+const datasafedOriginBin = "datasafed-origin"
+
+// datasafedProxy runs in place of datasafed, it streams the data of the push and pull commands
+// for datasafed, so that the data is throttled by the bandwidth limit, and the checksums of the
+// pushed files are recorded for the integrity manifest.
+type datasafedProxy struct {
+	ctx            context.Context
+	origin         string
+	bandwidthLimit int64
+	checksumsFile  string
+}
+
+// runDatasafedProxy runs datasafed with the args by the proxy, and returns the exit code of it.
+func runDatasafedProxy(ctx context.Context, args []string) (int, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return 1, err
+	}
+	limit, err := utils.ParseBandwidthLimit(os.Getenv(dptypes.DPDatasafedBandwidthLimit))
+	if err != nil {
+		return 1, err
+	}
+	p := &datasafedProxy{
+		ctx:            ctx,
+		origin:         filepath.Join(filepath.Dir(exe), datasafedOriginBin),
+		bandwidthLimit: limit,
+		checksumsFile:  os.Getenv(dptypes.DPIntegrityChecksumsFile),
+	}
+	return p.run(args)
+}
+
+func (p *datasafedProxy) run(args []string) (int, error) {
+	// the local path and the remote path are the last two args of the push and pull commands.
+	if len(args) < 3 || !isPathArg(args[len(args)-2]) || !isPathArg(args[len(args)-1]) {
+		return p.passthrough(args)
+	}
+	switch {
+	case args[0] == "push" && (p.bandwidthLimit > 0 || p.checksumsFile != ""):
+		return p.push(args)
+	case args[0] == "pull" && p.bandwidthLimit > 0:
+		return p.pull(args)
+	default:
+		return p.passthrough(args)
+	}
+}
+
+// passthrough runs datasafed with the args as it is.
+func (p *datasafedProxy) passthrough(args []string) (int, error) {
+	cmd := startCommand(p.ctx, append([]string{p.origin}, args...), os.Stdin, os.Stdout)
+	return exitCode(cmd.Run())
+}
+
+// push pushes the data of the local file, or the stdin if it is "-", by datasafed from its stdin,
+// and records the checksum of the data once it is pushed.
+func (p *datasafedProxy) push(args []string) (int, error) {
+	src, dst := args[len(args)-2], args[len(args)-1]
+	var r io.Reader = os.Stdin
+	if src != "-" {
+		f, err := os.Open(src)
+		if err != nil {
+			// let datasafed report the error.
+			return p.passthrough(args)
+		}
+		defer f.Close()
+		if info, err := f.Stat(); err != nil || !info.Mode().IsRegular() {
+			return p.passthrough(args)
+		}
+		r = f
+	}
+	cmd := startCommand(p.ctx, p.buildArgs(args, len(args)-2), nil, os.Stdout)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return 1, err
+	}
+	if err = cmd.Start(); err != nil {
+		return 1, err
+	}
+	w, copyErr := utils.CopyWithChecksum(stdin, utils.NewRateLimitedReader(p.ctx, r, p.bandwidthLimit))
+	_ = stdin.Close()
+	if code, err := exitCode(cmd.Wait()); code != 0 || err != nil {
+		return code, err
+	}
+	if copyErr != nil {
+		return 1, fmt.Errorf("failed to read the data pushed to %s: %w", dst, copyErr)
+	}
+	if err = p.recordChecksum(dst, w); err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// recordChecksum appends the checksum of the pushed file to the checksums file, in the format of
+// "<sha256> <size> <path>", the path is the full path of the file in the backup repo. The last record
+// of a file is the one of its last push.
+func (p *datasafedProxy) recordChecksum(file string, w *utils.ChecksumWriter) error {
+	if p.checksumsFile == "" {
+		return nil
+	}
+	f, err := os.OpenFile(p.checksumsFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	// the record is written at once, so that the records of the concurrent pushes are not interleaved.
+	record := fmt.Sprintf("%s %d %s\n", w.Checksum(), w.Size(), remotePath(os.Getenv(dptypes.DPDatasafedBackendBasePath), file))
+	if _, err = f.WriteString(record); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to record the checksum of %s: %w", file, err)
+	}
+	return f.Close()
+}
+
+// pull pulls the data by datasafed to its stdout, and writes it to the local file, or the stdout if it is "-".
+func (p *datasafedProxy) pull(args []string) (int, error) {
+	dst := args[len(args)-1]
+	var w io.Writer = os.Stdout
+	if dst != "-" {
+		f, err := os.Create(dst)
+		if err != nil {
+			return p.passthrough(args)
+		}
+		defer f.Close()
+		w = f
+	}
+	cmd := startCommand(p.ctx, p.buildArgs(args, len(args)-1), os.Stdin, nil)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return 1, err
+	}
+	if err = cmd.Start(); err != nil {
+		return 1, err
+	}
+	_, copyErr := io.Copy(w, utils.NewRateLimitedReader(p.ctx, stdout, p.bandwidthLimit))
+	if copyErr != nil {
+		// the data can not be written, stop pulling the rest data.
+		_ = cmd.Process.Kill()
+	}
+	if code, err := exitCode(cmd.Wait()); (code != 0 || err != nil) && copyErr == nil {
+		return code, err
+	}
+	if copyErr != nil {
+		return 1, fmt.Errorf("failed to write the data pulled to %s: %w", dst, copyErr)
+	}
+	return 0, nil
+}
+
+// buildArgs builds the command of datasafed, in which the local path at the index is replaced by "-",
+// so that the data is streamed by the proxy.
+func (p *datasafedProxy) buildArgs(args []string, localPathIndex int) []string {
+	cmdArgs := append([]string{p.origin}, args...)
+	cmdArgs[localPathIndex+1] = "-"
+	return cmdArgs
+}
+
+// remotePath returns the full path of the file in the backup repo.
+func remotePath(basePath, file string) string {
+	return path.Join("/", basePath, file)
+}
+
+func isPathArg(arg string) bool {
+	return arg == "-" || !strings.HasPrefix(arg, "-")
+}
+
+// exitCode returns the exit code of the command by the error of running it.
+func exitCode(err error) (int, error) {
+	if err == nil {
+		return 0, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode(), nil
+	}
+	return 1, err
+}
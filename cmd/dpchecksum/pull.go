@@ -0,0 +1,122 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+type pullOptions struct {
+	verify     bool
+	resultFile string
+}
+
+func newPullCommand() *cobra.Command {
+	o := &pullOptions{}
+	cmd := &cobra.Command{
+		Use:   "pull <remote-path> [-- command [args...]]",
+		Short: "Pulls the file of the backup repo to the stdin of the command, or to the stdout if no command is given.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), args[0], args[1:])
+		},
+	}
+	cmd.Flags().BoolVar(&o.verify, "verify", true, "verify the file against the <remote-path>.sha256 file before it is consumed")
+	cmd.Flags().StringVar(&o.resultFile, "result", "", "the file the size and the checksum of the data are written to")
+	return cmd
+}
+
+func (o *pullOptions) run(ctx context.Context, file string, args []string) error {
+	var expected string
+	if o.verify {
+		var err error
+		if expected, err = o.verifyFile(ctx, file); err != nil {
+			return err
+		}
+	}
+	w, err := o.pull(ctx, file, args)
+	if err != nil {
+		return err
+	}
+	// the file is pulled again to be consumed, make sure it is not changed after the verification.
+	if o.verify && w.Checksum() != expected {
+		return fmt.Errorf("the checksum of the file %s is changed during the pull, expected: %s, actual: %s", file, expected, w.Checksum())
+	}
+	return writeResult(o.resultFile, w)
+}
+
+// verifyFile verifies the file against its checksum file before any of the data is consumed,
+// and returns the expected checksum.
+func (o *pullOptions) verifyFile(ctx context.Context, file string) (string, error) {
+	content, err := pullFile(ctx, file+checksumFileSuffix)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the checksum of the file %s: %w", file, err)
+	}
+	fields := strings.Fields(content)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("the checksum file of the file %s is empty", file)
+	}
+	expected := fields[0]
+	w, err := pullWithChecksum(ctx, file, nil)
+	if err != nil {
+		return "", err
+	}
+	if w.Checksum() != expected {
+		return "", fmt.Errorf("the checksum of the file %s is mismatched, expected: %s, actual: %s", file, expected, w.Checksum())
+	}
+	return expected, nil
+}
+
+// pull pulls the file to the stdin of the command, it fails if either the pull or the command fails.
+func (o *pullOptions) pull(ctx context.Context, file string, args []string) (*utils.ChecksumWriter, error) {
+	if len(args) == 0 {
+		return pullWithChecksum(ctx, file, os.Stdout)
+	}
+	consumer := startCommand(ctx, args, nil, os.Stdout)
+	stdin, err := consumer.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = consumer.Start(); err != nil {
+		return nil, err
+	}
+	w, pullErr := pullWithChecksum(ctx, file, stdin)
+	if pullErr != nil {
+		// the data is incomplete, stop the command before it sees the EOF and finishes loading.
+		_ = consumer.Process.Kill()
+	}
+	_ = stdin.Close()
+	err = consumer.Wait()
+	// report the failure of the command if it exits by itself, which causes the broken pipe.
+	if pullErr != nil && (err == nil || !exited(consumer)) {
+		return nil, pullErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to run the command %s: %w", strings.Join(args, " "), err)
+	}
+	return w, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+// checksumFileSuffix is the suffix of the file which stores the checksum of the data file.
+const checksumFileSuffix = ".sha256"
+
+type pushOptions struct {
+	checksumFile bool
+	resultFile   string
+}
+
+func newPushCommand() *cobra.Command {
+	o := &pushOptions{}
+	cmd := &cobra.Command{
+		Use:   "push <remote-path> [-- command [args...]]",
+		Short: "Pushes the stdout of the command, or the stdin if no command is given, to the backup repo.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.run(cmd.Context(), args[0], args[1:])
+		},
+	}
+	cmd.Flags().BoolVar(&o.checksumFile, "checksum-file", false, "push the checksum of the data to the <remote-path>.sha256 file")
+	cmd.Flags().StringVar(&o.resultFile, "result", "", "the file the size and the checksum of the data are written to")
+	return cmd
+}
+
+func (o *pushOptions) run(ctx context.Context, file string, args []string) error {
+	var (
+		w   *utils.ChecksumWriter
+		err error
+	)
+	if len(args) == 0 {
+		w, err = pushWithChecksum(ctx, os.Stdin, file)
+	} else {
+		w, err = pushCommandOutput(ctx, file, args)
+	}
+	if err != nil {
+		return err
+	}
+	if o.checksumFile {
+		// the checksum file is in the format of the sha256sum tool.
+		content := fmt.Sprintf("%s  %s\n", w.Checksum(), path.Base(file))
+		if _, err = pushWithChecksum(ctx, strings.NewReader(content), file+checksumFileSuffix); err != nil {
+			return err
+		}
+	}
+	return writeResult(o.resultFile, w)
+}
+
+// pushCommandOutput pushes the stdout of the command to the file of the backup repo,
+// it fails if either the command or the push fails.
+func pushCommandOutput(ctx context.Context, file string, args []string) (*utils.ChecksumWriter, error) {
+	producer := startCommand(ctx, args, os.Stdin, nil)
+	stdout, err := producer.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = producer.Start(); err != nil {
+		return nil, err
+	}
+	w, pushErr := pushWithChecksum(ctx, stdout, file)
+	if pushErr != nil {
+		// the data can not be pushed, stop producing the rest data.
+		_ = producer.Process.Kill()
+	}
+	err = producer.Wait()
+	// report the failure of the command if it exits by itself, which causes the broken pipe.
+	if pushErr != nil && (err == nil || !exited(producer)) {
+		return nil, pushErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to run the command %s: %w", strings.Join(args, " "), err)
+	}
+	return w, nil
+}
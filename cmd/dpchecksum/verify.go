@@ -0,0 +1,96 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func newVerifyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Verifies the backup data against the integrity manifests specified by DP_INTEGRITY_MANIFESTS.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return verifyManifests(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+}
+
+// verifyManifests verifies the backup data against the integrity manifests, one manifest per line
+// in the format of "<digest> <path>". It fails once the digest of a manifest mismatches, or a file
+// listed in the manifest is missing or its size or checksum mismatches.
+func verifyManifests(ctx context.Context, out io.Writer) error {
+	verified := false
+	for _, line := range strings.Split(os.Getenv(dptypes.DPIntegrityManifests), "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		if err := verifyManifest(ctx, fields[0], fields[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "the backup data of %s is verified\n", fields[1])
+		verified = true
+	}
+	if !verified {
+		return fmt.Errorf("no integrity manifests are specified by %s", dptypes.DPIntegrityManifests)
+	}
+	return nil
+}
+
+func verifyManifest(ctx context.Context, expectedDigest, basePath string) error {
+	if err := os.Setenv(dptypes.DPDatasafedBackendBasePath, basePath); err != nil {
+		return err
+	}
+	manifest := &strings.Builder{}
+	w, err := pullWithChecksum(ctx, "/"+dptypes.IntegrityManifestFileName, manifest)
+	if err != nil {
+		return fmt.Errorf("failed to pull the integrity manifest of %s: %w", basePath, err)
+	}
+	if w.Digest() != expectedDigest {
+		return fmt.Errorf("the integrity manifest of %s is corrupted, expected digest %s, but got %s",
+			basePath, expectedDigest, w.Digest())
+	}
+	for _, line := range strings.Split(manifest.String(), "\n") {
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		expectedChecksum, expectedSize, file := fields[0], fields[1], fields[2]
+		if w, err = pullWithChecksum(ctx, file, nil); err != nil {
+			return err
+		}
+		if w.Checksum() != expectedChecksum || strconv.FormatInt(w.Size(), 10) != expectedSize {
+			return fmt.Errorf("the file %s%s is corrupted, expected size %s and checksum %s, but got size %d and checksum %s",
+				basePath, file, expectedSize, expectedChecksum, w.Size(), w.Checksum())
+		}
+	}
+	return nil
+}
@@ -437,6 +437,43 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = (&appscontrollers.OpsScheduleReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("ops-schedule-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OpsSchedule")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.StorageAutoscalingPolicyReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("storage-autoscaling-policy-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "StorageAutoscalingPolicy")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.ComponentAutoscalerReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: mgr.GetEventRecorderFor("component-autoscaler-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ComponentAutoscaler")
+			os.Exit(1)
+		}
+
+		if err = (&appscontrollers.ResourceRecommenderReconciler{
+			Client:     mgr.GetClient(),
+			Scheme:     mgr.GetScheme(),
+			Recorder:   mgr.GetEventRecorderFor("resource-recommender-controller"),
+			RestConfig: mgr.GetConfig(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ResourceRecommender")
+			os.Exit(1)
+		}
+
 		if err = (&configuration.ConfigConstraintReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
@@ -555,6 +592,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ServiceDescriptor")
 			os.Exit(1)
 		}
+		if err = (&appsv1alpha1.OpsRequest{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpsRequest")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
@@ -0,0 +1,48 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// getCheckInterval returns the interval of the periodic checks, the default is used if it is not specified.
+func getCheckInterval(seconds, defaultSeconds int32) time.Duration {
+	if seconds <= 0 {
+		seconds = defaultSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// timeToNextCheck returns how long to wait before the next periodic check, or 0 if the check is due.
+// The controllers running the periodic checks are also triggered by the updates of their status and
+// the OpsRequests they create, which should not bring the next check forward.
+func timeToNextCheck(lastCheckTime *metav1.Time, interval time.Duration) time.Duration {
+	if lastCheckTime == nil {
+		return 0
+	}
+	elapsed := time.Since(lastCheckTime.Time)
+	if elapsed < 0 || elapsed >= interval {
+		return 0
+	}
+	return interval - elapsed
+}
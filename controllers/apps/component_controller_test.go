@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -748,6 +749,72 @@ var _ = Describe("Component Controller", func() {
 		horizontalScale(int(updatedReplicas), testk8s.DefaultStorageClassName, bpt, compDefName)
 	}
 
+	testHorizontalScaleWithPreScaleIn := func(compName, compDefName string) {
+		By("update comp definition to define the preScaleIn action")
+		Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(compDefObj), func(compDef *kbappsv1.ComponentDefinition) {
+			compDef.Spec.LifecycleActions.PreScaleIn = testapps.NewLifecycleAction("preScaleIn")
+		})()).Should(Succeed())
+
+		By("mock the preScaleIn action to be in progress for the first calls")
+		var (
+			preScaleInCalls, memberLeaveCalls       atomic.Int32
+			preScaleInCompleted, leftBeforeComplete atomic.Bool
+		)
+		defer kbagent.UnsetMockClient()
+		mockKBAgentClient(func(recorder *kbagent.MockClientMockRecorder) {
+			recorder.Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req kbagentproto.ActionRequest) (kbagentproto.ActionResponse, error) {
+				switch req.Action {
+				case "preScaleIn":
+					// both leaving pods are in progress in the first round
+					if preScaleInCalls.Add(1) <= 2 {
+						return kbagentproto.ActionResponse{
+							Error:  kbagentproto.Error2Type(kbagentproto.ErrInProgress),
+							Output: []byte("50"),
+						}, nil
+					}
+					preScaleInCompleted.Store(true)
+				case "memberLeave":
+					// the member should not leave until the preScaleIn action has completed
+					if !preScaleInCompleted.Load() {
+						leftBeforeComplete.Store(true)
+					}
+					memberLeaveCalls.Add(1)
+				}
+				return kbagentproto.ActionResponse{}, nil
+			}).AnyTimes()
+		})
+
+		createClusterObj(compName, compDefName, func(f *testapps.MockClusterFactory) {
+			f.SetReplicas(3)
+		})
+
+		By("Creating mock pods in InstanceSet")
+		pods := mockPodsForTest(clusterObj, 3)
+		for _, pod := range pods {
+			Expect(testCtx.CheckedCreateObj(testCtx.Ctx, &pod)).Should(Succeed())
+		}
+
+		By("Changing replicas to 1")
+		changeComponentReplicas(clusterKey, 1)
+
+		By("Checking the progress of the preScaleIn action is recorded in the leaving pods")
+		for _, pod := range pods[1:] {
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(&pod), func(g Gomega, pod *corev1.Pod) {
+				g.Expect(pod.Annotations[constant.PreScaleInProgressAnnotationKey]).Should(Equal("100"))
+			})).WithTimeout(time.Minute).Should(Succeed())
+		}
+
+		By("Checking the leaving members left after the preScaleIn action completed")
+		Eventually(func(g Gomega) {
+			g.Expect(preScaleInCompleted.Load()).Should(BeTrue())
+			g.Expect(memberLeaveCalls.Load()).Should(BeNumerically(">=", 2))
+		}).Should(Succeed())
+		Expect(leftBeforeComplete.Load()).Should(BeFalse())
+		Eventually(testapps.CheckObj(&testCtx, compKey, func(g Gomega, its *workloads.InstanceSet) {
+			g.Expect(*its.Spec.Replicas).Should(BeEquivalentTo(1))
+		})).Should(Succeed())
+	}
+
 	testVolumeExpansion := func(compDef *kbappsv1.ComponentDefinition, compName string, storageClass *storagev1.StorageClass) {
 		var (
 			replicas          = 3
@@ -2061,6 +2128,10 @@ var _ = Describe("Component Controller", func() {
 			testHorizontalScale(defaultCompName, compDefObj.Name, 3, 0, &backupPolicyTPLName)
 		})
 
+		It("scale-in from 3 to 1 with the preScaleIn action", func() {
+			testHorizontalScaleWithPreScaleIn(defaultCompName, compDefObj.Name)
+		})
+
 		Context("with different backup methods", func() {
 			createNWaitClusterObj := func(components map[string]string,
 				processor func(compName string, factory *testapps.MockClusterFactory),
@@ -0,0 +1,439 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbagt "github.com/apecloud/kubeblocks/pkg/kbagent"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
+)
+
+const (
+	defaultAutoscalerCheckIntervalSeconds        = 30
+	defaultAutoscalerScaleInStabilizationSeconds = 300
+	// the scaling is skipped if the ratio of the metric value to the target value is within the tolerance
+	autoscalerMetricTolerance = 0.1
+
+	reasonComponentAutoscaling       = "ComponentAutoscaling"
+	reasonComponentAutoscalingFailed = "ComponentAutoscalingFailed"
+)
+
+// ComponentAutoscalerReconciler reconciles a ComponentAutoscaler object
+type ComponentAutoscalerReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentautoscalers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=componentautoscalers/finalizers,verbs=update
+
+// Reconcile collects the metric periodically, and creates the HorizontalScaling OpsRequest
+// if the stabilized desired replicas differ from the current replicas.
+func (r *ComponentAutoscalerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("componentAutoscaler", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	autoscaler := &appsv1alpha1.ComponentAutoscaler{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, autoscaler); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !autoscaler.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	checkInterval := getCheckInterval(autoscaler.Spec.CheckIntervalSeconds, defaultAutoscalerCheckIntervalSeconds)
+	// the autoscaler is also reconciled on the updates of its status and the OpsRequests,
+	// skip the check until the interval elapses unless the spec is changed.
+	if !autoscaler.Spec.Suspend && autoscaler.Status.ObservedGeneration == autoscaler.Generation {
+		if wait := timeToNextCheck(autoscaler.Status.LastCheckTime, checkInterval); wait > 0 {
+			return intctrlutil.RequeueAfter(wait, reqCtx.Log, "collect the metric")
+		}
+	}
+
+	statusPatch := client.MergeFrom(autoscaler.DeepCopy())
+	autoscaler.Status.ObservedGeneration = autoscaler.Generation
+	if autoscaler.Spec.Suspend {
+		autoscaler.Status.Recommendations = nil
+		if err := r.Client.Status().Patch(reqCtx.Ctx, autoscaler, statusPatch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+	err := r.autoscale(reqCtx, autoscaler, time.Now())
+	autoscaler.Status.LastCheckTime = &metav1.Time{Time: time.Now()}
+	if patchErr := r.Client.Status().Patch(reqCtx.Ctx, autoscaler, statusPatch); patchErr != nil && err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(checkInterval, reqCtx.Log, "collect the metric")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ComponentAutoscalerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.ComponentAutoscaler{}).
+		Owns(&appsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
+
+func (r *ComponentAutoscalerReconciler) autoscale(reqCtx intctrlutil.RequestCtx,
+	autoscaler *appsv1alpha1.ComponentAutoscaler,
+	now time.Time) error {
+	autoscaler.Status.Message = ""
+	cluster := &appsv1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: autoscaler.Spec.ClusterName, Namespace: autoscaler.Namespace}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			autoscaler.Status.Message = fmt.Sprintf(`cluster "%s" not found`, autoscaler.Spec.ClusterName)
+			return nil
+		}
+		return err
+	}
+	compSpec := cluster.Spec.GetComponentByName(autoscaler.Spec.ComponentName)
+	if compSpec == nil {
+		autoscaler.Status.Message = fmt.Sprintf(`component "%s" not found in the cluster "%s"`, autoscaler.Spec.ComponentName, cluster.Name)
+		return nil
+	}
+	currentReplicas := compSpec.Replicas
+	autoscaler.Status.CurrentReplicas = currentReplicas
+	minReplicas, maxReplicas, err := r.getReplicasRange(reqCtx, autoscaler, cluster)
+	if err != nil {
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			autoscaler.Status.Message = err.Error()
+			r.Recorder.Event(autoscaler, corev1.EventTypeWarning, reasonComponentAutoscalingFailed, autoscaler.Status.Message)
+			return nil
+		}
+		return err
+	}
+
+	metricValue, err := r.collectMetric(reqCtx, autoscaler)
+	if err != nil {
+		autoscaler.Status.Message = fmt.Sprintf("failed to collect the metric: %s", err.Error())
+		r.Recorder.Event(autoscaler, corev1.EventTypeWarning, reasonComponentAutoscalingFailed, autoscaler.Status.Message)
+		return nil
+	}
+	currentValue := resource.NewMilliQuantity(int64(math.Round(metricValue*1000)), resource.DecimalSI)
+	autoscaler.Status.CurrentMetricValue = currentValue
+
+	desiredReplicas := computeDesiredReplicas(currentReplicas, metricValue, autoscaler.Spec.Metric.TargetValue.AsApproximateFloat64(),
+		minReplicas, maxReplicas)
+	recommendations := append(autoscaler.Status.Recommendations, appsv1alpha1.ReplicasRecommendation{
+		Replicas:  desiredReplicas,
+		Timestamp: metav1.Time{Time: now},
+	})
+	scaleOutWindow := time.Duration(pointerInt32Value(autoscaler.Spec.ScaleOutStabilizationWindowSeconds, 0)) * time.Second
+	scaleInWindow := time.Duration(pointerInt32Value(autoscaler.Spec.ScaleInStabilizationWindowSeconds,
+		defaultAutoscalerScaleInStabilizationSeconds)) * time.Second
+	desiredReplicas, autoscaler.Status.Recommendations = stabilizeRecommendations(recommendations, currentReplicas,
+		scaleOutWindow, scaleInWindow, now)
+	autoscaler.Status.DesiredReplicas = desiredReplicas
+	if desiredReplicas == currentReplicas {
+		return nil
+	}
+
+	activeOpsName, err := r.getActiveOpsRequest(reqCtx, autoscaler)
+	if err != nil {
+		return err
+	}
+	if activeOpsName != "" {
+		autoscaler.Status.Message = fmt.Sprintf(`waiting for the OpsRequest "%s" to complete`, activeOpsName)
+		return nil
+	}
+	opsRequest, err := r.createHorizontalScalingOpsRequest(reqCtx, autoscaler, desiredReplicas-currentReplicas)
+	if err != nil {
+		return err
+	}
+	autoscaler.Status.LastScaleTime = &metav1.Time{Time: now}
+	autoscaler.Status.LastOpsRequest = opsRequest.Name
+	r.Recorder.Eventf(autoscaler, corev1.EventTypeNormal, reasonComponentAutoscaling,
+		`The metric value is %s and the target value is %s, scaling the replicas from %d to %d by the OpsRequest "%s"`,
+		currentValue.String(), autoscaler.Spec.Metric.TargetValue.String(), currentReplicas, desiredReplicas, opsRequest.Name)
+	return nil
+}
+
+// getReplicasRange gets the range of the replicas, which is the intersection of the range of the autoscaler
+// and the replicas limit of the ComponentDefinition.
+func (r *ComponentAutoscalerReconciler) getReplicasRange(reqCtx intctrlutil.RequestCtx,
+	autoscaler *appsv1alpha1.ComponentAutoscaler,
+	cluster *appsv1.Cluster) (int32, int32, error) {
+	minReplicas := pointerInt32Value(autoscaler.Spec.MinReplicas, 1)
+	maxReplicas := autoscaler.Spec.MaxReplicas
+	replicasLimit := defaultReplicasLimit
+	comp := &appsv1.Component{}
+	compKey := client.ObjectKey{
+		Name:      constant.GenerateClusterComponentName(cluster.Name, autoscaler.Spec.ComponentName),
+		Namespace: autoscaler.Namespace,
+	}
+	if err := r.Client.Get(reqCtx.Ctx, compKey, comp); err != nil && !apierrors.IsNotFound(err) {
+		return 0, 0, err
+	}
+	if comp.Spec.CompDef != "" {
+		compDef := &appsv1.ComponentDefinition{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: comp.Spec.CompDef}, compDef); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return 0, 0, err
+			}
+		} else if compDef.Spec.ReplicasLimit != nil {
+			replicasLimit = *compDef.Spec.ReplicasLimit
+		}
+	}
+	return intersectReplicasRange(minReplicas, maxReplicas, replicasLimit)
+}
+
+// intersectReplicasRange intersects the range of the autoscaler with the replicas limit,
+// a fatal error is returned if they don't overlap.
+func intersectReplicasRange(minReplicas, maxReplicas int32, replicasLimit appsv1.ReplicasLimit) (int32, int32, error) {
+	lower, upper := max(minReplicas, replicasLimit.MinReplicas), min(maxReplicas, replicasLimit.MaxReplicas)
+	if upper < lower {
+		return 0, 0, intctrlutil.NewFatalError(fmt.Sprintf("the replicas range [%d, %d] doesn't overlap with the replicas limit [%d, %d] of the ComponentDefinition",
+			minReplicas, maxReplicas, replicasLimit.MinReplicas, replicasLimit.MaxReplicas))
+	}
+	return lower, upper, nil
+}
+
+// collectMetric collects the value of the metric from the kb-agent or the Prometheus-compatible server.
+func (r *ComponentAutoscalerReconciler) collectMetric(reqCtx intctrlutil.RequestCtx,
+	autoscaler *appsv1alpha1.ComponentAutoscaler) (float64, error) {
+	metric := autoscaler.Spec.Metric
+	var (
+		values []float64
+		err    error
+	)
+	switch {
+	case metric.KBAgent != nil:
+		values, err = r.collectKBAgentMetric(reqCtx, autoscaler, metric.KBAgent.Action)
+	case metric.Prometheus != nil:
+		var samples []prometheusSample
+		samples, err = queryPrometheus(reqCtx.Ctx, metric.Prometheus.Endpoint, metric.Prometheus.Query)
+		for _, sample := range samples {
+			values = append(values, sample.value)
+		}
+	default:
+		return 0, fmt.Errorf("no metric source specified")
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, fmt.Errorf("no metric value reported")
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values)), nil
+}
+
+// collectKBAgentMetric calls the action of the kb-agent on each pod of the Component, and parses the outputs as numbers.
+func (r *ComponentAutoscalerReconciler) collectKBAgentMetric(reqCtx intctrlutil.RequestCtx,
+	autoscaler *appsv1alpha1.ComponentAutoscaler,
+	action string) ([]float64, error) {
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList, client.InNamespace(autoscaler.Namespace),
+		client.MatchingLabels{
+			constant.AppInstanceLabelKey:    autoscaler.Spec.ClusterName,
+			constant.KBAppComponentLabelKey: autoscaler.Spec.ComponentName,
+		}); err != nil {
+		return nil, err
+	}
+	var values []float64
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !pod.DeletionTimestamp.IsZero() || pod.Status.PodIP == "" {
+			continue
+		}
+		port, err := intctrlutil.GetPortByName(*pod, kbagt.ContainerName, kbagt.DefaultPortName)
+		if err != nil {
+			// has no kb-agent defined
+			continue
+		}
+		cli, err := kbacli.NewClient(pod.Status.PodIP, port)
+		if err != nil {
+			return nil, err
+		}
+		if cli == nil {
+			continue
+		}
+		rsp, err := cli.Action(reqCtx.Ctx, proto.ActionRequest{Action: action})
+		if err != nil {
+			return nil, fmt.Errorf("failed to call the action %s at pod %s: %s", action, pod.Name, err.Error())
+		}
+		if len(rsp.Error) > 0 {
+			return nil, fmt.Errorf("failed to call the action %s at pod %s: %s, %s", action, pod.Name, rsp.Error, rsp.Message)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(string(rsp.Output)), 64)
+		if err != nil {
+			return nil, fmt.Errorf(`the output "%s" of the action %s at pod %s is not a number`,
+				strings.TrimSpace(string(rsp.Output)), action, pod.Name)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// getActiveOpsRequest gets the name of the OpsRequest created by the autoscaler which has not completed yet.
+func (r *ComponentAutoscalerReconciler) getActiveOpsRequest(reqCtx intctrlutil.RequestCtx,
+	autoscaler *appsv1alpha1.ComponentAutoscaler) (string, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(autoscaler.Namespace),
+		client.MatchingLabels{constant.ComponentAutoscalerLabelKey: autoscaler.Name}); err != nil {
+		return "", err
+	}
+	for _, opsRequest := range opsList.Items {
+		if !opsRequest.IsComplete() {
+			return opsRequest.Name, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *ComponentAutoscalerReconciler) createHorizontalScalingOpsRequest(reqCtx intctrlutil.RequestCtx,
+	autoscaler *appsv1alpha1.ComponentAutoscaler,
+	replicaChanges int32) (*appsv1alpha1.OpsRequest, error) {
+	horizontalScaling := appsv1alpha1.HorizontalScaling{
+		ComponentOps: appsv1alpha1.ComponentOps{ComponentName: autoscaler.Spec.ComponentName},
+	}
+	if replicaChanges > 0 {
+		horizontalScaling.ScaleOut = &appsv1alpha1.ScaleOut{
+			ReplicaChanger: appsv1alpha1.ReplicaChanger{ReplicaChanges: &replicaChanges},
+		}
+	} else {
+		replicaChanges = -replicaChanges
+		horizontalScaling.ScaleIn = &appsv1alpha1.ScaleIn{
+			ReplicaChanger: appsv1alpha1.ReplicaChanger{ReplicaChanges: &replicaChanges},
+		}
+	}
+	opsRequest := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: autoscaler.Name + "-",
+			Namespace:    autoscaler.Namespace,
+			Labels: map[string]string{
+				constant.ComponentAutoscalerLabelKey: autoscaler.Name,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName: autoscaler.Spec.ClusterName,
+			Type:        appsv1alpha1.HorizontalScalingType,
+			SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+				HorizontalScalingList: []appsv1alpha1.HorizontalScaling{horizontalScaling},
+			},
+		},
+	}
+	if err := intctrlutil.SetControllerReference(autoscaler, opsRequest); err != nil {
+		return nil, err
+	}
+	if err := r.Client.Create(reqCtx.Ctx, opsRequest); err != nil {
+		return nil, err
+	}
+	return opsRequest, nil
+}
+
+// computeDesiredReplicas computes the desired replicas as `ceil(currentReplicas * currentValue / targetValue)`,
+// and keeps the current replicas if the ratio is within the tolerance.
+func computeDesiredReplicas(currentReplicas int32, currentValue, targetValue float64, minReplicas, maxReplicas int32) int32 {
+	desiredReplicas := currentReplicas
+	if targetValue > 0 && currentReplicas > 0 {
+		ratio := currentValue / targetValue
+		if math.Abs(ratio-1.0) > autoscalerMetricTolerance {
+			desiredReplicas = int32(math.Ceil(ratio * float64(currentReplicas)))
+		}
+	}
+	if desiredReplicas < minReplicas {
+		desiredReplicas = minReplicas
+	}
+	if desiredReplicas > maxReplicas {
+		desiredReplicas = maxReplicas
+	}
+	return desiredReplicas
+}
+
+// stabilizeRecommendations stabilizes the desired replicas by the recommendations within the windows,
+// the replicas are scaled out to the lowest recommendation within the scale-out window,
+// and scaled in to the highest recommendation within the scale-in window.
+// The recommendations out of both windows are dropped.
+func stabilizeRecommendations(recommendations []appsv1alpha1.ReplicasRecommendation,
+	currentReplicas int32,
+	scaleOutWindow, scaleInWindow time.Duration,
+	now time.Time) (int32, []appsv1alpha1.ReplicasRecommendation) {
+	if len(recommendations) == 0 {
+		return currentReplicas, nil
+	}
+	maxWindow := scaleOutWindow
+	if scaleInWindow > maxWindow {
+		maxWindow = scaleInWindow
+	}
+	latest := recommendations[len(recommendations)-1].Replicas
+	scaleOutRecommendation, scaleInRecommendation := latest, latest
+	var retained []appsv1alpha1.ReplicasRecommendation
+	for _, rec := range recommendations {
+		age := now.Sub(rec.Timestamp.Time)
+		if age <= scaleOutWindow && rec.Replicas < scaleOutRecommendation {
+			scaleOutRecommendation = rec.Replicas
+		}
+		if age <= scaleInWindow && rec.Replicas > scaleInRecommendation {
+			scaleInRecommendation = rec.Replicas
+		}
+		if age <= maxWindow {
+			retained = append(retained, rec)
+		}
+	}
+	desiredReplicas := currentReplicas
+	if desiredReplicas < scaleOutRecommendation {
+		desiredReplicas = scaleOutRecommendation
+	} else if desiredReplicas > scaleInRecommendation {
+		desiredReplicas = scaleInRecommendation
+	}
+	return desiredReplicas, retained
+}
+
+func pointerInt32Value(p *int32, defaultValue int32) int32 {
+	if p == nil {
+		return defaultValue
+	}
+	return *p
+}
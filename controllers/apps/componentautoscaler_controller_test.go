@@ -0,0 +1,233 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("ComponentAutoscaler Controller", func() {
+	const (
+		compName           = "mysql"
+		prometheusEndpoint = "http://prometheus-server.monitoring:9090"
+		prometheusQuery    = "avg(mysql_global_status_threads_connected)"
+	)
+
+	var (
+		randomStr       = testCtx.GetRandomStr()
+		clusterName     = "test-cluster-" + randomStr
+		origQueryMetric = queryPrometheus
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.ComponentAutoscalerSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(func() {
+		queryPrometheus = origQueryMetric
+		cleanEnv()
+	})
+
+	mockMetric := func(values ...float64) {
+		queryPrometheus = func(_ context.Context, endpoint, query string) ([]prometheusSample, error) {
+			Expect(endpoint).Should(Equal(prometheusEndpoint))
+			Expect(query).Should(Equal(prometheusQuery))
+			var samples []prometheusSample
+			for _, v := range values {
+				samples = append(samples, prometheusSample{value: v})
+			}
+			return samples, nil
+		}
+	}
+
+	createCluster := func(replicas int32) {
+		testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "").
+			AddComponent(compName, "test-compdef-"+randomStr).
+			SetReplicas(replicas).
+			Create(&testCtx)
+	}
+
+	newAutoscaler := func(targetValue string) *appsv1alpha1.ComponentAutoscaler {
+		return &appsv1alpha1.ComponentAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "autoscaler-" + randomStr,
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1alpha1.ComponentAutoscalerSpec{
+				ClusterName:   clusterName,
+				ComponentName: compName,
+				MinReplicas:   pointer.Int32(1),
+				MaxReplicas:   5,
+				Metric: appsv1alpha1.AutoscalingMetric{
+					Prometheus: &appsv1alpha1.PrometheusMetricSource{
+						Endpoint: prometheusEndpoint,
+						Query:    prometheusQuery,
+					},
+					TargetValue: resource.MustParse(targetValue),
+				},
+				ScaleOutStabilizationWindowSeconds: pointer.Int32(0),
+				ScaleInStabilizationWindowSeconds:  pointer.Int32(300),
+			},
+		}
+	}
+
+	Context("Test ComponentAutoscaler", func() {
+		It("Test computing the desired replicas", func() {
+			for _, tt := range []struct {
+				current  int32
+				value    float64
+				target   float64
+				expected int32
+			}{
+				{2, 100, 100, 2},
+				{2, 105, 100, 2},
+				{2, 150, 100, 3},
+				{2, 400, 100, 5},
+				{4, 50, 100, 2},
+				{2, 0, 100, 1},
+			} {
+				Expect(computeDesiredReplicas(tt.current, tt.value, tt.target, 1, 5)).Should(Equal(tt.expected),
+					"current: %d, value: %v, target: %v", tt.current, tt.value, tt.target)
+			}
+		})
+
+		It("Test intersecting the replicas range with the replicas limit", func() {
+			limit := appsv1.ReplicasLimit{MinReplicas: 2, MaxReplicas: 4}
+			minReplicas, maxReplicas, err := intersectReplicasRange(1, 5, limit)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(minReplicas).Should(BeEquivalentTo(2))
+			Expect(maxReplicas).Should(BeEquivalentTo(4))
+
+			minReplicas, maxReplicas, err = intersectReplicasRange(3, 3, limit)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(minReplicas).Should(BeEquivalentTo(3))
+			Expect(maxReplicas).Should(BeEquivalentTo(3))
+
+			_, _, err = intersectReplicasRange(5, 6, limit)
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+		})
+
+		It("Test stabilizing the recommendations", func() {
+			now := time.Now()
+			recommendation := func(replicas int32, ago time.Duration) appsv1alpha1.ReplicasRecommendation {
+				return appsv1alpha1.ReplicasRecommendation{Replicas: replicas, Timestamp: metav1.Time{Time: now.Add(-ago)}}
+			}
+
+			By("expect the scaling in is delayed by the highest recommendation within the window")
+			recommendations := []appsv1alpha1.ReplicasRecommendation{
+				recommendation(4, 10*time.Minute),
+				recommendation(3, 2*time.Minute),
+				recommendation(2, 0),
+			}
+			replicas, retained := stabilizeRecommendations(recommendations, 4, 0, 5*time.Minute, now)
+			Expect(replicas).Should(BeEquivalentTo(3))
+			Expect(retained).Should(HaveLen(2))
+
+			By("expect the scaling out is applied immediately")
+			replicas, _ = stabilizeRecommendations([]appsv1alpha1.ReplicasRecommendation{recommendation(5, 0)},
+				2, 0, 5*time.Minute, now)
+			Expect(replicas).Should(BeEquivalentTo(5))
+		})
+
+		It("Test parsing the response of the Prometheus query", func() {
+			samples, err := parsePrometheusQueryResponse([]byte(`{"status":"success","data":{"resultType":"vector",` +
+				`"result":[{"metric":{"pod":"a"},"value":[1714500000,"10"]},{"metric":{"pod":"b"},"value":[1714500000,"20.5"]}]}}`))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(samples).Should(Equal([]prometheusSample{
+				{labels: map[string]string{"pod": "a"}, value: 10},
+				{labels: map[string]string{"pod": "b"}, value: 20.5},
+			}))
+
+			samples, err = parsePrometheusQueryResponse([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1714500000,"3"]}}`))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(samples).Should(Equal([]prometheusSample{{value: 3}}))
+
+			_, err = parsePrometheusQueryResponse([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Test the HorizontalScaling OpsRequest is created if the metric exceeds the target", func() {
+			createCluster(2)
+			mockMetric(150, 150)
+			autoscaler := newAutoscaler("100")
+			Expect(testCtx.CreateObj(testCtx.Ctx, autoscaler)).Should(Succeed())
+
+			var opsName string
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(autoscaler), func(g Gomega, autoscaler *appsv1alpha1.ComponentAutoscaler) {
+				g.Expect(autoscaler.Status.CurrentReplicas).Should(BeEquivalentTo(2))
+				g.Expect(autoscaler.Status.DesiredReplicas).Should(BeEquivalentTo(3))
+				g.Expect(autoscaler.Status.LastOpsRequest).ShouldNot(BeEmpty())
+				opsName = autoscaler.Status.LastOpsRequest
+			})).Should(Succeed())
+
+			opsRequest := &appsv1alpha1.OpsRequest{}
+			Expect(k8sClient.Get(testCtx.Ctx, client.ObjectKey{Name: opsName, Namespace: testCtx.DefaultNamespace}, opsRequest)).Should(Succeed())
+			Expect(opsRequest.Labels).Should(HaveKeyWithValue(constant.ComponentAutoscalerLabelKey, autoscaler.Name))
+			Expect(opsRequest.Spec.Type).Should(Equal(appsv1alpha1.HorizontalScalingType))
+			Expect(opsRequest.Spec.HorizontalScalingList).Should(HaveLen(1))
+			scaleOut := opsRequest.Spec.HorizontalScalingList[0].ScaleOut
+			Expect(scaleOut).ShouldNot(BeNil())
+			Expect(*scaleOut.ReplicaChanges).Should(BeEquivalentTo(1))
+		})
+
+		It("Test the Component is not scaled if the metric is within the tolerance", func() {
+			createCluster(2)
+			mockMetric(105)
+			autoscaler := newAutoscaler("100")
+			Expect(testCtx.CreateObj(testCtx.Ctx, autoscaler)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(autoscaler), func(g Gomega, autoscaler *appsv1alpha1.ComponentAutoscaler) {
+				g.Expect(autoscaler.Status.CurrentMetricValue).ShouldNot(BeNil())
+				g.Expect(autoscaler.Status.DesiredReplicas).Should(BeEquivalentTo(2))
+				g.Expect(autoscaler.Status.LastOpsRequest).Should(BeEmpty())
+			})).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,470 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/builder"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+const (
+	dataTransferContainerName = "data-transfer"
+	dataTransferPathEnv       = "DP_DATA_PATH"
+	dataTransferRepoMountPath = "/backupdata"
+
+	// dataExportScript streams the output of the dataDump action to the BackupRepo by the dpchecksum tool,
+	// which calculates the size and the checksum of the data as it is pushed, and fails if either the
+	// action or the push fails.
+	dataExportScript = `set -e
+export PATH="$PATH:$%[1]s"
+exec dpchecksum push --checksum-file --result /dev/termination-log "${%[3]s}" -- %[2]s
+`
+
+	// dataImportScript verifies the data in the BackupRepo against the checksum recorded by the export
+	// before any of it is loaded, then streams the data to the dataLoad action by the dpchecksum tool,
+	// which fails if either the pull or the action fails.
+	dataImportScript = `set -e
+export PATH="$PATH:$%[1]s"
+exec dpchecksum pull --verify=%[4]t --result /dev/termination-log "${%[3]s}" -- %[2]s
+`
+)
+
+// dataTransferOpsHandler handles the DataExport and DataImport OpsRequests,
+// which stream the logical data between a component and a BackupRepo by the dataDump/dataLoad lifecycle actions.
+type dataTransferOpsHandler struct {
+	export bool
+}
+
+var _ OpsHandler = dataTransferOpsHandler{}
+
+// dataTransfer describes the data to transfer.
+type dataTransfer struct {
+	appsv1alpha1.ComponentOps
+	appsv1alpha1.DataRepoLocation
+	instanceName string
+}
+
+func init() {
+	// ToClusterPhase is not defined, because the data transfer does not affect the cluster phase.
+	dataExportBehaviour := OpsBehaviour{
+		FromClusterPhases: []appsv1.ClusterPhase{appsv1.RunningClusterPhase,
+			appsv1.UpdatingClusterPhase, appsv1.AbnormalClusterPhase},
+		ConflictRules: newSelfOpsConflictRules(appsv1alpha1.DataImportType, ComponentOpsScope),
+		OpsHandler:    dataTransferOpsHandler{export: true},
+	}
+	dataImportBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
+		ConflictRules: append(newWorkloadOpsConflictRules(),
+			newSelfOpsConflictRules(appsv1alpha1.DataImportType, ComponentOpsScope)...),
+		OpsHandler: dataTransferOpsHandler{},
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.DataExportType, dataExportBehaviour)
+	opsMgr.RegisterOps(appsv1alpha1.DataImportType, dataImportBehaviour)
+}
+
+// ActionStartedCondition the started condition when handling the dataExport/dataImport request.
+func (d dataTransferOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	if d.export {
+		return appsv1alpha1.NewDataExportingCondition(opsRes.OpsRequest), nil
+	}
+	return appsv1alpha1.NewDataImportingCondition(opsRes.OpsRequest), nil
+}
+
+// Action checks the lifecycle action and resolves the BackupRepo.
+// If the BackupRepo is not prepared in the namespace of the OpsRequest, it marks the OpsRequest
+// to wait for the BackupRepo controller to prepare it. The job will be created in ReconcileAction.
+func (d dataTransferOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	transfer := d.getDataTransfer(opsRes.OpsRequest)
+	if _, err := d.getLifecycleAction(reqCtx, cli, opsRes, transfer); err != nil {
+		return err
+	}
+	repo, err := getDataTransferBackupRepo(reqCtx, cli, transfer.BackupRepoName)
+	if err != nil {
+		return err
+	}
+	prepared, err := isBackupRepoPrepared(reqCtx, cli, repo, opsRes.OpsRequest.Namespace)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(opsRes.OpsRequest.DeepCopy())
+	if opsRes.OpsRequest.Labels == nil {
+		opsRes.OpsRequest.Labels = map[string]string{}
+	}
+	opsRes.OpsRequest.Labels[dptypes.BackupRepoNameLabelKey] = repo.Name
+	if !prepared {
+		opsRes.OpsRequest.Labels[dptypes.WaitRepoPreparationLabelKey] = "true"
+	}
+	return cli.Patch(reqCtx.Ctx, opsRes.OpsRequest, patch)
+}
+
+// ReconcileAction creates the data transfer job once the BackupRepo is prepared, and waits for it to finish.
+// The size and the checksum of the transferred data are recorded in status.dataTransfer.
+func (d dataTransferOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		opsRequest    = opsRes.OpsRequest
+		oldOpsRequest = opsRequest.DeepCopy()
+		transfer      = d.getDataTransfer(opsRequest)
+	)
+	repo, err := getDataTransferBackupRepo(reqCtx, cli, opsRequest.Labels[dptypes.BackupRepoNameLabelKey])
+	if err != nil {
+		return opsRequest.Status.Phase, 0, err
+	}
+	job := &batchv1.Job{}
+	jobKey := client.ObjectKey{Name: getDataTransferJobName(opsRequest), Namespace: opsRequest.Namespace}
+	if err = cli.Get(reqCtx.Ctx, jobKey, job); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return opsRequest.Status.Phase, 0, err
+		}
+		prepared, err := isBackupRepoPrepared(reqCtx, cli, repo, opsRequest.Namespace)
+		if err != nil {
+			return opsRequest.Status.Phase, 0, err
+		}
+		if !prepared {
+			// wait for the BackupRepo controller to prepare the BackupRepo in the namespace.
+			return opsRequest.Status.Phase, 5 * time.Second, nil
+		}
+		if job, err = d.buildJob(reqCtx, cli, opsRes, transfer, repo, jobKey); err != nil {
+			return opsRequest.Status.Phase, 0, err
+		}
+		if err = cli.Create(reqCtx.Ctx, job); err != nil {
+			return opsRequest.Status.Phase, 0, err
+		}
+	}
+
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	compStatus := opsRequest.Status.Components[transfer.ComponentName]
+	progressDetail := appsv1alpha1.ProgressStatusDetail{
+		ObjectKey: getProgressObjectKey(constant.JobKind, job.Name),
+		Status:    appsv1alpha1.ProcessingProgressStatus,
+		Message:   fmt.Sprintf("Transferring the data of the path %s in the BackupRepo %s", transfer.Path, repo.Name),
+	}
+	opsRequest.Status.DataTransfer = &appsv1alpha1.DataTransferStatus{
+		BackupRepoName: repo.Name,
+		Path:           transfer.Path,
+	}
+	var (
+		completedCount int
+		phase          = appsv1alpha1.OpsRunningPhase
+		jobErr         error
+	)
+	_, finishedType, msg := dputils.IsJobFinished(job)
+	switch finishedType {
+	case batchv1.JobComplete:
+		if err = d.setTransferResult(reqCtx, cli, job, opsRequest.Status.DataTransfer); err != nil {
+			return opsRequest.Status.Phase, 0, err
+		}
+		progressDetail.Status = appsv1alpha1.SucceedProgressStatus
+		progressDetail.Message = fmt.Sprintf("Transferred %d bytes of data", opsRequest.Status.DataTransfer.Size)
+		completedCount = 1
+		phase = appsv1alpha1.OpsSucceedPhase
+	case batchv1.JobFailed:
+		progressDetail.Status = appsv1alpha1.FailedProgressStatus
+		progressDetail.Message = fmt.Sprintf("Failed to transfer the data: %s", msg)
+		completedCount = 1
+		phase = appsv1alpha1.OpsFailedPhase
+		jobErr = fmt.Errorf("job %s failed: %s", job.Name, msg)
+	}
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+	opsRequest.Status.Components[transfer.ComponentName] = compStatus
+	if err = syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, 1); err != nil {
+		return opsRequest.Status.Phase, 0, err
+	}
+	return phase, 0, jobErr
+}
+
+// SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
+func (d dataTransferOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	return nil
+}
+
+func (d dataTransferOpsHandler) getDataTransfer(opsRequest *appsv1alpha1.OpsRequest) dataTransfer {
+	if d.export {
+		return dataTransfer{
+			ComponentOps:     opsRequest.Spec.DataExport.ComponentOps,
+			DataRepoLocation: opsRequest.Spec.DataExport.DataRepoLocation,
+			instanceName:     opsRequest.Spec.DataExport.InstanceName,
+		}
+	}
+	return dataTransfer{
+		ComponentOps:     opsRequest.Spec.DataImport.ComponentOps,
+		DataRepoLocation: opsRequest.Spec.DataImport.DataRepoLocation,
+		instanceName:     opsRequest.Spec.DataImport.InstanceName,
+	}
+}
+
+// getLifecycleAction gets the dataDump or dataLoad lifecycle action of the component.
+func (d dataTransferOpsHandler) getLifecycleAction(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	transfer dataTransfer) (*appsv1.Action, error) {
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(transfer.ComponentName)
+	if compSpec == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`component "%s" not found`, transfer.ComponentName))
+	}
+	compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, compSpec.ComponentDef)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		action     *appsv1.Action
+		actionName = "dataLoad"
+	)
+	if compDef.Spec.LifecycleActions != nil {
+		action = compDef.Spec.LifecycleActions.DataLoad
+		if d.export {
+			action = compDef.Spec.LifecycleActions.DataDump
+		}
+	}
+	if d.export {
+		actionName = "dataDump"
+	}
+	if action == nil || action.Exec == nil || len(action.Exec.Command) == 0 {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the %s action of the component "%s" is not defined`,
+			actionName, transfer.ComponentName))
+	}
+	return action, nil
+}
+
+// getTargetPod gets the instance to transfer the data from or to.
+// If the instance is not specified, the pod matching the target pod selector of the action is preferred.
+func (d dataTransferOpsHandler) getTargetPod(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	transfer dataTransfer,
+	action *appsv1.Action) (*corev1.Pod, error) {
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, transfer.ComponentName)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	for _, pod := range pods {
+		if transfer.instanceName != "" {
+			if pod.Name == transfer.instanceName {
+				return pod, nil
+			}
+			continue
+		}
+		if action.Exec.TargetPodSelector == appsv1.RoleSelector && action.Exec.MatchingKey != "" &&
+			pod.Labels[constant.RoleLabelKey] != action.Exec.MatchingKey {
+			continue
+		}
+		return pod, nil
+	}
+	if transfer.instanceName != "" {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`instance "%s" not found in the component "%s"`,
+			transfer.instanceName, transfer.ComponentName))
+	}
+	return nil, intctrlutil.NewFatalError(fmt.Sprintf(`no available instance found in the component "%s"`, transfer.ComponentName))
+}
+
+// buildJob builds the job which runs the lifecycle action and streams the data by datasafed.
+// The action runs in the image of the action or the target container, with the environment
+// variables of the target container, and connects to the target instance by KB_POD_NAME and KB_POD_IP.
+func (d dataTransferOpsHandler) buildJob(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	transfer dataTransfer,
+	repo *dpv1alpha1.BackupRepo,
+	jobKey client.ObjectKey) (*batchv1.Job, error) {
+	action, err := d.getLifecycleAction(reqCtx, cli, opsRes, transfer)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := d.getTargetPod(reqCtx, cli, opsRes, transfer, action)
+	if err != nil {
+		return nil, err
+	}
+	targetContainer := &pod.Spec.Containers[0]
+	if action.Exec.Container != "" {
+		if _, c := intctrlutil.GetContainerByName(pod.Spec.Containers, action.Exec.Container); c != nil {
+			targetContainer = c
+		}
+	}
+	image := action.Exec.Image
+	if image == "" {
+		image = targetContainer.Image
+	}
+	var env []corev1.EnvVar
+	for _, e := range targetContainer.Env {
+		// the field references are resolved against the job pod, skip them.
+		if e.ValueFrom != nil && e.ValueFrom.FieldRef != nil {
+			continue
+		}
+		env = append(env, e)
+	}
+	env = append(env, action.Exec.Env...)
+	env = append(env,
+		corev1.EnvVar{Name: constant.KBEnvPodName, Value: pod.Name},
+		corev1.EnvVar{Name: constant.KBEnvPodIP, Value: pod.Status.PodIP},
+		corev1.EnvVar{Name: dataTransferPathEnv, Value: transfer.Path},
+	)
+
+	var quotedCmd []string
+	for _, arg := range append(append([]string{}, action.Exec.Command...), action.Exec.Args...) {
+		quotedCmd = append(quotedCmd, shellQuote(arg))
+	}
+	script := fmt.Sprintf(dataImportScript, dptypes.DPDatasafedBinPath, strings.Join(quotedCmd, " "), dataTransferPathEnv,
+		!opsRes.OpsRequest.Spec.DataImport.SkipChecksumVerification)
+	if d.export {
+		script = fmt.Sprintf(dataExportScript, dptypes.DPDatasafedBinPath, strings.Join(quotedCmd, " "), dataTransferPathEnv)
+	}
+	container := corev1.Container{
+		Name:            dataTransferContainerName,
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"sh", "-c"},
+		Args:            []string{script},
+		Env:             env,
+		EnvFrom:         targetContainer.EnvFrom,
+		SecurityContext: targetContainer.SecurityContext,
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec := corev1.PodSpec{
+		Containers:       []corev1.Container{container},
+		RestartPolicy:    corev1.RestartPolicyNever,
+		Tolerations:      pod.Spec.Tolerations,
+		ImagePullSecrets: pod.Spec.ImagePullSecrets,
+		SecurityContext:  pod.Spec.SecurityContext,
+	}
+	dputils.InjectDatasafed(&podSpec, repo, dataTransferRepoMountPath, nil, "")
+	dputils.InjectChecksumTool(&podSpec)
+	for i := range podSpec.InitContainers {
+		intctrlutil.InjectZeroResourcesLimitsIfEmpty(&podSpec.InitContainers[i])
+	}
+	job := builder.NewJobBuilder(jobKey.Namespace, jobKey.Name).
+		SetBackoffLimit(0).
+		AddLabelsInMap(map[string]string{
+			constant.OpsRequestNameLabelKey:      opsRes.OpsRequest.Name,
+			constant.OpsRequestNamespaceLabelKey: opsRes.OpsRequest.Namespace,
+			constant.AppManagedByLabelKey:        constant.AppName,
+		}).
+		SetPodTemplateSpec(corev1.PodTemplateSpec{Spec: podSpec}).
+		GetObject()
+	if err = intctrlutil.SetControllerReference(opsRes.OpsRequest, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// setTransferResult reads the size and the checksum of the data from the termination message of the job pod.
+func (d dataTransferOpsHandler) setTransferResult(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	job *batchv1.Job,
+	status *appsv1alpha1.DataTransferStatus) error {
+	podList, err := dputils.GetAssociatedPodsOfJob(reqCtx.Ctx, cli, job.Namespace, job.Name)
+	if err != nil {
+		return err
+	}
+	for _, pod := range podList.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != dataTransferContainerName || cs.State.Terminated == nil ||
+				cs.State.Terminated.ExitCode != 0 || cs.State.Terminated.Message == "" {
+				continue
+			}
+			result := appsv1alpha1.DataTransferStatus{}
+			if err = json.Unmarshal([]byte(cs.State.Terminated.Message), &result); err != nil {
+				return intctrlutil.NewFatalError(fmt.Sprintf("failed to parse the result of the job %s: %s", job.Name, err.Error()))
+			}
+			status.Size = result.Size
+			status.Checksum = result.Checksum
+			return nil
+		}
+	}
+	return nil
+}
+
+// getDataTransferBackupRepo gets the BackupRepo by name, or the default BackupRepo if the name is empty.
+func getDataTransferBackupRepo(reqCtx intctrlutil.RequestCtx, cli client.Client, repoName string) (*dpv1alpha1.BackupRepo, error) {
+	if repoName != "" {
+		repo := &dpv1alpha1.BackupRepo{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: repoName}, repo); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, intctrlutil.NewFatalError(fmt.Sprintf(`BackupRepo "%s" not found`, repoName))
+			}
+			return nil, err
+		}
+		if repo.Status.Phase != dpv1alpha1.BackupRepoReady {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`BackupRepo "%s" is not ready`, repoName))
+		}
+		return repo, nil
+	}
+	repoList := &dpv1alpha1.BackupRepoList{}
+	if err := cli.List(reqCtx.Ctx, repoList); err != nil {
+		return nil, err
+	}
+	for i := range repoList.Items {
+		repo := &repoList.Items[i]
+		if repo.Annotations[dptypes.DefaultBackupRepoAnnotationKey] == "true" &&
+			repo.Status.Phase == dpv1alpha1.BackupRepoReady {
+			return repo, nil
+		}
+	}
+	return nil, intctrlutil.NewFatalError("no default BackupRepo found, please specify the backupRepoName")
+}
+
+// isBackupRepoPrepared checks if the PVC or the tool config secret of the BackupRepo exists in the namespace.
+func isBackupRepoPrepared(reqCtx intctrlutil.RequestCtx, cli client.Client, repo *dpv1alpha1.BackupRepo, namespace string) (bool, error) {
+	switch {
+	case repo.AccessByMount():
+		return intctrlutil.CheckResourceExists(reqCtx.Ctx, cli,
+			client.ObjectKey{Name: repo.Status.BackupPVCName, Namespace: namespace}, &corev1.PersistentVolumeClaim{})
+	case repo.AccessByTool():
+		return intctrlutil.CheckResourceExists(reqCtx.Ctx, cli,
+			client.ObjectKey{Name: repo.Status.ToolConfigSecretName, Namespace: namespace}, &corev1.Secret{})
+	default:
+		return false, intctrlutil.NewFatalError(fmt.Sprintf("unknown access method of the BackupRepo %s: %s",
+			repo.Name, repo.Spec.AccessMethod))
+	}
+}
+
+func getDataTransferJobName(opsRequest *appsv1alpha1.OpsRequest) string {
+	return fmt.Sprintf("%s-%s-%s", opsRequest.UID[:8], common.CutString(opsRequest.Name, 30),
+		strings.ToLower(string(opsRequest.Spec.Type)))
+}
+
+// shellQuote quotes the string to be used as a single word in the shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,169 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("DataExport OpsRequest", func() {
+
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		compDefName = "test-compdef-" + randomStr
+		clusterName = "test-cluster-" + randomStr
+		repoName    = "test-repo-" + randomStr
+		secretName  = "test-repo-config-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.InstanceSetSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.JobSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+		// default GracePeriod is 30s
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		// non-namespaced
+		testapps.ClearResources(&testCtx, generics.BackupRepoSignature, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	createBackupRepo := func() {
+		repo := &dpv1alpha1.BackupRepo{}
+		repo.Name = repoName
+		repo.Spec.StorageProviderRef = "test-provider"
+		repo.Spec.AccessMethod = dpv1alpha1.AccessMethodTool
+		repo.Spec.PVReclaimPolicy = corev1.PersistentVolumeReclaimRetain
+		Expect(testCtx.CreateObj(ctx, repo)).Should(Succeed())
+		Expect(testapps.ChangeObjStatus(&testCtx, repo, func() {
+			repo.Status.Phase = dpv1alpha1.BackupRepoReady
+			repo.Status.ToolConfigSecretName = secretName
+		})).Should(Succeed())
+	}
+
+	Context("Test DataExport opsRequest", func() {
+		It("export the data to the BackupRepo", func() {
+			By("init operations resources with the dataDump action")
+			opsRes, compDef, _ := initOperationsResources(compDefName, clusterName)
+			Expect(testapps.ChangeObj(&testCtx, compDef, func(compDef *appsv1.ComponentDefinition) {
+				compDef.Spec.LifecycleActions.DataDump = &appsv1.Action{
+					Exec: &appsv1.ExecAction{Command: []string{"dump", "--all"}},
+				}
+			})).Should(Succeed())
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			testapps.MockInstanceSetPods(&testCtx, its, opsRes.Cluster, defaultCompName)
+			createBackupRepo()
+
+			By("expect the opsRequest waits for the BackupRepo to be prepared")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			ops := testapps.NewOpsRequestObj("data-export-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.DataExportType)
+			ops.Spec.DataExport = &appsv1alpha1.DataExport{
+				ComponentOps:     appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				DataRepoLocation: appsv1alpha1.DataRepoLocation{BackupRepoName: repoName, Path: "export/data.sql"},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+			Expect(opsRes.OpsRequest.Labels[dptypes.BackupRepoNameLabelKey]).Should(Equal(repoName))
+			Expect(opsRes.OpsRequest.Labels[dptypes.WaitRepoPreparationLabelKey]).Should(Equal("true"))
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			jobKey := client.ObjectKey{Name: getDataTransferJobName(opsRes.OpsRequest), Namespace: testCtx.DefaultNamespace}
+			Consistently(testapps.CheckObjExists(&testCtx, jobKey, &batchv1.Job{}, false)).Should(Succeed())
+
+			By("expect to create the job after the BackupRepo is prepared")
+			secret := &corev1.Secret{}
+			secret.Name = secretName
+			secret.Namespace = testCtx.DefaultNamespace
+			Expect(testCtx.CreateObj(ctx, secret)).Should(Succeed())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			job := &batchv1.Job{}
+			Eventually(testapps.CheckObj(&testCtx, jobKey, func(g Gomega, obj *batchv1.Job) {
+				podSpec := obj.Spec.Template.Spec
+				g.Expect(podSpec.InitContainers).Should(HaveLen(2))
+				g.Expect(podSpec.Containers[0].Args[0]).Should(ContainSubstring(`dpchecksum push --checksum-file`))
+				g.Expect(podSpec.Containers[0].Args[0]).Should(ContainSubstring(`-- 'dump' '--all'`))
+				*job = *obj
+			})).Should(Succeed())
+
+			By("mock the job completed and expect the size and checksum are recorded")
+			Expect(testapps.ChangeObjStatus(&testCtx, job, func() {
+				job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			})).Should(Succeed())
+			pod := testapps.NewPodFactory(testCtx.DefaultNamespace, job.Name+"-pod").
+				AddLabels("job-name", job.Name).
+				AddContainer(corev1.Container{Name: dataTransferContainerName, Image: testapps.ApeCloudMySQLImage}).
+				Create(&testCtx).GetObject()
+			Expect(testapps.ChangeObjStatus(&testCtx, pod, func() {
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name: dataTransferContainerName,
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Message: `{"size":1024,"checksum":"sha256:abc"}`,
+					}},
+				}}
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(job), func(g Gomega, obj *batchv1.Job) {
+				g.Expect(obj.Status.Conditions).ShouldNot(BeEmpty())
+			})).Should(Succeed())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+			Expect(opsRes.OpsRequest.Status.DataTransfer).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.DataTransfer.Size).Should(BeEquivalentTo(1024))
+			Expect(opsRes.OpsRequest.Status.DataTransfer.Checksum).Should(Equal("sha256:abc"))
+			Expect(opsRes.OpsRequest.Status.Progress).Should(Equal("1/1"))
+		})
+	})
+})
@@ -45,8 +45,8 @@ var _ OpsHandler = ExposeOpsHandler{}
 func init() {
 	// ToClusterPhase is not defined, because 'expose' does not affect the cluster status.
 	exposeBehavior := OpsBehaviour{
-		OpsHandler:  ExposeOpsHandler{},
-		QueueBySelf: true,
+		OpsHandler:    ExposeOpsHandler{},
+		ConflictRules: newSelfOpsConflictRules(appsv1alpha1.ExposeType, ComponentOpsScope),
 	}
 
 	opsMgr := GetOpsManager()
@@ -48,7 +48,7 @@ func init() {
 		// if cluster is Abnormal or Failed, new opsRequest may repair it.
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
-		QueueByCluster:    true,
+		ConflictRules:     newWorkloadOpsConflictRules(),
 		OpsHandler:        hsHandler,
 		CancelFunc:        hsHandler.Cancel,
 	}
@@ -0,0 +1,471 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/job"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const switchingOverPodPrefixMsg = "Switching over the primary role away from pod"
+
+type migrateOpsHandler struct {
+	rebuildHandler rebuildInstanceOpsHandler
+}
+
+var _ OpsHandler = migrateOpsHandler{}
+
+func init() {
+	migrateHandler := migrateOpsHandler{}
+	migrateBehaviour := OpsBehaviour{
+		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
+		ToClusterPhase:    appsv1.UpdatingClusterPhase,
+		ConflictRules:     newWorkloadOpsConflictRules(),
+		OpsHandler:        migrateHandler,
+		CancelFunc:        migrateHandler.Cancel,
+	}
+	opsMgr := GetOpsManager()
+	opsMgr.RegisterOps(appsv1alpha1.MigrateType, migrateBehaviour)
+}
+
+// ActionStartedCondition the started condition when handle the migrate request.
+func (m migrateOpsHandler) ActionStartedCondition(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*metav1.Condition, error) {
+	return appsv1alpha1.NewInstancesMigratingCondition(opsRes.OpsRequest), nil
+}
+
+// Action checks whether the instances can be migrated, the instances will be migrated in ReconcileAction.
+func (m migrateOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	for _, v := range opsRes.OpsRequest.Spec.MigrateList {
+		for _, ins := range v.Instances {
+			pod := &corev1.Pod{}
+			if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: ins.Name, Namespace: opsRes.Cluster.Namespace}, pod); err != nil {
+				return err
+			}
+			if pod.Labels[constant.KBAppComponentLabelKey] != v.ComponentName {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`instance "%s" does not belong to the component "%s"`, ins.Name, v.ComponentName))
+			}
+			if _, err := m.resolveTargetNode(reqCtx, cli, opsRes, v.ComponentName, ins, pod); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (m migrateOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.MigrateList)
+	compOpsHelper.saveLastConfigurations(opsRes, func(compSpec appsv1.ClusterComponentSpec, comOps ComponentOpsInterface) appsv1alpha1.LastComponentConfiguration {
+		return appsv1alpha1.LastComponentConfiguration{
+			Replicas:         pointer.Int32(compSpec.Replicas),
+			Instances:        compSpec.Instances,
+			OfflineInstances: compSpec.OfflineInstances,
+		}
+	})
+	return nil
+}
+
+// ReconcileAction will be performed when action is done and loops till OpsRequest.status.phase is Succeed/Failed.
+// the Reconcile function for migrate opsRequest.
+func (m migrateOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
+	var (
+		oldOpsRequest   = opsRes.OpsRequest.DeepCopy()
+		oldCluster      = opsRes.Cluster.DeepCopy()
+		opsRequestPhase = opsRes.OpsRequest.Status.Phase
+		expectCount     int
+		completedCount  int
+		failedCount     int
+	)
+	if opsRes.OpsRequest.Status.Components == nil {
+		opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, v := range opsRes.OpsRequest.Spec.MigrateList {
+		compStatus := opsRes.OpsRequest.Status.Components[v.ComponentName]
+		subCompletedCount, subFailedCount, err := m.migrateInstances(reqCtx, cli, opsRes, v, &compStatus)
+		if err != nil {
+			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+				return appsv1alpha1.OpsFailedPhase, 0, err
+			}
+			return opsRequestPhase, 0, err
+		}
+		expectCount += len(v.Instances)
+		completedCount += subCompletedCount
+		failedCount += subFailedCount
+		opsRes.OpsRequest.Status.Components[v.ComponentName] = compStatus
+	}
+	if !reflect.DeepEqual(oldCluster.Spec, opsRes.Cluster.Spec) {
+		if err := cli.Update(reqCtx.Ctx, opsRes.Cluster); err != nil {
+			return opsRequestPhase, 0, err
+		}
+	}
+	if err := syncProgressToOpsRequest(reqCtx, cli, opsRes, oldOpsRequest, completedCount, expectCount); err != nil {
+		return opsRequestPhase, 0, err
+	}
+	if completedCount != expectCount {
+		return opsRequestPhase, 0, nil
+	}
+	if failedCount == 0 {
+		return appsv1alpha1.OpsSucceedPhase, 0, nil
+	}
+	return appsv1alpha1.OpsFailedPhase, 0, nil
+}
+
+// Cancel this function defines the cancel migrate action.
+// The instances which have not been taken offline will not be migrated and the scaled out instances are removed,
+// the switchover that has been triggered is not reverted.
+func (m migrateOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	var (
+		reverted    []string
+		notReverted []string
+		oldCluster  = opsRes.Cluster.DeepCopy()
+	)
+	if opsRes.OpsRequest.Status.Components == nil {
+		opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, v := range opsRes.OpsRequest.Spec.MigrateList {
+		var (
+			subReverted    []string
+			subNotReverted []string
+		)
+		compStatus := opsRes.OpsRequest.Status.Components[v.ComponentName]
+		if m.instancesScaledOut(v, &compStatus) {
+			rebuildInstance := appsv1alpha1.RebuildInstance{ComponentOps: v.ComponentOps}
+			for _, ins := range v.Instances {
+				rebuildInstance.Instances = append(rebuildInstance.Instances, appsv1alpha1.Instance{Name: ins.Name})
+			}
+			subReverted, subNotReverted = m.rebuildHandler.cancelRebuildInstancesWithHScaling(opsRes, rebuildInstance, &compStatus)
+		} else {
+			subReverted, subNotReverted = m.cancelSwitchoverOrPendingInstances(opsRes, v, &compStatus)
+		}
+		reverted = append(reverted, subReverted...)
+		notReverted = append(notReverted, subNotReverted...)
+		opsRes.OpsRequest.Status.Components[v.ComponentName] = compStatus
+	}
+	if !reflect.DeepEqual(oldCluster.Spec, opsRes.Cluster.Spec) {
+		if err := cli.Update(reqCtx.Ctx, opsRes.Cluster); err != nil {
+			return err
+		}
+	}
+	appendCancelResult(opsRes.OpsRequest, reverted, notReverted)
+	return nil
+}
+
+// cancelSwitchoverOrPendingInstances marks the instances which have not been scaled out as failed.
+func (m migrateOpsHandler) cancelSwitchoverOrPendingInstances(opsRes *OpsResource,
+	migrate appsv1alpha1.Migrate,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) ([]string, []string) {
+	var (
+		reverted    []string
+		notReverted []string
+	)
+	for _, ins := range migrate.Instances {
+		progressDetail := m.rebuildHandler.getInstanceProgressDetail(*compStatus, ins.Name)
+		if strings.HasPrefix(progressDetail.Message, switchingOverPodPrefixMsg) {
+			notReverted = append(notReverted, fmt.Sprintf("the switchover away from pod %s has been triggered", ins.Name))
+		}
+		progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, fmt.Sprintf("Migrating pod %s is cancelled", ins.Name))
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+		reverted = append(reverted, fmt.Sprintf("instance %s will not be migrated", ins.Name))
+	}
+	return reverted, notReverted
+}
+
+// migrateInstances migrates the instances of the component in three steps:
+// 1. switchover away from the instances if one of them holds the primary role.
+// 2. rebuild the instances on the target nodes by scaling out new instances and taking the old ones offline.
+// 3. remove the PVCs of the old instances.
+func (m migrateOpsHandler) migrateInstances(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	migrate appsv1alpha1.Migrate,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) (int, int, error) {
+	rebuildInstance := appsv1alpha1.RebuildInstance{ComponentOps: migrate.ComponentOps}
+	for _, ins := range migrate.Instances {
+		rebuildInstance.Instances = append(rebuildInstance.Instances, appsv1alpha1.Instance{Name: ins.Name})
+	}
+	if !m.instancesScaledOut(migrate, compStatus) {
+		if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+			// the migration of the instances has been cancelled.
+			return len(migrate.Instances), len(migrate.Instances), nil
+		}
+		switched, err := m.switchoverIfPrimary(reqCtx, cli, opsRes, migrate, compStatus)
+		if err != nil || !switched {
+			return 0, 0, err
+		}
+		for i, ins := range migrate.Instances {
+			pod := &corev1.Pod{}
+			if err = cli.Get(reqCtx.Ctx, client.ObjectKey{Name: ins.Name, Namespace: opsRes.Cluster.Namespace}, pod); err != nil {
+				return 0, 0, err
+			}
+			if rebuildInstance.Instances[i].TargetNodeName, err = m.resolveTargetNode(reqCtx, cli, opsRes, migrate.ComponentName, ins, pod); err != nil {
+				return 0, 0, err
+			}
+		}
+		return 0, 0, m.rebuildHandler.scaleOutRequiredInstances(reqCtx, cli, opsRes, rebuildInstance, compStatus)
+	}
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(migrate.ComponentName)
+	if compSpec == nil {
+		return 0, 0, intctrlutil.NewFatalError(fmt.Sprintf(`the component "%s" is not found`, migrate.ComponentName))
+	}
+	completedCount, failedCount, instancesNeedToOffline, err := m.rebuildHandler.checkProgressForScalingOutPods(reqCtx,
+		cli, opsRes, rebuildInstance, compSpec, compStatus)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(instancesNeedToOffline) > 0 {
+		m.rebuildHandler.offlineSpecifiedInstances(compSpec, opsRes.Cluster.Name, instancesNeedToOffline)
+	}
+	for _, ins := range migrate.Instances {
+		progressDetail := m.rebuildHandler.getInstanceProgressDetail(*compStatus, ins.Name)
+		if progressDetail.Status != appsv1alpha1.SucceedProgressStatus {
+			continue
+		}
+		removed, err := m.removeInstancePVCs(reqCtx, cli, opsRes, migrate.ComponentName, ins.Name, compStatus)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !removed {
+			completedCount -= 1
+		}
+	}
+	return completedCount, failedCount, nil
+}
+
+// instancesScaledOut checks whether the new instances have been scaled out.
+func (m migrateOpsHandler) instancesScaledOut(migrate appsv1alpha1.Migrate, compStatus *appsv1alpha1.OpsRequestComponentStatus) bool {
+	for _, ins := range migrate.Instances {
+		progressDetail := m.rebuildHandler.getInstanceProgressDetail(*compStatus, ins.Name)
+		if m.rebuildHandler.getScalingOutPodNameFromMessage(progressDetail.Message) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// switchoverIfPrimary performs a switchover if one of the instances holds the primary role,
+// and returns true if none of the instances holds the primary role.
+func (m migrateOpsHandler) switchoverIfPrimary(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	migrate appsv1alpha1.Migrate,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) (bool, error) {
+	compSpec := opsRes.Cluster.Spec.GetComponentByName(migrate.ComponentName)
+	synthesizedComp, err := buildSynthesizedComp(reqCtx, cli, opsRes, compSpec)
+	if err != nil {
+		return false, err
+	}
+	if synthesizedComp.LifecycleActions == nil || synthesizedComp.LifecycleActions.Switchover == nil ||
+		!slices.ContainsFunc(synthesizedComp.Roles, func(role appsv1.ReplicaRole) bool { return role.Serviceable && role.Writable }) {
+		return true, nil
+	}
+	primary, err := getServiceableNWritablePod(reqCtx.Ctx, cli, *synthesizedComp)
+	if err != nil {
+		return false, err
+	}
+	var instanceNames []string
+	for _, ins := range migrate.Instances {
+		instanceNames = append(instanceNames, ins.Name)
+	}
+	if !slices.Contains(instanceNames, primary.Name) {
+		// clean up the switchover job created by this opsRequest.
+		for _, ins := range migrate.Instances {
+			progressDetail := m.rebuildHandler.getInstanceProgressDetail(*compStatus, ins.Name)
+			if strings.HasPrefix(progressDetail.Message, switchingOverPodPrefixMsg) {
+				return true, job.CleanJobWithLabels(reqCtx.Ctx, cli, opsRes.Cluster,
+					getSwitchoverCmdJobLabel(opsRes.Cluster.Name, synthesizedComp.Name))
+			}
+		}
+		return true, nil
+	}
+	candidate, err := m.selectSwitchoverCandidate(reqCtx, cli, synthesizedComp, primary, instanceNames)
+	if err != nil {
+		return false, err
+	}
+	switchover := &appsv1alpha1.Switchover{ComponentOps: migrate.ComponentOps, InstanceName: candidate}
+	jobName := genOpsSwitchoverJobName(opsRes.Cluster.Name, synthesizedComp.Name, opsRes.OpsRequest)
+	if err = createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, switchover, jobName); err != nil {
+		return false, err
+	}
+	if err = job.CheckJobSucceed(reqCtx.Ctx, cli, opsRes.Cluster, jobName); err != nil &&
+		intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		return false, intctrlutil.NewFatalError(fmt.Sprintf(`switchover job %s failed: %s`, jobName, err.Error()))
+	}
+	progressDetail := m.rebuildHandler.getInstanceProgressDetail(*compStatus, primary.Name)
+	progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus,
+		fmt.Sprintf("%s %s to pod %s", switchingOverPodPrefixMsg, primary.Name, candidate))
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	return false, nil
+}
+
+// selectSwitchoverCandidate chooses the most up-to-date replica which is not being migrated as the new primary.
+func (m migrateOpsHandler) selectSwitchoverCandidate(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	synthesizedComp *component.SynthesizedComponent,
+	primary *corev1.Pod,
+	instanceNames []string) (string, error) {
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	var (
+		candidate string
+		minLag    int64 = -1
+	)
+	for _, pod := range pods {
+		if pod.Name == primary.Name || slices.Contains(instanceNames, pod.Name) {
+			continue
+		}
+		lag, reason, err := scoreSwitchoverCandidate(reqCtx.Ctx, cli, synthesizedComp, nil, pod)
+		if err != nil {
+			return "", err
+		}
+		if reason != "" {
+			continue
+		}
+		if minLag < 0 || lag < minLag {
+			minLag = lag
+			candidate = pod.Name
+		}
+	}
+	if candidate == "" {
+		return "", intctrlutil.NewFatalError(fmt.Sprintf(`no available switchover candidate found for component "%s"`, synthesizedComp.Name))
+	}
+	return candidate, nil
+}
+
+// resolveTargetNode resolves the node that the instance will be migrated to.
+// The schedulable node hosting the fewest instances of the component is preferred.
+func (m migrateOpsHandler) resolveTargetNode(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compName string,
+	ins appsv1alpha1.MigrateInstance,
+	pod *corev1.Pod) (string, error) {
+	if ins.TargetNodeName != "" {
+		node := &corev1.Node{}
+		if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: ins.TargetNodeName}, node); err != nil {
+			return "", err
+		}
+		return ins.TargetNodeName, nil
+	}
+	selector := client.MatchingLabels{}
+	for k, v := range ins.TargetNodeSelector {
+		selector[k] = v
+	}
+	if ins.TargetZone != "" {
+		selector[constant.ZoneLabelKey] = ins.TargetZone
+	}
+	nodeList := &corev1.NodeList{}
+	if err := cli.List(reqCtx.Ctx, nodeList, selector); err != nil {
+		return "", err
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, compName)
+	if err != nil {
+		return "", err
+	}
+	podCountOnNode := map[string]int{}
+	for _, v := range pods {
+		podCountOnNode[v.Spec.NodeName] += 1
+	}
+	var nodeNames []string
+	for _, node := range nodeList.Items {
+		if node.Spec.Unschedulable || node.Name == pod.Spec.NodeName {
+			continue
+		}
+		nodeNames = append(nodeNames, node.Name)
+	}
+	if len(nodeNames) == 0 {
+		return "", intctrlutil.NewFatalError(fmt.Sprintf(`no available node found to migrate the instance "%s"`, ins.Name))
+	}
+	sort.SliceStable(nodeNames, func(i, j int) bool {
+		if podCountOnNode[nodeNames[i]] != podCountOnNode[nodeNames[j]] {
+			return podCountOnNode[nodeNames[i]] < podCountOnNode[nodeNames[j]]
+		}
+		return nodeNames[i] < nodeNames[j]
+	})
+	return nodeNames[0], nil
+}
+
+// removeInstancePVCs removes the PVCs of the migrated instance, and returns true if all of them have been removed.
+func (m migrateOpsHandler) removeInstancePVCs(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compName string,
+	insName string,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) (bool, error) {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := cli.List(reqCtx.Ctx, pvcList, client.InNamespace(opsRes.Cluster.Namespace), client.MatchingLabels{
+		constant.AppInstanceLabelKey:    opsRes.Cluster.Name,
+		constant.KBAppComponentLabelKey: compName,
+	}); err != nil {
+		return false, err
+	}
+	removed := true
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if pvc.Name != fmt.Sprintf("%s-%s", pvc.Labels[constant.VolumeClaimTemplateNameLabelKey], insName) {
+			continue
+		}
+		removed = false
+		if pvc.DeletionTimestamp.IsZero() {
+			if err := intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, pvc); err != nil {
+				return false, err
+			}
+		}
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails,
+			appsv1alpha1.ProgressStatusDetail{
+				ObjectKey: getProgressObjectKey(constant.PersistentVolumeClaimKind, pvc.Name),
+				Status:    appsv1alpha1.ProcessingProgressStatus,
+				Message:   fmt.Sprintf("Removing the PVC %s of the migrated pod %s", pvc.Name, insName),
+			})
+	}
+	if !removed {
+		return false, nil
+	}
+	pvcKeyPrefix := getProgressObjectKey(constant.PersistentVolumeClaimKind, "")
+	for _, progressDetail := range compStatus.ProgressDetails {
+		if !strings.HasPrefix(progressDetail.ObjectKey, pvcKeyPrefix) || !strings.HasSuffix(progressDetail.ObjectKey, "-"+insName) ||
+			progressDetail.Status == appsv1alpha1.SucceedProgressStatus {
+			continue
+		}
+		progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus,
+			fmt.Sprintf("The PVC %s of the migrated pod %s is removed", strings.TrimPrefix(progressDetail.ObjectKey, pvcKeyPrefix), insName))
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	}
+	return true, nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
+)
+
+var _ = Describe("Migrate OpsRequest", func() {
+
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		compDefName = "test-compdef-" + randomStr
+		clusterName = "test-cluster-" + randomStr
+		targetZone  = "zone-2"
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.InstanceSetSignature, inNS, ml)
+		// default GracePeriod is 30s
+		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml, client.GracePeriodSeconds(0))
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ComponentSignature, true, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	createNode := func(name, zone string) {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{constant.ZoneLabelKey: zone},
+			},
+		}
+		Expect(testCtx.CreateObj(ctx, node)).Should(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, node))).Should(Succeed())
+		})
+	}
+
+	createMigrateOps := func(instanceName string) *appsv1alpha1.OpsRequest {
+		ops := testapps.NewOpsRequestObj("migrate-"+testCtx.GetRandomStr(), testCtx.DefaultNamespace,
+			clusterName, appsv1alpha1.MigrateType)
+		ops.Spec.MigrateList = []appsv1alpha1.Migrate{
+			{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				Instances:    []appsv1alpha1.MigrateInstance{{Name: instanceName, TargetZone: targetZone}},
+			},
+		}
+		opsRequest := testapps.CreateOpsRequest(ctx, testCtx, ops)
+		opsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+		return opsRequest
+	}
+
+	Context("Test Migrate opsRequest", func() {
+		It("migrate the instance to the target zone", func() {
+			By("init operations resources")
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+			comp, err := component.BuildComponent(opsRes.Cluster, &opsRes.Cluster.Spec.ComponentSpecs[0], nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(testCtx.CreateObj(ctx, comp)).Should(Succeed())
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			podList := testapps.MockInstanceSetPods(&testCtx, its, opsRes.Cluster, defaultCompName)
+			migratedPod := podList[2]
+			pvcName := fmt.Sprintf("%s-%s", testapps.DataVolumeName, migratedPod.Name)
+			testapps.NewPersistentVolumeClaimFactory(testCtx.DefaultNamespace, pvcName, clusterName, defaultCompName, testapps.DataVolumeName).
+				SetStorage("20Gi").Create(&testCtx)
+			createNode("migrate-node-"+randomStr+"-1", "zone-1")
+			targetNodeName := "migrate-node-" + randomStr + "-2"
+			createNode(targetNodeName, targetZone)
+
+			By("expect the opsRequest is running")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes.OpsRequest = createMigrateOps(migratedPod.Name)
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsRunningPhase))
+
+			By("expect to scale out a new instance on the node of the target zone")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.Cluster.Spec.GetComponentByName(defaultCompName).Replicas).Should(BeEquivalentTo(4))
+			podPrefix := constant.GenerateWorkloadNamePattern(clusterName, defaultCompName)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				mapping, err := instanceset.ParseNodeSelectorOnceAnnotation(its)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(mapping).To(HaveKeyWithValue(podPrefix+"-3", targetNodeName))
+			})).Should(Succeed())
+
+			By("mock the new pod to available and expect the instance to take offline")
+			testapps.MockInstanceSetPod(&testCtx, nil, clusterName, defaultCompName, podPrefix+"-3", "follower", "Readonly")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			compSpec := opsRes.Cluster.Spec.GetComponentByName(defaultCompName)
+			Expect(compSpec.Replicas).Should(BeEquivalentTo(3))
+			Expect(slices.Contains(compSpec.OfflineInstances, migratedPod.Name)).Should(BeTrue())
+
+			By("delete the pod and expect the PVC of the migrated instance is removed")
+			testk8s.MockPodIsTerminating(ctx, testCtx, migratedPod)
+			testk8s.RemovePodFinalizer(ctx, testCtx, migratedPod)
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			pvcKey := client.ObjectKey{Name: pvcName, Namespace: testCtx.DefaultNamespace}
+			Eventually(func(g Gomega) {
+				pvc := &corev1.PersistentVolumeClaim{}
+				if err := k8sClient.Get(ctx, pvcKey, pvc); err == nil {
+					g.Expect(pvc.DeletionTimestamp).ShouldNot(BeNil())
+					g.Expect(testapps.ChangeObj(&testCtx, pvc, func(pvc *corev1.PersistentVolumeClaim) {
+						pvc.Finalizers = nil
+					})).Should(Succeed())
+				}
+			}).Should(Succeed())
+			Eventually(testapps.CheckObjExists(&testCtx, pvcKey, &corev1.PersistentVolumeClaim{}, false)).Should(Succeed())
+
+			By("expect the opsRequest is succeed")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsSucceedPhase))
+		})
+
+		It("cancel the migration before the instance is taken offline", func() {
+			By("init operations resources")
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+			comp, err := component.BuildComponent(opsRes.Cluster, &opsRes.Cluster.Spec.ComponentSpecs[0], nil, nil)
+			Expect(err).Should(BeNil())
+			Expect(testCtx.CreateObj(ctx, comp)).Should(Succeed())
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			podList := testapps.MockInstanceSetPods(&testCtx, its, opsRes.Cluster, defaultCompName)
+			migratedPod := podList[2]
+			createNode("migrate-node-"+randomStr+"-1", "zone-1")
+			createNode("migrate-node-"+randomStr+"-2", targetZone)
+
+			By("expect to scale out a new instance")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes.OpsRequest = createMigrateOps(migratedPod.Name)
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.Cluster.Spec.GetComponentByName(defaultCompName).Replicas).Should(BeEquivalentTo(4))
+
+			By("cancel the opsRequest and expect the scaled out instance is removed")
+			cancelOpsRequest(reqCtx, opsRes, time.Now())
+			Expect(opsRes.OpsRequest.Status.CancelResult).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.CancelResult.Reverted).Should(HaveLen(1))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				compSpec := cluster.Spec.GetComponentByName(defaultCompName)
+				g.Expect(compSpec.Replicas).Should(BeEquivalentTo(3))
+				g.Expect(compSpec.OfflineInstances).ShouldNot(ContainElement(migratedPod.Name))
+			})).Should(Succeed())
+
+			By("expect the opsRequest is cancelled")
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Phase).Should(Equal(appsv1alpha1.OpsCancelledPhase))
+		})
+	})
+})
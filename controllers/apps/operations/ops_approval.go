@@ -0,0 +1,184 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// GetMatchedApprovalPolicy gets the OpsApprovalPolicy which requires the approval of the opsRequest.
+// If more than one policies match the opsRequest, the first one sorted by name is returned.
+func GetMatchedApprovalPolicy(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (*appsv1alpha1.OpsApprovalPolicy, error) {
+	policyList := &appsv1alpha1.OpsApprovalPolicyList{}
+	if err := cli.List(reqCtx.Ctx, policyList); err != nil {
+		return nil, err
+	}
+	if len(policyList.Items) == 0 {
+		return nil, nil
+	}
+	sort.Slice(policyList.Items, func(i, j int) bool {
+		return policyList.Items[i].Name < policyList.Items[j].Name
+	})
+	var namespaceLabels labels.Set
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if !slices.Contains(policy.Spec.OpsTypes, opsRes.OpsRequest.Spec.Type) {
+			continue
+		}
+		if policy.Spec.NamespaceSelector != nil {
+			if namespaceLabels == nil {
+				namespace := &corev1.Namespace{}
+				if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRes.OpsRequest.Namespace}, namespace); err != nil {
+					return nil, err
+				}
+				namespaceLabels = namespace.Labels
+			}
+			matched, err := matchLabelSelector(policy.Spec.NamespaceSelector, namespaceLabels)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		if policy.Spec.ClusterSelector != nil {
+			var clusterLabels labels.Set
+			if opsRes.Cluster != nil {
+				clusterLabels = opsRes.Cluster.Labels
+			}
+			matched, err := matchLabelSelector(policy.Spec.ClusterSelector, clusterLabels)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		return policy, nil
+	}
+	return nil, nil
+}
+
+// ReconcilePendingApproval checks the approval record of the opsRequest in the PendingApproval phase.
+// The opsRequest moves to the Pending phase once it is approved by an allowed approver,
+// and it is aborted if it is not approved before the expiration.
+// Returns the duration after which the opsRequest needs to be checked again.
+func ReconcilePendingApproval(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Approval == nil {
+		opsRequest.Status.Approval = &appsv1alpha1.OpsApprovalStatus{}
+	}
+	policy := &appsv1alpha1.OpsApprovalPolicy{}
+	if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: opsRequest.Status.Approval.PolicyName}, policy); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, err
+		}
+		// the policy has been deleted, the approval is no longer required.
+		return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingPhase,
+			appsv1alpha1.NewWaitForProcessingCondition(opsRequest))
+	}
+
+	var requeueAfter time.Duration
+	if policy.Spec.ExpirationSeconds > 0 {
+		deadline := opsRequest.CreationTimestamp.Add(time.Duration(policy.Spec.ExpirationSeconds) * time.Second)
+		if !time.Now().Before(deadline) {
+			return 0, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsAbortedPhase,
+				appsv1alpha1.NewApprovalExpiredCondition(opsRequest))
+		}
+		requeueAfter = time.Until(deadline)
+	}
+
+	approver := opsRequest.Annotations[constant.OpsApprovedByAnnotationKey]
+	if approver == "" {
+		return requeueAfter, nil
+	}
+	rejectApproval := func(message string) (time.Duration, error) {
+		condition := appsv1alpha1.NewApprovalRejectedCondition(message)
+		if existing := meta.FindStatusCondition(opsRequest.Status.Conditions, condition.Type); existing != nil &&
+			existing.Reason == condition.Reason && existing.Message == condition.Message {
+			return requeueAfter, nil
+		}
+		return requeueAfter, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsPendingApprovalPhase, condition)
+	}
+	// the approval annotations are recorded from the user info of the requests by the admission webhook,
+	// they can not be trusted if the webhook is disabled.
+	if !viper.GetBool(constant.EnableWebhooks) {
+		return rejectApproval("the approval of the OpsRequest requires the admission webhooks to be enabled")
+	}
+	requester := opsRequest.Annotations[constant.OpsRequestedByAnnotationKey]
+	if requester == "" || requester == approver {
+		return rejectApproval(fmt.Sprintf(`"%s" is the requester of the OpsRequest and is not allowed to approve it`, approver))
+	}
+	var approverGroups []string
+	if groups := opsRequest.Annotations[constant.OpsApprovedByGroupsAnnotationKey]; groups != "" {
+		approverGroups = strings.Split(groups, ",")
+	}
+	allowed, allowedGroups := isAllowedApprover(policy, approver, approverGroups)
+	if !allowed {
+		return rejectApproval(fmt.Sprintf(`"%s" is not allowed to approve the OpsRequest by OpsApprovalPolicy "%s"`,
+			approver, policy.Name))
+	}
+
+	opsRequestDeepCopy := opsRequest.DeepCopy()
+	opsRequest.Status.Approval.Approver = approver
+	opsRequest.Status.Approval.ApproverGroups = allowedGroups
+	opsRequest.Status.Approval.ApprovalTimestamp = &metav1.Time{Time: time.Now()}
+	return 0, PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsRequestDeepCopy, appsv1alpha1.OpsPendingPhase,
+		appsv1alpha1.NewApprovedCondition(opsRequest, approver), appsv1alpha1.NewWaitForProcessingCondition(opsRequest))
+}
+
+// isAllowedApprover checks whether the approver is allowed by the policy, and returns the allowed groups of the approver.
+func isAllowedApprover(policy *appsv1alpha1.OpsApprovalPolicy, approver string, approverGroups []string) (bool, []string) {
+	var allowedGroups []string
+	for _, group := range approverGroups {
+		group = strings.TrimSpace(group)
+		if slices.Contains(policy.Spec.Approvers.Groups, group) {
+			allowedGroups = append(allowedGroups, group)
+		}
+	}
+	if slices.Contains(policy.Spec.Approvers.Users, approver) {
+		return true, allowedGroups
+	}
+	return len(allowedGroups) > 0, allowedGroups
+}
+
+func matchLabelSelector(labelSelector *metav1.LabelSelector, objLabels labels.Set) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(objLabels), nil
+}
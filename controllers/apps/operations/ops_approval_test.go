@@ -0,0 +1,187 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("OpsApproval", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		compDefName = "test-compdef-" + randomStr
+		clusterName = "test-cluster-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		// delete rest resources
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+
+		// non-namespaced
+		testapps.ClearResources(&testCtx, generics.OpsApprovalPolicySignature, ml)
+	}
+
+	BeforeEach(func() {
+		cleanEnv()
+		viper.Set(constant.EnableWebhooks, true)
+	})
+
+	AfterEach(func() {
+		viper.Set(constant.EnableWebhooks, false)
+		cleanEnv()
+	})
+
+	createApprovalPolicy := func(opsType appsv1alpha1.OpsType) *appsv1alpha1.OpsApprovalPolicy {
+		policy := &appsv1alpha1.OpsApprovalPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "approval-policy-" + testCtx.GetRandomStr(),
+			},
+			Spec: appsv1alpha1.OpsApprovalPolicySpec{
+				OpsTypes: []appsv1alpha1.OpsType{opsType},
+				Approvers: appsv1alpha1.OpsApprovers{
+					Users:  []string{"admin"},
+					Groups: []string{"dba"},
+				},
+			},
+		}
+		Expect(testCtx.CreateObj(testCtx.Ctx, policy)).Should(Succeed())
+		return policy
+	}
+
+	Context("Test OpsApprovalPolicy", func() {
+		It("Test the approval of the OpsRequest", func() {
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+
+			By("create an OpsApprovalPolicy for Restart")
+			policy := createApprovalPolicy(appsv1alpha1.RestartType)
+
+			By("expect the policy does not match the VerticalScaling opsRequest")
+			opsRes.OpsRequest = testapps.NewOpsRequestObj("vscale-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VerticalScalingType)
+			matchedPolicy, err := GetMatchedApprovalPolicy(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(matchedPolicy).Should(BeNil())
+
+			By("expect the policy matches the Restart opsRequest")
+			opsRes.OpsRequest = createRestartOpsObj(clusterName, "restart-"+randomStr)
+			matchedPolicy, err = GetMatchedApprovalPolicy(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(matchedPolicy).ShouldNot(BeNil())
+			Expect(matchedPolicy.Name).Should(Equal(policy.Name))
+
+			By("mock the opsRequest is pending for approval")
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingApprovalPhase
+				opsRes.OpsRequest.Status.Approval = &appsv1alpha1.OpsApprovalStatus{PolicyName: policy.Name}
+			})).Should(Succeed())
+			opsKey := client.ObjectKeyFromObject(opsRes.OpsRequest)
+
+			By("expect the opsRequest keeps pending without the approval")
+			_, err = ReconcilePendingApproval(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, opsKey)).Should(Equal(appsv1alpha1.OpsPendingApprovalPhase))
+
+			checkApprovalRejected := func(message string) {
+				Eventually(testapps.CheckObj(&testCtx, opsKey, func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+					g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingApprovalPhase))
+					condition := meta.FindStatusCondition(ops.Status.Conditions, appsv1alpha1.ConditionTypeApproved)
+					g.Expect(condition).ShouldNot(BeNil())
+					g.Expect(condition.Reason).Should(Equal(appsv1alpha1.ReasonApprovalRejected))
+					g.Expect(condition.Message).Should(ContainSubstring(message))
+				})).Should(Succeed())
+			}
+
+			By("expect the approval is rejected if the approver is the requester")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Annotations = map[string]string{
+					constant.OpsRequestedByAnnotationKey: "admin",
+					constant.OpsApprovedByAnnotationKey:  "admin",
+				}
+			})).Should(Succeed())
+			_, err = ReconcilePendingApproval(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			checkApprovalRejected("is the requester of the OpsRequest")
+
+			By("expect the approval is rejected if the admission webhooks are disabled")
+			viper.Set(constant.EnableWebhooks, false)
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Annotations[constant.OpsRequestedByAnnotationKey] = "developer"
+			})).Should(Succeed())
+			_, err = ReconcilePendingApproval(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			checkApprovalRejected("requires the admission webhooks to be enabled")
+			viper.Set(constant.EnableWebhooks, true)
+
+			By("expect the approval is rejected if the approver is not allowed")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Annotations = map[string]string{
+					constant.OpsRequestedByAnnotationKey: "developer",
+					constant.OpsApprovedByAnnotationKey:  "guest",
+				}
+			})).Should(Succeed())
+			_, err = ReconcilePendingApproval(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			checkApprovalRejected("is not allowed to approve")
+
+			By("expect the opsRequest is approved by the allowed group")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Annotations = map[string]string{
+					constant.OpsRequestedByAnnotationKey:      "developer",
+					constant.OpsApprovedByAnnotationKey:       "guest",
+					constant.OpsApprovedByGroupsAnnotationKey: "dev,dba",
+				}
+			})).Should(Succeed())
+			_, err = ReconcilePendingApproval(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, opsKey, func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsPendingPhase))
+				g.Expect(ops.Status.Approval).ShouldNot(BeNil())
+				g.Expect(ops.Status.Approval.Approver).Should(Equal("guest"))
+				g.Expect(ops.Status.Approval.ApproverGroups).Should(Equal([]string{"dba"}))
+				g.Expect(meta.IsStatusConditionTrue(ops.Status.Conditions, appsv1alpha1.ConditionTypeApproved)).Should(BeTrue())
+			})).Should(Succeed())
+		})
+	})
+})
@@ -110,6 +110,7 @@ func (c componentOpsHelper) cancelComponentOps(ctx context.Context,
 	cli client.Client,
 	opsRes *OpsResource,
 	updateCompSpec func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec)) error {
+	var reverted []string
 	rollBackCompSpec := func(compSpec *appsv1.ClusterComponentSpec,
 		lastCompInfos map[string]appsv1alpha1.LastComponentConfiguration,
 		componentName string) {
@@ -119,6 +120,7 @@ func (c componentOpsHelper) cancelComponentOps(ctx context.Context,
 		}
 		updateCompSpec(&lastConfig, compSpec)
 		lastCompInfos[componentName] = lastConfig
+		reverted = append(reverted, fmt.Sprintf("component %s has been rolled back to the last configuration", componentName))
 	}
 
 	// 1. rollback the clusterComponentSpecs
@@ -132,7 +134,11 @@ func (c componentOpsHelper) cancelComponentOps(ctx context.Context,
 		shardingSpec := &opsRes.Cluster.Spec.ShardingSpecs[index]
 		rollBackCompSpec(&shardingSpec.Template, lastCompInfos, shardingSpec.Name)
 	}
-	return cli.Update(ctx, opsRes.Cluster)
+	if err := cli.Update(ctx, opsRes.Cluster); err != nil {
+		return err
+	}
+	appendCancelResult(opsRes.OpsRequest, reverted, nil)
+	return nil
 }
 
 func (c componentOpsHelper) existFailure(ops *appsv1alpha1.OpsRequest, componentName string) bool {
@@ -0,0 +1,265 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+)
+
+// OpsScope defines the scope of the objects within which two opsRequests conflict.
+type OpsScope string
+
+const (
+	// ClusterOpsScope indicates that the opsRequests conflict if they operate on the same cluster.
+	ClusterOpsScope OpsScope = "Cluster"
+	// ComponentOpsScope indicates that the opsRequests conflict if they operate on the same component.
+	ComponentOpsScope OpsScope = "Component"
+	// InstanceOpsScope indicates that the opsRequests conflict if they operate on the same instance.
+	InstanceOpsScope OpsScope = "Instance"
+)
+
+// OpsConflictRule declares the ops types that an ops type conflicts with within a scope.
+// The conflict is mutual, two opsRequests conflict if either of their types declares a rule
+// for the other type and the objects they operate on overlap within the scope of the rule.
+type OpsConflictRule struct {
+	Scope         OpsScope
+	ConflictTypes []appsv1alpha1.OpsType
+}
+
+// workloadOpsTypes are the ops types which change the workloads of the components.
+var workloadOpsTypes = []appsv1alpha1.OpsType{
+	appsv1alpha1.StartType,
+	appsv1alpha1.StopType,
+	appsv1alpha1.RestartType,
+	appsv1alpha1.SwitchoverType,
+	appsv1alpha1.VerticalScalingType,
+	appsv1alpha1.HorizontalScalingType,
+	appsv1alpha1.UpgradeType,
+	appsv1alpha1.ReconfiguringType,
+	appsv1alpha1.RebuildInstanceType,
+	appsv1alpha1.MigrateType,
+}
+
+// newWorkloadOpsConflictRules creates the conflict rules for the ops types which change the workloads of the components.
+// They are mutually exclusive on the same component.
+// The Restore opsRequest is not queued since it creates a new cluster.
+func newWorkloadOpsConflictRules() []OpsConflictRule {
+	return []OpsConflictRule{
+		{Scope: ComponentOpsScope, ConflictTypes: workloadOpsTypes},
+	}
+}
+
+// newSelfOpsConflictRules creates the conflict rules for the ops type which only conflicts with itself within the scope.
+func newSelfOpsConflictRules(opsType appsv1alpha1.OpsType, scope OpsScope) []OpsConflictRule {
+	return []OpsConflictRule{
+		{Scope: scope, ConflictTypes: []appsv1alpha1.OpsType{opsType}},
+	}
+}
+
+// newOpsRecorder creates the OpsRecorder of the opsRequest with the objects it operates on.
+func newOpsRecorder(opsRequest *appsv1alpha1.OpsRequest) appsv1alpha1.OpsRecorder {
+	components, instances := getOpsTargets(opsRequest)
+	return appsv1alpha1.OpsRecorder{
+		Name:       opsRequest.Name,
+		Type:       opsRequest.Spec.Type,
+		Components: components,
+		Instances:  instances,
+	}
+}
+
+// getOpsTargets gets the components and instances that the opsRequest operates on.
+// Empty components means that the opsRequest operates on all components of the cluster,
+// and empty instances means that it operates on all instances of the components.
+func getOpsTargets(opsRequest *appsv1alpha1.OpsRequest) ([]string, []string) {
+	var (
+		components   []string
+		instances    []string
+		allComps     bool
+		allInstances bool
+	)
+	addComponent := func(compName string) {
+		if compName == "" {
+			allComps = true
+			return
+		}
+		if !slices.Contains(components, compName) {
+			components = append(components, compName)
+		}
+	}
+	spec := opsRequest.Spec
+	switch spec.Type {
+	case appsv1alpha1.RestartType:
+		for _, v := range spec.RestartList {
+			addComponent(v.ComponentName)
+		}
+	case appsv1alpha1.SwitchoverType:
+		for _, v := range spec.SwitchoverList {
+			addComponent(v.ComponentName)
+			if v.InstanceName == "" || v.InstanceName == KBSwitchoverCandidateInstanceForAnyPod {
+				allInstances = true
+			} else {
+				instances = append(instances, v.InstanceName)
+			}
+		}
+	case appsv1alpha1.VerticalScalingType:
+		for _, v := range spec.VerticalScalingList {
+			addComponent(v.ComponentName)
+		}
+	case appsv1alpha1.HorizontalScalingType:
+		for _, v := range spec.HorizontalScalingList {
+			addComponent(v.ComponentName)
+		}
+	case appsv1alpha1.VolumeExpansionType:
+		for _, v := range spec.VolumeExpansionList {
+			addComponent(v.ComponentName)
+		}
+	case appsv1alpha1.ReconfiguringType:
+		if spec.Reconfigure != nil {
+			addComponent(spec.Reconfigure.ComponentName)
+		}
+		for _, v := range spec.Reconfigures {
+			addComponent(v.ComponentName)
+		}
+	case appsv1alpha1.UpgradeType:
+		if spec.Upgrade == nil || len(spec.Upgrade.Components) == 0 {
+			allComps = true
+			break
+		}
+		for _, v := range spec.Upgrade.Components {
+			addComponent(v.ComponentName)
+		}
+	case appsv1alpha1.ExposeType:
+		for _, v := range spec.ExposeList {
+			addComponent(v.ComponentName)
+		}
+	case appsv1alpha1.RebuildInstanceType:
+		for _, v := range spec.RebuildFrom {
+			addComponent(v.ComponentName)
+			for _, ins := range v.Instances {
+				instances = append(instances, ins.Name)
+			}
+		}
+	case appsv1alpha1.MigrateType:
+		for _, v := range spec.MigrateList {
+			addComponent(v.ComponentName)
+			for _, ins := range v.Instances {
+				instances = append(instances, ins.Name)
+			}
+		}
+	case appsv1alpha1.DataExportType:
+		if spec.DataExport != nil {
+			addComponent(spec.DataExport.ComponentName)
+		}
+		allInstances = true
+	case appsv1alpha1.DataImportType:
+		if spec.DataImport != nil {
+			addComponent(spec.DataImport.ComponentName)
+		}
+		allInstances = true
+	default:
+		allComps = true
+	}
+	if allComps || len(components) == 0 {
+		return nil, nil
+	}
+	if allInstances {
+		return components, nil
+	}
+	return components, instances
+}
+
+// findConflictingOps finds the first opsRequest in the queue which conflicts with the given opsRequest.
+// The given opsRequest only waits for the running opsRequests and the queued ones ahead of it.
+// Returns the conflicting opsRequest and the reason of the conflict.
+func (opsMgr *OpsManager) findConflictingOps(opsRecorderSlice []appsv1alpha1.OpsRecorder,
+	opsRecorder appsv1alpha1.OpsRecorder) (*appsv1alpha1.OpsRecorder, string) {
+	index, _ := GetOpsRecorderFromSlice(opsRecorderSlice, opsRecorder.Name)
+	for i := range opsRecorderSlice {
+		other := opsRecorderSlice[i]
+		if other.Name == opsRecorder.Name {
+			continue
+		}
+		if other.InQueue && index != -1 && i > index {
+			// the queued opsRequest behind it.
+			continue
+		}
+		if reason := opsMgr.getConflictReason(opsRecorder, other); reason != "" {
+			return &opsRecorderSlice[i], reason
+		}
+	}
+	return nil, ""
+}
+
+// getConflictReason checks whether the two opsRequests conflict and returns the reason, empty means no conflict.
+func (opsMgr *OpsManager) getConflictReason(ops1, ops2 appsv1alpha1.OpsRecorder) string {
+	var scopes []OpsScope
+	collectScopes := func(opsType, otherOpsType appsv1alpha1.OpsType) {
+		for _, rule := range opsMgr.OpsMap[opsType].ConflictRules {
+			if slices.Contains(rule.ConflictTypes, otherOpsType) && !slices.Contains(scopes, rule.Scope) {
+				scopes = append(scopes, rule.Scope)
+			}
+		}
+	}
+	collectScopes(ops1.Type, ops2.Type)
+	collectScopes(ops2.Type, ops1.Type)
+	for _, scope := range scopes {
+		if reason := getOverlapReason(ops1, ops2, scope); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// getOverlapReason checks whether the objects the two opsRequests operate on overlap within the scope.
+func getOverlapReason(ops1, ops2 appsv1alpha1.OpsRecorder, scope OpsScope) string {
+	if scope == ClusterOpsScope {
+		return "they operate on the same cluster"
+	}
+	if len(ops1.Components) == 0 || len(ops2.Components) == 0 {
+		return "one of them operates on all components"
+	}
+	var commonComps []string
+	for _, comp := range ops1.Components {
+		if slices.Contains(ops2.Components, comp) {
+			commonComps = append(commonComps, comp)
+		}
+	}
+	if len(commonComps) == 0 {
+		return ""
+	}
+	if scope == ComponentOpsScope || len(ops1.Instances) == 0 || len(ops2.Instances) == 0 {
+		return fmt.Sprintf("they operate on the same components: %s", strings.Join(commonComps, ","))
+	}
+	var commonInstances []string
+	for _, ins := range ops1.Instances {
+		if slices.Contains(ops2.Instances, ins) {
+			commonInstances = append(commonInstances, ins)
+		}
+	}
+	if len(commonInstances) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("they operate on the same instances: %s", strings.Join(commonInstances, ","))
+}
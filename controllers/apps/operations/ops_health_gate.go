@@ -0,0 +1,394 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/component/lifecycle"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// pauseInstanceSetsRollout sets the partition of the InstanceSets of the components to 0 before their pod templates
+// are updated by the opsRequest, so the pods are rolled out by the opsRequest one by one with the health gate.
+func pauseInstanceSetsRollout(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compOpsHelper componentOpsHelper) error {
+	itsList, err := listTargetInstanceSets(reqCtx.Ctx, cli, opsRes, compOpsHelper)
+	if err != nil {
+		return err
+	}
+	for _, its := range itsList {
+		patch := client.MergeFrom(its.DeepCopy())
+		if its.Annotations == nil {
+			its.Annotations = map[string]string{}
+		}
+		its.Annotations[constant.OpsGatedRolloutAnnotationKey] = opsRes.OpsRequest.Name
+		setInstanceSetPartition(its, pointer.Int32(0))
+		if err = cli.Patch(reqCtx.Ctx, its, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseInstanceSetsRollout removes the partition set by the opsRequest, the InstanceSets roll out the rest pods
+// by themselves.
+func releaseInstanceSetsRollout(ctx context.Context, cli client.Client, opsRes *OpsResource) error {
+	itsList := &workloads.InstanceSetList{}
+	if err := cli.List(ctx, itsList, client.InNamespace(opsRes.OpsRequest.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRes.OpsRequest.Spec.GetClusterName()}); err != nil {
+		return err
+	}
+	for i := range itsList.Items {
+		if err := releaseRollout(ctx, cli, opsRes, &itsList.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseInstanceSetRollout removes the partition set by the opsRequest from the InstanceSet of the component.
+func releaseInstanceSetRollout(ctx context.Context, cli client.Client, opsRes *OpsResource, compName string) error {
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{
+		Name:      constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, compName),
+		Namespace: opsRes.Cluster.Namespace,
+	}
+	if err := cli.Get(ctx, itsKey, its); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return releaseRollout(ctx, cli, opsRes, its)
+}
+
+func releaseRollout(ctx context.Context, cli client.Client, opsRes *OpsResource, its *workloads.InstanceSet) error {
+	if its.Annotations[constant.OpsGatedRolloutAnnotationKey] != opsRes.OpsRequest.Name {
+		return nil
+	}
+	patch := client.MergeFrom(its.DeepCopy())
+	delete(its.Annotations, constant.OpsGatedRolloutAnnotationKey)
+	setInstanceSetPartition(its, nil)
+	return cli.Patch(ctx, its, patch)
+}
+
+func listTargetInstanceSets(ctx context.Context,
+	cli client.Client,
+	opsRes *OpsResource,
+	compOpsHelper componentOpsHelper) ([]*workloads.InstanceSet, error) {
+	itsList := &workloads.InstanceSetList{}
+	if err := cli.List(ctx, itsList, client.InNamespace(opsRes.Cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRes.Cluster.Name}); err != nil {
+		return nil, err
+	}
+	var targets []*workloads.InstanceSet
+	for i := range itsList.Items {
+		compName := itsList.Items[i].Labels[constant.KBAppComponentLabelKey]
+		if shardingName := itsList.Items[i].Labels[constant.KBAppShardingNameLabelKey]; shardingName != "" {
+			compName = shardingName
+		}
+		if _, ok := compOpsHelper.componentOpsSet[compName]; ok {
+			targets = append(targets, &itsList.Items[i])
+		}
+	}
+	return targets, nil
+}
+
+func setInstanceSetPartition(its *workloads.InstanceSet, partition *int32) {
+	if partition == nil && its.Spec.UpdateStrategy.RollingUpdate == nil {
+		return
+	}
+	if its.Spec.UpdateStrategy.RollingUpdate == nil {
+		its.Spec.UpdateStrategy.RollingUpdate = &appv1.RollingUpdateStatefulSetStrategy{}
+	}
+	its.Spec.UpdateStrategy.RollingUpdate.Partition = partition
+}
+
+// handleGatedRolloutProgress rolls out the pods of the component through the partition of the InstanceSet.
+// The InstanceSet updates the pods in its update order, and the partition is increased to update the next pod
+// only after all the updated pods pass the health gate.
+func handleGatedRolloutProgress(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	podApplyOps func(*appsv1alpha1.OpsRequest, *corev1.Pod, ComponentOpsInterface, string) bool) (int32, int32, error) {
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{
+		Name:      constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, pgRes.fullComponentName),
+		Namespace: opsRes.Cluster.Namespace,
+	}
+	if err := cli.Get(reqCtx.Ctx, itsKey, its); err != nil {
+		return 0, 0, client.IgnoreNotFound(err)
+	}
+	if its.Annotations[constant.OpsGatedRolloutAnnotationKey] != opsRes.OpsRequest.Name {
+		// the rollout has been released.
+		return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, podApplyOps)
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pgRes.fullComponentName)
+	if err != nil {
+		return 0, 0, err
+	}
+	// sort the pods in the update order of the InstanceSet.
+	sortedPods := make([]corev1.Pod, len(pods))
+	for i := range pods {
+		sortedPods[i] = *pods[i]
+	}
+	instanceset.SortPods(sortedPods, instanceset.ComposeRolePriorityMap(its.Spec.Roles), false)
+
+	var (
+		opsRequest     = opsRes.OpsRequest
+		paused         = meta.IsStatusConditionTrue(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypePaused)
+		expectCount    int32
+		completedCount int32
+		blocked        bool
+		nextPartition  = -1
+	)
+	for i := range sortedPods {
+		pod := &sortedPods[i]
+		insTemplateName, ok := pgRes.updatedPodSet[pod.Name]
+		if len(pgRes.updatedPodSet) > 0 && !ok {
+			continue
+		}
+		expectCount += 1
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name)}
+		if !pod.DeletionTimestamp.IsZero() || !podApplyOps(opsRequest, pod, pgRes.compOps, insTemplateName) {
+			if nextPartition == -1 {
+				nextPartition = i + 1
+				progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("updating pod %s", pod.Name))
+				setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+				continue
+			}
+			progressDetail.Message = "waiting for the updated instances to pass the health gate"
+			handlePendingProgressDetail(opsRes, compStatus, progressDetail)
+			continue
+		}
+		passed, message, err := checkHealthGateOrPause(reqCtx, cli, opsRes, pgRes, opsRequest.Spec.HealthGate, compStatus, pod)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !passed {
+			blocked = true
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, message)
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+			continue
+		}
+		completedCount += 1
+		handleSucceedProgressDetail(opsRes, pgRes, compStatus, progressDetail)
+	}
+	if blocked || paused {
+		return expectCount, completedCount, nil
+	}
+	if nextPartition == -1 {
+		// all the pods have been updated and passed the health gate.
+		return expectCount, completedCount, releaseInstanceSetsRollout(reqCtx.Ctx, cli, opsRes)
+	}
+	rollingUpdate := its.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate != nil && rollingUpdate.Partition != nil && int(*rollingUpdate.Partition) >= nextPartition {
+		return expectCount, completedCount, nil
+	}
+	patch := client.MergeFrom(its.DeepCopy())
+	setInstanceSetPartition(its, pointer.Int32(int32(nextPartition)))
+	return expectCount, completedCount, cli.Patch(reqCtx.Ctx, its, patch)
+}
+
+// checkHealthGateOrPause checks whether the pod passes the health gate.
+// If the pod fails the health gate, the opsRequest will be paused.
+func checkHealthGateOrPause(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	healthGate *appsv1alpha1.InstanceHealthGate,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	pod *corev1.Pod) (bool, string, error) {
+	passed, justFailed, message, err := checkInstanceHealthGate(reqCtx, cli, opsRes, pgRes, healthGate, compStatus, pod)
+	if err != nil || !justFailed {
+		return passed, message, err
+	}
+	opsRes.OpsRequest.SetStatusCondition(*appsv1alpha1.NewHealthGatePausedCondition(pod.Name, getOrCreateHealthGateStatus(compStatus, pod.Name).Message))
+	opsRes.Recorder.Eventf(opsRes.OpsRequest, corev1.EventTypeWarning, appsv1alpha1.ReasonHealthGateFailed,
+		`pod %s failed the health gate, add the annotation "%s" to resume the opsRequest`, pod.Name, constant.OpsResumeAnnotationKey)
+	return false, message, nil
+}
+
+// resumeHealthGateIfRequested resumes the opsRequest paused by the health gate if the resume annotation is specified.
+func resumeHealthGateIfRequested(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	opsRequest := opsRes.OpsRequest
+	if _, ok := opsRequest.Annotations[constant.OpsResumeAnnotationKey]; !ok {
+		return nil
+	}
+	patch := client.MergeFrom(opsRequest.DeepCopy())
+	delete(opsRequest.Annotations, constant.OpsResumeAnnotationKey)
+	if err := cli.Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+		return err
+	}
+	if !meta.IsStatusConditionTrue(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypePaused) {
+		return nil
+	}
+	statusPatch := client.MergeFrom(opsRequest.DeepCopy())
+	failureThreshold := getHealthGateFailureThreshold(opsRequest.Spec.HealthGate)
+	for compName, compStatus := range opsRequest.Status.Components {
+		for i := range compStatus.HealthGates {
+			// reset the failed instance to check the health gate again.
+			if compStatus.HealthGates[i].FailedCount >= failureThreshold {
+				compStatus.HealthGates[i].FailedCount = 0
+				compStatus.HealthGates[i].SucceededCount = 0
+			}
+		}
+		opsRequest.Status.Components[compName] = compStatus
+	}
+	opsRequest.SetStatusCondition(*appsv1alpha1.NewResumedCondition(opsRequest))
+	return cli.Status().Patch(reqCtx.Ctx, opsRequest, statusPatch)
+}
+
+// checkInstanceHealthGate checks whether the pod passes the health gate.
+// The second return value reports whether the pod fails the health gate in this check.
+func checkInstanceHealthGate(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	healthGate *appsv1alpha1.InstanceHealthGate,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	pod *corev1.Pod) (bool, bool, string, error) {
+	if !intctrlutil.PodIsReady(pod) {
+		return false, false, fmt.Sprintf("waiting for pod %s to be ready", pod.Name), nil
+	}
+	if healthGate == nil {
+		return true, false, "", nil
+	}
+	readyCondition := intctrlutil.GetPodCondition(&pod.Status, corev1.PodReady)
+	soakDuration := time.Duration(healthGate.SoakSeconds) * time.Second
+	if readyCondition != nil && time.Since(readyCondition.LastTransitionTime.Time) < soakDuration {
+		return false, false, fmt.Sprintf("waiting for pod %s to stay ready for %d seconds", pod.Name, healthGate.SoakSeconds), nil
+	}
+	gateStatus := getOrCreateHealthGateStatus(compStatus, pod.Name)
+	successThreshold := getHealthGateSuccessThreshold(healthGate)
+	failureThreshold := getHealthGateFailureThreshold(healthGate)
+	compDef := pgRes.componentDef
+	if compDef == nil || compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.HealthCheck == nil {
+		// the gate can not be passed without the healthCheck action, e.g. the componentDefinition is upgraded to
+		// the one without the action.
+		message := fmt.Sprintf("pod %s failed the health gate: the componentDefinition does not define the healthCheck action", pod.Name)
+		if gateStatus.FailedCount >= failureThreshold {
+			return false, false, message, nil
+		}
+		gateStatus.FailedCount = failureThreshold
+		gateStatus.Message = "the componentDefinition does not define the healthCheck action"
+		return false, true, message, nil
+	}
+	if gateStatus.SucceededCount >= successThreshold {
+		return true, false, "", nil
+	}
+	if gateStatus.FailedCount >= failureThreshold {
+		return false, false, fmt.Sprintf("pod %s failed the health gate: %s", pod.Name, gateStatus.Message), nil
+	}
+	period := time.Duration(getHealthGatePeriodSeconds(healthGate)) * time.Second
+	if gateStatus.LastProbeTime == nil || time.Since(gateStatus.LastProbeTime.Time) >= period {
+		if err := doInstanceHealthCheck(reqCtx, cli, opsRes, pgRes, gateStatus, pod); err != nil {
+			return false, false, "", err
+		}
+	}
+	if gateStatus.SucceededCount >= successThreshold {
+		return true, false, "", nil
+	}
+	if gateStatus.FailedCount >= failureThreshold {
+		return false, true, fmt.Sprintf("pod %s failed the health gate: %s", pod.Name, gateStatus.Message), nil
+	}
+	return false, false, fmt.Sprintf("pod %s passed the health check %d/%d times", pod.Name, gateStatus.SucceededCount, successThreshold), nil
+}
+
+// doInstanceHealthCheck executes the healthCheck lifecycle action on the pod and records the result.
+func doInstanceHealthCheck(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	gateStatus *appsv1alpha1.InstanceHealthGateStatus,
+	pod *corev1.Pod) error {
+	comp, compDef, err := component.GetCompNCompDefByName(reqCtx.Ctx, cli, opsRes.Cluster.Namespace,
+		constant.GenerateClusterComponentName(opsRes.Cluster.Name, pgRes.fullComponentName))
+	if err != nil {
+		return err
+	}
+	synthesizedComp, err := component.BuildSynthesizedComponent(reqCtx, cli, opsRes.Cluster, compDef, comp)
+	if err != nil {
+		return err
+	}
+	lfa, err := lifecycle.New(synthesizedComp, pod)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	gateStatus.LastProbeTime = &now
+	if err = lfa.HealthCheck(reqCtx.Ctx, cli, nil); err != nil {
+		gateStatus.SucceededCount = 0
+		gateStatus.FailedCount += 1
+		gateStatus.Message = err.Error()
+		return nil
+	}
+	gateStatus.SucceededCount += 1
+	gateStatus.FailedCount = 0
+	gateStatus.Message = ""
+	return nil
+}
+
+func getOrCreateHealthGateStatus(compStatus *appsv1alpha1.OpsRequestComponentStatus, instanceName string) *appsv1alpha1.InstanceHealthGateStatus {
+	for i := range compStatus.HealthGates {
+		if compStatus.HealthGates[i].InstanceName == instanceName {
+			return &compStatus.HealthGates[i]
+		}
+	}
+	compStatus.HealthGates = append(compStatus.HealthGates, appsv1alpha1.InstanceHealthGateStatus{InstanceName: instanceName})
+	return &compStatus.HealthGates[len(compStatus.HealthGates)-1]
+}
+
+func getHealthGateSuccessThreshold(healthGate *appsv1alpha1.InstanceHealthGate) int32 {
+	if healthGate.SuccessThreshold <= 0 {
+		return 1
+	}
+	return healthGate.SuccessThreshold
+}
+
+func getHealthGateFailureThreshold(healthGate *appsv1alpha1.InstanceHealthGate) int32 {
+	if healthGate.FailureThreshold <= 0 {
+		return 3
+	}
+	return healthGate.FailureThreshold
+}
+
+func getHealthGatePeriodSeconds(healthGate *appsv1alpha1.InstanceHealthGate) int32 {
+	if healthGate == nil || healthGate.PeriodSeconds <= 0 {
+		return 5
+	}
+	return healthGate.PeriodSeconds
+}
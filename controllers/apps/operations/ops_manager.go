@@ -77,8 +77,11 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		}
 		// validate OpsRequest.spec
 		// if the operation will create a new cluster, don't validate the cluster phase
-		if err = opsRequest.Validate(reqCtx.Ctx, cli, opsRes.Cluster, !opsBehaviour.IsClusterCreation); err != nil {
-			return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+		// if the opsRequest is retried, it has been validated by the first attempt
+		if len(opsRequest.Status.Attempts) == 0 {
+			if err = opsRequest.Validate(reqCtx.Ctx, cli, opsRes.Cluster, !opsBehaviour.IsClusterCreation); err != nil {
+				return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
+			}
 		}
 	}
 
@@ -87,17 +90,17 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 			return &ctrl.Result{}, PatchOpsStatus(reqCtx.Ctx, cli, opsRes, appsv1alpha1.OpsCancelledPhase)
 		}
 		// TODO: abort last OpsRequest if using 'force' and intersecting with cluster component name or shard name.
-		if opsBehaviour.QueueByCluster || opsBehaviour.QueueBySelf {
-			// if ToClusterPhase is not empty, enqueue OpsRequest to the cluster Annotation.
-			opsRecorde, err := enqueueOpsRequestToClusterAnnotation(reqCtx.Ctx, cli, opsRes, opsBehaviour)
+		if len(opsBehaviour.ConflictRules) > 0 {
+			// if the opsType has conflict rules, enqueue OpsRequest to the cluster Annotation.
+			opsRecorde, waitingCondition, err := enqueueOpsRequestToClusterAnnotation(reqCtx.Ctx, cli, opsRes, opsBehaviour)
 			if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
 				return &ctrl.Result{}, patchValidateErrorCondition(reqCtx.Ctx, cli, opsRes, err.Error())
 			} else if err != nil {
 				return nil, err
 			}
 			if opsRecorde != nil && opsRecorde.InQueue {
-				// if the opsRequest is in the queue, return
-				return intctrlutil.ResultToP(intctrlutil.Reconciled())
+				// if the opsRequest is in the queue, record the reason why it is waiting and return
+				return &ctrl.Result{}, patchWaitingConditionIfChanged(reqCtx, cli, opsRes, waitingCondition)
 			}
 		}
 
@@ -118,12 +121,24 @@ func (opsMgr *OpsManager) Do(reqCtx intctrlutil.RequestCtx, cli client.Client, o
 		return &ctrl.Result{}, patchOpsRequestToCreating(reqCtx, cli, opsRes, opsDeepCopy, opsBehaviour.OpsHandler)
 	}
 
+	// wait for the backoff if the opsRequest is retried by the retry policy
+	if waitingTime := getRetryWaitingTime(opsRequest); waitingTime > 0 {
+		return intctrlutil.ResultToP(intctrlutil.RequeueAfter(waitingTime, reqCtx.Log, "wait for the retry backoff"))
+	}
 	if err = updateHAConfigIfNecessary(reqCtx, cli, opsRes.OpsRequest, "false"); err != nil {
 		return nil, err
 	}
 	if err = opsBehaviour.OpsHandler.Action(reqCtx, cli, opsRes); err != nil {
-		// patch the status.phase to Failed when the error is Fatal, which means the operation is failed and there is no need to retry
+		// patch the status.phase to Failed when the error is Fatal, which means the operation is failed and there is no need to requeue,
+		// unless the failure is retryable by the retry policy of the opsRequest.
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			retried, backoff, retryErr := retryOpsIfNecessary(reqCtx, cli, opsRes, appsv1alpha1.ActionFailedReason, err)
+			if retryErr != nil {
+				return nil, retryErr
+			}
+			if retried {
+				return intctrlutil.ResultToP(intctrlutil.RequeueAfter(backoff, reqCtx.Log, ""))
+			}
 			return &ctrl.Result{}, patchFatalFailErrorCondition(reqCtx.Ctx, cli, opsRes, err)
 		}
 		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeNeedWaiting) {
@@ -166,6 +181,13 @@ func (opsMgr *OpsManager) Reconcile(reqCtx intctrlutil.RequestCtx, cli client.Cl
 		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
 			appsv1alpha1.NewCancelSucceedCondition(opsRequest.Name), appsv1alpha1.NewSucceedCondition(opsRequest))
 	case appsv1alpha1.OpsFailedPhase:
+		retried, backoff, retryErr := retryOpsIfNecessary(reqCtx, cli, opsRes, appsv1alpha1.ProgressFailedReason, err)
+		if retryErr != nil {
+			return 0, retryErr
+		}
+		if retried {
+			return backoff, nil
+		}
 		return 0, opsMgr.handleOpsCompleted(reqCtx, cli, opsRes, opsRequestPhase,
 			appsv1alpha1.NewCancelFailedCondition(opsRequest, err), appsv1alpha1.NewFailedCondition(opsRequest, err))
 	default:
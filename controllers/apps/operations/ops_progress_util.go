@@ -407,7 +407,7 @@ func handleComponentProgressForScalingReplicas(reqCtx intctrlutil.RequestCtx,
 		completedCount += scaleOutCompletedCount
 	}
 	if len(pgRes.deletedPodSet) > 0 {
-		scaleInCompletedCount, scaleInErr := handleScaleInProgressWithInstanceSet(opsRes, pgRes, its, compStatus)
+		scaleInCompletedCount, scaleInErr := handleScaleInProgressWithInstanceSet(reqCtx, cli, opsRes, pgRes, its, compStatus)
 		if scaleInErr != nil {
 			err = fmt.Errorf(scaleInErr.Error(), err)
 		}
@@ -484,13 +484,24 @@ func handleScaleOutProgressWithInstanceSet(
 	return completedCount, nil
 }
 
-func handleScaleInProgressWithInstanceSet(
+func handleScaleInProgressWithInstanceSet(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
 	opsRes *OpsResource,
 	pgRes *progressResource,
 	its *workloads.InstanceSet,
 	compStatus *appsv1alpha1.OpsRequestComponentStatus) (completedCount int32, err error) {
 	currPodRevisionMap, _ := instanceset.GetRevisions(its.Status.CurrentRevisions)
 	notReadyPodSet := instanceset.GetPodNameSetFromInstanceSetCondition(its, workloads.InstanceReady)
+	pods, err := intctrlcomp.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pgRes.fullComponentName)
+	if err != nil {
+		return 0, err
+	}
+	preScaleInProgressMap := map[string]string{}
+	for _, pod := range pods {
+		if progress, ok := pod.Annotations[constant.PreScaleInProgressAnnotationKey]; ok {
+			preScaleInProgressMap[pod.Name] = progress
+		}
+	}
 	pgRes.opsMessageKey = "Delete"
 	for podName := range pgRes.deletedPodSet {
 		objectKey := getProgressObjectKey(constant.PodKind, podName)
@@ -499,6 +510,11 @@ func handleScaleInProgressWithInstanceSet(
 			updateProgressDetailForHScale(opsRes, pgRes, compStatus, objectKey, appsv1alpha1.SucceedProgressStatus)
 			continue
 		}
+		// the pod is waiting for the preScaleIn action to move the data off it
+		if progress, ok := preScaleInProgressMap[podName]; ok {
+			updatePreScaleInProgressDetail(opsRes, pgRes, compStatus, objectKey, progress)
+			continue
+		}
 		if _, ok := notReadyPodSet[podName]; ok {
 			updateProgressDetailForHScale(opsRes, pgRes, compStatus, objectKey, appsv1alpha1.ProcessingProgressStatus)
 			continue
@@ -508,6 +524,22 @@ func handleScaleInProgressWithInstanceSet(
 	return completedCount, nil
 }
 
+func updatePreScaleInProgressDetail(
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	objectKey, progress string) {
+	progressDetail := appsv1alpha1.ProgressStatusDetail{
+		Group:     fmt.Sprintf("%s/%s", pgRes.fullComponentName, pgRes.opsMessageKey),
+		ObjectKey: objectKey,
+		Status:    appsv1alpha1.ProcessingProgressStatus,
+		Message: fmt.Sprintf("Start to move data off pod: %s in Component: %s, preScaleIn progress: %s%%",
+			objectKey, pgRes.clusterComponent.Name, progress),
+	}
+	setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest,
+		&compStatus.ProgressDetails, progressDetail)
+}
+
 func syncProgressToOpsRequest(
 	reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
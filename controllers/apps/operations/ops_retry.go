@@ -0,0 +1,128 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"time"
+
+	"golang.org/x/exp/slices"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	defaultRetryMaxAttempts       = 3
+	defaultRetryBackoffSeconds    = 10
+	defaultRetryMaxBackoffSeconds = 300
+)
+
+// retryOpsIfNecessary records the failed attempt of the opsRequest if `spec.retryPolicy` is specified,
+// and moves the opsRequest back to the Creating phase if the failure is retryable.
+// Returns true and the backoff if the opsRequest will be retried.
+func retryOpsIfNecessary(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	reason appsv1alpha1.OpsFailureReason,
+	failedErr error) (bool, time.Duration, error) {
+	opsRequest := opsRes.OpsRequest
+	retryPolicy := opsRequest.Spec.RetryPolicy
+	if retryPolicy == nil || opsRequest.Spec.Cancel || opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+		return false, 0, nil
+	}
+	opsDeepCopy := opsRequest.DeepCopy()
+	now := time.Now()
+	message := appsv1alpha1.NewFailedCondition(opsRequest, failedErr).Message
+	attempt := appsv1alpha1.OpsAttemptStatus{
+		Attempt:             int32(len(opsRequest.Status.Attempts)) + 1,
+		StartTimestamp:      getAttemptStartTimestamp(opsRequest),
+		CompletionTimestamp: metav1.Time{Time: now},
+		Reason:              reason,
+		Message:             message,
+	}
+	if !isRetryableFailure(retryPolicy, reason) || attempt.Attempt >= getRetryMaxAttempts(retryPolicy) {
+		opsRequest.Status.Attempts = append(opsRequest.Status.Attempts, attempt)
+		return false, 0, cli.Status().Patch(reqCtx.Ctx, opsRequest, client.MergeFrom(opsDeepCopy))
+	}
+	backoff := getRetryBackoff(retryPolicy, attempt.Attempt)
+	attempt.NextRetryTimestamp = &metav1.Time{Time: now.Add(backoff)}
+	opsRequest.Status.Attempts = append(opsRequest.Status.Attempts, attempt)
+	// reset the progress of the failed attempt, otherwise the failed progress details can not be updated by the next attempt.
+	opsRequest.Status.Progress = "-/-"
+	for name, compStatus := range opsRequest.Status.Components {
+		compStatus.ProgressDetails = nil
+		opsRequest.Status.Components[name] = compStatus
+	}
+	return true, backoff, PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, cli, opsRes, opsDeepCopy, appsv1alpha1.OpsCreatingPhase,
+		appsv1alpha1.NewRetryingCondition(attempt.Attempt, backoff, message))
+}
+
+// getRetryWaitingTime gets the remaining time before the next attempt of the opsRequest starts.
+func getRetryWaitingTime(opsRequest *appsv1alpha1.OpsRequest) time.Duration {
+	attemptCount := len(opsRequest.Status.Attempts)
+	if attemptCount == 0 || opsRequest.Status.Attempts[attemptCount-1].NextRetryTimestamp == nil {
+		return 0
+	}
+	return time.Until(opsRequest.Status.Attempts[attemptCount-1].NextRetryTimestamp.Time)
+}
+
+func getAttemptStartTimestamp(opsRequest *appsv1alpha1.OpsRequest) metav1.Time {
+	attemptCount := len(opsRequest.Status.Attempts)
+	if attemptCount == 0 {
+		return opsRequest.Status.StartTimestamp
+	}
+	lastAttempt := opsRequest.Status.Attempts[attemptCount-1]
+	if lastAttempt.NextRetryTimestamp != nil {
+		return *lastAttempt.NextRetryTimestamp
+	}
+	return lastAttempt.CompletionTimestamp
+}
+
+func isRetryableFailure(retryPolicy *appsv1alpha1.OpsRetryPolicy, reason appsv1alpha1.OpsFailureReason) bool {
+	return len(retryPolicy.RetryableReasons) == 0 || slices.Contains(retryPolicy.RetryableReasons, reason)
+}
+
+func getRetryMaxAttempts(retryPolicy *appsv1alpha1.OpsRetryPolicy) int32 {
+	if retryPolicy.MaxAttempts <= 0 {
+		return defaultRetryMaxAttempts
+	}
+	return retryPolicy.MaxAttempts
+}
+
+// getRetryBackoff gets the backoff before the next attempt, which is doubled for each retry.
+func getRetryBackoff(retryPolicy *appsv1alpha1.OpsRetryPolicy, attempt int32) time.Duration {
+	backoffSeconds := int64(retryPolicy.BackoffSeconds)
+	if backoffSeconds <= 0 {
+		backoffSeconds = defaultRetryBackoffSeconds
+	}
+	maxBackoffSeconds := int64(retryPolicy.MaxBackoffSeconds)
+	if maxBackoffSeconds <= 0 {
+		maxBackoffSeconds = defaultRetryMaxBackoffSeconds
+	}
+	for i := int32(1); i < attempt && backoffSeconds < maxBackoffSeconds; i++ {
+		backoffSeconds *= 2
+	}
+	if backoffSeconds > maxBackoffSeconds {
+		backoffSeconds = maxBackoffSeconds
+	}
+	return time.Duration(backoffSeconds) * time.Second
+}
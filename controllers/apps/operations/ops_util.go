@@ -96,6 +96,12 @@ func PatchOpsStatusWithOpsDeepCopy(ctx context.Context,
 		if err := DequeueOpsRequestInClusterAnnotation(ctx, cli, opsRes); err != nil {
 			return err
 		}
+		if opsRequest.Spec.HealthGate != nil || hasCanaryUpgrade(opsRequest) {
+			// release the InstanceSets which are rolled out by the opsRequest.
+			if err := releaseInstanceSetsRollout(ctx, cli, opsRes); err != nil {
+				return err
+			}
+		}
 	}
 	if phase == appsv1alpha1.OpsCreatingPhase && opsRequest.Status.StartTimestamp.IsZero() {
 		opsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now()}
@@ -322,3 +328,15 @@ func getComponentSpecOrShardingTemplate(cluster *appsv1.Cluster, componentName s
 	}
 	return nil
 }
+
+// appendCancelResult records the changes which are reverted and not reverted by the cancellation.
+func appendCancelResult(ops *appsv1alpha1.OpsRequest, reverted, notReverted []string) {
+	if len(reverted) == 0 && len(notReverted) == 0 {
+		return
+	}
+	if ops.Status.CancelResult == nil {
+		ops.Status.CancelResult = &appsv1alpha1.CancelResult{}
+	}
+	ops.Status.CancelResult.Reverted = append(ops.Status.CancelResult.Reverted, reverted...)
+	ops.Status.CancelResult.NotReverted = append(ops.Status.CancelResult.NotReverted, notReverted...)
+}
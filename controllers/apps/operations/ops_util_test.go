@@ -20,6 +20,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -191,6 +192,127 @@ var _ = Describe("OpsUtil functions", func() {
 			})).Should(Succeed())
 		})
 
+		It("Test opsRequest with retry policy", func() {
+			By("init operations resources ")
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+			opsRes, _, _ := initOperationsResources(compDefName, clusterName)
+
+			By("create a restart opsRequest with retry policy")
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: defaultCompName}}
+			ops.Spec.RetryPolicy = &appsv1alpha1.OpsRetryPolicy{
+				MaxAttempts:       2,
+				BackoffSeconds:    5,
+				MaxBackoffSeconds: 8,
+				RetryableReasons:  []appsv1alpha1.OpsFailureReason{appsv1alpha1.ProgressFailedReason},
+			}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+				opsRes.OpsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now()}
+			})).Should(Succeed())
+			opsKey := client.ObjectKeyFromObject(opsRes.OpsRequest)
+
+			By("expect the backoff is doubled and limited by maxBackoffSeconds")
+			Expect(getRetryBackoff(ops.Spec.RetryPolicy, 1)).Should(Equal(5 * time.Second))
+			Expect(getRetryBackoff(ops.Spec.RetryPolicy, 2)).Should(Equal(8 * time.Second))
+
+			By("expect the failure which is not retryable is not retried")
+			retried, _, err := retryOpsIfNecessary(reqCtx, k8sClient, opsRes, appsv1alpha1.ActionFailedReason, errors.New("action failed"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retried).Should(BeFalse())
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Attempts = nil
+			})).Should(Succeed())
+
+			By("expect the opsRequest is retried after the first attempt failed")
+			retried, backoff, err := retryOpsIfNecessary(reqCtx, k8sClient, opsRes, appsv1alpha1.ProgressFailedReason, errors.New("pod failed"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retried).Should(BeTrue())
+			Expect(backoff).Should(Equal(5 * time.Second))
+			Eventually(testapps.CheckObj(&testCtx, opsKey, func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Phase).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+				g.Expect(ops.Status.Attempts).Should(HaveLen(1))
+				g.Expect(ops.Status.Attempts[0].Reason).Should(Equal(appsv1alpha1.ProgressFailedReason))
+				g.Expect(ops.Status.Attempts[0].NextRetryTimestamp).ShouldNot(BeNil())
+			})).Should(Succeed())
+
+			By("expect the action waits for the backoff")
+			res, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(res).ShouldNot(BeNil())
+			Expect(res.RequeueAfter).Should(BeNumerically(">", 0))
+
+			By("expect the opsRequest is not retried when reaching the max attempts")
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			retried, _, err = retryOpsIfNecessary(reqCtx, k8sClient, opsRes, appsv1alpha1.ProgressFailedReason, errors.New("pod failed"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(retried).Should(BeFalse())
+			Eventually(testapps.CheckObj(&testCtx, opsKey, func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Attempts).Should(HaveLen(2))
+				g.Expect(ops.Status.Attempts[1].NextRetryTimestamp).Should(BeNil())
+			})).Should(Succeed())
+		})
+
+		It("Test the conflict rules of opsRequests", func() {
+			newRecorder := func(name string, opsType appsv1alpha1.OpsType, setSpec func(ops *appsv1alpha1.OpsRequest)) appsv1alpha1.OpsRecorder {
+				ops := testapps.NewOpsRequestObj(name, testCtx.DefaultNamespace, clusterName, opsType)
+				if setSpec != nil {
+					setSpec(ops)
+				}
+				return newOpsRecorder(ops)
+			}
+			restartComp1 := newRecorder("restart-comp1", appsv1alpha1.RestartType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: "comp1"}}
+			})
+			hscaleComp1 := newRecorder("hscale-comp1", appsv1alpha1.HorizontalScalingType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.HorizontalScalingList = []appsv1alpha1.HorizontalScaling{{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "comp1"}}}
+			})
+			vscaleComp2 := newRecorder("vscale-comp2", appsv1alpha1.VerticalScalingType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{{ComponentOps: appsv1alpha1.ComponentOps{ComponentName: "comp2"}}}
+			})
+			exposeComp1 := newRecorder("expose-comp1", appsv1alpha1.ExposeType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.ExposeList = []appsv1alpha1.Expose{{ComponentName: "comp1"}}
+			})
+			exposeComp2 := newRecorder("expose-comp2", appsv1alpha1.ExposeType, func(ops *appsv1alpha1.OpsRequest) {
+				ops.Spec.ExposeList = []appsv1alpha1.Expose{{ComponentName: "comp2"}}
+			})
+			stopCluster := newRecorder("stop", appsv1alpha1.StopType, nil)
+			restore := newRecorder("restore", appsv1alpha1.RestoreType, nil)
+			Expect(restartComp1.Components).Should(Equal([]string{"comp1"}))
+			Expect(stopCluster.Components).Should(BeEmpty())
+
+			By("expect the workload opsRequests on different components do not conflict")
+			opsMgr := GetOpsManager()
+			Expect(opsMgr.getConflictReason(restartComp1, vscaleComp2)).Should(BeEmpty())
+			Expect(opsMgr.getConflictReason(restartComp1, hscaleComp1)).ShouldNot(BeEmpty())
+			Expect(opsMgr.getConflictReason(stopCluster, vscaleComp2)).ShouldNot(BeEmpty())
+
+			By("expect the restore opsRequest is not queued since it creates a new cluster")
+			Expect(opsMgr.OpsMap[appsv1alpha1.RestoreType].ConflictRules).Should(BeEmpty())
+			Expect(opsMgr.getConflictReason(restore, vscaleComp2)).Should(BeEmpty())
+
+			By("expect the expose opsRequests only conflict with themselves on the same component")
+			Expect(opsMgr.getConflictReason(restartComp1, exposeComp1)).Should(BeEmpty())
+			Expect(opsMgr.getConflictReason(exposeComp1, exposeComp2)).Should(BeEmpty())
+			Expect(opsMgr.getConflictReason(exposeComp1, exposeComp1)).ShouldNot(BeEmpty())
+
+			By("expect the opsRequest only waits for the running and the earlier queued opsRequests")
+			hscaleComp1.InQueue = true
+			vscaleComp2.InQueue = true
+			opsSlice := []appsv1alpha1.OpsRecorder{restartComp1, hscaleComp1, vscaleComp2}
+			conflictingOps, reason := opsMgr.findConflictingOps(opsSlice, hscaleComp1)
+			Expect(conflictingOps).ShouldNot(BeNil())
+			Expect(conflictingOps.Name).Should(Equal(restartComp1.Name))
+			Expect(reason).Should(ContainSubstring("comp1"))
+			conflictingOps, _ = opsMgr.findConflictingOps(opsSlice, vscaleComp2)
+			Expect(conflictingOps).Should(BeNil())
+			conflictingOps, _ = opsMgr.findConflictingOps(opsSlice, stopCluster)
+			Expect(conflictingOps).ShouldNot(BeNil())
+			Expect(conflictingOps.Name).Should(Equal(restartComp1.Name))
+		})
+
 		It("Test opsRequest Queue functions", func() {
 			By("init operations resources ")
 			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
@@ -224,7 +346,7 @@ var _ = Describe("OpsUtil functions", func() {
 
 			By("test enqueueOpsRequestToClusterAnnotation function with Reentry")
 			opsBehaviour := opsManager.OpsMap[ops2.Spec.Type]
-			_, _ = enqueueOpsRequestToClusterAnnotation(ctx, k8sClient, opsRes, opsBehaviour)
+			_, _, _ = enqueueOpsRequestToClusterAnnotation(ctx, k8sClient, opsRes, opsBehaviour)
 			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), cluster)).Should(Succeed())
 			opsSlice, _ = opsutil.GetOpsRequestSliceFromCluster(cluster)
 			Expect(len(opsSlice)).Should(Equal(2))
@@ -26,6 +26,7 @@ import (
 
 	"golang.org/x/exp/slices"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -50,12 +51,18 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 	if index == -1 {
 		return nil
 	}
-	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsFailedPhase && index == 0 {
-		var newOpsRequestSlice []appsv1alpha1.OpsRecorder
-		// 1. update all pending opsRequest phase to Cancelled if the head opsRequest is Failed.
-		for i := 1; i < len(opsRequestSlice); i++ {
-			if !opsRequestSlice[i].InQueue {
-				// ignore the running opsRequests.
+	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsFailedPhase {
+		var (
+			newOpsRequestSlice []appsv1alpha1.OpsRecorder
+			failedOpsRecorder  = opsRequestSlice[index]
+		)
+		// 1. update the pending opsRequests phase to Cancelled if they are queued behind the Failed opsRequest and conflict with it.
+		for i := range opsRequestSlice {
+			if i == index {
+				continue
+			}
+			if i < index || !opsRequestSlice[i].InQueue || GetOpsManager().getConflictReason(failedOpsRecorder, opsRequestSlice[i]) == "" {
+				// ignore the running opsRequests and the ones which do not wait for the Failed opsRequest.
 				newOpsRequestSlice = append(newOpsRequestSlice, opsRequestSlice[i])
 				continue
 			}
@@ -89,75 +96,76 @@ func DequeueOpsRequestInClusterAnnotation(ctx context.Context, cli client.Client
 }
 
 // enqueueOpsRequestToClusterAnnotation adds the OpsRequest Annotation to Cluster.metadata.Annotations to acquire the lock.
-func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) (*appsv1alpha1.OpsRecorder, error) {
+// If the opsRequest is in the queue, returns the reason why it is waiting.
+func enqueueOpsRequestToClusterAnnotation(ctx context.Context, cli client.Client, opsRes *OpsResource, opsBehaviour OpsBehaviour) (*appsv1alpha1.OpsRecorder, *metav1.Condition, error) {
 	var (
 		opsRequestSlice []appsv1alpha1.OpsRecorder
 		err             error
 	)
-	if !opsBehaviour.QueueByCluster && !opsBehaviour.QueueBySelf {
-		return nil, nil
+	if len(opsBehaviour.ConflictRules) == 0 {
+		return nil, nil, nil
 	}
 	// if the running opsRequest is deleted, do not enqueue the opsRequest to cluster annotation.
 	if !opsRes.OpsRequest.DeletionTimestamp.IsZero() {
-		return nil, nil
+		return nil, nil, nil
 	}
 	if opsRequestSlice, err = opsutil.GetOpsRequestSliceFromCluster(opsRes.Cluster); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	inQueue := func() bool {
-		if opsRes.OpsRequest.Force() && !opsRes.OpsRequest.Spec.EnqueueOnForce {
-			return false
+	opsMgr := GetOpsManager()
+	waitForConflictingOps := func(opsRecorder appsv1alpha1.OpsRecorder) *metav1.Condition {
+		conflictingOps, reason := opsMgr.findConflictingOps(opsRequestSlice, opsRecorder)
+		if conflictingOps == nil {
+			return nil
 		}
-		return existOtherRunningOps(opsRequestSlice, opsRes.OpsRequest.Spec.Type, opsBehaviour)
+		return appsv1alpha1.NewWaitForConflictingOpsCondition(opsRes.OpsRequest, *conflictingOps, reason)
 	}
 
+	var waitingCondition *metav1.Condition
 	index, opsRecorder := GetOpsRecorderFromSlice(opsRequestSlice, opsRes.OpsRequest.Name)
 	switch index {
 	case -1:
 		// if not exists but reach the queue limit size, throw an error
 		if len(opsRequestSlice) >= opsRequestQueueLimitSize {
-			return nil, intctrlutil.NewFatalError(fmt.Sprintf("The opsRequest queue is limited to a size of %d", opsRequestQueueLimitSize))
+			return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf("The opsRequest queue is limited to a size of %d", opsRequestQueueLimitSize))
 		}
 		// if not exists, enqueue
 		if opsRequestSlice == nil {
 			opsRequestSlice = make([]appsv1alpha1.OpsRecorder, 0)
 		}
-		opsRecorder = appsv1alpha1.OpsRecorder{
-			Name:        opsRes.OpsRequest.Name,
-			Type:        opsRes.OpsRequest.Spec.Type,
-			QueueBySelf: opsBehaviour.QueueBySelf,
-			// check if the opsRequest should be in the queue.
-			InQueue: inQueue(),
+		opsRecorder = newOpsRecorder(opsRes.OpsRequest)
+		// check if the opsRequest should be in the queue.
+		if !opsRes.OpsRequest.Force() || opsRes.OpsRequest.Spec.EnqueueOnForce {
+			waitingCondition = waitForConflictingOps(opsRecorder)
 		}
+		opsRecorder.InQueue = waitingCondition != nil
 		opsRequestSlice = append(opsRequestSlice, opsRecorder)
 	default:
 		if !opsRecorder.InQueue {
 			// the opsRequest is already running.
-			return &opsRecorder, nil
+			return &opsRecorder, nil, nil
 		}
-		if !opsRes.OpsRequest.Spec.Force && existOtherRunningOps(opsRequestSlice, opsRecorder.Type, opsBehaviour) {
-			// if exists other running opsRequest, return.
-			return &opsRecorder, nil
+		if !opsRes.OpsRequest.Spec.Force {
+			if waitingCondition = waitForConflictingOps(opsRecorder); waitingCondition != nil {
+				// if exists other conflicting opsRequest, return.
+				return &opsRecorder, waitingCondition, nil
+			}
 		}
 		// mark to handle the next opsRequest
 		opsRequestSlice[index].InQueue = false
 	}
-	return &opsRecorder, opsutil.UpdateClusterOpsAnnotations(ctx, cli, opsRes.Cluster, opsRequestSlice)
+	return &opsRecorder, waitingCondition, opsutil.UpdateClusterOpsAnnotations(ctx, cli, opsRes.Cluster, opsRequestSlice)
 }
 
-// existOtherRunningOps checks if exists other running opsRequest.
-func existOtherRunningOps(opsRecorderSlice []appsv1alpha1.OpsRecorder, opsType appsv1alpha1.OpsType, opsBehaviour OpsBehaviour) bool {
-	for i := range opsRecorderSlice {
-		if opsBehaviour.QueueByCluster && opsRecorderSlice[i].QueueBySelf {
-			continue
-		}
-		if opsBehaviour.QueueBySelf && opsRecorderSlice[i].Type != opsType {
-			continue
-		}
-		if !opsRecorderSlice[i].InQueue {
-			return true
-		}
+// patchWaitingConditionIfChanged patches the condition which describes why the opsRequest is waiting in the queue.
+func patchWaitingConditionIfChanged(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource, condition *metav1.Condition) error {
+	if condition == nil {
+		return nil
+	}
+	existing := meta.FindStatusCondition(opsRes.OpsRequest.Status.Conditions, condition.Type)
+	if existing != nil && existing.Reason == condition.Reason && existing.Message == condition.Message {
+		return nil
 	}
-	return false
+	return PatchOpsStatus(reqCtx.Ctx, cli, opsRes, opsRes.OpsRequest.Status.Phase, condition)
 }
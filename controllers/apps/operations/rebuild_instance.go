@@ -60,11 +60,13 @@ type rebuildInstanceOpsHandler struct{}
 var _ OpsHandler = rebuildInstanceOpsHandler{}
 
 func init() {
+	rebuildHandler := rebuildInstanceOpsHandler{}
 	rebuildInstanceBehaviour := OpsBehaviour{
 		FromClusterPhases: []appsv1.ClusterPhase{appsv1.AbnormalClusterPhase, appsv1.FailedClusterPhase, appsv1.UpdatingClusterPhase},
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
-		QueueByCluster:    true,
-		OpsHandler:        rebuildInstanceOpsHandler{},
+		ConflictRules:     newWorkloadOpsConflictRules(),
+		OpsHandler:        rebuildHandler,
+		CancelFunc:        rebuildHandler.Cancel,
 	}
 	opsMgr := GetOpsManager()
 	opsMgr.RegisterOps(appsv1alpha1.RebuildInstanceType, rebuildInstanceBehaviour)
@@ -224,9 +226,139 @@ func (r rebuildInstanceOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx
 	if failedCount == 0 {
 		return appsv1alpha1.OpsSucceedPhase, 0, r.cleanupTmpResources(reqCtx, cli, opsRes)
 	}
+	if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
+		// the cancelled instances are marked as failed, release the temporary resources of them.
+		return appsv1alpha1.OpsFailedPhase, 0, r.cleanupTmpResources(reqCtx, cli, opsRes)
+	}
 	return appsv1alpha1.OpsFailedPhase, 0, nil
 }
 
+// Cancel this function defines the cancel rebuildInstance action.
+// The instances whose volumes have been replaced will continue to be rebuilt,
+// and the others will not be rebuilt anymore.
+func (r rebuildInstanceOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	var (
+		reverted    []string
+		notReverted []string
+		oldCluster  = opsRes.Cluster.DeepCopy()
+	)
+	if opsRes.OpsRequest.Status.Components == nil {
+		opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	for _, v := range opsRes.OpsRequest.Spec.RebuildFrom {
+		var (
+			subReverted    []string
+			subNotReverted []string
+			err            error
+		)
+		compStatus := opsRes.OpsRequest.Status.Components[v.ComponentName]
+		if v.InPlace {
+			subReverted, subNotReverted, err = r.cancelRebuildInstancesInPlace(reqCtx, cli, opsRes, v, &compStatus)
+		} else {
+			subReverted, subNotReverted = r.cancelRebuildInstancesWithHScaling(opsRes, v, &compStatus)
+		}
+		if err != nil {
+			return err
+		}
+		reverted = append(reverted, subReverted...)
+		notReverted = append(notReverted, subNotReverted...)
+		opsRes.OpsRequest.Status.Components[v.ComponentName] = compStatus
+	}
+	if !reflect.DeepEqual(oldCluster.Spec, opsRes.Cluster.Spec) {
+		if err := cli.Update(reqCtx.Ctx, opsRes.Cluster); err != nil {
+			return err
+		}
+	}
+	appendCancelResult(opsRes.OpsRequest, reverted, notReverted)
+	return nil
+}
+
+// cancelRebuildInstancesInPlace marks the instances that have not replaced their volumes as failed.
+func (r rebuildInstanceOpsHandler) cancelRebuildInstancesInPlace(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	rebuildInstance appsv1alpha1.RebuildInstance,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) ([]string, []string, error) {
+	var (
+		reverted    []string
+		notReverted []string
+	)
+	// the restored pvs are labeled with the tmp pvc name before the source pvcs are replaced.
+	pvList := &corev1.PersistentVolumeList{}
+	if err := cli.List(reqCtx.Ctx, pvList, client.HasLabels{rebuildTmpPVCNameLabel}); err != nil {
+		return nil, nil, err
+	}
+	rebuildPrefix := fmt.Sprintf("rebuild-%s", opsRes.OpsRequest.UID[:8])
+	volumesReplaced := func(index int) bool {
+		for _, pv := range pvList.Items {
+			tmpPVCName := pv.Labels[rebuildTmpPVCNameLabel]
+			if strings.HasPrefix(tmpPVCName, rebuildPrefix) && strings.HasSuffix(tmpPVCName, fmt.Sprintf("-%d", index)) {
+				return true
+			}
+		}
+		return false
+	}
+	for i, instance := range rebuildInstance.Instances {
+		progressDetail := r.getInstanceProgressDetail(*compStatus, instance.Name)
+		switch {
+		case progressDetail.Status == appsv1alpha1.SucceedProgressStatus:
+			notReverted = append(notReverted, fmt.Sprintf("instance %s has been rebuilt", instance.Name))
+		case progressDetail.Status == appsv1alpha1.FailedProgressStatus:
+			continue
+		case progressDetail.Message == waitingForInstanceReadyMessage,
+			strings.HasPrefix(progressDetail.Message, waitingForPostReadyRestorePrefix),
+			volumesReplaced(i):
+			notReverted = append(notReverted, fmt.Sprintf("the volumes of instance %s have been replaced, the rebuilding will continue", instance.Name))
+		default:
+			progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, fmt.Sprintf("Rebuilding pod %s is cancelled", instance.Name))
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+			reverted = append(reverted, fmt.Sprintf("instance %s will not be rebuilt", instance.Name))
+		}
+	}
+	return reverted, notReverted, nil
+}
+
+// cancelRebuildInstancesWithHScaling rolls back the scaled out instances if none of the instances has been taken offline.
+func (r rebuildInstanceOpsHandler) cancelRebuildInstancesWithHScaling(opsRes *OpsResource,
+	rebuildInstance appsv1alpha1.RebuildInstance,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) ([]string, []string) {
+	var (
+		reverted    []string
+		notReverted []string
+		compSpec    *appsv1.ClusterComponentSpec
+	)
+	for i := range opsRes.Cluster.Spec.ComponentSpecs {
+		if opsRes.Cluster.Spec.ComponentSpecs[i].Name == rebuildInstance.ComponentName {
+			compSpec = &opsRes.Cluster.Spec.ComponentSpecs[i]
+			break
+		}
+	}
+	lastCompConfiguration, ok := opsRes.OpsRequest.Status.LastConfiguration.Components[rebuildInstance.ComponentName]
+	if compSpec == nil || !ok || lastCompConfiguration.Replicas == nil {
+		return nil, nil
+	}
+	for _, instance := range rebuildInstance.Instances {
+		if slices.Contains(compSpec.OfflineInstances, instance.Name) &&
+			!slices.Contains(lastCompConfiguration.OfflineInstances, instance.Name) {
+			// the new instances have been available and the old instances are being replaced.
+			for _, ins := range rebuildInstance.Instances {
+				notReverted = append(notReverted, fmt.Sprintf("instance %s is being replaced by the scaled out pod, the rebuilding will continue", ins.Name))
+			}
+			return nil, notReverted
+		}
+	}
+	compSpec.Replicas = *lastCompConfiguration.Replicas
+	compSpec.Instances = lastCompConfiguration.Instances
+	compSpec.OfflineInstances = lastCompConfiguration.OfflineInstances
+	for _, instance := range rebuildInstance.Instances {
+		progressDetail := r.getInstanceProgressDetail(*compStatus, instance.Name)
+		progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, fmt.Sprintf("Rebuilding pod %s is cancelled", instance.Name))
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+		reverted = append(reverted, fmt.Sprintf("instance %s will not be rebuilt and the scaled out pod is removed", instance.Name))
+	}
+	return reverted, notReverted
+}
+
 func (r rebuildInstanceOpsHandler) rebuildInstancesInPlace(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource,
@@ -452,6 +584,14 @@ func (r rebuildInstanceOpsHandler) checkProgressForScalingOutPods(reqCtx intctrl
 		opsRes.Cluster.Name, compSpec.Name)
 	for _, instance := range rebuildInstance.Instances {
 		progressDetail := r.getInstanceProgressDetail(*compStatus, instance.Name)
+		if opsRes.OpsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase && isCompletedProgressStatus(progressDetail.Status) {
+			// the rebuilding of the instance has been cancelled.
+			completedCount += 1
+			if progressDetail.Status == appsv1alpha1.FailedProgressStatus {
+				failedCount += 1
+			}
+			continue
+		}
 		scalingOutPodName := r.getScalingOutPodNameFromMessage(progressDetail.Message)
 		if _, ok := currPodSet[scalingOutPodName]; !ok {
 			return 0, 0, nil, intctrlutil.NewFatalError(fmt.Sprintf(`the replicas of the component "%s" has been modifeied by another operation`, compSpec.Name))
@@ -622,7 +762,49 @@ func (r rebuildInstanceOpsHandler) cleanupTmpResources(reqCtx intctrlutil.Reques
 		constant.OpsRequestNameLabelKey:      opsRes.OpsRequest.Name,
 		constant.OpsRequestNamespaceLabelKey: opsRes.OpsRequest.Namespace,
 	}
-	// TODO: need to delete the restore CR?
 	// Pods are limited in k8s, so we need to release them if they are not needed.
-	return intctrlutil.DeleteOwnedResources(reqCtx.Ctx, cli, opsRes.OpsRequest, matchLabels, generics.PodSignature)
+	if err := intctrlutil.DeleteOwnedResources(reqCtx.Ctx, cli, opsRes.OpsRequest, matchLabels, generics.PodSignature); err != nil {
+		return err
+	}
+	if opsRes.OpsRequest.Status.Phase != appsv1alpha1.OpsCancellingPhase {
+		// TODO: need to delete the restore CR?
+		return nil
+	}
+	// release the restores and tmp pvcs of the cancelled instances.
+	if err := intctrlutil.DeleteOwnedResources(reqCtx.Ctx, cli, opsRes.OpsRequest, matchLabels, generics.RestoreSignature); err != nil {
+		return err
+	}
+	return r.cleanupCancelledTmpPVCs(reqCtx, cli, opsRes)
+}
+
+// cleanupCancelledTmpPVCs deletes the tmp pvcs of the cancelled instances.
+// The tmp pvcs whose volumes are being handed over to the source pvcs are kept, they are released by the rebuilding.
+func (r rebuildInstanceOpsHandler) cleanupCancelledTmpPVCs(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource) error {
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := cli.List(reqCtx.Ctx, pvcList, client.InNamespace(opsRes.OpsRequest.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRes.Cluster.Name}); err != nil {
+		return err
+	}
+	rebuildPrefix := fmt.Sprintf("rebuild-%s", opsRes.OpsRequest.UID[:8])
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if !strings.HasPrefix(pvc.Name, rebuildPrefix) || !metav1.IsControlledBy(pvc, opsRes.OpsRequest) {
+			continue
+		}
+		if pvc.Spec.VolumeName != "" {
+			pv := &corev1.PersistentVolume{}
+			if err := cli.Get(reqCtx.Ctx, client.ObjectKey{Name: pvc.Spec.VolumeName}, pv); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			if pv.Labels[rebuildTmpPVCNameLabel] == pvc.Name {
+				continue
+			}
+		}
+		if err := intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, pvc); err != nil {
+			return err
+		}
+	}
+	return nil
 }
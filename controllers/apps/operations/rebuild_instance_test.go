@@ -21,12 +21,14 @@ package operations
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -319,6 +321,63 @@ var _ = Describe("OpsUtil functions", func() {
 			})).Should(Succeed())
 		})
 
+		It("test cancel rebuild instance in place", func() {
+			By("init operations resources ")
+			opsRes := prepareOpsRes("", true)
+			testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+			reqCtx := intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
+
+			By("expect for the tmp pvcs are created")
+			_, _ = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			tmpPVCNames := make([]string, rebuildInstanceCount)
+			for i := range tmpPVCNames {
+				tmpPVCNames[i] = fmt.Sprintf("rebuild-%s-%s-%d", opsRes.OpsRequest.UID[:8], common.CutString(defaultCompName+"-"+testapps.DataVolumeName, 30), i)
+				Eventually(testapps.CheckObjExists(&testCtx, client.ObjectKey{Name: tmpPVCNames[i], Namespace: testCtx.DefaultNamespace},
+					&corev1.PersistentVolumeClaim{}, true)).Should(Succeed())
+			}
+
+			By("mock the volume of the first instance is being handed over to the source pvc")
+			tmpPVC := &corev1.PersistentVolumeClaim{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Name: tmpPVCNames[0], Namespace: testCtx.DefaultNamespace}, tmpPVC)).Should(Succeed())
+			pvName := tmpPVC.Name + "-pv"
+			testapps.NewPersistentVolumeFactory(tmpPVC.Namespace, pvName, tmpPVC.Name).
+				SetStorage("20Gi").
+				SetClaimRef(tmpPVC).
+				AddLabels(rebuildTmpPVCNameLabel, tmpPVC.Name).
+				Create(&testCtx)
+			Expect(testapps.ChangeObj(&testCtx, tmpPVC, func(p *corev1.PersistentVolumeClaim) {
+				p.Spec.VolumeName = pvName
+			})).Should(Succeed())
+
+			By("cancel the opsRequest and expect only the second instance is not rebuilt")
+			cancelOpsRequest(reqCtx, opsRes, time.Now())
+			Expect(opsRes.OpsRequest.Status.CancelResult).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.CancelResult.Reverted).Should(HaveLen(1))
+			Expect(opsRes.OpsRequest.Status.CancelResult.NotReverted).Should(HaveLen(1))
+			compStatus := opsRes.OpsRequest.Status.Components[defaultCompName]
+			cancelledInsName := opsRes.OpsRequest.Spec.RebuildFrom[0].Instances[1].Name
+			progressDetail := findStatusProgressDetail(compStatus.ProgressDetails, getProgressObjectKey(constant.PodKind, cancelledInsName))
+			Expect(progressDetail).ShouldNot(BeNil())
+			Expect(progressDetail.Status).Should(Equal(appsv1alpha1.FailedProgressStatus))
+
+			By("expect only the tmp pvc of the cancelled instance is released")
+			Expect(rebuildInstanceOpsHandler{}.cleanupTmpResources(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(func(g Gomega) {
+				pvc := &corev1.PersistentVolumeClaim{}
+				err := k8sClient.Get(ctx, client.ObjectKey{Name: tmpPVCNames[1], Namespace: testCtx.DefaultNamespace}, pvc)
+				if apierrors.IsNotFound(err) {
+					return
+				}
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(pvc.DeletionTimestamp).ShouldNot(BeNil())
+			}).Should(Succeed())
+			Consistently(testapps.CheckObj(&testCtx, client.ObjectKey{Name: tmpPVCNames[0], Namespace: testCtx.DefaultNamespace},
+				func(g Gomega, pvc *corev1.PersistentVolumeClaim) {
+					g.Expect(pvc.DeletionTimestamp).Should(BeNil())
+				})).Should(Succeed())
+		})
+
 		testRebuildInstanceWithBackup := func(ignoreRoleCheck bool) {
 			By("init operation resources and backup")
 			actionSet := testapps.CreateCustomizedObj(&testCtx, "backup/actionset.yaml",
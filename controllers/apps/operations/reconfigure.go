@@ -46,8 +46,9 @@ func init() {
 		FromClusterPhases: appsv1.GetReconfiguringRunningPhases(),
 		// TODO: add cluster reconcile Reconfiguring phase.
 		ToClusterPhase: appsv1.UpdatingClusterPhase,
-		QueueByCluster: true,
+		ConflictRules:  newWorkloadOpsConflictRules(),
 		OpsHandler:     &reAction,
+		CancelFunc:     reAction.Cancel,
 	}
 	opsManager.RegisterOps(appsv1alpha1.ReconfiguringType, reconfigureBehaviour)
 }
@@ -61,6 +62,60 @@ func (r *reconfigureAction) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Cancel restores the parameters and files updated by the opsRequest to the values before reconfiguring,
+// the replicas which have not been reconfigured are kept as they are and the reconfigured replicas are rolled back.
+func (r *reconfigureAction) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	var (
+		reverted    []string
+		notReverted []string
+	)
+	for _, params := range fromReconfigureOperations(opsRes.OpsRequest.Spec, reqCtx, cli, opsRes) {
+		configName := params.configurationItem.Name
+		var previousConfigs []appsv1alpha1.ParameterConfig
+		for _, cmStatus := range params.configurationStatus.ConfigurationStatus {
+			if cmStatus.Name == configName {
+				previousConfigs = cmStatus.PreviousConfiguration
+				break
+			}
+		}
+		if len(previousConfigs) == 0 {
+			notReverted = append(notReverted, fmt.Sprintf("config %s of component %s has not been updated or has no previous configuration to restore",
+				configName, params.componentName))
+			continue
+		}
+		fetcher, err := r.syncDependResources(reqCtx, cli, opsRes, params.configurationItem, params.componentName)
+		if err != nil {
+			return err
+		}
+		configObj := fetcher.ConfigurationObj.DeepCopy()
+		item := configObj.Spec.GetConfigurationItem(configName)
+		if item == nil {
+			return intctrlutil.NewFatalError(fmt.Sprintf(`config "%s" not found in the configuration of component "%s"`,
+				configName, params.componentName))
+		}
+		if item.ConfigFileParams == nil {
+			item.ConfigFileParams = make(map[string]appsv1alpha1.ConfigParams)
+		}
+		// restore the configuration in the same way as it is updated, the parameters of the files managed by
+		// the ConfigConstraint are restored one by one, and the other files are restored with the whole content.
+		for _, config := range previousConfigs {
+			switch {
+			case len(config.Parameters) > 0:
+				updateParameters(item, config.Key, config.Parameters, func(string) bool { return true })
+			case config.FileContent != "":
+				updateFileContent(item, config.Key, config.FileContent)
+			}
+		}
+		if err = cli.Patch(reqCtx.Ctx, configObj, client.MergeFrom(fetcher.ConfigurationObj)); err != nil {
+			return err
+		}
+		reverted = append(reverted, fmt.Sprintf("config %s of component %s has been restored to the previous configuration",
+			configName, params.componentName))
+	}
+	appendCancelResult(opsRes.OpsRequest, reverted, notReverted)
+	return nil
+}
+
 func handleReconfigureStatusProgress(result *appsv1alpha1.ReconcileDetail, opsStatus *appsv1alpha1.OpsRequestStatus, phase appsv1alpha1.ConfigurationPhase) handleReconfigureOpsStatus {
 	return func(cmStatus *appsv1alpha1.ConfigurationItemStatus) (err error) {
 		// the Pending phase is waiting to be executed, and there is currently no valid ReconcileDetail information.
@@ -76,10 +131,12 @@ func handleReconfigureStatusProgress(result *appsv1alpha1.ReconcileDetail, opsSt
 	}
 }
 
-func handleNewReconfigureRequest(configPatch *core.ConfigPatchInfo, lastAppliedConfigs map[string]string) handleReconfigureOpsStatus {
+func handleNewReconfigureRequest(configPatch *core.ConfigPatchInfo, lastAppliedConfigs map[string]string,
+	previousConfigs []appsv1alpha1.ParameterConfig) handleReconfigureOpsStatus {
 	return func(cmStatus *appsv1alpha1.ConfigurationItemStatus) (err error) {
 		cmStatus.Status = appsv1alpha1.ReasonReconfigurePersisted
 		cmStatus.LastAppliedConfiguration = lastAppliedConfigs
+		cmStatus.PreviousConfiguration = previousConfigs
 		if configPatch != nil {
 			cmStatus.UpdatedParameters = appsv1alpha1.UpdatedParameters{
 				AddedKeys:   i2sMap(configPatch.AddConfig),
@@ -264,7 +321,7 @@ func (r *reconfigureAction) doReconfiguring(params reconfigureParams) error {
 
 	// merged successfully
 	if err := updateReconfigureStatusByCM(params.configurationStatus, opsPipeline.configSpec.Name,
-		handleNewReconfigureRequest(result.configPatch, result.lastAppliedConfigs)); err != nil {
+		handleNewReconfigureRequest(result.configPatch, result.lastAppliedConfigs, result.previousConfigs)); err != nil {
 		return err
 	}
 	condition := constructReconfiguringConditions(result, params.resource, opsPipeline.configSpec)
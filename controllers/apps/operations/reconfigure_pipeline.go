@@ -55,6 +55,7 @@ type pipeline struct {
 
 	updatedParameters []cfgcore.ParamPairs
 	mergedConfig      map[string]string
+	previousConfigs   []appsv1alpha1.ParameterConfig
 	configPatch       *cfgcore.ConfigPatchInfo
 	isFileUpdated     bool
 
@@ -151,12 +152,20 @@ func (p *pipeline) doMergeImpl(parameters appsv1alpha1.ConfigurationItem) error
 	}
 	filter := validate.WithKeySelector(configSpec.Keys)
 	paramFilter := createImmutableParamsFilter(p.configConstraint)
+	p.previousConfigs = make([]appsv1alpha1.ParameterConfig, 0, len(parameters.Keys))
 	for _, key := range parameters.Keys {
 		// patch parameters
 		if configSpec.ConfigConstraintRef != "" && filter(key.Key) {
 			if key.FileContent != "" {
 				return cfgcore.MakeError("not allowed to update file content: %s", key.Key)
 			}
+			// record the values of the parameters before reconfiguring, which are restored if the opsRequest is cancelled.
+			previousParams, err := fetchPreviousParameters(key.Key, p.ConfigMapObj.Data[key.Key], key.Parameters,
+				p.configConstraint.Spec.FileFormatConfig)
+			if err != nil {
+				return err
+			}
+			p.previousConfigs = append(p.previousConfigs, appsv1alpha1.ParameterConfig{Key: key.Key, Parameters: previousParams})
 			updateParameters(item, key.Key, key.Parameters, paramFilter)
 			p.updatedParameters = append(p.updatedParameters, cfgcore.ParamPairs{
 				Key:           key.Key,
@@ -168,6 +177,7 @@ func (p *pipeline) doMergeImpl(parameters appsv1alpha1.ConfigurationItem) error
 		if len(key.Parameters) != 0 {
 			return cfgcore.MakeError("not allowed to patch parameters: %s", key.Key)
 		}
+		p.previousConfigs = append(p.previousConfigs, appsv1alpha1.ParameterConfig{Key: key.Key, FileContent: p.ConfigMapObj.Data[key.Key]})
 		updateFileContent(item, key.Key, key.FileContent)
 		p.isFileUpdated = true
 	}
@@ -237,6 +247,7 @@ func (p *pipeline) Complete() reconfiguringResult {
 
 	return makeReconfiguringResult(nil,
 		withReturned(p.mergedConfig, p.configPatch),
+		withPreviousConfigs(p.previousConfigs),
 		withNoFormatFilesUpdated(p.isFileUpdated),
 	)
 }
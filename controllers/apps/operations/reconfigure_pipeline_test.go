@@ -343,3 +343,31 @@ func Test_createImmutableParamsFilter(t *testing.T) {
 		})
 	}
 }
+
+func Test_fetchPreviousParameters(t *testing.T) {
+	formatter := &appsv1beta1.FileFormatConfig{
+		Format: appsv1beta1.Ini,
+		FormatterAction: appsv1beta1.FormatterAction{
+			IniConfig: &appsv1beta1.IniConfig{SectionName: "mysqld"},
+		},
+	}
+	data := "[mysqld]\ngtid_mode=OFF\nport=3306\n"
+	newValue := "ON"
+	params := []appsv1alpha1.ParameterPair{
+		{Key: "gtid_mode", Value: &newValue},
+		{Key: "binlog_stmt_cache_size", Value: &newValue},
+	}
+	previous, err := fetchPreviousParameters("my.cnf", data, params, formatter)
+	if err != nil {
+		t.Fatalf("fetchPreviousParameters() error = %v", err)
+	}
+	if len(previous) != 2 {
+		t.Fatalf("fetchPreviousParameters() = %v, want 2 parameters", previous)
+	}
+	if previous[0].Value == nil || *previous[0].Value != "OFF" {
+		t.Errorf("fetchPreviousParameters() gtid_mode = %v, want OFF", previous[0].Value)
+	}
+	if previous[1].Value != nil {
+		t.Errorf("fetchPreviousParameters() binlog_stmt_cache_size = %v, want nil", *previous[1].Value)
+	}
+}
@@ -21,6 +21,7 @@ package operations
 
 import (
 	"encoding/json"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -342,5 +343,65 @@ var _ = Describe("Reconfigure OpsRequest", func() {
 			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.Cluster), opsRes.Cluster)).Should(Succeed())
 			Expect(opsRes.Cluster.Status.Components[defaultCompName].Phase).Should(Equal(appsv1.RunningClusterCompPhase))
 		})
+
+		It("Test cancel Reconfigure OpsRequest", func() {
+			opsRes, config, _ := assureMockReconfigureData("autoReload")
+			reqCtx := intctrlutil.RequestCtx{
+				Ctx:      testCtx.Ctx,
+				Log:      log.FromContext(ctx).WithName("Reconfigure"),
+				Recorder: opsRes.Recorder,
+			}
+
+			By("create Reconfiguring opsRequest")
+			newValue := "ON"
+			ops := testapps.NewOpsRequestObj("reconfigure-ops-"+randomStr+"-cancel", testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.ReconfiguringType)
+			ops.Spec.Reconfigure = &appsv1alpha1.Reconfigure{
+				Configurations: []appsv1alpha1.ConfigurationItem{{
+					Name: "mysql-test",
+					Keys: []appsv1alpha1.ParameterConfig{{
+						Key: "my.cnf",
+						Parameters: []appsv1alpha1.ParameterPair{
+							{Key: "gtid_mode", Value: &newValue},
+							{Key: "binlog_stmt_cache_size", Value: func() *string { v := "4096"; return &v }()},
+						},
+					}},
+				}},
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+			}
+			opsRes.OpsRequest = ops
+			Expect(testCtx.CheckedCreateObj(ctx, ops)).Should(Succeed())
+			initClusterForOps(opsRes)
+
+			By("reconfigure and expect the previous configuration is recorded")
+			opsManager := GetOpsManager()
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			_, err := opsManager.Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = opsManager.Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(config), func(g Gomega, config *appsv1alpha1.Configuration) {
+				item := config.Spec.GetConfigurationItem("mysql-test")
+				g.Expect(item).ShouldNot(BeNil())
+				g.Expect(item.ConfigFileParams["my.cnf"].Parameters).Should(HaveKeyWithValue("gtid_mode", &newValue))
+			})).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(opsRes.OpsRequest), opsRes.OpsRequest)).Should(Succeed())
+			reconfiguringStatus := opsRes.OpsRequest.Status.ReconfiguringStatusAsComponent[defaultCompName]
+			Expect(reconfiguringStatus).ShouldNot(BeNil())
+			cmStatus := reconfiguringStatus.ConfigurationStatus
+			Expect(cmStatus).Should(HaveLen(1))
+			Expect(cmStatus[0].PreviousConfiguration).Should(HaveLen(1))
+
+			By("cancel the opsRequest and expect the parameters are restored")
+			cancelOpsRequest(reqCtx, opsRes, time.Now())
+			Expect(opsRes.OpsRequest.Status.CancelResult).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.CancelResult.Reverted).Should(HaveLen(1))
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(config), func(g Gomega, config *appsv1alpha1.Configuration) {
+				params := config.Spec.GetConfigurationItem("mysql-test").ConfigFileParams["my.cnf"].Parameters
+				g.Expect(params["gtid_mode"]).ShouldNot(BeNil())
+				g.Expect(*params["gtid_mode"]).Should(Equal("OFF"))
+				g.Expect(params["binlog_stmt_cache_size"]).Should(BeNil())
+			})).Should(Succeed())
+		})
 	})
 })
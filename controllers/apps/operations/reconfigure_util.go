@@ -40,6 +40,7 @@ type reconfiguringResult struct {
 	noFormatFilesUpdated bool
 	configPatch          *core.ConfigPatchInfo
 	lastAppliedConfigs   map[string]string
+	previousConfigs      []appsv1alpha1.ParameterConfig
 	err                  error
 }
 
@@ -101,6 +102,25 @@ func fetchOriginalValue(keyFile, data string, params map[string]interface{}, for
 	return string(b), err
 }
 
+// fetchPreviousParameters returns the values of the parameters in the configuration file before they are updated,
+// the value of a parameter which does not exist in the file is nil.
+func fetchPreviousParameters(keyFile, data string, params []appsv1alpha1.ParameterPair, formatter *appsv1beta1.FileFormatConfig) ([]appsv1alpha1.ParameterPair, error) {
+	baseConfigObj, err := core.FromConfigObject(keyFile, data, formatter)
+	if err != nil {
+		return nil, err
+	}
+	previous := make([]appsv1alpha1.ParameterPair, 0, len(params))
+	for _, param := range params {
+		pair := appsv1alpha1.ParameterPair{Key: param.Key}
+		if oldVal := baseConfigObj.Get(param.Key); oldVal != nil {
+			value := cast.ToString(oldVal)
+			pair.Value = &value
+		}
+		previous = append(previous, pair)
+	}
+	return previous, nil
+}
+
 func fromKeyValuePair(parameters []appsv1alpha1.ParameterPair) map[string]interface{} {
 	m := make(map[string]interface{}, len(parameters))
 	for _, param := range parameters {
@@ -126,6 +146,12 @@ func withReturned(configs map[string]string, patch *core.ConfigPatchInfo) func(r
 	}
 }
 
+func withPreviousConfigs(configs []appsv1alpha1.ParameterConfig) func(result *reconfiguringResult) {
+	return func(result *reconfiguringResult) {
+		result.previousConfigs = configs
+	}
+}
+
 func withNoFormatFilesUpdated(changed bool) func(result *reconfiguringResult) {
 	return func(result *reconfiguringResult) {
 		result.noFormatFilesUpdated = changed
@@ -22,10 +22,12 @@ package operations
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -33,6 +35,8 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
 
@@ -43,12 +47,14 @@ type restartOpsHandler struct {
 var _ OpsHandler = restartOpsHandler{}
 
 func init() {
+	restartHandler := restartOpsHandler{}
 	restartBehaviour := OpsBehaviour{
 		// if cluster is Abnormal or Failed, new opsRequest may repair it.
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
-		QueueByCluster:    true,
-		OpsHandler:        restartOpsHandler{},
+		ConflictRules:     newWorkloadOpsConflictRules(),
+		OpsHandler:        restartHandler,
+		CancelFunc:        restartHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -75,7 +81,11 @@ func (r restartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 	r.compOpsHelper = newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList)
 	componentKindList := []client.ObjectList{
 		&appv1.StatefulSetList{},
-		&workloads.InstanceSetList{},
+	}
+	if opsRes.OpsRequest.Spec.HealthGate == nil {
+		// the InstanceSets restart the pods by themselves in their update order.
+		// if the health gate is specified, the pods are restarted by the opsRequest one by one during reconciling.
+		componentKindList = append(componentKindList, &workloads.InstanceSetList{})
 	}
 	for _, objectList := range componentKindList {
 		if err := r.restartComponent(reqCtx, cli, opsRes, objectList); err != nil {
@@ -89,15 +99,38 @@ func (r restartOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 // the Reconcile function for restart opsRequest.
 func (r restartOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList)
-	handleRestartProgress := func(reqCtx intctrlutil.RequestCtx,
+	healthGate := opsRes.OpsRequest.Spec.HealthGate
+	if healthGate == nil {
+		handleRestartProgress := func(reqCtx intctrlutil.RequestCtx,
+			cli client.Client,
+			opsRes *OpsResource,
+			pgRes *progressResource,
+			compStatus *appsv1alpha1.OpsRequestComponentStatus) (expectProgressCount int32, completedCount int32, err error) {
+			return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, r.podApplyCompOps)
+		}
+		return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes,
+			"restart", handleRestartProgress)
+	}
+	if err := resumeHealthGateIfRequested(reqCtx, cli, opsRes); err != nil {
+		return "", 0, err
+	}
+	handleGatedRestartProgress := func(reqCtx intctrlutil.RequestCtx,
 		cli client.Client,
 		opsRes *OpsResource,
 		pgRes *progressResource,
 		compStatus *appsv1alpha1.OpsRequestComponentStatus) (expectProgressCount int32, completedCount int32, err error) {
+		if opsRes.OpsRequest.Status.Phase != appsv1alpha1.OpsCancellingPhase {
+			return r.handleInstanceRestartProgress(reqCtx, cli, opsRes, pgRes, compStatus)
+		}
 		return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, r.podApplyCompOps)
 	}
-	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes,
-		"restart", handleRestartProgress)
+	opsPhase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes,
+		"restart", handleGatedRestartProgress)
+	if err == nil && opsPhase == appsv1alpha1.OpsRunningPhase && requeueAfter == 0 {
+		// requeue to check the restarted pods periodically.
+		requeueAfter = time.Duration(getHealthGatePeriodSeconds(healthGate)) * time.Second
+	}
+	return opsPhase, requeueAfter, err
 }
 
 // SaveLastConfiguration this operation only restart the pods of the component, no changes for Cluster.spec.
@@ -106,6 +139,95 @@ func (r restartOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Cancel stops restarting the pods which have not been restarted yet, the restarted pods are kept as they are.
+// If the pods are restarted by the InstanceSet, the pending pods are marked as updated to the latest revision
+// of the InstanceSet, so they will not be re-created by the rollout.
+// If the pods are restarted by the opsRequest with the health gate, nothing has to be reverted on the workloads.
+func (r restartOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	r.compOpsHelper = newComponentOpsHelper(opsRes.OpsRequest.Spec.RestartList)
+	itsList := &workloads.InstanceSetList{}
+	if err := cli.List(reqCtx.Ctx, itsList,
+		client.InNamespace(opsRes.Cluster.Namespace),
+		client.MatchingLabels{constant.AppInstanceLabelKey: opsRes.Cluster.Name}); err != nil {
+		return err
+	}
+	var (
+		reverted    []string
+		notReverted []string
+	)
+	for i := range itsList.Items {
+		its := &itsList.Items[i]
+		if !r.isTargetComponent(its) {
+			continue
+		}
+		pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace,
+			opsRes.Cluster.Name, its.Labels[constant.KBAppComponentLabelKey])
+		if err != nil {
+			return err
+		}
+		var pendingPods []*corev1.Pod
+		for _, pod := range pods {
+			switch {
+			case r.podApplyCompOps(opsRes.OpsRequest, pod, nil, ""):
+				notReverted = append(notReverted, fmt.Sprintf("pod %s has been restarted", pod.Name))
+			case !pod.DeletionTimestamp.IsZero():
+				notReverted = append(notReverted, fmt.Sprintf("pod %s is being restarted", pod.Name))
+			default:
+				pendingPods = append(pendingPods, pod)
+			}
+		}
+		if opsRes.OpsRequest.Spec.HealthGate == nil {
+			if err = r.skipPendingPods(reqCtx, cli, its, pendingPods); err != nil {
+				return err
+			}
+		}
+		for _, pod := range pendingPods {
+			reverted = append(reverted, fmt.Sprintf("pod %s will not be restarted", pod.Name))
+		}
+	}
+	appendCancelResult(opsRes.OpsRequest, reverted, notReverted)
+	return nil
+}
+
+// skipPendingPods marks the pods as updated to the latest revision of the InstanceSet,
+// so the InstanceSet stops rolling out the restart annotation to them.
+func (r restartOpsHandler) skipPendingPods(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	its *workloads.InstanceSet,
+	pods []*corev1.Pod) error {
+	if len(pods) == 0 {
+		return nil
+	}
+	if its.Status.ObservedGeneration != its.Generation {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorTypeNeedWaiting,
+			`waiting for the InstanceSet "%s" to observe the latest generation`, its.Name)
+	}
+	updateRevisions, err := instanceset.GetRevisions(its.Status.UpdateRevisions)
+	if err != nil {
+		return err
+	}
+	restartTime := its.Spec.Template.Annotations[constant.RestartAnnotationKey]
+	for _, pod := range pods {
+		revision, ok := updateRevisions[pod.Name]
+		if !ok {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Annotations[constant.RestartAnnotationKey] = restartTime
+		pod.Labels[appv1.ControllerRevisionHashLabelKey] = revision
+		if err = cli.Patch(reqCtx.Ctx, pod, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r restartOpsHandler) podApplyCompOps(
 	ops *appsv1alpha1.OpsRequest,
 	pod *corev1.Pod,
@@ -138,18 +260,20 @@ func (r restartOpsHandler) restartComponent(reqCtx intctrlutil.RequestCtx, cli c
 	return nil
 }
 
+// isTargetComponent checks whether the workload belongs to a component to restart.
+func (r restartOpsHandler) isTargetComponent(object client.Object) bool {
+	compName := object.GetLabels()[constant.KBAppComponentLabelKey]
+	if shardingName := object.GetLabels()[constant.KBAppShardingNameLabelKey]; shardingName != "" {
+		compName = shardingName
+	}
+	_, ok := r.compOpsHelper.componentOpsSet[compName]
+	return ok
+}
+
 // isRestarted checks whether the component has been restarted
 func (r restartOpsHandler) isRestarted(opsRes *OpsResource, object client.Object, podTemplate *corev1.PodTemplateSpec) bool {
-	cName := object.GetLabels()[constant.KBAppComponentLabelKey]
-	shardingName := object.GetLabels()[constant.KBAppShardingNameLabelKey]
-	if shardingName != "" {
-		if _, ok := r.compOpsHelper.componentOpsSet[shardingName]; !ok {
-			return true
-		}
-	} else {
-		if _, ok := r.compOpsHelper.componentOpsSet[cName]; !ok {
-			return true
-		}
+	if !r.isTargetComponent(object) {
+		return true
 	}
 	if podTemplate.Annotations == nil {
 		podTemplate.Annotations = map[string]string{}
@@ -163,3 +287,93 @@ func (r restartOpsHandler) isRestarted(opsRes *OpsResource, object client.Object
 	}
 	return hasRestarted
 }
+
+// handleInstanceRestartProgress restarts the pods of the component one by one with the health gate, the next pod
+// will not be restarted until the restarted pod is ready and passes the health gate. The leader is restarted at last.
+func (r restartOpsHandler) handleInstanceRestartProgress(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus) (int32, int32, error) {
+	// the component will not be updated, so no need to wait for the component to complete.
+	pgRes.noWaitComponentCompleted = true
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pgRes.fullComponentName)
+	if err != nil {
+		return 0, 0, err
+	}
+	sortPodsForGatedRestart(pods, pgRes.componentDef)
+	var (
+		opsRequest     = opsRes.OpsRequest
+		paused         = meta.IsStatusConditionTrue(opsRequest.Status.Conditions, appsv1alpha1.ConditionTypePaused)
+		completedCount int32
+		blocked        bool
+	)
+	for _, pod := range pods {
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name)}
+		if existing := findStatusProgressDetail(compStatus.ProgressDetails, progressDetail.ObjectKey); existing != nil &&
+			existing.Status == appsv1alpha1.SucceedProgressStatus {
+			completedCount += 1
+			continue
+		}
+		if blocked {
+			progressDetail.Message = "waiting for the previous instance to be restarted"
+			handlePendingProgressDetail(opsRes, compStatus, progressDetail)
+			continue
+		}
+		blocked = true
+		if !r.podApplyCompOps(opsRequest, pod, pgRes.compOps, "") {
+			if paused {
+				progressDetail.Message = "the opsRequest is paused"
+				handlePendingProgressDetail(opsRes, compStatus, progressDetail)
+				continue
+			}
+			if pod.DeletionTimestamp.IsZero() {
+				if err = intctrlutil.BackgroundDeleteObject(cli, reqCtx.Ctx, pod); err != nil {
+					return 0, 0, err
+				}
+			}
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("restarting pod %s", pod.Name))
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+			continue
+		}
+		passed, message, err := checkHealthGateOrPause(reqCtx, cli, opsRes, pgRes, opsRes.OpsRequest.Spec.HealthGate, compStatus, pod)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !passed {
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, message)
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+			continue
+		}
+		completedCount += 1
+		blocked = false
+		handleSucceedProgressDetail(opsRes, pgRes, compStatus, progressDetail)
+	}
+	expectCount := pgRes.clusterComponent.Replicas
+	if int32(len(pods)) > expectCount {
+		expectCount = int32(len(pods))
+	}
+	return expectCount, completedCount, nil
+}
+
+// sortPodsForGatedRestart sorts the pods by name and moves the pods with the writable role to the end.
+func sortPodsForGatedRestart(pods []*corev1.Pod, compDef *appsv1.ComponentDefinition) {
+	isWritable := func(pod *corev1.Pod) bool {
+		if compDef == nil {
+			return false
+		}
+		for _, role := range compDef.Spec.Roles {
+			if role.Writable && role.Name == pod.Labels[constant.RoleLabelKey] {
+				return true
+			}
+		}
+		return false
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		wi, wj := isWritable(pods[i]), isWritable(pods[j])
+		if wi != wj {
+			return !wi
+		}
+		return pods[i].Name < pods[j].Name
+	})
+}
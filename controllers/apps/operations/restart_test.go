@@ -20,17 +20,26 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
 )
 
 var _ = Describe("Restart OpsRequest", func() {
@@ -56,22 +65,27 @@ var _ = Describe("Restart OpsRequest", func() {
 		ml := client.HasLabels{testCtx.TestObjLabelKey}
 		// namespaced
 		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.ComponentSignature, inNS, ml)
 	}
 
 	BeforeEach(cleanEnv)
 
-	AfterEach(cleanEnv)
+	AfterEach(func() {
+		kbacli.UnsetMockClient()
+		cleanEnv()
+	})
 
 	Context("Test OpsRequest", func() {
 		var (
 			opsRes  *OpsResource
+			compDef *appsv1.ComponentDefinition
 			cluster *appsv1.Cluster
 			reqCtx  intctrlutil.RequestCtx
 		)
 
 		BeforeEach(func() {
 			By("init operations resources ")
-			opsRes, _, cluster = initOperationsResources(compDefName, clusterName)
+			opsRes, compDef, cluster = initOperationsResources(compDefName, clusterName)
 			reqCtx = intctrlutil.RequestCtx{Ctx: testCtx.Ctx}
 		})
 
@@ -93,6 +107,168 @@ var _ = Describe("Restart OpsRequest", func() {
 			Expect(err == nil).Should(BeTrue())
 		})
 
+		It("Test cancel restart OpsRequest", func() {
+			By("mock the InstanceSet and pods of the component")
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			pods := initInstanceSetPods(ctx, k8sClient, opsRes)
+
+			By("create Restart opsRequest and restart the component")
+			opsRes.OpsRequest = createRestartOpsObj(clusterName, "restart-ops-"+randomStr)
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			Expect(restartOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+
+			By("mock the InstanceSet has observed the restart")
+			updateRevisions := map[string]string{}
+			for _, pod := range pods {
+				updateRevisions[pod.Name] = "restart-revision"
+			}
+			Eventually(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(its), func(its *workloads.InstanceSet) {
+				its.Status.ObservedGeneration = its.Generation
+				its.Status.UpdateRevisions = updateRevisions
+			})).Should(Succeed())
+
+			By("mock pods[0] is being restarted by the InstanceSet")
+			testk8s.MockPodIsTerminating(ctx, testCtx, pods[0])
+
+			By("cancel the restart opsRequest")
+			cancelOpsRequest(reqCtx, opsRes, time.Now())
+			Expect(opsRes.OpsRequest.Status.CancelResult).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.CancelResult.Reverted).Should(HaveLen(2))
+			Expect(opsRes.OpsRequest.Status.CancelResult.NotReverted).Should(HaveLen(1))
+
+			By("expect the pods which have not been restarted are marked as updated")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, its *workloads.InstanceSet) {
+				restartTime := its.Spec.Template.Annotations[constant.RestartAnnotationKey]
+				g.Expect(restartTime).ShouldNot(BeEmpty())
+				for _, pod := range pods[1:] {
+					newPod := &corev1.Pod{}
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), newPod)).Should(Succeed())
+					g.Expect(newPod.Labels[appv1.ControllerRevisionHashLabelKey]).Should(Equal("restart-revision"))
+					g.Expect(newPod.Annotations[constant.RestartAnnotationKey]).Should(Equal(restartTime))
+				}
+			})).Should(Succeed())
+		})
+
+		It("Test restart OpsRequest with health gate", func() {
+			By("mock the healthCheck action and the pods of the component")
+			mockComponentHealthCheck(compDef, cluster, func() bool { return true })
+			pods := initInstanceSetPods(ctx, k8sClient, opsRes)
+
+			By("create Restart opsRequest with health gate")
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: defaultCompName}}
+			ops.Spec.HealthGate = &appsv1alpha1.InstanceHealthGate{SuccessThreshold: 1, FailureThreshold: 1}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest))).Should(Equal(appsv1alpha1.OpsCreatingPhase))
+			Expect(restartOpsHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+
+			checkRestartingPods := func() {
+				var restartingPods []*corev1.Pod
+				for _, pod := range pods {
+					newPod := &corev1.Pod{}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), newPod)).Should(Succeed())
+					if !newPod.DeletionTimestamp.IsZero() {
+						restartingPods = append(restartingPods, newPod)
+					}
+				}
+				Expect(restartingPods).Should(HaveLen(1))
+				Expect(restartingPods[0].Labels[constant.RoleLabelKey]).ShouldNot(Equal(constant.Leader))
+			}
+
+			By("expect only one follower is restarted")
+			_, _, err = restartOpsHandler{}.ReconcileAction(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			checkRestartingPods()
+
+			By("expect the next pod is not restarted before the restarted pod passes the health gate")
+			_, _, err = restartOpsHandler{}.ReconcileAction(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			checkRestartingPods()
+		})
+
+		It("Test health gate of the restarted instances", func() {
+			By("mock the healthCheck action of the component")
+			healthy := true
+			mockComponentHealthCheck(compDef, cluster, func() bool { return healthy })
+			pods := initInstanceSetPods(ctx, k8sClient, opsRes)
+
+			By("create Restart opsRequest with health gate")
+			ops := testapps.NewOpsRequestObj("restart-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.RestartType)
+			ops.Spec.RestartList = []appsv1alpha1.ComponentOps{{ComponentName: defaultCompName}}
+			ops.Spec.HealthGate = &appsv1alpha1.InstanceHealthGate{SuccessThreshold: 2, FailureThreshold: 2, PeriodSeconds: 1}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			pgRes := &progressResource{fullComponentName: defaultCompName, componentDef: compDef}
+			compStatus := &appsv1alpha1.OpsRequestComponentStatus{}
+			checkGate := func(pod *corev1.Pod) (bool, string) {
+				// skip the probe period of the health gate.
+				getOrCreateHealthGateStatus(compStatus, pod.Name).LastProbeTime = nil
+				passed, message, err := checkHealthGateOrPause(reqCtx, k8sClient, opsRes, pgRes, opsRes.OpsRequest.Spec.HealthGate, compStatus, pod)
+				Expect(err).ShouldNot(HaveOccurred())
+				return passed, message
+			}
+
+			By("expect the instance passes the health gate after the success threshold is reached")
+			passed, message := checkGate(pods[0])
+			Expect(passed).Should(BeFalse())
+			Expect(message).Should(ContainSubstring("1/2"))
+			passed, _ = checkGate(pods[0])
+			Expect(passed).Should(BeTrue())
+
+			By("expect the opsRequest is paused after the instance fails the health gate")
+			healthy = false
+			passed, _ = checkGate(pods[1])
+			Expect(passed).Should(BeFalse())
+			Expect(meta.IsStatusConditionTrue(opsRes.OpsRequest.Status.Conditions, appsv1alpha1.ConditionTypePaused)).Should(BeFalse())
+			passed, message = checkGate(pods[1])
+			Expect(passed).Should(BeFalse())
+			Expect(message).Should(ContainSubstring("failed the health gate"))
+			Expect(meta.IsStatusConditionTrue(opsRes.OpsRequest.Status.Conditions, appsv1alpha1.ConditionTypePaused)).Should(BeTrue())
+
+			By("expect the failed instance is not checked again until the opsRequest is resumed")
+			healthy = true
+			passed, _ = checkGate(pods[1])
+			Expect(passed).Should(BeFalse())
+
+			By("resume the opsRequest with the annotation")
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{defaultCompName: *compStatus}
+			})).Should(Succeed())
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(obj *appsv1alpha1.OpsRequest) {
+				obj.Annotations = map[string]string{constant.OpsResumeAnnotationKey: "true"}
+			})).Should(Succeed())
+			Expect(resumeHealthGateIfRequested(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, fetched *appsv1alpha1.OpsRequest) {
+				g.Expect(fetched.Annotations).ShouldNot(HaveKey(constant.OpsResumeAnnotationKey))
+				g.Expect(meta.IsStatusConditionFalse(fetched.Status.Conditions, appsv1alpha1.ConditionTypePaused)).Should(BeTrue())
+				for _, gateStatus := range fetched.Status.Components[defaultCompName].HealthGates {
+					g.Expect(gateStatus.FailedCount).Should(BeZero())
+				}
+			})).Should(Succeed())
+			*compStatus = opsRes.OpsRequest.Status.Components[defaultCompName]
+			checkGate(pods[1])
+			passed, _ = checkGate(pods[1])
+			Expect(passed).Should(BeTrue())
+
+			By("expect the instance is not checked before the soak time")
+			opsRes.OpsRequest.Spec.HealthGate.SoakSeconds = 60
+			Expect(testapps.ChangeObjStatus(&testCtx, pods[2], func() {
+				pods[2].Status.Conditions = []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()},
+				}
+			})).Should(Succeed())
+			passed, message = checkGate(pods[2])
+			Expect(passed).Should(BeFalse())
+			Expect(message).Should(ContainSubstring("to stay ready for 60 seconds"))
+			Expect(getOrCreateHealthGateStatus(compStatus, pods[2].Name).SucceededCount).Should(BeZero())
+		})
+
 		It("expect failed when cluster is stopped", func() {
 			By("mock cluster is stopped")
 			Expect(testapps.ChangeObjStatus(&testCtx, cluster, func() {
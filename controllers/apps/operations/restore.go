@@ -156,7 +156,11 @@ func (r RestoreOpsHandler) restoreClusterFromBackup(reqCtx intctrlutil.RequestCt
 
 	// format and validate the restore time
 	if backupType == string(dpv1alpha1.BackupTypeContinuous) {
-		restoreTimeStr, err := restore.FormatRestoreTimeAndValidate(restoreSpec.RestorePointInTime, backup)
+		windows, err := restore.GetRecoverableWindows(reqCtx, cli, backup)
+		if err != nil {
+			return nil, err
+		}
+		restoreTimeStr, err := restore.FormatRestoreTimeAndValidate(restoreSpec.RestorePointInTime, backup, windows)
 		if err != nil {
 			return nil, err
 		}
@@ -39,7 +39,7 @@ func init() {
 	stopBehaviour := OpsBehaviour{
 		FromClusterPhases: []appsv1.ClusterPhase{appsv1.StoppedClusterPhase},
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
-		QueueByCluster:    true,
+		ConflictRules:     newWorkloadOpsConflictRules(),
 		OpsHandler:        StartOpsHandler{},
 	}
 
@@ -40,7 +40,7 @@ func init() {
 	stopBehaviour := OpsBehaviour{
 		FromClusterPhases: append(appsv1.GetClusterUpRunningPhases(), appsv1.UpdatingClusterPhase),
 		ToClusterPhase:    appsv1.StoppingClusterPhase,
-		QueueByCluster:    true,
+		ConflictRules:     newWorkloadOpsConflictRules(),
 		OpsHandler:        StopOpsHandler{},
 	}
 
@@ -21,10 +21,12 @@ package operations
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -48,6 +50,8 @@ import (
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlcomp "github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 	"github.com/apecloud/kubeblocks/pkg/testutil"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -201,3 +205,30 @@ func mockComponentIsOperating(cluster *appsv1.Cluster, expectPhase appsv1.Cluste
 		}
 	})).Should(Succeed())
 }
+
+// mockComponentHealthCheck defines the healthCheck action of the component, and mocks the kb-agent client
+// to return the result of the action by the healthy function.
+func mockComponentHealthCheck(compDef *appsv1.ComponentDefinition, cluster *appsv1.Cluster, healthy func() bool) {
+	Expect(testapps.GetAndChangeObj(&testCtx, client.ObjectKeyFromObject(compDef), func(obj *appsv1.ComponentDefinition) {
+		obj.Spec.LifecycleActions = &appsv1.ComponentLifecycleActions{
+			HealthCheck: &appsv1.Action{
+				Exec: &appsv1.ExecAction{Command: []string{"/bin/sh", "-c", "exit 0"}},
+			},
+		}
+	})()).Should(Succeed())
+	Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(compDef), compDef)).Should(Succeed())
+	testapps.NewComponentFactory(testCtx.DefaultNamespace, constant.GenerateClusterComponentName(cluster.Name, defaultCompName), compDef.Name).
+		AddLabels(constant.AppInstanceLabelKey, cluster.Name).
+		AddLabels(constant.KBAppClusterUIDLabelKey, string(cluster.UID)).
+		SetReplicas(3).
+		Create(&testCtx)
+	kbaCli := kbacli.NewMockClient(gomock.NewController(GinkgoT()))
+	kbaCli.EXPECT().Action(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req proto.ActionRequest) (proto.ActionResponse, error) {
+		Expect(req.Action).Should(Equal("healthCheck"))
+		if !healthy() {
+			return proto.ActionResponse{}, fmt.Errorf("unhealthy")
+		}
+		return proto.ActionResponse{}, nil
+	}).AnyTimes()
+	kbacli.SetMockClient(kbaCli, nil)
+}
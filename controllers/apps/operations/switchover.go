@@ -54,7 +54,7 @@ func init() {
 	switchoverBehaviour := OpsBehaviour{
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
-		QueueByCluster:    true,
+		ConflictRules:     newWorkloadOpsConflictRules(),
 		OpsHandler:        switchoverOpsHandler{},
 	}
 
@@ -155,7 +155,26 @@ func doSwitchoverComponents(reqCtx intctrlutil.RequestCtx, cli client.Client, op
 				ProgressDetails: []appsv1alpha1.ProgressStatusDetail{},
 			}
 		}
-		if err := createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, &switchover); err != nil {
+		targetSwitchover := switchover
+		if needSelectSwitchoverCandidate(synthesizedComp, &switchover) {
+			candidate, details, err := selectSwitchoverCandidate(reqCtx.Ctx, cli, synthesizedComp, &switchover)
+			compStatus := opsRequest.Status.Components[switchover.ComponentName]
+			for _, detail := range details {
+				setComponentStatusProgressDetail(reqCtx.Recorder, opsRequest, &compStatus.ProgressDetails, detail)
+			}
+			opsRequest.Status.Components[switchover.ComponentName] = compStatus
+			if err != nil {
+				// record the scoring details before failing the opsRequest.
+				if patchErr := cli.Status().Patch(reqCtx.Ctx, opsRequest, patch); patchErr != nil {
+					return patchErr
+				}
+				return err
+			}
+			targetSwitchover.InstanceName = candidate
+		}
+		// jobName named with generation to distinguish different switchover jobs.
+		jobName := genSwitchoverJobName(opsRes.Cluster.Name, synthesizedComp.Name, opsRes.Cluster.Generation)
+		if err := createSwitchoverJob(reqCtx, cli, opsRes.Cluster, synthesizedComp, &targetSwitchover, jobName); err != nil {
 			return err
 		}
 	}
@@ -27,15 +27,18 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
 )
 
 var _ = Describe("", func() {
@@ -213,5 +216,80 @@ var _ = Describe("", func() {
 			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
 			Expect(err).ShouldNot(HaveOccurred())
 		})
+
+		It("Test select the switchover candidate automatically", func() {
+			compName := clusterName + "-" + defaultCompName
+			By("Creating the pods of the component, the second follower is not ready")
+			labels := map[string]string{
+				constant.AppInstanceLabelKey:    clusterName,
+				constant.KBAppComponentLabelKey: defaultCompName,
+				constant.AppManagedByLabelKey:   constant.AppName,
+			}
+			for i := int32(0); i < 3; i++ {
+				pod := testapps.NewPodFactory(testCtx.DefaultNamespace, fmt.Sprintf("%s-%d", compName, i)).
+					AddContainer(corev1.Container{Name: "mock-container-name", Image: testapps.ApeCloudMySQLImage}).
+					AddLabelsInMap(labels).
+					AddRoleLabel(defaultRole(i)).
+					AddNodeName(fmt.Sprintf("%s-node-%d", clusterName, i)).
+					Create(&testCtx).GetObject()
+				if i == 1 {
+					continue
+				}
+				Expect(testapps.ChangeObjStatus(&testCtx, pod, func() {
+					testk8s.MockPodAvailable(pod, metav1.Now())
+				})).Should(Succeed())
+			}
+
+			synthesizedComp := &component.SynthesizedComponent{
+				Namespace:   testCtx.DefaultNamespace,
+				ClusterName: clusterName,
+				Name:        defaultCompName,
+				Roles:       compDefObj.Spec.Roles,
+			}
+			switchover := &appsv1alpha1.Switchover{
+				ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+				InstanceName: KBSwitchoverCandidateInstanceForAnyPod,
+				CandidateSelector: &appsv1alpha1.SwitchoverCandidateSelector{
+					InstanceTemplates: []string{constant.EmptyInsTemplateName},
+				},
+			}
+			Expect(needSelectSwitchoverCandidate(synthesizedComp, switchover)).Should(BeTrue())
+
+			By("expect the ready follower is selected and the scoring is recorded")
+			candidate, details, err := selectSwitchoverCandidate(ctx, k8sClient, synthesizedComp, switchover)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(candidate).Should(Equal(compName + "-2"))
+			Expect(details).Should(HaveLen(2))
+			Expect(details[0].ObjectKey).Should(Equal(getProgressObjectKey(KBSwitchoverCandidateKey, compName+"-1")))
+			Expect(details[0].Status).Should(Equal(appsv1alpha1.FailedProgressStatus))
+			Expect(details[1].Status).Should(Equal(appsv1alpha1.SucceedProgressStatus))
+
+			By("expect failed if no replica matches the candidate selector")
+			switchover.CandidateSelector.InstanceTemplates = []string{"not-exist"}
+			_, _, err = selectSwitchoverCandidate(ctx, k8sClient, synthesizedComp, switchover)
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+
+			By("expect the replica on a deleted node is not selected")
+			switchover.CandidateSelector = &appsv1alpha1.SwitchoverCandidateSelector{Zones: []string{"zone-a"}}
+			_, _, err = selectSwitchoverCandidate(ctx, k8sClient, synthesizedComp, switchover)
+			Expect(intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal)).Should(BeTrue())
+
+			By("expect the replica is selected once its node is in the selected zone")
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   fmt.Sprintf("%s-node-%d", clusterName, 2),
+					Labels: map[string]string{constant.ZoneLabelKey: "zone-a"},
+				},
+			}
+			Expect(testCtx.CreateObj(ctx, node)).Should(Succeed())
+			defer func() {
+				Expect(k8sClient.Delete(ctx, node)).Should(Succeed())
+			}()
+			Eventually(func(g Gomega) {
+				candidate, _, err := selectSwitchoverCandidate(ctx, k8sClient, synthesizedComp, switchover)
+				g.Expect(err).ShouldNot(HaveOccurred())
+				g.Expect(candidate).Should(Equal(compName + "-2"))
+			}).Should(Succeed())
+		})
 	})
 })
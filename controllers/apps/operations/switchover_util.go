@@ -23,11 +23,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
+	"golang.org/x/exp/slices"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,6 +40,7 @@ import (
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/component/lifecycle"
 	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
 	jobutil "github.com/apecloud/kubeblocks/pkg/controller/job"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
@@ -53,6 +58,7 @@ const (
 	KBSwitchoverJobContainerName  = "kb-switchover-job-container"
 	KBSwitchoverCheckJobKey       = "CheckJob"
 	KBSwitchoverCheckRoleLabelKey = "CheckRoleLabel"
+	KBSwitchoverCandidateKey      = "Candidate"
 
 	KBSwitchoverCandidateName = "KB_SWITCHOVER_CANDIDATE_NAME"
 	KBSwitchoverCandidateFqdn = "KB_SWITCHOVER_CANDIDATE_FQDN"
@@ -96,13 +102,133 @@ func needDoSwitchover(ctx context.Context,
 	return true, nil
 }
 
+// needSelectSwitchoverCandidate checks whether the controller should choose the switchover candidate by itself.
+func needSelectSwitchoverCandidate(synthesizedComp *component.SynthesizedComponent, switchover *appsv1alpha1.Switchover) bool {
+	if switchover.InstanceName != KBSwitchoverCandidateInstanceForAnyPod {
+		return false
+	}
+	if switchover.CandidateSelector != nil {
+		return true
+	}
+	return synthesizedComp.LifecycleActions != nil && synthesizedComp.LifecycleActions.ReplicationLag != nil
+}
+
+// selectSwitchoverCandidate chooses the most up-to-date replica which matches the candidate selector as the new primary.
+// It returns the selected instance name and the scoring details of all the replicas.
+func selectSwitchoverCandidate(ctx context.Context,
+	cli client.Client,
+	synthesizedComp *component.SynthesizedComponent,
+	switchover *appsv1alpha1.Switchover) (string, []appsv1alpha1.ProgressStatusDetail, error) {
+	primary, err := getServiceableNWritablePod(ctx, cli, *synthesizedComp)
+	if err != nil {
+		return "", nil, err
+	}
+	pods, err := component.ListOwnedPods(ctx, cli, synthesizedComp.Namespace, synthesizedComp.ClusterName, synthesizedComp.Name)
+	if err != nil {
+		return "", nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	var (
+		candidate string
+		minLag    int64 = -1
+		details   []appsv1alpha1.ProgressStatusDetail
+	)
+	for _, pod := range pods {
+		if pod.Name == primary.Name {
+			continue
+		}
+		detail := appsv1alpha1.ProgressStatusDetail{
+			ObjectKey: getProgressObjectKey(KBSwitchoverCandidateKey, pod.Name),
+			Status:    appsv1alpha1.FailedProgressStatus,
+		}
+		lag, reason, err := scoreSwitchoverCandidate(ctx, cli, synthesizedComp, switchover.CandidateSelector, pod)
+		if err != nil {
+			return "", nil, err
+		}
+		if reason != "" {
+			detail.Message = reason
+			details = append(details, detail)
+			continue
+		}
+		detail.Status = appsv1alpha1.SucceedProgressStatus
+		detail.Message = fmt.Sprintf("replication lag: %d", lag)
+		details = append(details, detail)
+		if minLag < 0 || lag < minLag {
+			minLag = lag
+			candidate = pod.Name
+		}
+	}
+	if candidate == "" {
+		return "", details, intctrlutil.NewFatalError(fmt.Sprintf(`no available switchover candidate found for component "%s"`, synthesizedComp.Name))
+	}
+	for i := range details {
+		if details[i].ObjectKey == getProgressObjectKey(KBSwitchoverCandidateKey, candidate) {
+			details[i].Message += ", selected as the switchover candidate"
+		}
+	}
+	return candidate, details, nil
+}
+
+// scoreSwitchoverCandidate checks whether the pod is eligible to be the switchover candidate and queries its replication lag.
+// A non-empty reason is returned if the pod is not eligible.
+func scoreSwitchoverCandidate(ctx context.Context,
+	cli client.Client,
+	synthesizedComp *component.SynthesizedComponent,
+	selector *appsv1alpha1.SwitchoverCandidateSelector,
+	pod *corev1.Pod) (int64, string, error) {
+	if !intctrlutil.PodIsReady(pod) {
+		return 0, "replica is not ready", nil
+	}
+	if selector != nil && len(selector.InstanceTemplates) > 0 {
+		templateName := appsv1.GetInstanceTemplateName(synthesizedComp.ClusterName, synthesizedComp.Name, pod.Name)
+		if !slices.Contains(selector.InstanceTemplates, templateName) {
+			return 0, fmt.Sprintf(`instance template "%s" does not match the candidate selector`, templateName), nil
+		}
+	}
+	if selector != nil && len(selector.Zones) > 0 {
+		zone := ""
+		if pod.Spec.NodeName != "" {
+			node := &corev1.Node{}
+			if err := cli.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+				if apierrors.IsNotFound(err) {
+					return 0, fmt.Sprintf(`node "%s" of the replica is not found`, pod.Spec.NodeName), nil
+				}
+				return 0, "", err
+			}
+			zone = node.Labels[constant.ZoneLabelKey]
+		}
+		if !slices.Contains(selector.Zones, zone) {
+			return 0, fmt.Sprintf(`zone "%s" does not match the candidate selector`, zone), nil
+		}
+	}
+	if synthesizedComp.LifecycleActions == nil || synthesizedComp.LifecycleActions.ReplicationLag == nil {
+		return 0, "", nil
+	}
+	lfa, err := lifecycle.New(synthesizedComp, pod)
+	if err != nil {
+		return 0, "", err
+	}
+	output, err := lfa.ReplicationLag(ctx, cli, nil)
+	if err != nil {
+		return 0, fmt.Sprintf("failed to query the replication lag: %s", err.Error()), nil
+	}
+	lag, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil || lag < 0 {
+		return 0, fmt.Sprintf("invalid replication lag: %s", strings.TrimSpace(string(output))), nil
+	}
+	return lag, "", nil
+}
+
 // createSwitchoverJob creates a switchover job to do switchover.
 func createSwitchoverJob(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	cluster *appsv1.Cluster,
 	synthesizedComp *component.SynthesizedComponent,
-	switchover *appsv1alpha1.Switchover) error {
-	switchoverJob, err := renderSwitchoverCmdJob(reqCtx.Ctx, cli, cluster, synthesizedComp, switchover)
+	switchover *appsv1alpha1.Switchover,
+	jobName string) error {
+	switchoverJob, err := renderSwitchoverCmdJob(reqCtx.Ctx, cli, cluster, synthesizedComp, switchover, jobName)
 	if err != nil {
 		return err
 	}
@@ -176,7 +302,8 @@ func renderSwitchoverCmdJob(ctx context.Context,
 	cli client.Client,
 	cluster *appsv1.Cluster,
 	synthesizedComp *component.SynthesizedComponent,
-	switchover *appsv1alpha1.Switchover) (*batchv1.Job, error) {
+	switchover *appsv1alpha1.Switchover,
+	jobName string) (*batchv1.Job, error) {
 	if synthesizedComp.LifecycleActions == nil || synthesizedComp.LifecycleActions.Switchover == nil || switchover == nil {
 		return nil, errors.New("switchover spec not found")
 	}
@@ -193,8 +320,6 @@ func renderSwitchoverCmdJob(ctx context.Context,
 			return nil, errors.New("switchover exec action not found")
 		}
 
-		// jobName named with generation to distinguish different switchover jobs.
-		jobName := genSwitchoverJobName(cluster.Name, synthesizedComp.Name, cluster.Generation)
 		job := &batchv1.Job{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: cluster.Namespace,
@@ -248,6 +373,12 @@ func genSwitchoverJobName(clusterName, componentName string, generation int64) s
 	return fmt.Sprintf("%s-%s-%s-%d", KBSwitchoverJobNamePrefix, clusterName, componentName, generation)
 }
 
+// genOpsSwitchoverJobName generates the name of the switchover job created by the opsRequest,
+// which keeps unchanged while the cluster is updated by the opsRequest.
+func genOpsSwitchoverJobName(clusterName, componentName string, opsRequest *appsv1alpha1.OpsRequest) string {
+	return fmt.Sprintf("%s-%s-%s-%s", KBSwitchoverJobNamePrefix, clusterName, componentName, opsRequest.UID[:8])
+}
+
 // getSwitchoverCmdJobLabel gets the labels for job that execute the switchover commands.
 func getSwitchoverCmdJobLabel(clusterName, componentName string) map[string]string {
 	return map[string]string{
@@ -63,11 +63,10 @@ type OpsBehaviour struct {
 	// IsClusterCreation indicates whether the opsRequest will create a new cluster.
 	IsClusterCreation bool
 
-	// QueueByCluster indicates that the operation is queued for execution within the cluster-wide scope.
-	QueueByCluster bool
-
-	// QueueWithSelf indicates that the operation is queued for execution within opsType scope.
-	QueueBySelf bool
+	// ConflictRules declares the ops types that the operation conflicts with.
+	// The operation is queued until the conflicting opsRequests are completed.
+	// If it is empty, the operation is not queued.
+	ConflictRules []OpsConflictRule
 
 	OpsHandler OpsHandler
 }
@@ -29,6 +29,7 @@ import (
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/component"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 )
@@ -38,12 +39,14 @@ type upgradeOpsHandler struct{}
 var _ OpsHandler = upgradeOpsHandler{}
 
 func init() {
+	upgradeHandler := upgradeOpsHandler{}
 	upgradeBehaviour := OpsBehaviour{
 		// if cluster is Abnormal or Failed, new opsRequest may can repair it.
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
-		QueueByCluster:    true,
-		OpsHandler:        upgradeOpsHandler{},
+		ConflictRules:     newWorkloadOpsConflictRules(),
+		OpsHandler:        upgradeHandler,
+		CancelFunc:        upgradeHandler.Cancel,
 	}
 
 	opsMgr := GetOpsManager()
@@ -64,6 +67,10 @@ func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		compOpsHelper = newComponentOpsHelper(upgradeSpec.Components)
 		if err := compOpsHelper.updateClusterComponentsAndShardings(opsRes.Cluster, func(compSpec *appsv1.ClusterComponentSpec, obj ComponentOpsInterface) error {
 			upgradeComp := obj.(appsv1alpha1.UpgradeComponent)
+			if upgradeComp.Canary != nil {
+				// only the canary instances are upgraded until the canary is promoted.
+				return u.deployCanary(reqCtx, cli, opsRes, compSpec, upgradeComp)
+			}
 			if u.needUpdateCompDef(upgradeComp, opsRes.Cluster) {
 				compSpec.ComponentDef = *upgradeComp.ComponentDefinitionName
 			}
@@ -91,6 +98,19 @@ func (u upgradeOpsHandler) Action(reqCtx intctrlutil.RequestCtx, cli client.Clie
 		}); err != nil {
 		return err
 	}
+	// the pods will be rolled out one by one with the health gate during reconciling,
+	// the components with the canary are paused after the canary is promoted.
+	var pausedComps []appsv1alpha1.UpgradeComponent
+	for _, v := range upgradeSpec.Components {
+		if v.Canary == nil && opsRes.OpsRequest.Spec.HealthGate != nil {
+			pausedComps = append(pausedComps, v)
+		}
+	}
+	if len(pausedComps) > 0 {
+		if err := pauseInstanceSetsRollout(reqCtx, cli, opsRes, newComponentOpsHelper(pausedComps)); err != nil {
+			return err
+		}
+	}
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
 
@@ -111,6 +131,12 @@ func (u upgradeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 			return opsRes.OpsRequest.Status.Phase, 0, err
 		}
 	}
+	healthGate := opsRes.OpsRequest.Spec.HealthGate
+	if healthGate != nil {
+		if err = resumeHealthGateIfRequested(reqCtx, cli, opsRes); err != nil {
+			return "", 0, err
+		}
+	}
 	componentUpgraded := func(cluster *appsv1.Cluster,
 		lastCompConfiguration appsv1alpha1.LastComponentConfiguration,
 		upgradeComp appsv1alpha1.UpgradeComponent) bool {
@@ -148,9 +174,20 @@ func (u upgradeOpsHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli cl
 		opsRes *OpsResource,
 		pgRes *progressResource,
 		compStatus *appsv1alpha1.OpsRequestComponentStatus) (expectProgressCount int32, completedCount int32, err error) {
+		if upgradeComp, ok := pgRes.compOps.(appsv1alpha1.UpgradeComponent); ok && upgradeComp.Canary != nil && compStatus.Canary != nil {
+			return u.handleCanaryProgress(reqCtx, cli, opsRes, pgRes, compStatus, upgradeComp, podApplyCompOps)
+		}
+		if healthGate != nil && opsRes.OpsRequest.Status.Phase != appsv1alpha1.OpsCancellingPhase {
+			return handleGatedRolloutProgress(reqCtx, cli, opsRes, pgRes, compStatus, podApplyCompOps)
+		}
 		return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, podApplyCompOps)
 	}
-	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "upgrade", handleUpgradeProgress)
+	opsPhase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "upgrade", handleUpgradeProgress)
+	if err == nil && healthGate != nil && opsPhase == appsv1alpha1.OpsRunningPhase && requeueAfter == 0 {
+		// requeue to check the health gate periodically.
+		requeueAfter = time.Duration(getHealthGatePeriodSeconds(healthGate)) * time.Second
+	}
+	return opsPhase, requeueAfter, err
 }
 
 // SaveLastConfiguration records last configuration to the OpsRequest.status.lastConfiguration
@@ -165,8 +202,28 @@ func (u upgradeOpsHandler) SaveLastConfiguration(reqCtx intctrlutil.RequestCtx,
 	return nil
 }
 
+// Cancel this function defines the cancel upgrade action.
+// It rolls back the componentDefinition and serviceVersion of the components, the upgraded pods will be rolled back
+// and the pods which have not been upgraded are kept as they are.
+func (u upgradeOpsHandler) Cancel(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	if u.existClusterVersion(opsRes.OpsRequest) {
+		return intctrlutil.NewErrorf(intctrlutil.ErrorIgnoreCancel, "does not support to cancel the upgrade with clusterVersion")
+	}
+	// the InstanceSets roll back the pods by themselves.
+	if err := releaseInstanceSetsRollout(reqCtx.Ctx, cli, opsRes); err != nil {
+		return err
+	}
+	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.Upgrade.Components)
+	return compOpsHelper.cancelComponentOps(reqCtx.Ctx, cli, opsRes, func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec) {
+		comp.ComponentDef = lastConfig.ComponentDefinitionName
+		comp.ServiceVersion = lastConfig.ServiceVersion
+		comp.Instances = removeCanaryInsTemplate(comp.Instances)
+	})
+}
+
 // getComponentDefMapWithUpdatedImages gets the desired componentDefinition map
 // that is updated with the corresponding images of the ComponentDefinition and service version.
+// If the canary instances are being upgraded, the images of the canary instance template are desired.
 func (u upgradeOpsHandler) getComponentDefMapWithUpdatedImages(reqCtx intctrlutil.RequestCtx,
 	cli client.Client,
 	opsRes *OpsResource) (map[string]*appsv1.ComponentDefinition, error) {
@@ -177,11 +234,23 @@ func (u upgradeOpsHandler) getComponentDefMapWithUpdatedImages(reqCtx intctrluti
 			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`"can not found the component "%s" in the cluster "%s"`,
 				v.ComponentName, opsRes.Cluster.Name))
 		}
-		compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, compSpec.ComponentDef)
+		compDefName, serviceVersion := compSpec.ComponentDef, compSpec.ServiceVersion
+		for _, ins := range compSpec.Instances {
+			if ins.Name != constant.CanaryInsTemplateName {
+				continue
+			}
+			if len(ins.CompDef) > 0 {
+				compDefName = ins.CompDef
+			}
+			if len(ins.ServiceVersion) > 0 {
+				serviceVersion = ins.ServiceVersion
+			}
+		}
+		compDef, err := component.GetCompDefByName(reqCtx.Ctx, cli, compDefName)
 		if err != nil {
 			return nil, err
 		}
-		if err = component.UpdateCompDefinitionImages4ServiceVersion(reqCtx.Ctx, cli, compDef, compSpec.ServiceVersion); err != nil {
+		if err = component.UpdateCompDefinitionImages4ServiceVersion(reqCtx.Ctx, cli, compDef, serviceVersion); err != nil {
 			return nil, err
 		}
 		compDefMap[v.ComponentName] = compDef
@@ -0,0 +1,383 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/instanceset"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+// deployCanary chooses the canary instances and adds a temporary instance template to the component,
+// the template overrides the canary instances in place with the images of the target serviceVersion and
+// componentDefinition, so only the canary instances are re-created and their PVCs are retained.
+// The rest instances are kept until the canary is promoted.
+func (u upgradeOpsHandler) deployCanary(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1.ClusterComponentSpec,
+	upgradeComp appsv1alpha1.UpgradeComponent) error {
+	canaryInstances, ordinals, err := u.chooseCanaryInstances(reqCtx, cli, opsRes, compSpec, upgradeComp.Canary)
+	if err != nil {
+		return err
+	}
+	canaryTemplate := appsv1.InstanceTemplate{
+		Name:     constant.CanaryInsTemplateName,
+		Replicas: pointer.Int32(int32(len(ordinals))),
+		Ordinals: ordinals,
+	}
+	if upgradeComp.ServiceVersion != nil {
+		canaryTemplate.ServiceVersion = *upgradeComp.ServiceVersion
+	}
+	if u.needUpdateCompDef(upgradeComp, opsRes.Cluster) {
+		canaryTemplate.CompDef = *upgradeComp.ComponentDefinitionName
+	}
+	compSpec.Instances = append(removeCanaryInsTemplate(compSpec.Instances), canaryTemplate)
+
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Components == nil {
+		opsRequest.Status.Components = map[string]appsv1alpha1.OpsRequestComponentStatus{}
+	}
+	compStatus := opsRequest.Status.Components[upgradeComp.ComponentName]
+	compStatus.Canary = &appsv1alpha1.CanaryUpgradeStatus{
+		Phase:           appsv1alpha1.CanaryDeployingPhase,
+		CanaryInstances: canaryInstances,
+	}
+	opsRequest.Status.Components[upgradeComp.ComponentName] = compStatus
+	return nil
+}
+
+// chooseCanaryInstances chooses the instances of the default instance template to be upgraded first,
+// and returns the names and ordinals of them.
+// If the replicas of the canary is specified, the first instances in the update order of the InstanceSet are chosen.
+func (u upgradeOpsHandler) chooseCanaryInstances(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1.ClusterComponentSpec,
+	canary *appsv1alpha1.CanaryUpgrade) ([]string, []int32, error) {
+	var canaryInstances []string
+	if canary.Replicas == nil {
+		for _, ordinal := range canary.Ordinals {
+			canaryInstances = append(canaryInstances, constant.GeneratePodName(opsRes.Cluster.Name, compSpec.Name, int(ordinal)))
+		}
+		return canaryInstances, canary.Ordinals, nil
+	}
+	its := &workloads.InstanceSet{}
+	itsKey := client.ObjectKey{
+		Name:      constant.GenerateWorkloadNamePattern(opsRes.Cluster.Name, compSpec.Name),
+		Namespace: opsRes.Cluster.Namespace,
+	}
+	if err := cli.Get(reqCtx.Ctx, itsKey, its); err != nil {
+		return nil, nil, err
+	}
+	podSet, err := component.GenerateAllPodNamesToSet(compSpec.Replicas, compSpec.Instances, compSpec.OfflineInstances,
+		opsRes.Cluster.Name, compSpec.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, compSpec.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	var defaultPods []corev1.Pod
+	for i := range pods {
+		if templateName, ok := podSet[pods[i].Name]; ok && templateName == constant.EmptyInsTemplateName {
+			defaultPods = append(defaultPods, *pods[i])
+		}
+	}
+	if int32(len(defaultPods)) < *canary.Replicas {
+		return nil, nil, intctrlutil.NewFatalError(fmt.Sprintf(`the canary replicas %d of the component "%s" must not be greater than the existing instances %d of the default instance template`,
+			*canary.Replicas, compSpec.Name, len(defaultPods)))
+	}
+	instanceset.SortPods(defaultPods, instanceset.ComposeRolePriorityMap(its.Spec.Roles), false)
+	var ordinals []int32
+	for i := int32(0); i < *canary.Replicas; i++ {
+		_, ordinal := instanceset.ParseParentNameAndOrdinal(defaultPods[i].Name)
+		canaryInstances = append(canaryInstances, defaultPods[i].Name)
+		ordinals = append(ordinals, int32(ordinal))
+	}
+	return canaryInstances, ordinals, nil
+}
+
+// handleCanaryProgress handles the progress of the canary upgrade.
+// The canary instances are upgraded and verified first, then the canary is promoted or rolled back.
+// After the canary is promoted, the InstanceSet rolls out the rest instances.
+func (u upgradeOpsHandler) handleCanaryProgress(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	upgradeComp appsv1alpha1.UpgradeComponent,
+	podApplyCompOps func(*appsv1alpha1.OpsRequest, *corev1.Pod, ComponentOpsInterface, string) bool) (int32, int32, error) {
+	canaryStatus := compStatus.Canary
+	// the rest instances are not updated before the canary is promoted, so no need to wait for the component to complete.
+	pgRes.noWaitComponentCompleted = true
+	pods, err := component.ListOwnedPods(reqCtx.Ctx, cli, opsRes.Cluster.Namespace, opsRes.Cluster.Name, pgRes.fullComponentName)
+	if err != nil {
+		return 0, 0, err
+	}
+	podMap := map[string]*corev1.Pod{}
+	canaryStatus.BaselineInstances = nil
+	for _, pod := range pods {
+		podMap[pod.Name] = pod
+		if !slices.Contains(canaryStatus.CanaryInstances, pod.Name) {
+			canaryStatus.BaselineInstances = append(canaryStatus.BaselineInstances, pod.Name)
+		}
+	}
+	expectCount := int32(len(canaryStatus.CanaryInstances))
+	opsRequest := opsRes.OpsRequest
+	if opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase && canaryStatus.Phase != appsv1alpha1.CanaryPromotedPhase {
+		// the canary instances are rolled back by the InstanceSet after the opsRequest is cancelled.
+		return u.handleCanaryRevertedProgress(opsRes, pgRes, compStatus, podMap, podApplyCompOps, false)
+	}
+	switch canaryStatus.Phase {
+	case appsv1alpha1.CanaryDeployingPhase:
+		if !u.upgradeCanaryInstances(opsRes, pgRes, compStatus, podMap, podApplyCompOps) {
+			return expectCount, 0, nil
+		}
+		canaryStatus.Phase = appsv1alpha1.CanaryVerifyingPhase
+		fallthrough
+	case appsv1alpha1.CanaryVerifyingPhase:
+		var canaryPods []*corev1.Pod
+		for _, podName := range canaryStatus.CanaryInstances {
+			if pod, ok := podMap[podName]; ok {
+				canaryPods = append(canaryPods, pod)
+			}
+		}
+		decision, verifiedCount, err := u.verifyCanary(reqCtx, cli, opsRes, pgRes, compStatus, upgradeComp.Canary, canaryPods)
+		if err != nil || decision == "" {
+			return expectCount, verifiedCount, err
+		}
+		if err = u.finishCanary(reqCtx, cli, opsRes, pgRes.clusterComponent, upgradeComp, canaryStatus, decision); err != nil {
+			return expectCount, verifiedCount, err
+		}
+		// waits for the next reconciliation to handle the progress of the updated cluster.
+		return expectCount, 0, nil
+	case appsv1alpha1.CanaryPromotedPhase:
+		canaryStatus.Message = "the canary is promoted, upgrading the rest instances"
+		pgRes.noWaitComponentCompleted = false
+		if opsRequest.Spec.HealthGate != nil {
+			return handleGatedRolloutProgress(reqCtx, cli, opsRes, pgRes, compStatus, podApplyCompOps)
+		}
+		return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, podApplyCompOps)
+	case appsv1alpha1.CanaryRolledBackPhase:
+		return u.handleCanaryRevertedProgress(opsRes, pgRes, compStatus, podMap, podApplyCompOps, true)
+	default:
+		return 0, 0, nil
+	}
+}
+
+// upgradeCanaryInstances checks whether the canary instances are re-created by the InstanceSet with the
+// temporary instance template, and returns whether all canary instances are upgraded and ready.
+func (u upgradeOpsHandler) upgradeCanaryInstances(opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	podMap map[string]*corev1.Pod,
+	podApplyCompOps func(*appsv1alpha1.OpsRequest, *corev1.Pod, ComponentOpsInterface, string) bool) bool {
+	var (
+		opsRequest = opsRes.OpsRequest
+		deployed   = true
+	)
+	for _, podName := range compStatus.Canary.CanaryInstances {
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: getProgressObjectKey(constant.PodKind, podName)}
+		pod, ok := podMap[podName]
+		switch {
+		case !ok || !pod.DeletionTimestamp.IsZero() || !podApplyCompOps(opsRequest, pod, pgRes.compOps, constant.CanaryInsTemplateName):
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("upgrading canary pod %s", podName))
+		case !intctrlutil.PodIsReady(pod):
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("waiting for canary pod %s to be ready", podName))
+		default:
+			continue
+		}
+		deployed = false
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+	}
+	if !deployed {
+		compStatus.Canary.Message = "waiting for the canary instances to be upgraded"
+	}
+	return deployed
+}
+
+// verifyCanary verifies the canary instances by the health gate or the manual decision,
+// and returns the decision and the count of the verified canary instances.
+func (u upgradeOpsHandler) verifyCanary(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	canary *appsv1alpha1.CanaryUpgrade,
+	canaryPods []*corev1.Pod) (appsv1alpha1.CanaryDecision, int32, error) {
+	var (
+		opsRequest    = opsRes.OpsRequest
+		canaryStatus  = compStatus.Canary
+		verifiedCount int32
+	)
+	if canary.HealthGate == nil {
+		decision := appsv1alpha1.CanaryDecision(opsRequest.Annotations[constant.OpsCanaryDecisionAnnotationKey])
+		switch decision {
+		case appsv1alpha1.CanaryPromoteDecision:
+			canaryStatus.Message = "the canary is promoted manually"
+		case appsv1alpha1.CanaryRollbackDecision:
+			canaryStatus.Message = "the canary is rolled back manually"
+		default:
+			canaryStatus.Message = fmt.Sprintf(`waiting for the annotation "%s" to promote or roll back the canary`,
+				constant.OpsCanaryDecisionAnnotationKey)
+			return "", 0, nil
+		}
+		return decision, int32(len(canaryPods)), nil
+	}
+	for _, pod := range canaryPods {
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: getProgressObjectKey(constant.PodKind, pod.Name)}
+		passed, _, message, err := checkInstanceHealthGate(reqCtx, cli, opsRes, pgRes, canary.HealthGate, compStatus, pod)
+		if err != nil {
+			return "", 0, err
+		}
+		if passed {
+			verifiedCount += 1
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus, fmt.Sprintf("canary pod %s passed the health gate", pod.Name))
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+			continue
+		}
+		gateStatus := getOrCreateHealthGateStatus(compStatus, pod.Name)
+		if gateStatus.FailedCount >= getHealthGateFailureThreshold(canary.HealthGate) {
+			canaryStatus.Message = message
+			return appsv1alpha1.CanaryRollbackDecision, verifiedCount, nil
+		}
+		progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, message)
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRequest, &compStatus.ProgressDetails, progressDetail)
+	}
+	if verifiedCount < int32(len(canaryStatus.CanaryInstances)) {
+		canaryStatus.Message = "waiting for the canary instances to pass the health gate"
+		return "", verifiedCount, nil
+	}
+	canaryStatus.Message = "all canary instances passed the health gate"
+	return appsv1alpha1.CanaryPromoteDecision, verifiedCount, nil
+}
+
+// finishCanary promotes or rolls back the canary according to the decision.
+// If the canary is promoted, the target serviceVersion and componentDefinition are folded back into the component
+// and the temporary instance template is removed, the canary instances are kept as they are already updated,
+// and the InstanceSet rolls out the rest instances, or the opsRequest rolls them out one by one if the health gate
+// is specified.
+// If the canary is rolled back, the temporary instance template is removed and the InstanceSet rolls back
+// the canary instances.
+func (u upgradeOpsHandler) finishCanary(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	opsRes *OpsResource,
+	compSpec *appsv1.ClusterComponentSpec,
+	upgradeComp appsv1alpha1.UpgradeComponent,
+	canaryStatus *appsv1alpha1.CanaryUpgradeStatus,
+	decision appsv1alpha1.CanaryDecision) error {
+	if decision == appsv1alpha1.CanaryPromoteDecision {
+		if opsRes.OpsRequest.Spec.HealthGate != nil {
+			// pause the rollout before the rest instances are updated.
+			compOpsHelper := newComponentOpsHelper([]appsv1alpha1.UpgradeComponent{upgradeComp})
+			if err := pauseInstanceSetsRollout(reqCtx, cli, opsRes, compOpsHelper); err != nil {
+				return err
+			}
+		}
+		if u.needUpdateCompDef(upgradeComp, opsRes.Cluster) {
+			compSpec.ComponentDef = *upgradeComp.ComponentDefinitionName
+		}
+		if upgradeComp.ServiceVersion != nil {
+			compSpec.ServiceVersion = *upgradeComp.ServiceVersion
+		}
+	}
+	compSpec.Instances = removeCanaryInsTemplate(compSpec.Instances)
+	if err := cli.Update(reqCtx.Ctx, opsRes.Cluster); err != nil {
+		return err
+	}
+	if decision == appsv1alpha1.CanaryPromoteDecision {
+		canaryStatus.Phase = appsv1alpha1.CanaryPromotedPhase
+	} else {
+		canaryStatus.Phase = appsv1alpha1.CanaryRolledBackPhase
+	}
+	return nil
+}
+
+// handleCanaryRevertedProgress waits for the canary instances to be reverted to the original serviceVersion.
+// If the canary is rolled back, the canary instances will be marked as failed.
+func (u upgradeOpsHandler) handleCanaryRevertedProgress(opsRes *OpsResource,
+	pgRes *progressResource,
+	compStatus *appsv1alpha1.OpsRequestComponentStatus,
+	podMap map[string]*corev1.Pod,
+	podApplyCompOps func(*appsv1alpha1.OpsRequest, *corev1.Pod, ComponentOpsInterface, string) bool,
+	rolledBack bool) (int32, int32, error) {
+	var (
+		canaryStatus   = compStatus.Canary
+		expectCount    = int32(len(canaryStatus.CanaryInstances))
+		completedCount int32
+	)
+	for _, podName := range canaryStatus.CanaryInstances {
+		objectKey := getProgressObjectKey(constant.PodKind, podName)
+		progressDetail := appsv1alpha1.ProgressStatusDetail{ObjectKey: objectKey}
+		pod, ok := podMap[podName]
+		if !ok || !pod.DeletionTimestamp.IsZero() || !intctrlutil.PodIsReady(pod) ||
+			!podApplyCompOps(opsRes.OpsRequest, pod, pgRes.compOps, "") {
+			canaryStatus.Message = "waiting for the canary instances to be rolled back"
+			progressDetail.SetStatusAndMessage(appsv1alpha1.ProcessingProgressStatus, fmt.Sprintf("rolling back canary pod %s", podName))
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+			continue
+		}
+		if !rolledBack {
+			completedCount += 1
+			progressDetail.SetStatusAndMessage(appsv1alpha1.SucceedProgressStatus, fmt.Sprintf("canary pod %s is rolled back", podName))
+			setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+			continue
+		}
+		// the failed progressDetail should be recorded before the opsRequest is completed.
+		if existing := findStatusProgressDetail(compStatus.ProgressDetails, objectKey); existing != nil &&
+			existing.Status == appsv1alpha1.FailedProgressStatus {
+			completedCount += 1
+			continue
+		}
+		progressDetail.SetStatusAndMessage(appsv1alpha1.FailedProgressStatus, fmt.Sprintf("canary pod %s is rolled back", podName))
+		setComponentStatusProgressDetail(opsRes.Recorder, opsRes.OpsRequest, &compStatus.ProgressDetails, progressDetail)
+	}
+	return expectCount, completedCount, nil
+}
+
+// removeCanaryInsTemplate removes the temporary instance template of the canary upgrade.
+func removeCanaryInsTemplate(instances []appsv1.InstanceTemplate) []appsv1.InstanceTemplate {
+	return slices.DeleteFunc(slices.Clone(instances), func(ins appsv1.InstanceTemplate) bool {
+		return ins.Name == constant.CanaryInsTemplateName
+	})
+}
+
+// hasCanaryUpgrade checks whether the opsRequest upgrades any component with the canary.
+func hasCanaryUpgrade(opsRequest *appsv1alpha1.OpsRequest) bool {
+	if opsRequest.Spec.Type != appsv1alpha1.UpgradeType || opsRequest.Spec.Upgrade == nil {
+		return false
+	}
+	return slices.ContainsFunc(opsRequest.Spec.Upgrade.Components, func(v appsv1alpha1.UpgradeComponent) bool {
+		return v.Canary != nil
+	})
+}
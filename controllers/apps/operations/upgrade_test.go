@@ -20,6 +20,9 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package operations
 
 import (
+	"fmt"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -29,6 +32,8 @@ import (
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
@@ -385,6 +390,124 @@ var _ = Describe("Upgrade OpsRequest", func() {
 				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(""))
 			})).Should(Succeed())
 		})
+		It("Test cancel upgrade OpsRequest", func() {
+			By("init operations resources")
+			compDef1, compDef2, opsRes := initOpsResWithComponentDef(true)
+
+			By("create Upgrade Ops")
+			opsRes.OpsRequest = createUpgradeOpsRequest(opsRes.Cluster, appsv1alpha1.Upgrade{
+				Components: []appsv1alpha1.UpgradeComponent{
+					{
+						ComponentOps:            appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+						ServiceVersion:          pointer.String(serviceVer2),
+						ComponentDefinitionName: &compDef2.Name,
+					},
+				},
+			})
+
+			By("expect for this opsRequest is Running")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			makeUpgradeOpsIsRunning(reqCtx, opsRes)
+
+			By("cancel the upgrade opsRequest")
+			cancelOpsRequest(reqCtx, opsRes, time.Now())
+			Expect(opsRes.OpsRequest.Status.CancelResult).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.CancelResult.Reverted).Should(HaveLen(1))
+
+			By("expect the componentDefinition and serviceVersion are rolled back")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ComponentDef).Should(Equal(compDef1.Name))
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVer0))
+			})).Should(Succeed())
+		})
+		It("Test canary upgrade OpsRequest", func() {
+			By("init operations resources with the InstanceSet and pods")
+			_, _, opsRes := initOpsResWithComponentDef(true)
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			initInstanceSetPods(ctx, k8sClient, opsRes)
+
+			By("create Upgrade Ops with the canary")
+			opsRes.OpsRequest = createUpgradeOpsRequest(opsRes.Cluster, appsv1alpha1.Upgrade{
+				Components: []appsv1alpha1.UpgradeComponent{
+					{
+						ComponentOps:   appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+						ServiceVersion: pointer.String(serviceVer1),
+						Canary:         &appsv1alpha1.CanaryUpgrade{Ordinals: []int32{1}},
+					},
+				},
+			})
+
+			By("expect the canary instance template is added and the serviceVersion of the component is kept")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			makeUpgradeOpsIsRunning(reqCtx, opsRes)
+			canaryPodName := fmt.Sprintf("%s-%s-1", clusterName, defaultCompName)
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				compSpec := cluster.Spec.ComponentSpecs[0]
+				g.Expect(compSpec.ServiceVersion).Should(Equal(serviceVer0))
+				g.Expect(compSpec.Instances).Should(HaveLen(1))
+				g.Expect(compSpec.Instances[0].Name).Should(Equal(constant.CanaryInsTemplateName))
+				g.Expect(compSpec.Instances[0].Replicas).Should(Equal(pointer.Int32(1)))
+				g.Expect(compSpec.Instances[0].Ordinals).Should(Equal([]int32{1}))
+				g.Expect(compSpec.Instances[0].ServiceVersion).Should(Equal(serviceVer1))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, fetched *workloads.InstanceSet) {
+				g.Expect(fetched.Annotations).ShouldNot(HaveKey(constant.OpsGatedRolloutAnnotationKey))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				canaryStatus := ops.Status.Components[defaultCompName].Canary
+				g.Expect(canaryStatus).ShouldNot(BeNil())
+				g.Expect(canaryStatus.Phase).Should(Equal(appsv1alpha1.CanaryDeployingPhase))
+				g.Expect(canaryStatus.CanaryInstances).Should(ConsistOf(canaryPodName))
+			})).Should(Succeed())
+
+			By("expect the canary is deploying before the canary pod is upgraded")
+			_, err := GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRes.OpsRequest.Status.Components[defaultCompName].Canary.Phase).Should(Equal(appsv1alpha1.CanaryDeployingPhase))
+			canaryPodKey := client.ObjectKey{Name: canaryPodName, Namespace: testCtx.DefaultNamespace}
+			Consistently(testapps.CheckObj(&testCtx, canaryPodKey, func(g Gomega, pod *corev1.Pod) {
+				g.Expect(pod.DeletionTimestamp).Should(BeNil())
+			})).Should(Succeed())
+
+			By("mock the canary pod is re-created with the target image, expect the canary is verifying")
+			canaryPod := &corev1.Pod{}
+			Expect(k8sClient.Get(ctx, canaryPodKey, canaryPod)).Should(Succeed())
+			Expect(testapps.ChangeObj(&testCtx, canaryPod, func(pod *corev1.Pod) {
+				pod.Spec.Containers[0].Image = testapps.AppImage(testapps.AppName, release1)
+			})).Should(Succeed())
+			Expect(testapps.ChangeObjStatus(&testCtx, canaryPod, func() {
+				for i := range canaryPod.Status.ContainerStatuses {
+					canaryPod.Status.ContainerStatuses[i].Image = testapps.AppImage(testapps.AppName, release1)
+				}
+			})).Should(Succeed())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Components[defaultCompName].Canary.Phase).Should(Equal(appsv1alpha1.CanaryVerifyingPhase))
+			})).Should(Succeed())
+
+			By("promote the canary manually")
+			Expect(testapps.ChangeObj(&testCtx, opsRes.OpsRequest, func(ops *appsv1alpha1.OpsRequest) {
+				if ops.Annotations == nil {
+					ops.Annotations = map[string]string{}
+				}
+				ops.Annotations[constant.OpsCanaryDecisionAnnotationKey] = string(appsv1alpha1.CanaryPromoteDecision)
+			})).Should(Succeed())
+			_, err = GetOpsManager().Reconcile(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("expect the canary is promoted and folded back into the component")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.OpsRequest), func(g Gomega, ops *appsv1alpha1.OpsRequest) {
+				g.Expect(ops.Status.Components[defaultCompName].Canary.Phase).Should(Equal(appsv1alpha1.CanaryPromotedPhase))
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsRes.Cluster), func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.ComponentSpecs[0].ServiceVersion).Should(Equal(serviceVer1))
+				g.Expect(cluster.Spec.ComponentSpecs[0].Instances).Should(BeEmpty())
+			})).Should(Succeed())
+			Consistently(testapps.CheckObj(&testCtx, canaryPodKey, func(g Gomega, pod *corev1.Pod) {
+				g.Expect(pod.DeletionTimestamp).Should(BeNil())
+			})).Should(Succeed())
+		})
 		// TODO: add case with ClusterDefinition and topology
 	})
 })
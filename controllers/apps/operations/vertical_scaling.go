@@ -44,7 +44,7 @@ func init() {
 		FromClusterPhases: appsv1.GetClusterUpRunningPhases(),
 		ToClusterPhase:    appsv1.UpdatingClusterPhase,
 		OpsHandler:        vsHandler,
-		QueueByCluster:    true,
+		ConflictRules:     newWorkloadOpsConflictRules(),
 		CancelFunc:        vsHandler.Cancel,
 	}
 
@@ -92,6 +92,12 @@ func (vs verticalScalingHandler) Action(reqCtx intctrlutil.RequestCtx, cli clien
 	if err := compOpsSet.updateClusterComponentsAndShardings(opsRes.Cluster, applyVerticalScaling); err != nil {
 		return err
 	}
+	if opsRes.OpsRequest.Spec.HealthGate != nil {
+		// the pods will be rolled out one by one with the health gate during reconciling.
+		if err := pauseInstanceSetsRollout(reqCtx, cli, opsRes, compOpsSet); err != nil {
+			return err
+		}
+	}
 	return cli.Update(reqCtx.Ctx, opsRes.Cluster)
 }
 
@@ -99,6 +105,12 @@ func (vs verticalScalingHandler) Action(reqCtx intctrlutil.RequestCtx, cli clien
 // the Reconcile function for vertical scaling opsRequest.
 func (vs verticalScalingHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) (appsv1alpha1.OpsPhase, time.Duration, error) {
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
+	healthGate := opsRes.OpsRequest.Spec.HealthGate
+	if healthGate != nil {
+		if err := resumeHealthGateIfRequested(reqCtx, cli, opsRes); err != nil {
+			return "", 0, err
+		}
+	}
 	handleComponentStatusProgressForVS := func(
 		reqCtx intctrlutil.RequestCtx,
 		cli client.Client,
@@ -144,9 +156,17 @@ func (vs verticalScalingHandler) ReconcileAction(reqCtx intctrlutil.RequestCtx,
 			}
 			pgRes.updatedPodSet = updatedPodSet
 		}
+		if healthGate != nil && opsRes.OpsRequest.Status.Phase != appsv1alpha1.OpsCancellingPhase {
+			return handleGatedRolloutProgress(reqCtx, cli, opsRes, pgRes, compStatus, vs.podApplyCompOps)
+		}
 		return handleComponentStatusProgress(reqCtx, cli, opsRes, pgRes, compStatus, vs.podApplyCompOps)
 	}
-	return compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "vertical scale", handleComponentStatusProgressForVS)
+	opsPhase, requeueAfter, err := compOpsHelper.reconcileActionWithComponentOps(reqCtx, cli, opsRes, "vertical scale", handleComponentStatusProgressForVS)
+	if err == nil && healthGate != nil && opsPhase == appsv1alpha1.OpsRunningPhase && requeueAfter == 0 {
+		// requeue to check the health gate periodically.
+		requeueAfter = time.Duration(getHealthGatePeriodSeconds(healthGate)) * time.Second
+	}
+	return opsPhase, requeueAfter, err
 }
 
 func (vs verticalScalingHandler) verticalScalingComp(verticalScaling appsv1alpha1.VerticalScaling) bool {
@@ -223,6 +243,10 @@ func (vs verticalScalingHandler) SaveLastConfiguration(reqCtx intctrlutil.Reques
 
 // Cancel this function defines the cancel verticalScaling action.
 func (vs verticalScalingHandler) Cancel(reqCxt intctrlutil.RequestCtx, cli client.Client, opsRes *OpsResource) error {
+	// the InstanceSets roll back the pods by themselves.
+	if err := releaseInstanceSetsRollout(reqCxt.Ctx, cli, opsRes); err != nil {
+		return err
+	}
 	compOpsHelper := newComponentOpsHelper(opsRes.OpsRequest.Spec.VerticalScalingList)
 	return compOpsHelper.cancelComponentOps(reqCxt.Ctx, cli, opsRes, func(lastConfig *appsv1alpha1.LastComponentConfiguration, comp *appsv1.ClusterComponentSpec) {
 		comp.Resources = lastConfig.ResourceRequirements
@@ -28,14 +28,17 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
 	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	workloads "github.com/apecloud/kubeblocks/apis/workloads/v1"
 	opsutil "github.com/apecloud/kubeblocks/controllers/apps/operations/util"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	"github.com/apecloud/kubeblocks/pkg/generics"
+	kbacli "github.com/apecloud/kubeblocks/pkg/kbagent/client"
 	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
 	testk8s "github.com/apecloud/kubeblocks/pkg/testutil/k8s"
 )
@@ -68,7 +71,10 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 
 	BeforeEach(cleanEnv)
 
-	AfterEach(cleanEnv)
+	AfterEach(func() {
+		kbacli.UnsetMockClient()
+		cleanEnv()
+	})
 
 	Context("Test OpsRequest", func() {
 
@@ -169,6 +175,8 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 
 			By("cancel verticalScaling opsRequest")
 			cancelOpsRequest(reqCtx, opsRes, opsRes.OpsRequest.Status.StartTimestamp.Time)
+			Expect(opsRes.OpsRequest.Status.CancelResult).ShouldNot(BeNil())
+			Expect(opsRes.OpsRequest.Status.CancelResult.Reverted).Should(HaveLen(1))
 
 			By("mock podList[0] rolled back successfully by re-creating it")
 			reCreatePod(podList[0])
@@ -189,6 +197,87 @@ var _ = Describe("VerticalScaling OpsRequest", func() {
 			Expect(progressDetail.Message).Should(ContainSubstring("with rollback"))
 		})
 
+		It("vertical scaling with health gate", func() {
+			By("init operations resources with the InstanceSet and pods")
+			reqCtx := intctrlutil.RequestCtx{Ctx: ctx}
+			opsRes, compDef, cluster := initOperationsResources(compDefName, clusterName)
+			mockComponentHealthCheck(compDef, cluster, func() bool { return true })
+			its := testapps.MockInstanceSetComponent(&testCtx, clusterName, defaultCompName)
+			podList := initInstanceSetPods(ctx, k8sClient, opsRes)
+
+			By("create VerticalScaling ops with health gate")
+			ops := testapps.NewOpsRequestObj("vertical-scaling-ops-"+randomStr, testCtx.DefaultNamespace,
+				clusterName, appsv1alpha1.VerticalScalingType)
+			ops.Spec.VerticalScalingList = []appsv1alpha1.VerticalScaling{
+				{
+					ComponentOps: appsv1alpha1.ComponentOps{ComponentName: defaultCompName},
+					ResourceRequirements: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("400m"),
+							corev1.ResourceMemory: resource.MustParse("300Mi"),
+						},
+					},
+				},
+			}
+			ops.Spec.HealthGate = &appsv1alpha1.InstanceHealthGate{}
+			opsRes.OpsRequest = testapps.CreateOpsRequest(ctx, testCtx, ops)
+			opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsPendingPhase
+			_, err := GetOpsManager().Do(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			checkPartition := func(expectPartition *int32) {
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(its), func(g Gomega, fetched *workloads.InstanceSet) {
+					if expectPartition == nil {
+						g.Expect(fetched.Annotations).ShouldNot(HaveKey(constant.OpsGatedRolloutAnnotationKey))
+						if fetched.Spec.UpdateStrategy.RollingUpdate != nil {
+							g.Expect(fetched.Spec.UpdateStrategy.RollingUpdate.Partition).Should(BeNil())
+						}
+						return
+					}
+					g.Expect(fetched.Annotations[constant.OpsGatedRolloutAnnotationKey]).Should(Equal(opsRes.OpsRequest.Name))
+					g.Expect(fetched.Spec.UpdateStrategy.RollingUpdate).ShouldNot(BeNil())
+					g.Expect(fetched.Spec.UpdateStrategy.RollingUpdate.Partition).Should(Equal(expectPartition))
+				})).Should(Succeed())
+			}
+
+			By("expect the rollout of the InstanceSet is paused")
+			Expect(verticalScalingHandler{}.Action(reqCtx, k8sClient, opsRes)).Should(Succeed())
+			checkPartition(pointer.Int32(0))
+
+			By("mock opsRequest is Running")
+			mockComponentIsOperating(opsRes.Cluster, appsv1.UpdatingClusterCompPhase, defaultCompName)
+			Expect(testapps.ChangeObjStatus(&testCtx, opsRes.OpsRequest, func() {
+				opsRes.OpsRequest.Status.Phase = appsv1alpha1.OpsRunningPhase
+				opsRes.OpsRequest.Status.StartTimestamp = metav1.Time{Time: time.Now()}
+			})).ShouldNot(HaveOccurred())
+			// wait 1 second for checking progress
+			time.Sleep(time.Second)
+
+			By("expect the partition allows the first pod to be updated")
+			_, _, err = verticalScalingHandler{}.ReconcileAction(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			checkPartition(pointer.Int32(1))
+
+			By("mock the first pod in the update order is updated, expect the partition is increased")
+			// the pods are updated from the highest ordinal.
+			pod := podList[len(podList)-1]
+			pod.Kind = constant.PodKind
+			testk8s.MockPodIsTerminating(ctx, testCtx, pod)
+			testk8s.RemovePodFinalizer(ctx, testCtx, pod)
+			testapps.MockInstanceSetPod(&testCtx, nil, clusterName, defaultCompName,
+				pod.Name, "", "", ops.Spec.VerticalScalingList[0].ResourceRequirements)
+			_, _, err = verticalScalingHandler{}.ReconcileAction(reqCtx, k8sClient, opsRes)
+			Expect(err).ShouldNot(HaveOccurred())
+			checkPartition(pointer.Int32(2))
+			progressDetail := findStatusProgressDetail(opsRes.OpsRequest.Status.Components[defaultCompName].ProgressDetails,
+				getProgressObjectKey(constant.PodKind, pod.Name))
+			Expect(progressDetail.Status).Should(Equal(appsv1alpha1.SucceedProgressStatus))
+
+			By("cancel the opsRequest, expect the rollout is released")
+			cancelOpsRequest(reqCtx, opsRes, opsRes.OpsRequest.Status.StartTimestamp.Time)
+			checkPartition(nil)
+		})
+
 		It("force run vertical scaling opsRequests", func() {
 			By("create the first vertical scaling")
 			verticalScaling1 := []appsv1alpha1.VerticalScaling{
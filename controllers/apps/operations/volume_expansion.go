@@ -61,8 +61,8 @@ const (
 func init() {
 	// the volume expansion operation only supports online expansion now
 	volumeExpansionBehaviour := OpsBehaviour{
-		OpsHandler:  volumeExpansionOpsHandler{},
-		QueueBySelf: true,
+		OpsHandler:    volumeExpansionOpsHandler{},
+		ConflictRules: newSelfOpsConflictRules(appsv1alpha1.VolumeExpansionType, ComponentOpsScope),
 	}
 	opsMgr := GetOpsManager()
 	opsMgr.RegisterOps(appsv1alpha1.VolumeExpansionType, volumeExpansionBehaviour)
@@ -63,6 +63,10 @@ type OpsRequestReconciler struct {
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsapprovalpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opstemplates,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -166,11 +170,15 @@ func (r *OpsRequestReconciler) fetchCluster(reqCtx intctrlutil.RequestCtx, opsRe
 func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	switch opsRes.OpsRequest.Status.Phase {
 	case "":
-		// update status.phase to pending
-		if err := operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsPendingPhase,
-			appsv1alpha1.NewWaitForProcessingCondition(opsRes.OpsRequest)); err != nil {
+		return r.handleNewOpsRequest(reqCtx, opsRes)
+	case appsv1alpha1.OpsPendingApprovalPhase:
+		requeueAfter, err := operations.ReconcilePendingApproval(reqCtx, r.Client, opsRes)
+		if err != nil {
 			return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
 		}
+		if requeueAfter != 0 {
+			return intctrlutil.ResultToP(intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, ""))
+		}
 		return intctrlutil.ResultToP(intctrlutil.Reconciled())
 	case appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsCreatingPhase:
 		return r.doOpsRequestAction(reqCtx, opsRes)
@@ -183,6 +191,28 @@ func (r *OpsRequestReconciler) handleOpsRequestByPhase(reqCtx intctrlutil.Reques
 	}
 }
 
+// handleNewOpsRequest updates the status.phase of the new OpsRequest to PendingApproval if it requires approval,
+// otherwise to Pending.
+func (r *OpsRequestReconciler) handleNewOpsRequest(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
+	policy, err := operations.GetMatchedApprovalPolicy(reqCtx, r.Client, opsRes)
+	if err != nil {
+		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	}
+	if policy != nil {
+		deepCopyOps := opsRes.OpsRequest.DeepCopy()
+		opsRes.OpsRequest.Status.Approval = &appsv1alpha1.OpsApprovalStatus{PolicyName: policy.Name}
+		err = operations.PatchOpsStatusWithOpsDeepCopy(reqCtx.Ctx, r.Client, opsRes, deepCopyOps, appsv1alpha1.OpsPendingApprovalPhase,
+			appsv1alpha1.NewWaitForApprovalCondition(opsRes.OpsRequest, policy.Name))
+	} else {
+		err = operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsPendingPhase,
+			appsv1alpha1.NewWaitForProcessingCondition(opsRes.OpsRequest))
+	}
+	if err != nil {
+		return intctrlutil.ResultToP(intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, ""))
+	}
+	return intctrlutil.ResultToP(intctrlutil.Reconciled())
+}
+
 // handleCancelSignal handles the cancel signal for opsRequest.
 func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx, opsRes *operations.OpsResource) (*ctrl.Result, error) {
 	opsRequest := opsRes.OpsRequest
@@ -192,7 +222,7 @@ func (r *OpsRequestReconciler) handleCancelSignal(reqCtx intctrlutil.RequestCtx,
 	if opsRequest.IsComplete() || opsRequest.Status.Phase == appsv1alpha1.OpsCancellingPhase {
 		return nil, nil
 	}
-	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase {
+	if opsRequest.Status.Phase == appsv1alpha1.OpsPendingPhase || opsRequest.Status.Phase == appsv1alpha1.OpsPendingApprovalPhase {
 		return &ctrl.Result{}, operations.PatchOpsStatus(reqCtx.Ctx, r.Client, opsRes, appsv1alpha1.OpsCancelledPhase)
 	}
 	opsBehaviour := operations.GetOpsManager().OpsMap[opsRequest.Spec.Type]
@@ -375,43 +405,19 @@ func (r *OpsRequestReconciler) getRunningOpsRequestsFromCluster(cluster *appsv1.
 		opsRequestSlice []appsv1alpha1.OpsRecorder
 		err             error
 		requests        []reconcile.Request
-		clusterType     = "cluster"
-		typeSet         = map[string]appsv1alpha1.OpsRecorder{}
 	)
 	if opsRequestSlice, err = opsutil.GetOpsRequestSliceFromCluster(cluster); err != nil {
 		return nil
 	}
+	// append the running opsRequests and the queued ones,
+	// the queued opsRequests check whether the conflicting opsRequests have been completed by themselves.
 	for i := range opsRequestSlice {
-		ops := opsRequestSlice[i]
-		if !ops.InQueue {
-			// append running opsRequest
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: cluster.Namespace,
-					Name:      ops.Name,
-				},
-			})
-		}
-		opsType := string(ops.Type)
-		if !opsRequestSlice[i].QueueBySelf {
-			// If opsRequest is not the type-scope queue, unified as "cluster" scope.
-			opsType = clusterType
-		}
-		if _, ok := typeSet[opsType]; !ok {
-			typeSet[opsType] = ops
-		}
-	}
-
-	for _, v := range typeSet {
-		if v.InQueue {
-			// append the first opsRequest which is in the queue.
-			requests = append(requests, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Namespace: cluster.Namespace,
-					Name:      v.Name,
-				},
-			})
-		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: cluster.Namespace,
+				Name:      opsRequestSlice[i].Name,
+			},
+		})
 	}
 	return requests
 }
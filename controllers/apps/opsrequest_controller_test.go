@@ -818,7 +818,7 @@ var _ = Describe("OpsRequest Controller", func() {
 			Eventually(testapps.GetOpsRequestPhase(&testCtx, client.ObjectKeyFromObject(ops3))).Should(Equal(appsv1alpha1.OpsSucceedPhase))
 		})
 
-		It("test opsRequest queue for QueueBySelf", func() {
+		It("test opsRequest queue for the ops type only conflicting with itself", func() {
 			By("create cluster and mock it to running")
 			replicas := int32(3)
 			createMysqlCluster(replicas)
@@ -0,0 +1,383 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/controllers/apps/operations"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	defaultSuccessfulOpsHistoryLimit = 3
+	defaultFailedOpsHistoryLimit     = 1
+	// maxMissedScheduledTimes is the max number of the missed scheduled times to look back.
+	maxMissedScheduledTimes = 100
+
+	reasonOpsScheduleInvalid  = "InvalidSchedule"
+	reasonOpsScheduleMissed   = "MissSchedule"
+	reasonOpsScheduleSkipped  = "SkipSchedule"
+	reasonOpsScheduleReplaced = "ReplaceOpsRequest"
+	reasonOpsScheduleCreated  = "CreateOpsRequest"
+)
+
+// OpsScheduleReconciler reconciles an OpsSchedule object
+type OpsScheduleReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsschedules/finalizers,verbs=update
+
+// Reconcile creates the OpsRequests from the template of the OpsSchedule when the scheduled time comes,
+// and cleans up the completed OpsRequests beyond the history limits.
+func (r *OpsScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("opsSchedule", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	schedule := &appsv1alpha1.OpsSchedule{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, schedule); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !schedule.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	statusPatch := client.MergeFrom(schedule.DeepCopy())
+	schedule.Status.ObservedGeneration = schedule.Generation
+	cronSchedule, err := common.ParseCronSchedule(schedule.Spec.Schedule)
+	if err != nil {
+		r.Recorder.Event(schedule, corev1.EventTypeWarning, reasonOpsScheduleInvalid, err.Error())
+		schedule.Status.Phase = appsv1alpha1.OpsSchedulePhaseFailed
+		schedule.Status.FailureReason = err.Error()
+		schedule.Status.NextScheduleTime = nil
+		if err = r.Client.Status().Patch(reqCtx.Ctx, schedule, statusPatch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+	schedule.Status.Phase = appsv1alpha1.OpsSchedulePhaseAvailable
+	schedule.Status.FailureReason = ""
+
+	activeOps, err := r.syncOpsHistory(reqCtx, schedule)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	requeueAfter, err := r.scheduleOpsRequest(reqCtx, schedule, cronSchedule, activeOps, time.Now())
+	if patchErr := r.Client.Status().Patch(reqCtx.Ctx, schedule, statusPatch); patchErr != nil && err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if requeueAfter <= 0 {
+		return intctrlutil.Reconciled()
+	}
+	return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "wait for the next scheduled time")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpsScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.OpsSchedule{}).
+		Owns(&appsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
+
+// syncOpsHistory updates the active OpsRequests and the last successful time in the status,
+// and deletes the oldest completed OpsRequests beyond the history limits.
+func (r *OpsScheduleReconciler) syncOpsHistory(reqCtx intctrlutil.RequestCtx,
+	schedule *appsv1alpha1.OpsSchedule) ([]*appsv1alpha1.OpsRequest, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(schedule.Namespace),
+		client.MatchingLabels{constant.OpsScheduleNameLabelKey: schedule.Name}); err != nil {
+		return nil, err
+	}
+	var activeOps, succeedOps, failedOps []*appsv1alpha1.OpsRequest
+	for i := range opsList.Items {
+		opsRequest := &opsList.Items[i]
+		if !metav1.IsControlledBy(opsRequest, schedule) {
+			continue
+		}
+		switch {
+		case !opsRequest.IsComplete():
+			activeOps = append(activeOps, opsRequest)
+		case opsRequest.Status.Phase == appsv1alpha1.OpsSucceedPhase:
+			succeedOps = append(succeedOps, opsRequest)
+			completionTime := opsRequest.Status.CompletionTimestamp
+			if schedule.Status.LastSuccessfulTime == nil || schedule.Status.LastSuccessfulTime.Before(&completionTime) {
+				schedule.Status.LastSuccessfulTime = completionTime.DeepCopy()
+			}
+		default:
+			failedOps = append(failedOps, opsRequest)
+		}
+	}
+	schedule.Status.Active = nil
+	for _, opsRequest := range activeOps {
+		schedule.Status.Active = append(schedule.Status.Active, corev1.ObjectReference{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       "OpsRequest",
+			Name:       opsRequest.Name,
+			Namespace:  opsRequest.Namespace,
+			UID:        opsRequest.UID,
+		})
+	}
+	successfulLimit := int32(defaultSuccessfulOpsHistoryLimit)
+	if schedule.Spec.SuccessfulOpsHistoryLimit != nil {
+		successfulLimit = *schedule.Spec.SuccessfulOpsHistoryLimit
+	}
+	failedLimit := int32(defaultFailedOpsHistoryLimit)
+	if schedule.Spec.FailedOpsHistoryLimit != nil {
+		failedLimit = *schedule.Spec.FailedOpsHistoryLimit
+	}
+	if err := r.deleteOldestOpsRequests(reqCtx, succeedOps, int(successfulLimit)); err != nil {
+		return nil, err
+	}
+	if err := r.deleteOldestOpsRequests(reqCtx, failedOps, int(failedLimit)); err != nil {
+		return nil, err
+	}
+	return activeOps, nil
+}
+
+// deleteOldestOpsRequests deletes the oldest completed OpsRequests to retain the given number of them.
+func (r *OpsScheduleReconciler) deleteOldestOpsRequests(reqCtx intctrlutil.RequestCtx,
+	opsRequests []*appsv1alpha1.OpsRequest, limit int) error {
+	if len(opsRequests) <= limit {
+		return nil
+	}
+	sort.Slice(opsRequests, func(i, j int) bool {
+		return opsRequests[i].Status.CompletionTimestamp.Before(&opsRequests[j].Status.CompletionTimestamp)
+	})
+	for _, opsRequest := range opsRequests[:len(opsRequests)-limit] {
+		if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, opsRequest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduleOpsRequest creates the OpsRequest for the most recent scheduled time that has not been handled,
+// according to the concurrency policy. It returns the duration until the next scheduled time.
+func (r *OpsScheduleReconciler) scheduleOpsRequest(reqCtx intctrlutil.RequestCtx,
+	schedule *appsv1alpha1.OpsSchedule,
+	cronSchedule *common.CronSchedule,
+	activeOps []*appsv1alpha1.OpsRequest,
+	now time.Time) (time.Duration, error) {
+	var requeueAfter time.Duration
+	schedule.Status.NextScheduleTime = nil
+	if next := cronSchedule.Next(now); !next.IsZero() {
+		schedule.Status.NextScheduleTime = &metav1.Time{Time: next}
+		requeueAfter = next.Sub(now)
+	}
+	if schedule.Spec.Suspend {
+		return requeueAfter, nil
+	}
+	scheduledTime := getMostRecentScheduledTime(schedule, cronSchedule, now)
+	if scheduledTime.IsZero() {
+		return requeueAfter, nil
+	}
+	// the scheduled time is handled whether the OpsRequest is created or skipped.
+	markScheduled := func() {
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	}
+	if deadline := schedule.Spec.StartingDeadlineSeconds; deadline != nil &&
+		scheduledTime.Add(time.Duration(*deadline)*time.Second).Before(now) {
+		r.Recorder.Eventf(schedule, corev1.EventTypeWarning, reasonOpsScheduleMissed,
+			"Missed the scheduled time %s, because the starting deadline is exceeded", scheduledTime.Format(time.RFC3339))
+		markScheduled()
+		return requeueAfter, nil
+	}
+	if len(activeOps) > 0 {
+		switch schedule.Spec.ConcurrencyPolicy {
+		case appsv1alpha1.ForbidOpsConcurrent:
+			r.Recorder.Eventf(schedule, corev1.EventTypeNormal, reasonOpsScheduleSkipped,
+				"Skip the scheduled time %s, because the OpsRequest %s has not completed yet",
+				scheduledTime.Format(time.RFC3339), activeOps[0].Name)
+			markScheduled()
+			return requeueAfter, nil
+		case appsv1alpha1.ReplaceOpsConcurrent:
+			replaced, err := r.replaceActiveOpsRequests(reqCtx, schedule, activeOps)
+			if err != nil {
+				return 0, err
+			}
+			if !replaced {
+				r.Recorder.Eventf(schedule, corev1.EventTypeWarning, reasonOpsScheduleSkipped,
+					"Skip the scheduled time %s, because the running OpsRequests can not be replaced",
+					scheduledTime.Format(time.RFC3339))
+				markScheduled()
+				return requeueAfter, nil
+			}
+		}
+	}
+	opsRequest, err := buildScheduledOpsRequest(schedule, scheduledTime)
+	if err != nil {
+		return 0, err
+	}
+	if err = r.Client.Create(reqCtx.Ctx, opsRequest); err != nil && !apierrors.IsAlreadyExists(err) {
+		return 0, err
+	}
+	r.Recorder.Eventf(schedule, corev1.EventTypeNormal, reasonOpsScheduleCreated,
+		"Created the OpsRequest %s for the scheduled time %s", opsRequest.Name, scheduledTime.Format(time.RFC3339))
+	schedule.Status.Active = append(schedule.Status.Active, corev1.ObjectReference{
+		APIVersion: appsv1alpha1.GroupVersion.String(),
+		Kind:       "OpsRequest",
+		Name:       opsRequest.Name,
+		Namespace:  opsRequest.Namespace,
+		UID:        opsRequest.UID,
+	})
+	markScheduled()
+	return requeueAfter, nil
+}
+
+// replaceActiveOpsRequests cancels the active OpsRequests, or deletes them if they have not started yet.
+// It returns false if any of the running OpsRequests does not support cancellation.
+func (r *OpsScheduleReconciler) replaceActiveOpsRequests(reqCtx intctrlutil.RequestCtx,
+	schedule *appsv1alpha1.OpsSchedule,
+	activeOps []*appsv1alpha1.OpsRequest) (bool, error) {
+	replaced := true
+	for _, opsRequest := range activeOps {
+		if opsRequest.Spec.Cancel {
+			continue
+		}
+		switch opsRequest.Status.Phase {
+		case "", appsv1alpha1.OpsPendingPhase, appsv1alpha1.OpsPendingApprovalPhase:
+			if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, opsRequest); err != nil {
+				return false, err
+			}
+		default:
+			if operations.GetOpsManager().OpsMap[opsRequest.Spec.Type].CancelFunc == nil {
+				replaced = false
+				continue
+			}
+			patch := client.MergeFrom(opsRequest.DeepCopy())
+			opsRequest.Spec.Cancel = true
+			if err := r.Client.Patch(reqCtx.Ctx, opsRequest, patch); err != nil {
+				return false, err
+			}
+		}
+		r.Recorder.Eventf(schedule, corev1.EventTypeNormal, reasonOpsScheduleReplaced,
+			"Replaced the OpsRequest %s which has not completed yet", opsRequest.Name)
+	}
+	return replaced, nil
+}
+
+// getMostRecentScheduledTime gets the most recent scheduled time which is not after now and has not been handled.
+// It returns the zero time if there is no such time.
+func getMostRecentScheduledTime(schedule *appsv1alpha1.OpsSchedule, cronSchedule *common.CronSchedule, now time.Time) time.Time {
+	earliestTime := schedule.CreationTimestamp.Time
+	if schedule.Status.LastScheduleTime != nil {
+		earliestTime = schedule.Status.LastScheduleTime.Time
+	}
+	if deadline := schedule.Spec.StartingDeadlineSeconds; deadline != nil {
+		// the scheduled times before the deadline are missed anyway
+		if deadlineTime := now.Add(-time.Duration(*deadline) * time.Second); deadlineTime.After(earliestTime) {
+			earliestTime = deadlineTime
+		}
+	}
+	scheduledTime, missedCount := getLastScheduledTime(cronSchedule, earliestTime, now, maxMissedScheduledTimes)
+	if missedCount <= maxMissedScheduledTimes {
+		return scheduledTime
+	}
+	// too many scheduled times are missed, e.g. the controller is down for a long time,
+	// look back from now instead of iterating all of them.
+	for lookback := time.Minute; ; lookback *= 2 {
+		start := now.Add(-lookback)
+		if start.Before(scheduledTime) {
+			start = scheduledTime
+		}
+		if t, _ := getLastScheduledTime(cronSchedule, start, now, -1); !t.IsZero() {
+			return t
+		}
+		if start.Equal(scheduledTime) {
+			return scheduledTime
+		}
+	}
+}
+
+// getLastScheduledTime gets the last scheduled time after the start and not after now, and the number of
+// the scheduled times iterated. It stops iterating once the number exceeds the limit if the limit is not negative.
+func getLastScheduledTime(cronSchedule *common.CronSchedule, start, now time.Time, limit int) (time.Time, int) {
+	var (
+		scheduledTime time.Time
+		count         int
+	)
+	for t := cronSchedule.Next(start); !t.IsZero() && !t.After(now); t = cronSchedule.Next(t) {
+		scheduledTime = t
+		count++
+		if limit >= 0 && count > limit {
+			break
+		}
+	}
+	return scheduledTime, count
+}
+
+// buildScheduledOpsRequest builds the OpsRequest from the template of the OpsSchedule.
+// The name of the OpsRequest is derived from the scheduled time, so that it is created only once for each scheduled time.
+func buildScheduledOpsRequest(schedule *appsv1alpha1.OpsSchedule, scheduledTime time.Time) (*appsv1alpha1.OpsRequest, error) {
+	template := schedule.Spec.OpsRequestTemplate
+	opsRequest := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%d", common.CutString(schedule.Name, 52), scheduledTime.Unix()/60),
+			Namespace:   schedule.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	for k, v := range template.Labels {
+		opsRequest.Labels[k] = v
+	}
+	for k, v := range template.Annotations {
+		opsRequest.Annotations[k] = v
+	}
+	// the scheduled opsRequest needs to be approved and its requester is recorded by the webhook.
+	delete(opsRequest.Annotations, constant.OpsApprovedByAnnotationKey)
+	delete(opsRequest.Annotations, constant.OpsApprovedByGroupsAnnotationKey)
+	delete(opsRequest.Annotations, constant.OpsRequestedByAnnotationKey)
+	opsRequest.Labels[constant.OpsScheduleNameLabelKey] = schedule.Name
+	opsRequest.Annotations[constant.OpsScheduledTimeAnnotationKey] = scheduledTime.UTC().Format(time.RFC3339)
+	if err := intctrlutil.SetControllerReference(schedule, opsRequest); err != nil {
+		return nil, err
+	}
+	return opsRequest, nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("OpsSchedule Controller", func() {
+	var (
+		randomStr   = testCtx.GetRandomStr()
+		clusterName = "test-cluster-" + randomStr
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.OpsScheduleSignature, inNS, ml)
+		testapps.ClearResources(&testCtx, generics.OpsRequestSignature, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	newOpsSchedule := func(schedule string) *appsv1alpha1.OpsSchedule {
+		return &appsv1alpha1.OpsSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "restart-schedule-" + testCtx.GetRandomStr(),
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1alpha1.OpsScheduleSpec{
+				Schedule: schedule,
+				OpsRequestTemplate: appsv1alpha1.OpsRequestTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "test"},
+					},
+					Spec: appsv1alpha1.OpsRequestSpec{
+						ClusterName: clusterName,
+						Type:        appsv1alpha1.RestartType,
+						SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+							RestartList: []appsv1alpha1.ComponentOps{{ComponentName: "mysql"}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	Context("Test OpsSchedule", func() {
+		It("Test the status of the OpsSchedule", func() {
+			By("expect the OpsSchedule is available with the next scheduled time")
+			opsSchedule := newOpsSchedule("0 0 1 1 *")
+			Expect(testCtx.CreateObj(testCtx.Ctx, opsSchedule)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsSchedule), func(g Gomega, schedule *appsv1alpha1.OpsSchedule) {
+				g.Expect(schedule.Status.Phase).Should(Equal(appsv1alpha1.OpsSchedulePhaseAvailable))
+				g.Expect(schedule.Status.ObservedGeneration).Should(Equal(schedule.Generation))
+				g.Expect(schedule.Status.NextScheduleTime).ShouldNot(BeNil())
+				g.Expect(schedule.Status.NextScheduleTime.Month()).Should(Equal(time.January))
+				g.Expect(schedule.Status.Active).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("expect the OpsSchedule is failed if the schedule is invalid")
+			opsSchedule = newOpsSchedule("0 25 * * *")
+			Expect(testCtx.CreateObj(testCtx.Ctx, opsSchedule)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(opsSchedule), func(g Gomega, schedule *appsv1alpha1.OpsSchedule) {
+				g.Expect(schedule.Status.Phase).Should(Equal(appsv1alpha1.OpsSchedulePhaseFailed))
+				g.Expect(schedule.Status.FailureReason).Should(ContainSubstring("hour 25 is out of range"))
+			})).Should(Succeed())
+		})
+
+		It("Test getting the most recent scheduled time", func() {
+			cronSchedule, err := common.ParseCronSchedule("*/10 * * * *")
+			Expect(err).ShouldNot(HaveOccurred())
+			now := time.Date(2024, 5, 1, 10, 35, 0, 0, time.UTC)
+			opsSchedule := newOpsSchedule("*/10 * * * *")
+			opsSchedule.CreationTimestamp = metav1.NewTime(now.Add(-time.Hour))
+
+			By("expect the most recent missed time is scheduled")
+			Expect(getMostRecentScheduledTime(opsSchedule, cronSchedule, now)).Should(Equal(now.Add(-5 * time.Minute)))
+
+			By("expect nothing is scheduled if the most recent time has been scheduled")
+			opsSchedule.Status.LastScheduleTime = &metav1.Time{Time: now.Add(-5 * time.Minute)}
+			Expect(getMostRecentScheduledTime(opsSchedule, cronSchedule, now).IsZero()).Should(BeTrue())
+
+			By("expect the times before the starting deadline are not scheduled")
+			opsSchedule.Status.LastScheduleTime = nil
+			opsSchedule.Spec.StartingDeadlineSeconds = pointer.Int64(60)
+			Expect(getMostRecentScheduledTime(opsSchedule, cronSchedule, now).IsZero()).Should(BeTrue())
+
+			By("expect the most recent time is scheduled if too many times are missed")
+			opsSchedule.Spec.StartingDeadlineSeconds = nil
+			opsSchedule.CreationTimestamp = metav1.NewTime(now.AddDate(-1, 0, 0))
+			Expect(getMostRecentScheduledTime(opsSchedule, cronSchedule, now)).Should(Equal(now.Add(-5 * time.Minute)))
+		})
+
+		It("Test building the OpsRequest from the template", func() {
+			opsSchedule := newOpsSchedule("0 2 * * *")
+			opsSchedule.Spec.OpsRequestTemplate.Annotations = map[string]string{
+				constant.OpsApprovedByAnnotationKey:  "admin",
+				constant.OpsRequestedByAnnotationKey: "admin",
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, opsSchedule)).Should(Succeed())
+			scheduledTime := time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC)
+			opsRequest, err := buildScheduledOpsRequest(opsSchedule, scheduledTime)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(opsRequest.Namespace).Should(Equal(opsSchedule.Namespace))
+			Expect(opsRequest.Labels).Should(HaveKeyWithValue("app", "test"))
+			Expect(opsRequest.Labels).Should(HaveKeyWithValue(constant.OpsScheduleNameLabelKey, opsSchedule.Name))
+			Expect(opsRequest.Annotations).Should(HaveKeyWithValue(constant.OpsScheduledTimeAnnotationKey, "2024-05-01T02:00:00Z"))
+			Expect(opsRequest.Annotations).ShouldNot(HaveKey(constant.OpsApprovedByAnnotationKey))
+			Expect(opsRequest.Annotations).ShouldNot(HaveKey(constant.OpsRequestedByAnnotationKey))
+			Expect(opsRequest.Spec.Type).Should(Equal(appsv1alpha1.RestartType))
+			Expect(metav1.IsControlledBy(opsRequest, opsSchedule)).Should(BeTrue())
+
+			By("expect the name of the OpsRequest is stable for the same scheduled time")
+			another, err := buildScheduledOpsRequest(opsSchedule, scheduledTime)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(another.Name).Should(Equal(opsRequest.Name))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const prometheusQueryTimeout = 10 * time.Second
+
+// prometheusQueryResponse is the subset of the response of the Prometheus instant query API.
+type prometheusQueryResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type prometheusVectorSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// prometheusSample is a sample returned by the instant query, the labels are empty for a scalar.
+type prometheusSample struct {
+	labels map[string]string
+	value  float64
+}
+
+// queryPrometheus evaluates the query by the instant query API of the Prometheus-compatible server,
+// and returns the samples.
+var queryPrometheus = func(ctx context.Context, endpoint, query string) ([]prometheusSample, error) {
+	ctx, cancel := context.WithTimeout(ctx, prometheusQueryTimeout)
+	defer cancel()
+	queryURL := strings.TrimSuffix(endpoint, "/") + "/api/v1/query?" + url.Values{"query": []string{query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, queryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parsePrometheusQueryResponse(body)
+}
+
+// parsePrometheusQueryResponse parses the samples from the response of the instant query API.
+func parsePrometheusQueryResponse(body []byte) ([]prometheusSample, error) {
+	rsp := &prometheusQueryResponse{}
+	if err := json.Unmarshal(body, rsp); err != nil {
+		return nil, fmt.Errorf("invalid response of the query: %s", err.Error())
+	}
+	if rsp.Status != "success" {
+		return nil, fmt.Errorf("query failed, %s: %s", rsp.ErrorType, rsp.Error)
+	}
+	parseSampleValue := func(value []interface{}) (float64, error) {
+		// the sample value is encoded as [<unix_time>, "<value>"]
+		if len(value) != 2 {
+			return 0, fmt.Errorf("invalid sample value %v", value)
+		}
+		str, ok := value[1].(string)
+		if !ok {
+			return 0, fmt.Errorf("invalid sample value %v", value)
+		}
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return 0, err
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, fmt.Errorf("invalid sample value %v", value)
+		}
+		return v, nil
+	}
+	var samples []prometheusSample
+	switch rsp.Data.ResultType {
+	case "scalar":
+		var value []interface{}
+		if err := json.Unmarshal(rsp.Data.Result, &value); err != nil {
+			return nil, err
+		}
+		v, err := parseSampleValue(value)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, prometheusSample{value: v})
+	case "vector":
+		var vector []prometheusVectorSample
+		if err := json.Unmarshal(rsp.Data.Result, &vector); err != nil {
+			return nil, err
+		}
+		for _, sample := range vector {
+			v, err := parseSampleValue(sample.Value)
+			if err != nil {
+				return nil, err
+			}
+			samples = append(samples, prometheusSample{labels: sample.Metric, value: v})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported result type %s of the query", rsp.Data.ResultType)
+	}
+	return samples, nil
+}
@@ -0,0 +1,686 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	defaultRecommenderCheckIntervalSeconds = 60
+	defaultRecommenderTargetPercentile     = 90
+	defaultRecommenderHalfLifeHours        = 24
+	defaultMaintenanceWindowMinutes        = 60
+	// the recommendations are applied only if they are computed from enough samples
+	minSamplesToApplyRecommendations = 30
+
+	// the buckets of the histograms grow exponentially, the bucket i (i > 0) covers
+	// [firstBucketSize * ratio^(i-1), firstBucketSize * ratio^i), and the bucket 0 covers [0, firstBucketSize).
+	histogramBucketRatio       = 1.1
+	histogramMaxBucketIndex    = 200
+	histogramWeightPerSample   = 1000000
+	cpuHistogramFirstBucket    = 0.01     // cores
+	memoryHistogramFirstBucket = 10 << 20 // bytes
+
+	reasonResourceRecommendation       = "ResourceRecommendation"
+	reasonResourceRecommendationFailed = "ResourceRecommendationFailed"
+)
+
+// ResourceRecommenderReconciler reconciles a ResourceRecommender object
+type ResourceRecommenderReconciler struct {
+	client.Client
+	Scheme     *runtime.Scheme
+	Recorder   record.EventRecorder
+	RestConfig *rest.Config
+}
+
+// containerUsage describes the CPU usage in cores and the memory usage in bytes of a container.
+type containerUsage struct {
+	podName       string
+	containerName string
+	cpu           float64
+	memory        float64
+}
+
+// resourceUsageSource collects the resource usage of the containers of the pods.
+type resourceUsageSource interface {
+	getContainerUsages(ctx context.Context, namespace string, podNames []string) ([]containerUsage, error)
+}
+
+// newResourceUsageSource creates the resource usage source specified by the ResourceRecommender.
+var newResourceUsageSource = func(restConfig *rest.Config, source appsv1alpha1.ResourceUsageSource) resourceUsageSource {
+	if source.Prometheus != nil {
+		return &prometheusUsageSource{endpoint: source.Prometheus.Endpoint}
+	}
+	return &metricsAPIUsageSource{restConfig: restConfig}
+}
+
+// podMetrics is the subset of the PodMetrics of the resource metrics API.
+type podMetrics struct {
+	Containers []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+type metricsAPIUsageSource struct {
+	restConfig *rest.Config
+}
+
+func (s *metricsAPIUsageSource) getContainerUsages(ctx context.Context, namespace string, podNames []string) ([]containerUsage, error) {
+	cli, err := corev1client.NewForConfig(s.restConfig)
+	if err != nil {
+		return nil, err
+	}
+	var usages []containerUsage
+	for _, podName := range podNames {
+		data, err := cli.RESTClient().Get().
+			AbsPath("/apis/metrics.k8s.io/v1beta1", "namespaces", namespace, "pods", podName).DoRaw(ctx)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// the metrics of the pod are not available until the pod has been running for a while
+				continue
+			}
+			return nil, err
+		}
+		metrics := &podMetrics{}
+		if err = json.Unmarshal(data, metrics); err != nil {
+			return nil, err
+		}
+		for _, container := range metrics.Containers {
+			usages = append(usages, containerUsage{
+				podName:       podName,
+				containerName: container.Name,
+				cpu:           container.Usage.Cpu().AsApproximateFloat64(),
+				memory:        container.Usage.Memory().AsApproximateFloat64(),
+			})
+		}
+	}
+	return usages, nil
+}
+
+type prometheusUsageSource struct {
+	endpoint string
+}
+
+func (s *prometheusUsageSource) getContainerUsages(ctx context.Context, namespace string, podNames []string) ([]containerUsage, error) {
+	if len(podNames) == 0 {
+		return nil, nil
+	}
+	var podPatterns []string
+	for _, podName := range podNames {
+		podPatterns = append(podPatterns, strings.ReplaceAll(regexp.QuoteMeta(podName), `\`, `\\`))
+	}
+	selector := fmt.Sprintf(`namespace="%s",pod=~"%s",container!="",container!="POD"`, namespace, strings.Join(podPatterns, "|"))
+	cpuSamples, err := queryPrometheus(ctx, s.endpoint,
+		fmt.Sprintf("sum by (pod, container) (rate(container_cpu_usage_seconds_total{%s}[5m]))", selector))
+	if err != nil {
+		return nil, err
+	}
+	memorySamples, err := queryPrometheus(ctx, s.endpoint,
+		fmt.Sprintf("sum by (pod, container) (container_memory_working_set_bytes{%s})", selector))
+	if err != nil {
+		return nil, err
+	}
+	usages := map[string]*containerUsage{}
+	getUsage := func(sample prometheusSample) *containerUsage {
+		key := sample.labels["pod"] + "/" + sample.labels["container"]
+		if _, ok := usages[key]; !ok {
+			usages[key] = &containerUsage{podName: sample.labels["pod"], containerName: sample.labels["container"]}
+		}
+		return usages[key]
+	}
+	for _, sample := range cpuSamples {
+		getUsage(sample).cpu = sample.value
+	}
+	for _, sample := range memorySamples {
+		getUsage(sample).memory = sample.value
+	}
+	var result []containerUsage
+	for _, usage := range usages {
+		result = append(result, *usage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].podName != result[j].podName {
+			return result[i].podName < result[j].podName
+		}
+		return result[i].containerName < result[j].containerName
+	})
+	return result, nil
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=resourcerecommenders,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=resourcerecommenders/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=resourcerecommenders/finalizers,verbs=update
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// Reconcile samples the resource usage of the containers periodically, updates the recommendations,
+// and creates the VerticalScaling OpsRequest to apply the recommendations if required.
+func (r *ResourceRecommenderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("resourceRecommender", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	recommender := &appsv1alpha1.ResourceRecommender{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, recommender); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !recommender.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	checkInterval := getCheckInterval(recommender.Spec.CheckIntervalSeconds, defaultRecommenderCheckIntervalSeconds)
+	// the recommender is also reconciled on the updates of its status and the OpsRequests,
+	// skip the sampling until the interval elapses unless the spec is changed.
+	if recommender.Status.ObservedGeneration == recommender.Generation {
+		if wait := timeToNextCheck(recommender.Status.LastSampleTime, checkInterval); wait > 0 {
+			return intctrlutil.RequeueAfter(wait, reqCtx.Log, "sample the resource usage")
+		}
+	}
+
+	statusPatch := client.MergeFrom(recommender.DeepCopy())
+	recommender.Status.ObservedGeneration = recommender.Generation
+	err := r.recommend(reqCtx, recommender, time.Now())
+	if patchErr := r.Client.Status().Patch(reqCtx.Ctx, recommender, statusPatch); patchErr != nil && err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(checkInterval, reqCtx.Log, "sample the resource usage")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceRecommenderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.ResourceRecommender{}).
+		Owns(&appsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
+
+func (r *ResourceRecommenderReconciler) recommend(reqCtx intctrlutil.RequestCtx,
+	recommender *appsv1alpha1.ResourceRecommender,
+	now time.Time) error {
+	recommender.Status.Message = ""
+	cluster := &appsv1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: recommender.Spec.ClusterName, Namespace: recommender.Namespace}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			recommender.Status.Message = fmt.Sprintf(`cluster "%s" not found`, recommender.Spec.ClusterName)
+			return nil
+		}
+		return err
+	}
+	if cluster.Spec.GetComponentByName(recommender.Spec.ComponentName) == nil {
+		recommender.Status.Message = fmt.Sprintf(`component "%s" not found in the cluster "%s"`, recommender.Spec.ComponentName, cluster.Name)
+		return nil
+	}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList, client.InNamespace(recommender.Namespace),
+		client.MatchingLabels{
+			constant.AppInstanceLabelKey:    recommender.Spec.ClusterName,
+			constant.KBAppComponentLabelKey: recommender.Spec.ComponentName,
+		}); err != nil {
+		return err
+	}
+	pods := map[string]*corev1.Pod{}
+	var podNames []string
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.DeletionTimestamp.IsZero() && pod.Status.Phase == corev1.PodRunning {
+			pods[pod.Name] = pod
+			podNames = append(podNames, pod.Name)
+		}
+	}
+
+	source := newResourceUsageSource(r.RestConfig, recommender.Spec.Source)
+	usages, err := source.getContainerUsages(reqCtx.Ctx, recommender.Namespace, podNames)
+	if err != nil {
+		recommender.Status.Message = fmt.Sprintf("failed to collect the resource usage: %s", err.Error())
+		r.Recorder.Event(recommender, corev1.EventTypeWarning, reasonResourceRecommendationFailed, recommender.Status.Message)
+		return nil
+	}
+	recordContainerUsages(recommender, pods, usages, now)
+
+	currentResources := map[string]corev1.ResourceRequirements{}
+	for _, pod := range pods {
+		template := pod.Labels[constant.KBAppComponentInstanceTemplateLabelKey]
+		if len(pod.Spec.Containers) > 0 {
+			currentResources[template+"/"+pod.Spec.Containers[0].Name] = pod.Spec.Containers[0].Resources
+		}
+	}
+	compDefs := map[string]*appsv1.ComponentDefinition{}
+	recommender.Status.Recommendations = nil
+	for _, histogram := range recommender.Status.Histograms {
+		current, ok := currentResources[histogram.InstanceTemplate+"/"+histogram.ContainerName]
+		if !ok {
+			continue
+		}
+		compDef, ok := compDefs[histogram.InstanceTemplate]
+		if !ok {
+			if compDef, err = r.getComponentDefinition(reqCtx, recommender, cluster, histogram.InstanceTemplate); err != nil {
+				return err
+			}
+			compDefs[histogram.InstanceTemplate] = compDef
+		}
+		minAllowed, maxAllowed := getRecommendationBounds(recommender.Spec.ResourcePolicy, compDef)
+		recommender.Status.Recommendations = append(recommender.Status.Recommendations,
+			computeContainerRecommendation(recommender.Spec, histogram, current, minAllowed, maxAllowed))
+	}
+	return r.applyRecommendations(reqCtx, recommender, pods, now)
+}
+
+// recordContainerUsages decays the usage histograms by the time elapsed since the last sample, and adds the samples to them.
+func recordContainerUsages(recommender *appsv1alpha1.ResourceRecommender,
+	pods map[string]*corev1.Pod,
+	usages []containerUsage,
+	now time.Time) {
+	halfLifeHours := recommender.Spec.HistoryHalfLifeHours
+	if halfLifeHours <= 0 {
+		halfLifeHours = defaultRecommenderHalfLifeHours
+	}
+	if lastSampleTime := recommender.Status.LastSampleTime; lastSampleTime != nil && now.After(lastSampleTime.Time) {
+		factor := math.Exp2(-now.Sub(lastSampleTime.Time).Hours() / float64(halfLifeHours))
+		var histograms []appsv1alpha1.ContainerUsageHistogram
+		for _, histogram := range recommender.Status.Histograms {
+			decayHistogram(&histogram.CPU, factor)
+			decayHistogram(&histogram.Memory, factor)
+			// drop the histograms of the containers which have been removed for a long time
+			if len(histogram.CPU.Buckets) > 0 || len(histogram.Memory.Buckets) > 0 {
+				histograms = append(histograms, histogram)
+			}
+		}
+		recommender.Status.Histograms = histograms
+	}
+	for _, usage := range usages {
+		// only the resources of the main container, which is the first container, can be changed by the VerticalScaling
+		pod, ok := pods[usage.podName]
+		if !ok || len(pod.Spec.Containers) == 0 || pod.Spec.Containers[0].Name != usage.containerName {
+			continue
+		}
+		histogram := getContainerUsageHistogram(recommender, pod.Labels[constant.KBAppComponentInstanceTemplateLabelKey], usage.containerName)
+		addHistogramSample(&histogram.CPU, usage.cpu, cpuHistogramFirstBucket)
+		addHistogramSample(&histogram.Memory, usage.memory, memoryHistogramFirstBucket)
+		histogram.SampleCount++
+	}
+	recommender.Status.LastSampleTime = &metav1.Time{Time: now}
+}
+
+// getComponentDefinition gets the ComponentDefinition of the instances of the template,
+// which is the one specified by the InstanceTemplate, or the one of the Component if not specified.
+func (r *ResourceRecommenderReconciler) getComponentDefinition(reqCtx intctrlutil.RequestCtx,
+	recommender *appsv1alpha1.ResourceRecommender,
+	cluster *appsv1.Cluster,
+	template string) (*appsv1.ComponentDefinition, error) {
+	compDefName := ""
+	if compSpec := cluster.Spec.GetComponentByName(recommender.Spec.ComponentName); compSpec != nil && template != "" {
+		for _, tpl := range compSpec.Instances {
+			if tpl.Name == template {
+				compDefName = tpl.CompDef
+			}
+		}
+	}
+	if compDefName == "" {
+		comp := &appsv1.Component{}
+		compKey := client.ObjectKey{
+			Name:      constant.GenerateClusterComponentName(cluster.Name, recommender.Spec.ComponentName),
+			Namespace: recommender.Namespace,
+		}
+		if err := r.Client.Get(reqCtx.Ctx, compKey, comp); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		compDefName = comp.Spec.CompDef
+	}
+	if compDefName == "" {
+		return nil, nil
+	}
+	compDef := &appsv1.ComponentDefinition{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: compDefName}, compDef); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return compDef, nil
+}
+
+// applyRecommendations creates the VerticalScaling OpsRequest to apply the recommendations of the main container,
+// if the update mode is Auto, the time is within the maintenance window and the recommendations differ enough.
+func (r *ResourceRecommenderReconciler) applyRecommendations(reqCtx intctrlutil.RequestCtx,
+	recommender *appsv1alpha1.ResourceRecommender,
+	pods map[string]*corev1.Pod,
+	now time.Time) error {
+	updatePolicy := recommender.Spec.UpdatePolicy
+	if updatePolicy == nil || updatePolicy.Mode != appsv1alpha1.ResourceRecommendationUpdateModeAuto {
+		return nil
+	}
+	inWindow, err := isInMaintenanceWindow(updatePolicy.MaintenanceWindow, now)
+	if err != nil {
+		recommender.Status.Message = err.Error()
+		return nil
+	}
+	if !inWindow {
+		return nil
+	}
+	// only the resources of the main container, which is the first container, can be changed by the VerticalScaling
+	mainContainers := map[string]corev1.Container{}
+	for _, pod := range pods {
+		if len(pod.Spec.Containers) > 0 {
+			mainContainers[pod.Labels[constant.KBAppComponentInstanceTemplateLabelKey]] = pod.Spec.Containers[0]
+		}
+	}
+	var recommendations []appsv1alpha1.ContainerResourceRecommendation
+	for _, rec := range recommender.Status.Recommendations {
+		container, ok := mainContainers[rec.InstanceTemplate]
+		if !ok || container.Name != rec.ContainerName || rec.SampleCount < minSamplesToApplyRecommendations {
+			continue
+		}
+		if isResourceChangeSignificant(container.Resources.Requests, rec.Resources.Requests, updatePolicy.MinChangePercent) {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	if len(recommendations) == 0 {
+		return nil
+	}
+	activeOpsName, err := r.getActiveOpsRequest(reqCtx, recommender)
+	if err != nil {
+		return err
+	}
+	if activeOpsName != "" {
+		recommender.Status.Message = fmt.Sprintf(`waiting for the OpsRequest "%s" to complete`, activeOpsName)
+		return nil
+	}
+	opsRequest := buildVerticalScalingOpsRequest(recommender, recommendations)
+	if err = intctrlutil.SetControllerReference(recommender, opsRequest); err != nil {
+		return err
+	}
+	if err = r.Client.Create(reqCtx.Ctx, opsRequest); err != nil {
+		return err
+	}
+	recommender.Status.LastApplyTime = &metav1.Time{Time: now}
+	recommender.Status.LastOpsRequest = opsRequest.Name
+	r.Recorder.Eventf(recommender, corev1.EventTypeNormal, reasonResourceRecommendation,
+		`Applying the recommended resources by the OpsRequest "%s"`, opsRequest.Name)
+	return nil
+}
+
+// getActiveOpsRequest gets the name of the OpsRequest created by the recommender which has not completed yet.
+func (r *ResourceRecommenderReconciler) getActiveOpsRequest(reqCtx intctrlutil.RequestCtx,
+	recommender *appsv1alpha1.ResourceRecommender) (string, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(recommender.Namespace),
+		client.MatchingLabels{constant.ResourceRecommenderLabelKey: recommender.Name}); err != nil {
+		return "", err
+	}
+	for _, opsRequest := range opsList.Items {
+		if !opsRequest.IsComplete() {
+			return opsRequest.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// buildVerticalScalingOpsRequest builds the VerticalScaling OpsRequest, the recommendation of the instances
+// not created by any template is applied to the Component, and the others are applied to the instance templates.
+func buildVerticalScalingOpsRequest(recommender *appsv1alpha1.ResourceRecommender,
+	recommendations []appsv1alpha1.ContainerResourceRecommendation) *appsv1alpha1.OpsRequest {
+	verticalScaling := appsv1alpha1.VerticalScaling{
+		ComponentOps: appsv1alpha1.ComponentOps{ComponentName: recommender.Spec.ComponentName},
+	}
+	for _, rec := range recommendations {
+		if rec.InstanceTemplate == "" {
+			verticalScaling.ResourceRequirements = *rec.Resources.DeepCopy()
+		} else {
+			verticalScaling.Instances = append(verticalScaling.Instances, appsv1alpha1.InstanceResourceTemplate{
+				Name:                 rec.InstanceTemplate,
+				ResourceRequirements: *rec.Resources.DeepCopy(),
+			})
+		}
+	}
+	return &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: recommender.Name + "-",
+			Namespace:    recommender.Namespace,
+			Labels: map[string]string{
+				constant.ResourceRecommenderLabelKey: recommender.Name,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName: recommender.Spec.ClusterName,
+			Type:        appsv1alpha1.VerticalScalingType,
+			SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+				VerticalScalingList: []appsv1alpha1.VerticalScaling{verticalScaling},
+			},
+		},
+	}
+}
+
+func getContainerUsageHistogram(recommender *appsv1alpha1.ResourceRecommender, template, containerName string) *appsv1alpha1.ContainerUsageHistogram {
+	for i := range recommender.Status.Histograms {
+		histogram := &recommender.Status.Histograms[i]
+		if histogram.InstanceTemplate == template && histogram.ContainerName == containerName {
+			return histogram
+		}
+	}
+	recommender.Status.Histograms = append(recommender.Status.Histograms, appsv1alpha1.ContainerUsageHistogram{
+		InstanceTemplate: template,
+		ContainerName:    containerName,
+	})
+	return &recommender.Status.Histograms[len(recommender.Status.Histograms)-1]
+}
+
+// getRecommendationBounds gets the minimum and maximum requests which can be recommended for the main container.
+// The bounds not specified by the resource policy default to the requests and the limits of the main container
+// declared in the ComponentDefinition.
+func getRecommendationBounds(policy appsv1alpha1.ResourceRecommendationPolicy,
+	compDef *appsv1.ComponentDefinition) (corev1.ResourceList, corev1.ResourceList) {
+	minAllowed, maxAllowed := corev1.ResourceList{}, corev1.ResourceList{}
+	if compDef != nil && len(compDef.Spec.Runtime.Containers) > 0 {
+		container := compDef.Spec.Runtime.Containers[0]
+		for name, quantity := range container.Resources.Requests {
+			minAllowed[name] = quantity.DeepCopy()
+		}
+		for name, quantity := range container.Resources.Limits {
+			maxAllowed[name] = quantity.DeepCopy()
+		}
+	}
+	for name, quantity := range policy.MinAllowed {
+		minAllowed[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range policy.MaxAllowed {
+		maxAllowed[name] = quantity.DeepCopy()
+	}
+	return minAllowed, maxAllowed
+}
+
+// computeContainerRecommendation computes the recommended resources from the histograms,
+// the requests are the target percentile of the usage plus the safety margin, bounded by the min and max allowed,
+// and the limits keep the current ratio of the limits to the requests.
+func computeContainerRecommendation(spec appsv1alpha1.ResourceRecommenderSpec,
+	histogram appsv1alpha1.ContainerUsageHistogram,
+	current corev1.ResourceRequirements,
+	minAllowed, maxAllowed corev1.ResourceList) appsv1alpha1.ContainerResourceRecommendation {
+	targetPercentile := spec.TargetPercentile
+	if targetPercentile <= 0 {
+		targetPercentile = defaultRecommenderTargetPercentile
+	}
+	margin := 1 + float64(spec.SafetyMarginPercent)/100
+	cpu := histogramPercentile(histogram.CPU, float64(targetPercentile)/100, cpuHistogramFirstBucket) * margin
+	memory := histogramPercentile(histogram.Memory, float64(targetPercentile)/100, memoryHistogramFirstBucket) * margin
+	uncapped := corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewMilliQuantity(int64(math.Ceil(cpu*1000)), resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity(int64(math.Ceil(memory/(1<<20)))<<20, resource.BinarySI),
+	}
+	rec := appsv1alpha1.ContainerResourceRecommendation{
+		InstanceTemplate: histogram.InstanceTemplate,
+		ContainerName:    histogram.ContainerName,
+		UncappedRequests: uncapped,
+		SampleCount:      histogram.SampleCount,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{},
+		},
+	}
+	for name, quantity := range uncapped {
+		request := quantity.DeepCopy()
+		if minQuantity, ok := minAllowed[name]; ok && request.Cmp(minQuantity) < 0 {
+			request = minQuantity.DeepCopy()
+		}
+		if maxQuantity, ok := maxAllowed[name]; ok && request.Cmp(maxQuantity) > 0 {
+			request = maxQuantity.DeepCopy()
+		}
+		rec.Resources.Requests[name] = request
+		currentLimit, hasLimit := current.Limits[name]
+		if !hasLimit {
+			continue
+		}
+		if rec.Resources.Limits == nil {
+			rec.Resources.Limits = corev1.ResourceList{}
+		}
+		currentRequest, hasRequest := current.Requests[name]
+		if !hasRequest || currentRequest.IsZero() {
+			rec.Resources.Limits[name] = request.DeepCopy()
+			continue
+		}
+		ratio := currentLimit.AsApproximateFloat64() / currentRequest.AsApproximateFloat64()
+		if name == corev1.ResourceCPU {
+			rec.Resources.Limits[name] = *resource.NewMilliQuantity(int64(math.Ceil(float64(request.MilliValue())*ratio)), resource.DecimalSI)
+		} else {
+			rec.Resources.Limits[name] = *resource.NewQuantity(int64(math.Ceil(float64(request.Value())*ratio)), resource.BinarySI)
+		}
+	}
+	return rec
+}
+
+// isResourceChangeSignificant checks whether the recommended requests differ from the current requests
+// by at least the percentage.
+func isResourceChangeSignificant(current, recommended corev1.ResourceList, minChangePercent int32) bool {
+	for name, quantity := range recommended {
+		currentQuantity, ok := current[name]
+		if !ok || currentQuantity.IsZero() {
+			return true
+		}
+		change := math.Abs(quantity.AsApproximateFloat64()-currentQuantity.AsApproximateFloat64()) / currentQuantity.AsApproximateFloat64()
+		if change*100 >= float64(minChangePercent) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInMaintenanceWindow checks whether the time is within the maintenance window, no window means always.
+func isInMaintenanceWindow(window *appsv1alpha1.MaintenanceWindow, now time.Time) (bool, error) {
+	if window == nil {
+		return true, nil
+	}
+	schedule, err := common.ParseCronSchedule(window.Schedule)
+	if err != nil {
+		return false, err
+	}
+	duration := window.DurationMinutes
+	if duration <= 0 {
+		duration = defaultMaintenanceWindowMinutes
+	}
+	// the window is open if it starts within the duration before now
+	start := schedule.Next(now.Add(-time.Duration(duration) * time.Minute))
+	return !start.IsZero() && !start.After(now), nil
+}
+
+func histogramBucketIndex(value, firstBucketSize float64) int32 {
+	if value < firstBucketSize {
+		return 0
+	}
+	index := int32(math.Floor(math.Log(value/firstBucketSize)/math.Log(histogramBucketRatio))) + 1
+	if index > histogramMaxBucketIndex {
+		index = histogramMaxBucketIndex
+	}
+	return index
+}
+
+func histogramBucketEnd(index int32, firstBucketSize float64) float64 {
+	return firstBucketSize * math.Pow(histogramBucketRatio, float64(index))
+}
+
+func addHistogramSample(histogram *appsv1alpha1.UsageHistogram, value, firstBucketSize float64) {
+	index := histogramBucketIndex(value, firstBucketSize)
+	i := sort.Search(len(histogram.Buckets), func(i int) bool { return histogram.Buckets[i].Index >= index })
+	if i < len(histogram.Buckets) && histogram.Buckets[i].Index == index {
+		histogram.Buckets[i].Weight += histogramWeightPerSample
+		return
+	}
+	histogram.Buckets = append(histogram.Buckets, appsv1alpha1.HistogramBucket{})
+	copy(histogram.Buckets[i+1:], histogram.Buckets[i:])
+	histogram.Buckets[i] = appsv1alpha1.HistogramBucket{Index: index, Weight: histogramWeightPerSample}
+}
+
+// decayHistogram multiplies the weights of the buckets by the factor, and drops the buckets with little weight.
+func decayHistogram(histogram *appsv1alpha1.UsageHistogram, factor float64) {
+	var buckets []appsv1alpha1.HistogramBucket
+	for _, bucket := range histogram.Buckets {
+		bucket.Weight = int64(math.Round(float64(bucket.Weight) * factor))
+		if bucket.Weight*100 >= histogramWeightPerSample {
+			buckets = append(buckets, bucket)
+		}
+	}
+	histogram.Buckets = buckets
+}
+
+// histogramPercentile returns the end of the bucket where the cumulative weight reaches the percentile.
+func histogramPercentile(histogram appsv1alpha1.UsageHistogram, percentile, firstBucketSize float64) float64 {
+	var total int64
+	for _, bucket := range histogram.Buckets {
+		total += bucket.Weight
+	}
+	if total == 0 {
+		return 0
+	}
+	threshold := float64(total) * percentile
+	var cumulative int64
+	for _, bucket := range histogram.Buckets {
+		cumulative += bucket.Weight
+		if float64(cumulative) >= threshold {
+			return histogramBucketEnd(bucket.Index, firstBucketSize)
+		}
+	}
+	return histogramBucketEnd(histogram.Buckets[len(histogram.Buckets)-1].Index, firstBucketSize)
+}
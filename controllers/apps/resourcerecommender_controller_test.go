@@ -0,0 +1,270 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+type fakeResourceUsageSource struct {
+	cpu, memory float64
+}
+
+func (s *fakeResourceUsageSource) getContainerUsages(_ context.Context, _ string, podNames []string) ([]containerUsage, error) {
+	var usages []containerUsage
+	for _, podName := range podNames {
+		usages = append(usages, containerUsage{podName: podName, containerName: "mysql", cpu: s.cpu, memory: s.memory})
+	}
+	return usages, nil
+}
+
+var _ = Describe("ResourceRecommender Controller", func() {
+	const compName = "mysql"
+
+	var (
+		randomStr     = testCtx.GetRandomStr()
+		clusterName   = "test-cluster-" + randomStr
+		origNewSource = newResourceUsageSource
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.ResourceRecommenderSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PodSignature, true, inNS, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(func() {
+		newResourceUsageSource = origNewSource
+		cleanEnv()
+	})
+
+	newRecommender := func() *appsv1alpha1.ResourceRecommender {
+		return &appsv1alpha1.ResourceRecommender{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "recommender-" + randomStr,
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1alpha1.ResourceRecommenderSpec{
+				ClusterName:         clusterName,
+				ComponentName:       compName,
+				TargetPercentile:    90,
+				SafetyMarginPercent: 15,
+			},
+		}
+	}
+
+	newHistogram := func(cpu, memory float64, samples int) appsv1alpha1.ContainerUsageHistogram {
+		histogram := appsv1alpha1.ContainerUsageHistogram{ContainerName: compName}
+		for i := 0; i < samples; i++ {
+			addHistogramSample(&histogram.CPU, cpu, cpuHistogramFirstBucket)
+			addHistogramSample(&histogram.Memory, memory, memoryHistogramFirstBucket)
+			histogram.SampleCount++
+		}
+		return histogram
+	}
+
+	Context("Test ResourceRecommender", func() {
+		It("Test the usage histograms", func() {
+			histogram := appsv1alpha1.UsageHistogram{}
+			for i := 0; i < 9; i++ {
+				addHistogramSample(&histogram, 0.1, cpuHistogramFirstBucket)
+			}
+			addHistogramSample(&histogram, 1, cpuHistogramFirstBucket)
+			Expect(histogram.Buckets).Should(HaveLen(2))
+			Expect(histogram.Buckets[0].Index).Should(BeNumerically("<", histogram.Buckets[1].Index))
+
+			By("expect the percentile is the end of the bucket")
+			p50 := histogramPercentile(histogram, 0.5, cpuHistogramFirstBucket)
+			Expect(p50).Should(And(BeNumerically(">", 0.1), BeNumerically("<=", 0.1*histogramBucketRatio)))
+			Expect(histogramPercentile(histogram, 1, cpuHistogramFirstBucket)).Should(BeNumerically(">", 1))
+
+			By("expect the buckets with little weight are dropped after decaying")
+			decayHistogram(&histogram, 0.005)
+			Expect(histogram.Buckets).Should(HaveLen(1))
+			Expect(histogram.Buckets[0].Weight).Should(BeEquivalentTo(9 * histogramWeightPerSample / 200))
+		})
+
+		It("Test the maintenance window", func() {
+			window := &appsv1alpha1.MaintenanceWindow{Schedule: "0 2 * * *", DurationMinutes: 60}
+			inWindow, err := isInMaintenanceWindow(window, time.Date(2024, 5, 1, 2, 30, 0, 0, time.UTC))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(inWindow).Should(BeTrue())
+			inWindow, err = isInMaintenanceWindow(window, time.Date(2024, 5, 1, 3, 30, 0, 0, time.UTC))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(inWindow).Should(BeFalse())
+			inWindow, err = isInMaintenanceWindow(nil, time.Now())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(inWindow).Should(BeTrue())
+		})
+
+		It("Test computing the recommendation", func() {
+			spec := newRecommender().Spec
+			histogram := newHistogram(1, 1<<30, 10)
+			current := corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			}
+
+			By("expect the requests are the percentile plus the margin, and the limits keep the ratio")
+			rec := computeContainerRecommendation(spec, histogram, current, nil, nil)
+			cpu := rec.Resources.Requests.Cpu().AsApproximateFloat64()
+			Expect(cpu).Should(And(BeNumerically(">=", 1.15), BeNumerically("<=", 1.15*histogramBucketRatio+0.001)))
+			Expect(rec.Resources.Limits.Cpu().MilliValue()).Should(BeNumerically("~", 2*rec.Resources.Requests.Cpu().MilliValue(), 1))
+			Expect(rec.Resources.Limits.Memory().Cmp(*rec.Resources.Requests.Memory())).Should(BeZero())
+
+			By("expect the requests are bounded by the resource policy")
+			maxAllowed := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+			minAllowed := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")}
+			rec = computeContainerRecommendation(spec, histogram, current, minAllowed, maxAllowed)
+			Expect(rec.Resources.Requests.Cpu().Cmp(resource.MustParse("1"))).Should(BeZero())
+			Expect(rec.Resources.Requests.Memory().Cmp(resource.MustParse("4Gi"))).Should(BeZero())
+			Expect(rec.UncappedRequests.Cpu().Cmp(resource.MustParse("1"))).Should(BeNumerically(">", 0))
+		})
+
+		It("Test the bounds of the recommendation", func() {
+			compDef := &appsv1.ComponentDefinition{}
+			compDef.Spec.Runtime.Containers = []corev1.Container{
+				{
+					Name: compName,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("4"),
+							corev1.ResourceMemory: resource.MustParse("8Gi"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+				},
+			}
+			policy := appsv1alpha1.ResourceRecommendationPolicy{
+				MaxAllowed: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+			}
+
+			By("expect the bounds are the main container of the ComponentDefinition overridden by the policy")
+			minAllowed, maxAllowed := getRecommendationBounds(policy, compDef)
+			Expect(minAllowed.Cpu().Cmp(resource.MustParse("100m"))).Should(BeZero())
+			Expect(maxAllowed.Cpu().Cmp(resource.MustParse("4"))).Should(BeZero())
+			Expect(maxAllowed.Memory().Cmp(resource.MustParse("16Gi"))).Should(BeZero())
+
+			By("expect only the policy bounds if the ComponentDefinition is not found")
+			minAllowed, maxAllowed = getRecommendationBounds(policy, nil)
+			Expect(minAllowed).Should(BeEmpty())
+			Expect(maxAllowed).Should(HaveLen(1))
+		})
+
+		It("Test building the VerticalScaling OpsRequest", func() {
+			recommender := newRecommender()
+			resources := corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}
+			opsRequest := buildVerticalScalingOpsRequest(recommender, []appsv1alpha1.ContainerResourceRecommendation{
+				{ContainerName: compName, Resources: resources},
+				{InstanceTemplate: "large", ContainerName: compName, Resources: resources},
+			})
+			Expect(opsRequest.Labels).Should(HaveKeyWithValue(constant.ResourceRecommenderLabelKey, recommender.Name))
+			Expect(opsRequest.Spec.Type).Should(Equal(appsv1alpha1.VerticalScalingType))
+			Expect(opsRequest.Spec.VerticalScalingList).Should(HaveLen(1))
+			verticalScaling := opsRequest.Spec.VerticalScalingList[0]
+			Expect(verticalScaling.ComponentName).Should(Equal(compName))
+			Expect(verticalScaling.Requests.Cpu().Cmp(resource.MustParse("1"))).Should(BeZero())
+			Expect(verticalScaling.Instances).Should(HaveLen(1))
+			Expect(verticalScaling.Instances[0].Name).Should(Equal("large"))
+		})
+
+		It("Test the recommendations are published in the status", func() {
+			newResourceUsageSource = func(_ *rest.Config, _ appsv1alpha1.ResourceUsageSource) resourceUsageSource {
+				return &fakeResourceUsageSource{cpu: 0.5, memory: 512 << 20}
+			}
+			testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "").
+				AddComponent(compName, "test-compdef-"+randomStr).
+				Create(&testCtx)
+			pod := testapps.NewPodFactory(testCtx.DefaultNamespace, fmt.Sprintf("%s-%s-0", clusterName, compName)).
+				AddAppInstanceLabel(clusterName).
+				AddAppComponentLabel(compName).
+				AddContainer(corev1.Container{
+					Name:  compName,
+					Image: testapps.ApeCloudMySQLImage,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					},
+				}).
+				Create(&testCtx).
+				GetObject()
+			Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(pod), func(pod *corev1.Pod) {
+				pod.Status.Phase = corev1.PodRunning
+			})()).ShouldNot(HaveOccurred())
+
+			recommender := newRecommender()
+			Expect(testCtx.CreateObj(testCtx.Ctx, recommender)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(recommender), func(g Gomega, recommender *appsv1alpha1.ResourceRecommender) {
+				g.Expect(recommender.Status.LastSampleTime).ShouldNot(BeNil())
+				g.Expect(recommender.Status.Histograms).Should(HaveLen(1))
+				g.Expect(recommender.Status.Recommendations).Should(HaveLen(1))
+				rec := recommender.Status.Recommendations[0]
+				g.Expect(rec.ContainerName).Should(Equal(compName))
+				g.Expect(rec.Resources.Requests.Cpu().Cmp(resource.MustParse("500m"))).Should(BeNumerically(">", 0))
+				g.Expect(rec.Resources.Limits).Should(BeEmpty())
+				g.Expect(recommender.Status.LastOpsRequest).Should(BeEmpty())
+			})).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,529 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/component"
+	"github.com/apecloud/kubeblocks/pkg/controller/component/lifecycle"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+)
+
+const (
+	defaultStorageCheckIntervalSeconds = 60
+	defaultStorageThresholdPercent     = 80
+	defaultStorageIncrement            = "20%"
+
+	reasonStorageAutoscaling       = "StorageAutoscaling"
+	reasonStorageAutoscalingFailed = "StorageAutoscalingFailed"
+)
+
+// StorageAutoscalingPolicyReconciler reconciles a StorageAutoscalingPolicy object
+type StorageAutoscalingPolicyReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// volumeUsage describes the usage of a persistent volume claim.
+type volumeUsage struct {
+	pvc           *corev1.PersistentVolumeClaim
+	usedBytes     uint64
+	capacityBytes uint64
+}
+
+// podVolumeUsage is the usage of a volume reported by the volumeUsage lifecycle action.
+type podVolumeUsage struct {
+	UsedBytes     uint64 `json:"usedBytes"`
+	CapacityBytes uint64 `json:"capacityBytes"`
+}
+
+// getPodVolumeUsages calls the volumeUsage lifecycle action of the pod, the result is keyed by the volumeClaimTemplate name.
+var getPodVolumeUsages = func(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	cluster *appsv1.Cluster, pod *corev1.Pod) (map[string]podVolumeUsage, error) {
+	compName := constant.GenerateClusterComponentName(cluster.Name, pod.Labels[constant.KBAppComponentLabelKey])
+	comp, compDef, err := component.GetCompNCompDefByName(reqCtx.Ctx, cli, pod.Namespace, compName)
+	if err != nil {
+		return nil, err
+	}
+	if compDef.Spec.LifecycleActions == nil || compDef.Spec.LifecycleActions.VolumeUsage == nil {
+		return nil, fmt.Errorf("the volumeUsage action is not defined in the component definition %s", compDef.Name)
+	}
+	synthesizedComp, err := component.BuildSynthesizedComponent(reqCtx, cli, cluster, compDef, comp)
+	if err != nil {
+		return nil, err
+	}
+	lfa, err := lifecycle.New(synthesizedComp, pod)
+	if err != nil {
+		return nil, err
+	}
+	output, err := lfa.VolumeUsage(reqCtx.Ctx, cli, nil)
+	if err != nil {
+		return nil, err
+	}
+	usages := map[string]podVolumeUsage{}
+	if err = json.Unmarshal(output, &usages); err != nil {
+		return nil, fmt.Errorf("invalid output of the volumeUsage action: %s", err.Error())
+	}
+	return usages, nil
+}
+
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=storageautoscalingpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=storageautoscalingpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=storageautoscalingpolicies/finalizers,verbs=update
+
+// Reconcile checks the usage of the volumes periodically,
+// and creates the VolumeExpansion OpsRequest if the usage exceeds the threshold.
+func (r *StorageAutoscalingPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("storageAutoscalingPolicy", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	policy := &appsv1alpha1.StorageAutoscalingPolicy{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, policy); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !policy.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	checkInterval := getCheckInterval(policy.Spec.CheckIntervalSeconds, defaultStorageCheckIntervalSeconds)
+	// the policy is also reconciled on the updates of its status and the OpsRequests,
+	// skip the check until the interval elapses unless the spec is changed.
+	if !policy.Spec.Suspend && policy.Status.ObservedGeneration == policy.Generation {
+		if wait := timeToNextCheck(policy.Status.LastCheckTime, checkInterval); wait > 0 {
+			return intctrlutil.RequeueAfter(wait, reqCtx.Log, "check the usage of the volumes")
+		}
+	}
+
+	statusPatch := client.MergeFrom(policy.DeepCopy())
+	policy.Status.ObservedGeneration = policy.Generation
+	if policy.Spec.Suspend {
+		if err := r.Client.Status().Patch(reqCtx.Ctx, policy, statusPatch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+	err := r.autoscale(reqCtx, policy, time.Now())
+	policy.Status.LastCheckTime = &metav1.Time{Time: time.Now()}
+	if patchErr := r.Client.Status().Patch(reqCtx.Ctx, policy, statusPatch); patchErr != nil && err == nil {
+		err = patchErr
+	}
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.RequeueAfter(checkInterval, reqCtx.Log, "check the usage of the volumes")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *StorageAutoscalingPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&appsv1alpha1.StorageAutoscalingPolicy{}).
+		Owns(&appsv1alpha1.OpsRequest{}).
+		Complete(r)
+}
+
+func (r *StorageAutoscalingPolicyReconciler) autoscale(reqCtx intctrlutil.RequestCtx,
+	policy *appsv1alpha1.StorageAutoscalingPolicy,
+	now time.Time) error {
+	// remove the status of the volumeClaimTemplates which are removed from the spec
+	var vctStatuses []appsv1alpha1.StorageAutoscalingVolumeStatus
+	for _, vctStatus := range policy.Status.VolumeClaimTemplates {
+		for _, tpl := range policy.Spec.VolumeClaimTemplates {
+			if tpl.Name == vctStatus.Name {
+				vctStatuses = append(vctStatuses, vctStatus)
+				break
+			}
+		}
+	}
+	policy.Status.VolumeClaimTemplates = vctStatuses
+	setMessage := func(message string) {
+		for _, tpl := range policy.Spec.VolumeClaimTemplates {
+			getStorageAutoscalingVolumeStatus(policy, tpl.Name).Message = message
+		}
+	}
+	cluster := &appsv1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: policy.Spec.ClusterName, Namespace: policy.Namespace}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			setMessage(fmt.Sprintf(`cluster "%s" not found`, policy.Spec.ClusterName))
+			return nil
+		}
+		return err
+	}
+	compSpec, isSharding := getComponentSpecOrShardingTemplate(cluster, policy.Spec.ComponentName)
+	if compSpec == nil {
+		setMessage(fmt.Sprintf(`component "%s" not found in the cluster "%s"`, policy.Spec.ComponentName, cluster.Name))
+		return nil
+	}
+	activeOpsName, err := r.getActiveOpsRequest(reqCtx, policy)
+	if err != nil {
+		return err
+	}
+	usages, err := r.getVolumeUsages(reqCtx, policy, cluster, isSharding)
+	if err != nil {
+		return err
+	}
+
+	var vctsToExpand []appsv1alpha1.OpsRequestVolumeClaimTemplate
+	for _, tpl := range policy.Spec.VolumeClaimTemplates {
+		vctStatus := getStorageAutoscalingVolumeStatus(policy, tpl.Name)
+		vctStatus.Message = ""
+		var vct *appsv1.ClusterComponentVolumeClaimTemplate
+		for i := range compSpec.VolumeClaimTemplates {
+			if compSpec.VolumeClaimTemplates[i].Name == tpl.Name {
+				vct = &compSpec.VolumeClaimTemplates[i]
+				break
+			}
+		}
+		if vct == nil {
+			vctStatus.Message = fmt.Sprintf(`volumeClaimTemplate "%s" not found in the component`, tpl.Name)
+			continue
+		}
+		currentSize := vct.Spec.Resources.Requests.Storage().DeepCopy()
+		vctStatus.UsedPercent = 0
+		var storageClassName *string
+		for _, usage := range usages[tpl.Name] {
+			if capacity := usage.pvc.Status.Capacity.Storage(); capacity != nil && capacity.Cmp(currentSize) > 0 {
+				currentSize = capacity.DeepCopy()
+			}
+			if usage.capacityBytes > 0 {
+				if usedPercent := int32(usage.usedBytes * 100 / usage.capacityBytes); usedPercent > vctStatus.UsedPercent {
+					vctStatus.UsedPercent = usedPercent
+				}
+			}
+			if storageClassName == nil {
+				storageClassName = usage.pvc.Spec.StorageClassName
+			}
+		}
+		vctStatus.CurrentSize = &currentSize
+		thresholdPercent := tpl.ThresholdPercent
+		if thresholdPercent <= 0 {
+			thresholdPercent = defaultStorageThresholdPercent
+		}
+		if vctStatus.UsedPercent < thresholdPercent {
+			continue
+		}
+		if activeOpsName != "" {
+			vctStatus.Message = fmt.Sprintf(`waiting for the OpsRequest "%s" to complete`, activeOpsName)
+			continue
+		}
+		if vctStatus.LastScaleTime != nil &&
+			vctStatus.LastScaleTime.Add(time.Duration(tpl.CooldownSeconds)*time.Second).After(now) {
+			vctStatus.Message = fmt.Sprintf("in the cooldown period since the last expansion at %s",
+				vctStatus.LastScaleTime.Format(time.RFC3339))
+			continue
+		}
+		expandedSize, err := computeExpandedStorageSize(currentSize, tpl.Increment, tpl.MaxSize)
+		if err != nil {
+			vctStatus.Message = err.Error()
+			continue
+		}
+		if expandedSize.Cmp(currentSize) <= 0 {
+			vctStatus.Message = fmt.Sprintf("the volumes have reached the max size %s", tpl.MaxSize.String())
+			r.Recorder.Eventf(policy, corev1.EventTypeWarning, reasonStorageAutoscalingFailed,
+				`The usage of the volumes of "%s" is %d%%, but the volumes have reached the max size %s`,
+				tpl.Name, vctStatus.UsedPercent, tpl.MaxSize.String())
+			continue
+		}
+		if storageClassName == nil {
+			storageClassName = vct.Spec.StorageClassName
+		}
+		allowExpansion, err := r.isStorageClassAllowExpansion(reqCtx, storageClassName)
+		if err != nil {
+			return err
+		}
+		if !allowExpansion {
+			vctStatus.Message = "the storage class of the volumes does not allow volume expansion"
+			continue
+		}
+		vctsToExpand = append(vctsToExpand, appsv1alpha1.OpsRequestVolumeClaimTemplate{
+			Name:    tpl.Name,
+			Storage: expandedSize,
+		})
+	}
+	if len(vctsToExpand) == 0 {
+		return nil
+	}
+
+	opsRequest, err := r.createVolumeExpansionOpsRequest(reqCtx, policy, vctsToExpand)
+	if err != nil {
+		return err
+	}
+	for _, vct := range vctsToExpand {
+		vctStatus := getStorageAutoscalingVolumeStatus(policy, vct.Name)
+		vctStatus.LastScaleTime = &metav1.Time{Time: now}
+		vctStatus.LastOpsRequest = opsRequest.Name
+		r.Recorder.Eventf(policy, corev1.EventTypeNormal, reasonStorageAutoscaling,
+			`The usage of the volumes of "%s" is %d%%, expanding them from %s to %s by the OpsRequest "%s"`,
+			vct.Name, vctStatus.UsedPercent, vctStatus.CurrentSize.String(), vct.Storage.String(), opsRequest.Name)
+	}
+	return nil
+}
+
+// getActiveOpsRequest gets the name of the OpsRequest created by the policy which has not completed yet.
+func (r *StorageAutoscalingPolicyReconciler) getActiveOpsRequest(reqCtx intctrlutil.RequestCtx,
+	policy *appsv1alpha1.StorageAutoscalingPolicy) (string, error) {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reqCtx.Ctx, opsList, client.InNamespace(policy.Namespace),
+		client.MatchingLabels{constant.StorageAutoscalingPolicyLabelKey: policy.Name}); err != nil {
+		return "", err
+	}
+	for _, opsRequest := range opsList.Items {
+		if !opsRequest.IsComplete() {
+			return opsRequest.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// getVolumeUsages gets the usages of the volumes of the volumeClaimTemplates from the usage source of the policy.
+func (r *StorageAutoscalingPolicyReconciler) getVolumeUsages(reqCtx intctrlutil.RequestCtx,
+	policy *appsv1alpha1.StorageAutoscalingPolicy,
+	cluster *appsv1.Cluster,
+	isSharding bool) (map[string][]volumeUsage, error) {
+	matchingLabels := client.MatchingLabels{constant.AppInstanceLabelKey: policy.Spec.ClusterName}
+	if isSharding {
+		matchingLabels[constant.KBAppShardingNameLabelKey] = policy.Spec.ComponentName
+	} else {
+		matchingLabels[constant.KBAppComponentLabelKey] = policy.Spec.ComponentName
+	}
+	pvcList := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(reqCtx.Ctx, pvcList, client.InNamespace(policy.Namespace), matchingLabels); err != nil {
+		return nil, err
+	}
+	if source := policy.Spec.UsageSource.Prometheus; source != nil {
+		return getVolumeUsagesByPrometheus(reqCtx, source.Endpoint, policy.Namespace, pvcList)
+	}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList, client.InNamespace(policy.Namespace), matchingLabels); err != nil {
+		return nil, err
+	}
+	return r.getVolumeUsagesByKBAgent(reqCtx, cluster, pvcList, podList), nil
+}
+
+// getVolumeUsagesByPrometheus queries the kubelet volume metrics of the PVCs from the Prometheus-compatible server.
+func getVolumeUsagesByPrometheus(reqCtx intctrlutil.RequestCtx,
+	endpoint, namespace string, pvcList *corev1.PersistentVolumeClaimList) (map[string][]volumeUsage, error) {
+	pvcs := map[string]*corev1.PersistentVolumeClaim{}
+	var pvcPatterns []string
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if pvc.Labels[constant.VolumeClaimTemplateNameLabelKey] == "" {
+			continue
+		}
+		pvcs[pvc.Name] = pvc
+		pvcPatterns = append(pvcPatterns, strings.ReplaceAll(regexp.QuoteMeta(pvc.Name), `\`, `\\`))
+	}
+	if len(pvcs) == 0 {
+		return nil, nil
+	}
+	selector := fmt.Sprintf(`namespace="%s",persistentvolumeclaim=~"%s"`, namespace, strings.Join(pvcPatterns, "|"))
+	query := func(metric string) (map[string]float64, error) {
+		samples, err := queryPrometheus(reqCtx.Ctx, endpoint, fmt.Sprintf("max by (persistentvolumeclaim) (%s{%s})", metric, selector))
+		if err != nil {
+			return nil, err
+		}
+		values := map[string]float64{}
+		for _, sample := range samples {
+			values[sample.labels["persistentvolumeclaim"]] = sample.value
+		}
+		return values, nil
+	}
+	usedBytes, err := query("kubelet_volume_stats_used_bytes")
+	if err != nil {
+		return nil, err
+	}
+	capacityBytes, err := query("kubelet_volume_stats_capacity_bytes")
+	if err != nil {
+		return nil, err
+	}
+	usages := map[string][]volumeUsage{}
+	for name, pvc := range pvcs {
+		used, ok1 := usedBytes[name]
+		capacity, ok2 := capacityBytes[name]
+		if !ok1 || !ok2 {
+			// the volume is ignored until its metrics are scraped
+			continue
+		}
+		vctName := pvc.Labels[constant.VolumeClaimTemplateNameLabelKey]
+		usages[vctName] = append(usages[vctName], volumeUsage{
+			pvc:           pvc,
+			usedBytes:     uint64(used),
+			capacityBytes: uint64(capacity),
+		})
+	}
+	return usages, nil
+}
+
+func (r *StorageAutoscalingPolicyReconciler) getVolumeUsagesByKBAgent(reqCtx intctrlutil.RequestCtx,
+	cluster *appsv1.Cluster, pvcList *corev1.PersistentVolumeClaimList, podList *corev1.PodList) map[string][]volumeUsage {
+	pvcs := map[string]*corev1.PersistentVolumeClaim{}
+	for i := range pvcList.Items {
+		pvcs[pvcList.Items[i].Name] = &pvcList.Items[i]
+	}
+	usages := map[string][]volumeUsage{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		podUsages, err := getPodVolumeUsages(reqCtx, r.Client, cluster, pod)
+		if err != nil {
+			// the volumes of the pod are ignored until the action succeeds
+			reqCtx.Log.Info("failed to get the volume usage of the pod", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+		for vctName, usage := range podUsages {
+			pvc, ok := pvcs[fmt.Sprintf("%s-%s", vctName, pod.Name)]
+			if !ok || pvc.Labels[constant.VolumeClaimTemplateNameLabelKey] != vctName {
+				continue
+			}
+			usages[vctName] = append(usages[vctName], volumeUsage{
+				pvc:           pvc,
+				usedBytes:     usage.UsedBytes,
+				capacityBytes: usage.CapacityBytes,
+			})
+		}
+	}
+	return usages
+}
+
+func (r *StorageAutoscalingPolicyReconciler) isStorageClassAllowExpansion(reqCtx intctrlutil.RequestCtx, storageClassName *string) (bool, error) {
+	if storageClassName == nil || *storageClassName == "" {
+		return false, nil
+	}
+	storageClass := &storagev1.StorageClass{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: *storageClassName}, storageClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return storageClass.AllowVolumeExpansion != nil && *storageClass.AllowVolumeExpansion, nil
+}
+
+func (r *StorageAutoscalingPolicyReconciler) createVolumeExpansionOpsRequest(reqCtx intctrlutil.RequestCtx,
+	policy *appsv1alpha1.StorageAutoscalingPolicy,
+	vcts []appsv1alpha1.OpsRequestVolumeClaimTemplate) (*appsv1alpha1.OpsRequest, error) {
+	opsRequest := &appsv1alpha1.OpsRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: policy.Name + "-",
+			Namespace:    policy.Namespace,
+			Labels: map[string]string{
+				constant.StorageAutoscalingPolicyLabelKey: policy.Name,
+			},
+		},
+		Spec: appsv1alpha1.OpsRequestSpec{
+			ClusterName: policy.Spec.ClusterName,
+			Type:        appsv1alpha1.VolumeExpansionType,
+			SpecificOpsRequest: appsv1alpha1.SpecificOpsRequest{
+				VolumeExpansionList: []appsv1alpha1.VolumeExpansion{
+					{
+						ComponentOps:         appsv1alpha1.ComponentOps{ComponentName: policy.Spec.ComponentName},
+						VolumeClaimTemplates: vcts,
+					},
+				},
+			},
+		},
+	}
+	if err := intctrlutil.SetControllerReference(policy, opsRequest); err != nil {
+		return nil, err
+	}
+	if err := r.Client.Create(reqCtx.Ctx, opsRequest); err != nil {
+		return nil, err
+	}
+	return opsRequest, nil
+}
+
+// getComponentSpecOrShardingTemplate gets the spec of the component, or the template of the sharding with the name.
+func getComponentSpecOrShardingTemplate(cluster *appsv1.Cluster, name string) (*appsv1.ClusterComponentSpec, bool) {
+	if compSpec := cluster.Spec.GetComponentByName(name); compSpec != nil {
+		return compSpec, false
+	}
+	if sharding := cluster.Spec.GetShardingByName(name); sharding != nil {
+		return &sharding.Template, true
+	}
+	return nil, false
+}
+
+func getStorageAutoscalingVolumeStatus(policy *appsv1alpha1.StorageAutoscalingPolicy, vctName string) *appsv1alpha1.StorageAutoscalingVolumeStatus {
+	for i := range policy.Status.VolumeClaimTemplates {
+		if policy.Status.VolumeClaimTemplates[i].Name == vctName {
+			return &policy.Status.VolumeClaimTemplates[i]
+		}
+	}
+	policy.Status.VolumeClaimTemplates = append(policy.Status.VolumeClaimTemplates,
+		appsv1alpha1.StorageAutoscalingVolumeStatus{Name: vctName})
+	return &policy.Status.VolumeClaimTemplates[len(policy.Status.VolumeClaimTemplates)-1]
+}
+
+// computeExpandedStorageSize computes the size to expand the volumes to, which does not exceed the max size.
+// The size increased by a percentage is rounded up to GiB.
+func computeExpandedStorageSize(currentSize resource.Quantity, increment string, maxSize resource.Quantity) (resource.Quantity, error) {
+	if increment == "" {
+		increment = defaultStorageIncrement
+	}
+	var expandedSize resource.Quantity
+	if strings.HasSuffix(increment, "%") {
+		percent, err := strconv.ParseInt(strings.TrimSuffix(increment, "%"), 10, 64)
+		if err != nil || percent <= 0 {
+			return expandedSize, fmt.Errorf(`invalid increment "%s"`, increment)
+		}
+		const gi = int64(1) << 30
+		value := currentSize.Value() + currentSize.Value()*percent/100
+		value = (value + gi - 1) / gi * gi
+		expandedSize = *resource.NewQuantity(value, resource.BinarySI)
+	} else {
+		delta, err := resource.ParseQuantity(increment)
+		if err != nil || delta.Sign() <= 0 {
+			return expandedSize, fmt.Errorf(`invalid increment "%s"`, increment)
+		}
+		expandedSize = currentSize.DeepCopy()
+		expandedSize.Add(delta)
+	}
+	if expandedSize.Cmp(maxSize) > 0 {
+		expandedSize = maxSize.DeepCopy()
+	}
+	return expandedSize, nil
+}
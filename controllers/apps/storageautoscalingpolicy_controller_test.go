@@ -0,0 +1,245 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+)
+
+var _ = Describe("StorageAutoscalingPolicy Controller", func() {
+	const (
+		compName = "mysql"
+		nodeName = "test-node"
+	)
+
+	var (
+		randomStr        = testCtx.GetRandomStr()
+		clusterName      = "test-cluster-" + randomStr
+		storageClassName = "test-sc-" + randomStr
+		pvcName          = fmt.Sprintf("%s-%s-%s-0", testapps.DataVolumeName, clusterName, compName)
+		origUsagesGetter = getPodVolumeUsages
+		origQuery        = queryPrometheus
+	)
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		// delete cluster(and all dependent sub-resources), cluster definition
+		testapps.ClearClusterResourcesWithRemoveFinalizerOption(&testCtx)
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+		// namespaced
+		testapps.ClearResources(&testCtx, generics.StorageAutoscalingPolicySignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.OpsRequestSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PodSignature, true, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, inNS, ml)
+		// non-namespaced
+		testapps.ClearResources(&testCtx, generics.StorageClassSignature, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(func() {
+		getPodVolumeUsages = origUsagesGetter
+		queryPrometheus = origQuery
+		cleanEnv()
+	})
+
+	mockVolumeUsage := func(usedBytes, capacityBytes uint64) {
+		queryPrometheus = func(_ context.Context, _, _ string) ([]prometheusSample, error) {
+			return nil, fmt.Errorf("the Prometheus should not be queried")
+		}
+		getPodVolumeUsages = func(_ intctrlutil.RequestCtx, _ client.Client, cluster *appsv1.Cluster, pod *corev1.Pod) (map[string]podVolumeUsage, error) {
+			Expect(cluster.Name).Should(Equal(clusterName))
+			Expect(pod.Name).Should(Equal(fmt.Sprintf("%s-%s-0", clusterName, compName)))
+			return map[string]podVolumeUsage{
+				testapps.DataVolumeName: {UsedBytes: usedBytes, CapacityBytes: capacityBytes},
+			}, nil
+		}
+	}
+
+	mockPrometheusVolumeUsage := func(usedBytes, capacityBytes uint64) {
+		getPodVolumeUsages = func(_ intctrlutil.RequestCtx, _ client.Client, _ *appsv1.Cluster, _ *corev1.Pod) (map[string]podVolumeUsage, error) {
+			return nil, fmt.Errorf("the volumeUsage action should not be called")
+		}
+		queryPrometheus = func(_ context.Context, endpoint, query string) ([]prometheusSample, error) {
+			Expect(endpoint).Should(Equal("http://prometheus:9090"))
+			Expect(query).Should(ContainSubstring(fmt.Sprintf(`namespace="%s",persistentvolumeclaim=~"%s"`, testCtx.DefaultNamespace, pvcName)))
+			value := usedBytes
+			if strings.Contains(query, "kubelet_volume_stats_capacity_bytes") {
+				value = capacityBytes
+			}
+			return []prometheusSample{{labels: map[string]string{"persistentvolumeclaim": pvcName}, value: float64(value)}}, nil
+		}
+	}
+
+	createClusterAndVolumes := func() {
+		testapps.CreateStorageClass(&testCtx, storageClassName, true)
+		pvcSpec := testapps.NewPVCSpec("10Gi")
+		pvcSpec.StorageClassName = &storageClassName
+		testapps.NewClusterFactory(testCtx.DefaultNamespace, clusterName, "").
+			AddComponent(compName, "test-compdef-"+randomStr).
+			AddVolumeClaimTemplate(testapps.DataVolumeName, pvcSpec).
+			Create(&testCtx)
+
+		pvc := testapps.NewPersistentVolumeClaimFactory(testCtx.DefaultNamespace, pvcName, clusterName,
+			compName, testapps.DataVolumeName).
+			SetStorage("10Gi").
+			SetStorageClass(storageClassName).
+			Create(&testCtx).
+			GetObject()
+		Expect(testapps.GetAndChangeObjStatus(&testCtx, client.ObjectKeyFromObject(pvc), func(pvc *corev1.PersistentVolumeClaim) {
+			pvc.Status.Phase = corev1.ClaimBound
+			pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")}
+		})()).ShouldNot(HaveOccurred())
+
+		testapps.NewPodFactory(testCtx.DefaultNamespace, fmt.Sprintf("%s-%s-0", clusterName, compName)).
+			AddAppInstanceLabel(clusterName).
+			AddAppComponentLabel(compName).
+			AddContainer(corev1.Container{Name: compName, Image: testapps.ApeCloudMySQLImage}).
+			AddVolume(corev1.Volume{
+				Name: testapps.DataVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+				},
+			}).
+			AddNodeName(nodeName).
+			Create(&testCtx)
+	}
+
+	newPolicy := func() *appsv1alpha1.StorageAutoscalingPolicy {
+		return &appsv1alpha1.StorageAutoscalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "storage-autoscaling-" + randomStr,
+				Namespace: testCtx.DefaultNamespace,
+			},
+			Spec: appsv1alpha1.StorageAutoscalingPolicySpec{
+				ClusterName:   clusterName,
+				ComponentName: compName,
+				VolumeClaimTemplates: []appsv1alpha1.StorageAutoscalingVolumeClaimTemplate{
+					{
+						Name:             testapps.DataVolumeName,
+						ThresholdPercent: 80,
+						Increment:        "20%",
+						MaxSize:          resource.MustParse("100Gi"),
+						CooldownSeconds:  3600,
+					},
+				},
+			},
+		}
+	}
+
+	Context("Test StorageAutoscalingPolicy", func() {
+		It("Test computing the expanded size", func() {
+			tenGi := resource.MustParse("10Gi")
+			maxSize := resource.MustParse("20Gi")
+			for _, tt := range []struct {
+				increment string
+				expected  string
+			}{
+				{"20%", "12Gi"},
+				{"5%", "11Gi"},
+				{"5Gi", "15Gi"},
+				{"200%", "20Gi"},
+			} {
+				size, err := computeExpandedStorageSize(tenGi, tt.increment, maxSize)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(size.Cmp(resource.MustParse(tt.expected))).Should(BeZero(), "increment: %s", tt.increment)
+			}
+			_, err := computeExpandedStorageSize(tenGi, "abc", maxSize)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("Test the volumes are not expanded if the usage is below the threshold", func() {
+			createClusterAndVolumes()
+			mockVolumeUsage(5<<30, 10<<30)
+			policy := newPolicy()
+			Expect(testCtx.CreateObj(testCtx.Ctx, policy)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(policy), func(g Gomega, policy *appsv1alpha1.StorageAutoscalingPolicy) {
+				g.Expect(policy.Status.VolumeClaimTemplates).Should(HaveLen(1))
+				g.Expect(policy.Status.VolumeClaimTemplates[0].UsedPercent).Should(BeEquivalentTo(50))
+				g.Expect(policy.Status.VolumeClaimTemplates[0].LastOpsRequest).Should(BeEmpty())
+			})).Should(Succeed())
+		})
+
+		It("Test the VolumeExpansion OpsRequest is created if the usage exceeds the threshold", func() {
+			createClusterAndVolumes()
+			mockVolumeUsage(9<<30, 10<<30)
+			policy := newPolicy()
+			Expect(testCtx.CreateObj(testCtx.Ctx, policy)).Should(Succeed())
+
+			var opsName string
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(policy), func(g Gomega, policy *appsv1alpha1.StorageAutoscalingPolicy) {
+				g.Expect(policy.Status.VolumeClaimTemplates).Should(HaveLen(1))
+				g.Expect(policy.Status.VolumeClaimTemplates[0].UsedPercent).Should(BeEquivalentTo(90))
+				g.Expect(policy.Status.VolumeClaimTemplates[0].LastOpsRequest).ShouldNot(BeEmpty())
+				g.Expect(policy.Status.VolumeClaimTemplates[0].LastScaleTime).ShouldNot(BeNil())
+				opsName = policy.Status.VolumeClaimTemplates[0].LastOpsRequest
+			})).Should(Succeed())
+
+			opsRequest := &appsv1alpha1.OpsRequest{}
+			Expect(k8sClient.Get(testCtx.Ctx, client.ObjectKey{Name: opsName, Namespace: testCtx.DefaultNamespace}, opsRequest)).Should(Succeed())
+			Expect(opsRequest.Labels).Should(HaveKeyWithValue(constant.StorageAutoscalingPolicyLabelKey, policy.Name))
+			Expect(opsRequest.Spec.Type).Should(Equal(appsv1alpha1.VolumeExpansionType))
+			Expect(opsRequest.Spec.VolumeExpansionList).Should(HaveLen(1))
+			vcts := opsRequest.Spec.VolumeExpansionList[0].VolumeClaimTemplates
+			Expect(vcts).Should(HaveLen(1))
+			Expect(vcts[0].Storage.Cmp(resource.MustParse("12Gi"))).Should(BeZero())
+		})
+
+		It("Test the usage is collected from Prometheus", func() {
+			createClusterAndVolumes()
+			mockPrometheusVolumeUsage(9<<30, 10<<30)
+			policy := newPolicy()
+			policy.Spec.UsageSource = appsv1alpha1.StorageUsageSource{
+				Prometheus: &appsv1alpha1.PrometheusUsageSource{Endpoint: "http://prometheus:9090"},
+			}
+			Expect(testCtx.CreateObj(testCtx.Ctx, policy)).Should(Succeed())
+
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(policy), func(g Gomega, policy *appsv1alpha1.StorageAutoscalingPolicy) {
+				g.Expect(policy.Status.VolumeClaimTemplates).Should(HaveLen(1))
+				g.Expect(policy.Status.VolumeClaimTemplates[0].UsedPercent).Should(BeEquivalentTo(90))
+				g.Expect(policy.Status.VolumeClaimTemplates[0].LastOpsRequest).ShouldNot(BeEmpty())
+			})).Should(Succeed())
+		})
+	})
+})
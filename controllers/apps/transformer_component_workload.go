@@ -24,16 +24,20 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
+	appv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
@@ -265,11 +269,22 @@ func copyAndMergeITS(oldITS, newITS *workloads.InstanceSet, synthesizeComp *comp
 	}
 
 	updateUpdateStrategy := func(itsObj, itsProto *workloads.InstanceSet) {
-		var objMaxUnavailable *intstr.IntOrString
+		var (
+			objMaxUnavailable *intstr.IntOrString
+			objPartition      *int32
+		)
 		if itsObj.Spec.UpdateStrategy.RollingUpdate != nil {
 			objMaxUnavailable = itsObj.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable
+			objPartition = itsObj.Spec.UpdateStrategy.RollingUpdate.Partition
 		}
 		itsObj.Spec.UpdateStrategy = itsProto.Spec.UpdateStrategy
+		if objPartition != nil && itsObj.Annotations[constant.OpsGatedRolloutAnnotationKey] != "" {
+			// keep the partition set by the opsRequest which rolls out the pods with the health gate.
+			if itsObj.Spec.UpdateStrategy.RollingUpdate == nil {
+				itsObj.Spec.UpdateStrategy.RollingUpdate = &appv1.RollingUpdateStatefulSetStrategy{}
+			}
+			itsObj.Spec.UpdateStrategy.RollingUpdate.Partition = objPartition
+		}
 		if objMaxUnavailable == nil && itsObj.Spec.UpdateStrategy.RollingUpdate != nil {
 			// HACK: This field is alpha-level (since v1.24) and is only honored by servers that enable the
 			// MaxUnavailableStatefulSet feature.
@@ -617,6 +632,42 @@ func (r *componentWorkloadOps) leaveMember4ScaleIn() error {
 		return err
 	}
 
+	graphCli := model.NewGraphClient(r.cli)
+	// preScaleIn moves the data off the leaving pod, and returns whether the action has completed.
+	// The progress reported by the action is recorded in the annotation of the pod, and the action will not be
+	// called again once it has completed.
+	preScaleIn := func(lfa lifecycle.Lifecycle, pod *corev1.Pod) (bool, error) {
+		if r.synthesizeComp.LifecycleActions == nil || r.synthesizeComp.LifecycleActions.PreScaleIn == nil {
+			return true, nil
+		}
+		if pod.Annotations[constant.PreScaleInProgressAnnotationKey] == preScaleInCompleted {
+			return true, nil
+		}
+		output, err := lfa.PreScaleIn(r.reqCtx.Ctx, r.cli, &lifecycle.Options{
+			NonBlocking:    pointer.Bool(true),
+			ReportProgress: pointer.Bool(true),
+		})
+		progress := preScaleInCompleted
+		if err != nil {
+			if errors.Is(err, lifecycle.ErrActionNotDefined) {
+				return true, nil
+			}
+			if !errors.Is(err, lifecycle.ErrActionInProgress) {
+				return false, err
+			}
+			progress = parsePreScaleInProgress(output, pod.Annotations[constant.PreScaleInProgressAnnotationKey])
+		}
+		if pod.Annotations[constant.PreScaleInProgressAnnotationKey] != progress {
+			podCopy := pod.DeepCopy()
+			if podCopy.Annotations == nil {
+				podCopy.Annotations = map[string]string{}
+			}
+			podCopy.Annotations[constant.PreScaleInProgressAnnotationKey] = progress
+			graphCli.Do(r.dag, pod, podCopy, model.ActionPatchPtr(), nil, inDataContext4G())
+		}
+		return err == nil, nil
+	}
+
 	// TODO: Move memberLeave to the ITS controller. Instead of performing a switchover, we can directly scale down the non-leader nodes. This is because the pod ordinal is not guaranteed to be continuous.
 	podsToMemberLeave := make([]*corev1.Pod, 0)
 	for _, pod := range pods {
@@ -626,9 +677,11 @@ func (r *componentWorkloadOps) leaveMember4ScaleIn() error {
 		}
 		podsToMemberLeave = append(podsToMemberLeave, pod)
 	}
+	preScaleInInProgress := false
 	for _, pod := range podsToMemberLeave {
 		if !(isLeader(pod) || // if the pod is leader, it needs to call switchover
-			(r.synthesizeComp.LifecycleActions != nil && r.synthesizeComp.LifecycleActions.MemberLeave != nil)) { // if the memberLeave action is defined, it needs to call it
+			(r.synthesizeComp.LifecycleActions != nil && r.synthesizeComp.LifecycleActions.MemberLeave != nil) || // if the memberLeave action is defined, it needs to call it
+			(r.synthesizeComp.LifecycleActions != nil && r.synthesizeComp.LifecycleActions.PreScaleIn != nil)) { // if the preScaleIn action is defined, it needs to call it
 			continue
 		}
 
@@ -645,15 +698,47 @@ func (r *componentWorkloadOps) leaveMember4ScaleIn() error {
 			return switchoverErr
 		}
 
+		// move the data off the leaving pod before it leaves the membership
+		completed, preScaleInErr := preScaleIn(lfa, pod)
+		if preScaleInErr != nil {
+			if err == nil {
+				err = preScaleInErr
+			}
+			continue
+		}
+		if !completed {
+			preScaleInInProgress = true
+			continue
+		}
+
 		if err2 := lfa.MemberLeave(r.reqCtx.Ctx, r.cli, nil); err2 != nil {
 			if !errors.Is(err2, lifecycle.ErrActionNotDefined) && err == nil {
 				err = err2
 			}
 		}
 	}
+	if err == nil && preScaleInInProgress {
+		return intctrlutil.NewRequeueError(time.Second*5, "wait for the preScaleIn action to complete")
+	}
 	return err // TODO: use requeue-after
 }
 
+const preScaleInCompleted = "100"
+
+// parsePreScaleInProgress parses the progress reported by the preScaleIn action, which is an integer
+// between 0 and 100, with an optional percent sign. The @last progress is kept if the output is not a valid one.
+func parsePreScaleInProgress(output []byte, last string) string {
+	progress, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(string(output)), "%"))
+	if err != nil || progress < 0 || progress > 100 {
+		return last
+	}
+	// the action is not considered as completed until it returns successfully
+	if progress == 100 {
+		progress = 99
+	}
+	return strconv.Itoa(progress)
+}
+
 func (r *componentWorkloadOps) deletePVCs4ScaleIn(itsObj *workloads.InstanceSet) error {
 	graphCli := model.NewGraphClient(r.cli)
 	for _, podName := range r.runningItsPodNames {
@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,6 +32,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -112,8 +115,21 @@ func (r *BackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		}
 	}
 
+	// the backup copy is taken over by the backup copy controller until it is completed or failed.
+	if backup.Labels[dptypes.BackupCopySourceLabelKey] != "" {
+		switch backup.Status.Phase {
+		case "", dpv1alpha1.BackupPhaseNew, dpv1alpha1.BackupPhaseRunning:
+			return intctrlutil.Reconciled()
+		}
+	}
+
+	// the status of the imported backup is set by the backup repo controller.
+	if backup.Labels[dptypes.BackupImportedFromRepoLabelKey] != "" && backup.Status.Phase == "" {
+		return intctrlutil.Reconciled()
+	}
+
 	switch backup.Status.Phase {
-	case "", dpv1alpha1.BackupPhaseNew:
+	case "", dpv1alpha1.BackupPhaseNew, dpv1alpha1.BackupPhasePending:
 		return r.handleNewPhase(reqCtx, backup)
 	case dpv1alpha1.BackupPhaseRunning:
 		return r.handleRunningPhase(reqCtx, backup)
@@ -274,6 +290,10 @@ func (r *BackupReconciler) handleNewPhase(
 	if err = r.recordBackupStatusTargets(reqCtx, request); err != nil {
 		return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
 	}
+	// record the nodes of the target pods for limiting the concurrent backups on each node.
+	if err = r.setBackupTargetNodes(reqCtx, request); err != nil {
+		return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
+	}
 	backupStatusCopy := request.Backup.Status.DeepCopy()
 	// set and patch backup object meta, including labels, annotations and finalizers
 	// if the backup object meta is changed, the backup object will be patched.
@@ -283,6 +303,12 @@ func (r *BackupReconciler) handleNewPhase(
 		return intctrlutil.Reconciled()
 	}
 	request.Backup.Status = *backupStatusCopy
+	// wait in the Pending phase if the concurrency limits are reached.
+	if admitted, err := r.admitBackup(reqCtx, backup, request); err != nil {
+		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
+	} else if !admitted {
+		return intctrlutil.RequeueAfter(pendingCheckInterval, reqCtx.Log, "backup is pending")
+	}
 	// set and patch backup status
 	if err = r.patchBackupStatus(backup, request); err != nil {
 		return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
@@ -431,9 +457,54 @@ func (r *BackupReconciler) prepareBackupRequest(
 		}
 	}
 	request.BackupMethod = backupMethod
+	if dpbackup.IsConsolidationBackup(backup) {
+		if err = r.prepareConsolidatedBackups(reqCtx, request); err != nil {
+			return nil, err
+		}
+	}
 	return request, nil
 }
 
+// prepareConsolidatedBackups prepares the incremental backup chain consolidated by the synthetic full backup.
+// The chain is resolved when the backup is new, and recorded in the annotation of the backup.
+func (r *BackupReconciler) prepareConsolidatedBackups(
+	reqCtx intctrlutil.RequestCtx,
+	request *dpbackup.Request) error {
+	if request.SnapshotVolumes || request.ActionSet == nil ||
+		request.ActionSet.Spec.BackupType != dpv1alpha1.BackupTypeIncremental {
+		return intctrlutil.NewFatalError(fmt.Sprintf(`backup method "%s" does not support the consolidation`,
+			request.Spec.BackupMethod))
+	}
+	names := dpbackup.GetConsolidatedBackupNames(request.Backup)
+	if len(names) == 0 {
+		backupList := &dpv1alpha1.BackupList{}
+		if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(request.Namespace)); err != nil {
+			return err
+		}
+		chain, err := dpbackup.GetConsolidationChain(request.Backup, backupList.Items, request.BackupRepo.Name)
+		if err != nil {
+			return err
+		}
+		for _, item := range chain {
+			names = append(names, item.Name)
+		}
+		request.Annotations[dptypes.ConsolidatedBackupsAnnotationKey] = strings.Join(names, ",")
+		request.ConsolidatedBackups = chain
+		return nil
+	}
+	for _, name := range names {
+		consolidated := &dpv1alpha1.Backup{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: name, Namespace: request.Namespace}, consolidated); err != nil {
+			if apierrors.IsNotFound(err) {
+				return intctrlutil.NewFatalError(fmt.Sprintf(`consolidated backup "%s" not found`, name))
+			}
+			return err
+		}
+		request.ConsolidatedBackups = append(request.ConsolidatedBackups, consolidated)
+	}
+	return nil
+}
+
 // prepareRequestTargetInfo prepares the backup target info for request object.
 func (r *BackupReconciler) prepareRequestTargetInfo(reqCtx intctrlutil.RequestCtx,
 	request *dpbackup.Request,
@@ -489,6 +560,13 @@ func (r *BackupReconciler) patchBackupStatus(
 	if request.BackupPolicy.Spec.EncryptionConfig != nil {
 		request.Status.EncryptionConfig = request.BackupPolicy.Spec.EncryptionConfig
 	}
+	// the synthetic full backup covers the time range of the consolidated backup chain.
+	if n := len(request.ConsolidatedBackups); n > 0 && request.Status.TimeRange == nil {
+		request.Status.TimeRange = &dpv1alpha1.BackupTimeRange{
+			Start: request.ConsolidatedBackups[0].GetStartTime(),
+			End:   request.ConsolidatedBackups[n-1].GetEndTime(),
+		}
+	}
 	// init action status
 	actions, err := request.BuildActions()
 	if err != nil {
@@ -562,6 +640,9 @@ func (r *BackupReconciler) handleRunningPhase(
 					return r.updateStatusIfFailed(reqCtx, backup, request.Backup, err)
 				}
 				status.TargetPodName = targetPodName
+				if err = request.RecordTargetPodBackupInfo(status); err != nil {
+					return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+				}
 				mergeActionStatus(request, status)
 				switch status.Phase {
 				case dpv1alpha1.ActionPhaseCompleted:
@@ -589,6 +670,7 @@ func (r *BackupReconciler) handleRunningPhase(
 			fmt.Errorf("there are failed actions, you can obtain the more informations in the status.actions"))
 	}
 	// all actions completed, update backup status to completed
+	aggregateTargetPodsBackupInfo(&request.Status)
 	request.Status.Phase = dpv1alpha1.BackupPhaseCompleted
 	request.Status.CompletionTimestamp = &metav1.Time{Time: r.clock.Now().UTC()}
 	if !request.Status.StartTimestamp.IsZero() {
@@ -674,13 +756,126 @@ func (r *BackupReconciler) checkIsCompletedDuringRunning(reqCtx intctrlutil.Requ
 func (r *BackupReconciler) handleCompletedPhase(
 	reqCtx intctrlutil.RequestCtx,
 	backup *dpv1alpha1.Backup) (ctrl.Result, error) {
-	if err := r.deleteExternalResources(reqCtx, backup); err != nil {
+	if err := r.reparentIncrementalBackups(reqCtx, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	checking, err := r.checkBackupIntegrity(reqCtx, backup)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if checking {
+		// wait for the job checking the integrity to be finished.
+		return intctrlutil.Reconciled()
+	}
+	if err = r.deleteExternalResources(reqCtx, backup); err != nil {
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 
 	return intctrlutil.Reconciled()
 }
 
+// reparentIncrementalBackups re-parents the incremental backups whose parent is the latest backup consolidated
+// by the synthetic full backup to the synthetic full backup, so that the backups of the consolidated chain
+// are no longer depended on, and can be released by the garbage collection.
+func (r *BackupReconciler) reparentIncrementalBackups(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) error {
+	if !dpbackup.IsConsolidationBackup(backup) {
+		return nil
+	}
+	names := dpbackup.GetConsolidatedBackupNames(backup)
+	if len(names) == 0 {
+		return nil
+	}
+	tip := names[len(names)-1]
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(backup.Namespace)); err != nil {
+		return err
+	}
+	for i := range backupList.Items {
+		item := &backupList.Items[i]
+		if dputils.GetParentBackupName(item) != tip || item.Name == backup.Name || !item.DeletionTimestamp.IsZero() {
+			continue
+		}
+		// the parent in the spec is immutable, the new parent is recorded in the status.
+		patch := client.MergeFrom(item.DeepCopy())
+		item.Status.ParentBackupName = backup.Name
+		if err := r.Client.Status().Patch(reqCtx.Ctx, item, patch); err != nil {
+			return err
+		}
+		reqCtx.Log.Info("re-parent the incremental backup to the synthetic full backup",
+			"backup", item.Name, "oldParent", tip)
+	}
+	return nil
+}
+
+// checkBackupIntegrity checks the backup data in the backup repo against the integrity manifests
+// if it is requested by the annotation. It returns true if the check is still running, the result
+// of the check is recorded in the status and does not change the phase of the backup.
+func (r *BackupReconciler) checkBackupIntegrity(
+	reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (bool, error) {
+	if _, ok := backup.Annotations[dptypes.VerifyIntegrityAnnotationKey]; !ok {
+		return false, nil
+	}
+	original := backup.DeepCopy()
+	if backup.Status.Integrity == nil {
+		backup.Status.Integrity = &dpv1alpha1.BackupIntegrity{}
+	}
+	check := backup.Status.Integrity.LastCheck
+	if check == nil || check.Phase != dpv1alpha1.BackupIntegrityCheckPhaseRunning {
+		check = &dpv1alpha1.BackupIntegrityCheck{
+			Phase:          dpv1alpha1.BackupIntegrityCheckPhaseRunning,
+			StartTimestamp: &metav1.Time{Time: r.clock.Now().UTC()},
+		}
+		backup.Status.Integrity.LastCheck = check
+	}
+
+	finished, err := func() (bool, error) {
+		repo := &dpv1alpha1.BackupRepo{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: backup.Status.BackupRepoName}, repo); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, intctrlutil.NewFatalError(fmt.Sprintf("backup repo %s is not found", backup.Status.BackupRepoName))
+			}
+			return false, err
+		}
+		saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+		if err != nil {
+			return false, err
+		}
+		checker := &dpbackup.IntegrityChecker{
+			RequestCtx:           reqCtx,
+			Client:               r.Client,
+			Scheme:               r.Scheme,
+			WorkerServiceAccount: saName,
+		}
+		return checker.CheckIntegrity(backup, repo, *check.StartTimestamp)
+	}()
+	switch {
+	case intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal):
+		check.Phase = dpv1alpha1.BackupIntegrityCheckPhaseFailed
+		check.FailureReason = err.Error()
+		r.Recorder.Event(backup, corev1.EventTypeWarning, ReasonIntegrityCheckFailed, err.Error())
+	case err != nil:
+		return false, err
+	case !finished:
+		return true, r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original))
+	default:
+		check.Phase = dpv1alpha1.BackupIntegrityCheckPhasePassed
+		r.Recorder.Event(backup, corev1.EventTypeNormal, ReasonIntegrityCheckPassed,
+			"the backup data matches the integrity manifests")
+	}
+	check.CompletionTimestamp = &metav1.Time{Time: r.clock.Now().UTC()}
+	if err = r.Client.Status().Patch(reqCtx.Ctx, backup, client.MergeFrom(original)); err != nil {
+		return false, err
+	}
+
+	// remove the annotation, so that the check can be requested again.
+	patch := client.MergeFrom(backup.DeepCopy())
+	delete(backup.Annotations, dptypes.VerifyIntegrityAnnotationKey)
+	return false, r.Client.Patch(reqCtx.Ctx, backup, patch)
+}
+
 func (r *BackupReconciler) updateStatusIfFailed(
 	reqCtx intctrlutil.RequestCtx,
 	original *dpv1alpha1.Backup,
@@ -816,6 +1011,58 @@ func updateBackupStatusByActionStatus(backupStatus *dpv1alpha1.BackupStatus) {
 	}
 }
 
+// aggregateTargetPodsBackupInfo aggregates the backup info of the target pods when all the pods
+// of a target are backed up. The total size is the sum of the sizes of the target pods, and the
+// time range covers the time ranges of all the target pods.
+func aggregateTargetPodsBackupInfo(backupStatus *dpv1alpha1.BackupStatus) {
+	targets := backupStatus.Targets
+	if backupStatus.Target != nil {
+		targets = append(targets, *backupStatus.Target)
+	}
+	var targetPods []string
+	for _, target := range targets {
+		if target.PodSelector != nil && target.PodSelector.Strategy == dpv1alpha1.PodSelectionStrategyAll {
+			targetPods = append(targetPods, target.SelectedTargetPods...)
+		}
+	}
+	if len(targetPods) == 0 {
+		return
+	}
+	var (
+		totalSize resource.Quantity
+		timeRange *dpv1alpha1.BackupTimeRange
+		hasSize   bool
+	)
+	for _, act := range backupStatus.Actions {
+		if !slices.Contains(targetPods, act.TargetPodName) {
+			continue
+		}
+		if size, err := resource.ParseQuantity(act.TotalSize); err == nil {
+			totalSize.Add(size)
+			hasSize = true
+		}
+		if act.TimeRange == nil {
+			continue
+		}
+		if timeRange == nil {
+			timeRange = act.TimeRange.DeepCopy()
+			continue
+		}
+		if act.TimeRange.Start != nil && (timeRange.Start == nil || act.TimeRange.Start.Before(timeRange.Start)) {
+			timeRange.Start = act.TimeRange.Start.DeepCopy()
+		}
+		if act.TimeRange.End != nil && (timeRange.End == nil || timeRange.End.Before(act.TimeRange.End)) {
+			timeRange.End = act.TimeRange.End.DeepCopy()
+		}
+	}
+	if hasSize {
+		backupStatus.TotalSize = totalSize.String()
+	}
+	if timeRange != nil {
+		backupStatus.TimeRange = timeRange
+	}
+}
+
 func setEncryptedSystemAccountsAnnotation(request *dpbackup.Request, cluster *kbappsv1.Cluster) error {
 	usernameKey := constant.AccountNameForSecret
 	passwordKey := constant.AccountPasswdForSecret
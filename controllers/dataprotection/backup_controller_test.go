@@ -215,6 +215,63 @@ var _ = Describe("Backup Controller test", func() {
 			})
 		})
 
+		Context("re-parents the incremental backups", func() {
+			It("should record the synthetic full backup as the parent in the status", func() {
+				newBackup := func(name, parent string, change func(*dpv1alpha1.Backup)) *dpv1alpha1.Backup {
+					return testdp.NewBackupFactory(testCtx.DefaultNamespace, name).
+						SetBackupPolicyName("not-found").
+						SetBackupMethod(testdp.BackupMethodName).
+						Apply(func(backup *dpv1alpha1.Backup) {
+							backup.Spec.ParentBackupName = parent
+							if change != nil {
+								change(backup)
+							}
+						}).
+						Create(&testCtx).GetObject()
+				}
+
+				By("creating the incremental backup chain and the synthetic full backup consolidating it")
+				_ = newBackup("full", "", nil)
+				_ = newBackup("inc-1", "full", nil)
+				child := newBackup("inc-2", "inc-1", nil)
+				sibling := newBackup("inc-other", "full", nil)
+				synthetic := newBackup("synthetic", "", func(backup *dpv1alpha1.Backup) {
+					backup.Labels[dptypes.BackupConsolidationLabelKey] = trueVal
+					backup.Annotations = map[string]string{dptypes.ConsolidatedBackupsAnnotationKey: "full,inc-1"}
+				})
+
+				By("waiting for all the backups to be handled, they fail because the backupPolicy is not found")
+				for _, name := range []string{"full", "inc-1", "inc-2", "inc-other", "synthetic"} {
+					Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: name},
+						func(g Gomega, fetched *dpv1alpha1.Backup) {
+							g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseFailed))
+						})).Should(Succeed())
+				}
+
+				By("completing the synthetic full backup")
+				testdp.PatchBackupStatus(&testCtx, client.ObjectKeyFromObject(synthetic), dpv1alpha1.BackupStatus{
+					Phase: dpv1alpha1.BackupPhaseCompleted,
+				})
+
+				By("the child of the consolidated tip is re-parented to the synthetic full backup")
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(child), func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Spec.ParentBackupName).Should(Equal("inc-1"))
+					g.Expect(fetched.Status.ParentBackupName).Should(Equal(synthetic.Name))
+					g.Expect(dputils.GetParentBackupName(fetched)).Should(Equal(synthetic.Name))
+				})).Should(Succeed())
+
+				By("the other backups are not re-parented")
+				Consistently(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(sibling), func(g Gomega, fetched *dpv1alpha1.Backup) {
+					g.Expect(fetched.Status.ParentBackupName).Should(BeEmpty())
+					g.Expect(dputils.GetParentBackupName(fetched)).Should(Equal("full"))
+				})).Should(Succeed())
+				Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: "inc-1"},
+					func(g Gomega, fetched *dpv1alpha1.Backup) {
+						g.Expect(fetched.Status.ParentBackupName).Should(BeEmpty())
+					})).Should(Succeed())
+			})
+		})
+
 		Context("creates a backup with retentionPeriod", func() {
 			It("create a valid backup", func() {
 				By("creating a backup from backupPolicy " + testdp.BackupPolicyName)
@@ -321,8 +378,46 @@ var _ = Describe("Backup Controller test", func() {
 					g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
 					g.Expect(fetched.Status.CompletionTimestamp).ShouldNot(BeNil())
 					g.Expect(fetched.Status.Expiration.Second()).Should(Equal(fetched.Status.CompletionTimestamp.Add(time.Hour).Second()))
+					g.Expect(fetched.Status.Target.SelectedTargetPods).Should(Equal([]string{
+						testdp.ClusterName + "-" + testdp.ComponentName + "-0",
+						testdp.ClusterName + "-" + testdp.ComponentName + "-1",
+					}))
 				})).Should(Succeed())
 			})
+
+			It("aggregates the backup info of all the target pods", func() {
+				now := time.Now()
+				backupStatus := &dpv1alpha1.BackupStatus{
+					Target: &dpv1alpha1.BackupStatusTarget{
+						BackupTarget: dpv1alpha1.BackupTarget{
+							PodSelector: &dpv1alpha1.PodSelector{Strategy: dpv1alpha1.PodSelectionStrategyAll},
+						},
+						SelectedTargetPods: []string{"pod-0", "pod-1"},
+					},
+					Actions: []dpv1alpha1.ActionStatus{
+						{
+							TargetPodName: "pod-0",
+							TotalSize:     "1Gi",
+							TimeRange: &dpv1alpha1.BackupTimeRange{
+								Start: &metav1.Time{Time: now.Add(-time.Hour)},
+								End:   &metav1.Time{Time: now.Add(-time.Minute)},
+							},
+						},
+						{
+							TargetPodName: "pod-1",
+							TotalSize:     "512Mi",
+							TimeRange: &dpv1alpha1.BackupTimeRange{
+								Start: &metav1.Time{Time: now.Add(-2 * time.Hour)},
+								End:   &metav1.Time{Time: now.Add(-2 * time.Minute)},
+							},
+						},
+					},
+				}
+				aggregateTargetPodsBackupInfo(backupStatus)
+				Expect(backupStatus.TotalSize).Should(Equal("1536Mi"))
+				Expect(backupStatus.TimeRange.Start.Time).Should(Equal(now.Add(-2 * time.Hour)))
+				Expect(backupStatus.TimeRange.End.Time).Should(Equal(now.Add(-time.Minute)))
+			})
 		})
 
 		It("create a backup with backupMethod and multi targets", func() {
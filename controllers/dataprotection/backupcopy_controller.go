@@ -0,0 +1,468 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+)
+
+const (
+	reasonCreatedBackupCopy     = "CreatedBackupCopy"
+	reasonBackupCopyCompleted   = "BackupCopyCompleted"
+	reasonBackupCopyFailed      = "BackupCopyFailed"
+	reasonBackupCopyLagExceeded = "BackupCopyLagExceeded"
+
+	// kopiaRepoCopyLockAnnotationPrefix is the prefix of the annotation keys of the target backup repo,
+	// which record the backup copies copying the kopia repositories.
+	kopiaRepoCopyLockAnnotationPrefix = "dataprotection.kubeblocks.io/kopia-copy-lock-"
+)
+
+// BackupCopyReconciler copies the completed backups to the secondary backup repo
+// according to the copy policy of their backup policy.
+type BackupCopyReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backuprepos,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile creates a linked Backup for each completed backup whose backup policy has a copy policy,
+// and copies the backup files to the secondary backup repo for the linked Backup.
+func (r *BackupCopyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("backup", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	backup := &dpv1alpha1.Backup{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, backup); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !backup.DeletionTimestamp.IsZero() {
+		return intctrlutil.Reconciled()
+	}
+
+	if backup.Labels[dptypes.BackupCopySourceLabelKey] != "" {
+		return r.syncBackupCopy(reqCtx, backup)
+	}
+	if backup.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+		return r.ensureBackupCopy(reqCtx, backup)
+	}
+	return intctrlutil.Reconciled()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupCopyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		Named("backupcopy").
+		For(&dpv1alpha1.Backup{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// getCopyPolicy returns the copy policy which applies to the backup, or nil if the backup should not be copied.
+func (r *BackupCopyReconciler) getCopyPolicy(reqCtx intctrlutil.RequestCtx,
+	backup *dpv1alpha1.Backup) (*dpv1alpha1.BackupCopyPolicy, error) {
+	// the legacy backups without backup repo, the continuous backups and the volume snapshots are not copied
+	if backup.Status.BackupRepoName == "" ||
+		backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+		return nil, nil
+	}
+	if backup.Status.BackupMethod != nil && boolptr.IsSetToTrue(backup.Status.BackupMethod.SnapshotVolumes) {
+		return nil, nil
+	}
+	backupPolicy := &dpv1alpha1.BackupPolicy{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backup.Namespace,
+		Name: backup.Spec.BackupPolicyName}, backupPolicy); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	copyPolicy := backupPolicy.Spec.CopyPolicy
+	if copyPolicy == nil || copyPolicy.BackupRepoName == backup.Status.BackupRepoName {
+		return nil, nil
+	}
+	if len(copyPolicy.BackupMethods) > 0 && !slices.Contains(copyPolicy.BackupMethods, backup.Spec.BackupMethod) {
+		return nil, nil
+	}
+	return copyPolicy, nil
+}
+
+// ensureBackupCopy creates the linked Backup for the completed backup, and checks whether the copy
+// lags behind the backup more than the lag tolerance.
+func (r *BackupCopyReconciler) ensureBackupCopy(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	copyPolicy, err := r.getCopyPolicy(reqCtx, backup)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if copyPolicy == nil {
+		return intctrlutil.Reconciled()
+	}
+
+	backupCopy, err := r.createBackupCopyIfNotExists(reqCtx, backup, copyPolicy)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if backupCopy.Status.Phase == dpv1alpha1.BackupPhaseCompleted || copyPolicy.LagTolerance == nil ||
+		backup.Status.CompletionTimestamp == nil {
+		return intctrlutil.Reconciled()
+	}
+	deadline := backup.Status.CompletionTimestamp.Add(copyPolicy.LagTolerance.Duration)
+	if now := time.Now(); now.Before(deadline) {
+		return intctrlutil.RequeueAfter(deadline.Sub(now), reqCtx.Log, "wait for the backup copy to complete")
+	}
+	r.Recorder.Eventf(backup, corev1.EventTypeWarning, reasonBackupCopyLagExceeded,
+		"The backup copy %s is not completed within the lag tolerance %s", backupCopy.Name, copyPolicy.LagTolerance.Duration)
+	return intctrlutil.Reconciled()
+}
+
+// createBackupCopyIfNotExists creates the linked Backup for the backup if it does not exist. The parent
+// backup of an incremental backup is required to restore the backup copy, so the parent backups are
+// copied first, even if their backup methods are not in the copy policy.
+func (r *BackupCopyReconciler) createBackupCopyIfNotExists(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup,
+	copyPolicy *dpv1alpha1.BackupCopyPolicy) (*dpv1alpha1.Backup, error) {
+	backupCopy := &dpv1alpha1.Backup{}
+	copyKey := client.ObjectKey{Namespace: backup.Namespace, Name: dpbackup.GetBackupCopyName(backup.Name)}
+	exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client, copyKey, backupCopy)
+	if err != nil || exists {
+		return backupCopy, err
+	}
+
+	if backup.Spec.ParentBackupName != "" {
+		parent := &dpv1alpha1.Backup{}
+		parentExists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client,
+			client.ObjectKey{Namespace: backup.Namespace, Name: backup.Spec.ParentBackupName}, parent)
+		if err != nil {
+			return nil, err
+		}
+		// the backup copy fails if the parent backup can not be copied.
+		if parentExists && parent.Status.Phase == dpv1alpha1.BackupPhaseCompleted {
+			if _, err = r.createBackupCopyIfNotExists(reqCtx, parent, copyPolicy); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	backupCopy = r.buildBackupCopy(backup, copyKey, copyPolicy)
+	if err = r.Client.Create(reqCtx.Ctx, backupCopy); err != nil {
+		return nil, err
+	}
+	r.Recorder.Eventf(backup, corev1.EventTypeNormal, reasonCreatedBackupCopy,
+		"Created backup %s to copy the backup to the backup repo %s", backupCopy.Name, copyPolicy.BackupRepoName)
+	return backupCopy, nil
+}
+
+// buildBackupCopy builds the linked Backup which refers to the copy of the backup in the target backup repo.
+func (r *BackupCopyReconciler) buildBackupCopy(backup *dpv1alpha1.Backup, copyKey client.ObjectKey,
+	copyPolicy *dpv1alpha1.BackupCopyPolicy) *dpv1alpha1.Backup {
+	labels := map[string]string{}
+	for k, v := range backup.Labels {
+		// the backup copy is not managed by the backup schedule
+		if k == dptypes.BackupScheduleLabelKey || k == dptypes.AutoBackupLabelKey || k == dataProtectionWaitRepoPreparationKey {
+			continue
+		}
+		labels[k] = v
+	}
+	labels[dataProtectionBackupRepoKey] = copyPolicy.BackupRepoName
+	labels[dptypes.BackupCopySourceLabelKey] = backup.Name
+	annotations := map[string]string{}
+	for k, v := range backup.Annotations {
+		annotations[k] = v
+	}
+
+	backupCopy := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        copyKey.Name,
+			Namespace:   copyKey.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: *backup.Spec.DeepCopy(),
+	}
+	if backup.Spec.ParentBackupName != "" {
+		backupCopy.Spec.ParentBackupName = dpbackup.GetBackupCopyName(backup.Spec.ParentBackupName)
+	}
+	controllerutil.AddFinalizer(backupCopy, dptypes.DataProtectionFinalizerName)
+	return backupCopy
+}
+
+// syncBackupCopy copies the backup files of the source backup to the target backup repo, and updates
+// the status of the linked Backup.
+func (r *BackupCopyReconciler) syncBackupCopy(reqCtx intctrlutil.RequestCtx, backupCopy *dpv1alpha1.Backup) (ctrl.Result, error) {
+	if backupCopy.Status.Phase != "" && backupCopy.Status.Phase != dpv1alpha1.BackupPhaseNew &&
+		backupCopy.Status.Phase != dpv1alpha1.BackupPhaseRunning {
+		return intctrlutil.Reconciled()
+	}
+
+	source := &dpv1alpha1.Backup{}
+	sourceKey := client.ObjectKey{Namespace: backupCopy.Namespace, Name: backupCopy.Labels[dptypes.BackupCopySourceLabelKey]}
+	if err := r.Client.Get(reqCtx.Ctx, sourceKey, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.failBackupCopy(reqCtx, backupCopy, fmt.Sprintf("source backup %s is not found", sourceKey.Name))
+		}
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if source.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return r.failBackupCopy(reqCtx, backupCopy, fmt.Sprintf("source backup %s is not completed", source.Name))
+	}
+
+	getRepo := func(name string) (*dpv1alpha1.BackupRepo, error) {
+		repo := &dpv1alpha1.BackupRepo{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: name}, repo); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, intctrlutil.NewFatalError(fmt.Sprintf("backup repo %s not found", name))
+			}
+			return nil, err
+		}
+		if repo.Status.Phase != dpv1alpha1.BackupRepoReady {
+			return nil, intctrlutil.NewErrorf(intctrlutil.ErrorTypeRequeue, "backup repo %s is not ready", name)
+		}
+		return repo, nil
+	}
+	sourceRepo, err := getRepo(source.Status.BackupRepoName)
+	if err == nil {
+		var targetRepo *dpv1alpha1.BackupRepo
+		if targetRepo, err = getRepo(backupCopy.Labels[dataProtectionBackupRepoKey]); err == nil {
+			return r.copyBackupFiles(reqCtx, source, backupCopy, sourceRepo, targetRepo)
+		}
+	}
+	if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+		return r.failBackupCopy(reqCtx, backupCopy, err.Error())
+	}
+	if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeRequeue) {
+		return intctrlutil.RequeueAfter(reconcileInterval, reqCtx.Log, err.Error())
+	}
+	return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+}
+
+func (r *BackupCopyReconciler) copyBackupFiles(reqCtx intctrlutil.RequestCtx,
+	source, backupCopy *dpv1alpha1.Backup,
+	sourceRepo, targetRepo *dpv1alpha1.BackupRepo) (ctrl.Result, error) {
+	// wait for the backup repo controller to prepare the target repo in the namespace.
+	prepared, err := r.isRepoPreparedInNamespace(reqCtx, targetRepo, backupCopy.Namespace)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !prepared {
+		if backupCopy.Labels[dataProtectionWaitRepoPreparationKey] != trueVal {
+			patch := client.MergeFrom(backupCopy.DeepCopy())
+			backupCopy.Labels[dataProtectionWaitRepoPreparationKey] = trueVal
+			if err = r.Client.Patch(reqCtx.Ctx, backupCopy, patch); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	// the parent backup of an incremental backup must be copied first.
+	if backupCopy.Spec.ParentBackupName != "" {
+		parent := &dpv1alpha1.Backup{}
+		if err = r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: backupCopy.Namespace,
+			Name: backupCopy.Spec.ParentBackupName}, parent); client.IgnoreNotFound(err) != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		switch {
+		case err != nil:
+			return r.failBackupCopy(reqCtx, backupCopy,
+				fmt.Sprintf("the parent backup %s is not copied", source.Spec.ParentBackupName))
+		case parent.Status.Phase == dpv1alpha1.BackupPhaseFailed:
+			return r.failBackupCopy(reqCtx, backupCopy,
+				fmt.Sprintf("failed to copy the parent backup %s", source.Spec.ParentBackupName))
+		case parent.Status.Phase != dpv1alpha1.BackupPhaseCompleted:
+			return intctrlutil.RequeueAfter(reconcileInterval, reqCtx.Log, "wait for the parent backup to be copied")
+		}
+	}
+
+	if backupCopy.Status.Phase != dpv1alpha1.BackupPhaseRunning {
+		// the linked backup shares the status of the source backup, except the backup repo.
+		patch := client.MergeFrom(backupCopy.DeepCopy())
+		backupCopy.Status = *source.Status.DeepCopy()
+		backupCopy.Status.Phase = dpv1alpha1.BackupPhaseRunning
+		backupCopy.Status.BackupRepoName = targetRepo.Name
+		backupCopy.Status.PersistentVolumeClaimName = targetRepo.Status.BackupPVCName
+		backupCopy.Status.VolumeSnapshots = nil
+		backupCopy.Status.FailureReason = ""
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backupCopy, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return intctrlutil.Reconciled()
+	}
+
+	// the kopia repository is shared by the backups with the same path prefix, so only one job is
+	// allowed to copy it into the target repo at a time.
+	if source.Status.KopiaRepoPath != "" {
+		locked, err := r.lockKopiaRepoCopy(reqCtx, targetRepo, source, backupCopy)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if !locked {
+			return intctrlutil.RequeueAfter(reconcileInterval, reqCtx.Log,
+				"wait for the other backup copy to finish copying the kopia repository")
+		}
+	}
+
+	saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backupCopy.Namespace, nil)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	copier := &dpbackup.Copier{
+		RequestCtx:           reqCtx,
+		Client:               r.Client,
+		Scheme:               r.Scheme,
+		WorkerServiceAccount: saName,
+	}
+	status, err := copier.CopyBackupFiles(source, backupCopy, sourceRepo, targetRepo)
+	if status == dpbackup.CopyStatusSucceeded || status == dpbackup.CopyStatusFailed {
+		if unlockErr := r.unlockKopiaRepoCopy(reqCtx, targetRepo, source, backupCopy); unlockErr != nil {
+			return intctrlutil.CheckedRequeueWithError(unlockErr, reqCtx.Log, "")
+		}
+	}
+	switch status {
+	case dpbackup.CopyStatusSucceeded:
+		patch := client.MergeFrom(backupCopy.DeepCopy())
+		backupCopy.Status.Phase = dpv1alpha1.BackupPhaseCompleted
+		if err = r.Client.Status().Patch(reqCtx.Ctx, backupCopy, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		r.Recorder.Eventf(backupCopy, corev1.EventTypeNormal, reasonBackupCopyCompleted,
+			"Copied backup %s to the backup repo %s", source.Name, targetRepo.Name)
+		return intctrlutil.Reconciled()
+	case dpbackup.CopyStatusFailed:
+		return r.failBackupCopy(reqCtx, backupCopy, err.Error())
+	}
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// buildKopiaRepoCopyLockKey returns the annotation key of the target backup repo, which records the
+// backup copy copying the kopia repository.
+func buildKopiaRepoCopyLockKey(namespace, kopiaRepoPath string) string {
+	hash := sha256.Sum256([]byte(namespace + kopiaRepoPath))
+	return kopiaRepoCopyLockAnnotationPrefix + hex.EncodeToString(hash[:])[:16]
+}
+
+// lockKopiaRepoCopy records the backup copy as the holder of the kopia repository in the annotations
+// of the target backup repo. The annotations are patched with the optimistic lock, so only one backup
+// copy can hold the lock. The lock held by a backup copy which is not running anymore is taken over.
+func (r *BackupCopyReconciler) lockKopiaRepoCopy(reqCtx intctrlutil.RequestCtx, targetRepo *dpv1alpha1.BackupRepo,
+	source, backupCopy *dpv1alpha1.Backup) (bool, error) {
+	lockKey := buildKopiaRepoCopyLockKey(backupCopy.Namespace, source.Status.KopiaRepoPath)
+	holder := backupCopy.Name
+	if current, ok := targetRepo.Annotations[lockKey]; ok {
+		if current == holder {
+			return true, nil
+		}
+		currentHolder := &dpv1alpha1.Backup{}
+		exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client,
+			client.ObjectKey{Namespace: backupCopy.Namespace, Name: current}, currentHolder)
+		if err != nil {
+			return false, err
+		}
+		if exists && currentHolder.DeletionTimestamp.IsZero() &&
+			currentHolder.Status.Phase == dpv1alpha1.BackupPhaseRunning {
+			return false, nil
+		}
+	}
+	patch := client.MergeFromWithOptions(targetRepo.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if targetRepo.Annotations == nil {
+		targetRepo.Annotations = map[string]string{}
+	}
+	targetRepo.Annotations[lockKey] = holder
+	if err := r.Client.Patch(reqCtx.Ctx, targetRepo, patch); err != nil {
+		if apierrors.IsConflict(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// unlockKopiaRepoCopy releases the lock of the kopia repository held by the backup copy.
+func (r *BackupCopyReconciler) unlockKopiaRepoCopy(reqCtx intctrlutil.RequestCtx, targetRepo *dpv1alpha1.BackupRepo,
+	source, backupCopy *dpv1alpha1.Backup) error {
+	if source.Status.KopiaRepoPath == "" {
+		return nil
+	}
+	lockKey := buildKopiaRepoCopyLockKey(backupCopy.Namespace, source.Status.KopiaRepoPath)
+	if targetRepo.Annotations[lockKey] != backupCopy.Name {
+		return nil
+	}
+	patch := client.MergeFromWithOptions(targetRepo.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	delete(targetRepo.Annotations, lockKey)
+	return r.Client.Patch(reqCtx.Ctx, targetRepo, patch)
+}
+
+// isRepoPreparedInNamespace checks whether the resources to access the backup repo exist in the namespace.
+func (r *BackupCopyReconciler) isRepoPreparedInNamespace(reqCtx intctrlutil.RequestCtx,
+	repo *dpv1alpha1.BackupRepo, namespace string) (bool, error) {
+	switch {
+	case repo.AccessByMount():
+		return intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client,
+			client.ObjectKey{Namespace: namespace, Name: repo.Status.BackupPVCName}, &corev1.PersistentVolumeClaim{})
+	case repo.AccessByTool():
+		return intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client,
+			client.ObjectKey{Namespace: namespace, Name: repo.Status.ToolConfigSecretName}, &corev1.Secret{})
+	}
+	return true, nil
+}
+
+func (r *BackupCopyReconciler) failBackupCopy(reqCtx intctrlutil.RequestCtx,
+	backupCopy *dpv1alpha1.Backup, reason string) (ctrl.Result, error) {
+	r.Recorder.Event(backupCopy, corev1.EventTypeWarning, reasonBackupCopyFailed, reason)
+	patch := client.MergeFrom(backupCopy.DeepCopy())
+	backupCopy.Status.Phase = dpv1alpha1.BackupPhaseFailed
+	backupCopy.Status.FailureReason = reason
+	// make sure the failed backup copy will be deleted after the expiration time.
+	_ = dpbackup.SetExpirationByCreationTime(backupCopy)
+	if err := r.Client.Status().Patch(reqCtx.Ctx, backupCopy, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
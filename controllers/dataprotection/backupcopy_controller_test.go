@@ -0,0 +1,234 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+)
+
+var _ = Describe("BackupCopy Controller test", func() {
+	const copyRepoName = "test-copy-repo"
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupRepoSignature, true, ml)
+		Eventually(testapps.List(&testCtx, generics.BackupSignature, inNS)).Should(HaveLen(0))
+
+		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, inNS)
+
+		// non-namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ActionSetSignature, true, ml)
+		testapps.ClearResources(&testCtx, generics.StorageClassSignature, ml)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeSignature, true, ml)
+		testapps.ClearResources(&testCtx, generics.StorageProviderSignature, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	Context("copy backups to the secondary backup repo", func() {
+		BeforeEach(func() {
+			By("creating an actionSet")
+			testdp.NewFakeActionSet(&testCtx)
+
+			By("creating storage provider")
+			_ = testdp.NewFakeStorageProvider(&testCtx, nil)
+
+			By("creating the backup repos")
+			_, _ = testdp.NewFakeBackupRepo(&testCtx, nil)
+			_, _ = testdp.NewFakeBackupRepo(&testCtx, func(repo *dpv1alpha1.BackupRepo) {
+				repo.Name = copyRepoName
+			})
+
+			By("creating a backupPolicy with the copy policy")
+			testdp.NewFakeBackupPolicy(&testCtx, func(backupPolicy *dpv1alpha1.BackupPolicy) {
+				backupPolicy.Spec.CopyPolicy = &dpv1alpha1.BackupCopyPolicy{
+					BackupRepoName: copyRepoName,
+					BackupMethods:  []string{testdp.BackupMethodName},
+				}
+			})
+		})
+
+		// createCompletedBackup creates a backup and mocks it completed in the primary backup repo.
+		createCompletedBackup := func(name, method, parentName, kopiaRepoPath string) client.ObjectKey {
+			backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, name).
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				SetBackupMethod(method).
+				Apply(func(backup *dpv1alpha1.Backup) {
+					backup.Spec.ParentBackupName = parentName
+				}).
+				Create(&testCtx).GetObject()
+			backupKey := client.ObjectKeyFromObject(backup)
+
+			By("waiting for the backup to fail, since there is no target pod")
+			Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseFailed))
+			})).Should(Succeed())
+
+			By("mock the backup completed")
+			now := time.Now()
+			testdp.PatchBackupStatus(&testCtx, backupKey, dpv1alpha1.BackupStatus{
+				Phase:               dpv1alpha1.BackupPhaseCompleted,
+				BackupRepoName:      testdp.BackupRepoName,
+				Path:                "/" + testCtx.DefaultNamespace + "/" + name,
+				KopiaRepoPath:       kopiaRepoPath,
+				StartTimestamp:      &metav1.Time{Time: now.Add(-time.Minute)},
+				CompletionTimestamp: &metav1.Time{Time: now},
+			})
+			return backupKey
+		}
+
+		getCopyKey := func(backupKey client.ObjectKey) client.ObjectKey {
+			return client.ObjectKey{Namespace: backupKey.Namespace, Name: dpbackup.GetBackupCopyName(backupKey.Name)}
+		}
+
+		getCopyJobKey := func(copyKey client.ObjectKey) client.ObjectKey {
+			var jobKey client.ObjectKey
+			Eventually(testapps.CheckObj(&testCtx, copyKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseRunning))
+				jobKey = dpbackup.BuildCopyBackupFilesJobKey(fetched)
+			})).Should(Succeed())
+			return jobKey
+		}
+
+		It("should stream the backup files to the secondary backup repo", func() {
+			backupKey := createCompletedBackup(testdp.BackupName, testdp.BackupMethodName, "", "")
+
+			By("the linked backup refers to the secondary backup repo")
+			copyKey := getCopyKey(backupKey)
+			Eventually(testapps.CheckObj(&testCtx, copyKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Labels).Should(HaveKeyWithValue(dptypes.BackupCopySourceLabelKey, backupKey.Name))
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseRunning))
+				g.Expect(fetched.Status.BackupRepoName).Should(Equal(copyRepoName))
+			})).Should(Succeed())
+
+			By("the files are streamed by one container without staging volume")
+			jobKey := getCopyJobKey(copyKey)
+			Eventually(testapps.CheckObj(&testCtx, jobKey, func(g Gomega, job *batchv1.Job) {
+				podSpec := job.Spec.Template.Spec
+				g.Expect(podSpec.Containers).Should(HaveLen(1))
+				g.Expect(podSpec.Containers[0].Args[0]).Should(ContainSubstring("src_datasafed pull"))
+				for _, volume := range podSpec.Volumes {
+					g.Expect(volume.EmptyDir == nil || volume.Name == "dp-datasafed-bin").Should(BeTrue())
+				}
+			})).Should(Succeed())
+
+			By("mock the copy job completed")
+			testdp.PatchK8sJobStatus(&testCtx, jobKey, batchv1.JobComplete)
+			Eventually(testapps.CheckObj(&testCtx, copyKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseCompleted))
+			})).Should(Succeed())
+		})
+
+		It("should copy the parent backup first", func() {
+			By("the parent backup is not copied, since its backup method is not in the copy policy")
+			parentKey := createCompletedBackup("parent-backup", testdp.VSBackupMethodName, "", "")
+			Consistently(testapps.CheckObjExists(&testCtx, getCopyKey(parentKey),
+				&dpv1alpha1.Backup{}, false)).Should(Succeed())
+
+			By("copying the child backup copies the parent backup too")
+			childKey := createCompletedBackup("child-backup", testdp.BackupMethodName, parentKey.Name, "")
+			parentCopyKey := getCopyKey(parentKey)
+			childCopyKey := getCopyKey(childKey)
+			Eventually(testapps.CheckObj(&testCtx, childCopyKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Spec.ParentBackupName).Should(Equal(parentCopyKey.Name))
+			})).Should(Succeed())
+
+			By("the child backup waits for the parent backup to be copied")
+			parentJobKey := getCopyJobKey(parentCopyKey)
+			Consistently(testapps.CheckObj(&testCtx, childCopyKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Status.Phase).ShouldNot(Equal(dpv1alpha1.BackupPhaseRunning))
+			})).Should(Succeed())
+
+			By("the child backup is copied after the parent backup")
+			testdp.PatchK8sJobStatus(&testCtx, parentJobKey, batchv1.JobComplete)
+			childJobKey := getCopyJobKey(childCopyKey)
+			testdp.PatchK8sJobStatus(&testCtx, childJobKey, batchv1.JobComplete)
+			Eventually(testapps.CheckObj(&testCtx, childCopyKey, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseCompleted))
+			})).Should(Succeed())
+		})
+
+		It("should fail the copy if the parent backup can not be copied", func() {
+			backupKey := createCompletedBackup(testdp.BackupName, testdp.BackupMethodName, "missing-parent", "")
+			Eventually(testapps.CheckObj(&testCtx, getCopyKey(backupKey), func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseFailed))
+				g.Expect(fetched.Status.FailureReason).Should(ContainSubstring("missing-parent"))
+			})).Should(Succeed())
+		})
+
+		It("should copy the backups sharing the kopia repository one by one", func() {
+			kopiaRepoPath := "/" + testCtx.DefaultNamespace + "/kopia"
+			backupKey1 := createCompletedBackup("backup-1", testdp.BackupMethodName, "", kopiaRepoPath)
+			backupKey2 := createCompletedBackup("backup-2", testdp.BackupMethodName, "", kopiaRepoPath)
+
+			By("only one backup copy holds the kopia repository")
+			var holder, waiter client.ObjectKey
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Name: copyRepoName},
+				func(g Gomega, repo *dpv1alpha1.BackupRepo) {
+					lockKey := buildKopiaRepoCopyLockKey(testCtx.DefaultNamespace, kopiaRepoPath)
+					g.Expect(repo.Annotations).Should(HaveKey(lockKey))
+					holder = client.ObjectKey{Namespace: testCtx.DefaultNamespace, Name: repo.Annotations[lockKey]}
+				})).Should(Succeed())
+			waiter = getCopyKey(backupKey1)
+			if holder == waiter {
+				waiter = getCopyKey(backupKey2)
+			}
+			holderJobKey := getCopyJobKey(holder)
+			waiterJobKey := getCopyJobKey(waiter)
+			Consistently(testapps.CheckObjExists(&testCtx, waiterJobKey, &batchv1.Job{}, false)).Should(Succeed())
+
+			By("the other backup copy is copied after the lock is released")
+			testdp.PatchK8sJobStatus(&testCtx, holderJobKey, batchv1.JobComplete)
+			Eventually(testapps.CheckObjExists(&testCtx, waiterJobKey, &batchv1.Job{}, true)).Should(Succeed())
+			testdp.PatchK8sJobStatus(&testCtx, waiterJobKey, batchv1.JobComplete)
+			Eventually(testapps.CheckObj(&testCtx, waiter, func(g Gomega, fetched *dpv1alpha1.Backup) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupPhaseCompleted))
+			})).Should(Succeed())
+		})
+	})
+})
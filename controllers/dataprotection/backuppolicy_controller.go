@@ -22,17 +22,23 @@ package dataprotection
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 )
 
 // BackupPolicyReconciler reconciles a BackupPolicy object
@@ -72,7 +78,7 @@ func (r *BackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	if backupPolicy.Status.ObservedGeneration == backupPolicy.Generation &&
 		backupPolicy.Status.Phase.IsAvailable() {
-		return ctrl.Result{}, nil
+		return r.updateRecoverableWindows(reqCtx, backupPolicy)
 	}
 
 	patchStatus := func(phase dpv1alpha1.Phase, message string) error {
@@ -94,7 +100,52 @@ func (r *BackupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
 	}
 	intctrlutil.RecordCreatedEvent(r.Recorder, backupPolicy)
-	return ctrl.Result{}, nil
+	return r.updateRecoverableWindows(reqCtx, backupPolicy)
+}
+
+// updateRecoverableWindows updates the recoverable windows of the continuous backups taken by the BackupPolicy.
+func (r *BackupPolicyReconciler) updateRecoverableWindows(reqCtx intctrlutil.RequestCtx,
+	backupPolicy *dpv1alpha1.BackupPolicy) (ctrl.Result, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(backupPolicy.Namespace)); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	// cache the ActionSets by name, most continuous backups share the same ActionSet.
+	actionSets := map[string]*dpv1alpha1.ActionSet{}
+	var windows []dpv1alpha1.RecoverableWindow
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		if backup.Spec.BackupPolicyName != backupPolicy.Name ||
+			backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeContinuous) ||
+			!backup.DeletionTimestamp.IsZero() {
+			continue
+		}
+		var actionSetName string
+		if backup.Status.BackupMethod != nil {
+			actionSetName = backup.Status.BackupMethod.ActionSetName
+		}
+		actionSet, ok := actionSets[actionSetName]
+		if !ok {
+			var err error
+			if actionSet, err = dputils.GetActionSetByName(reqCtx, r.Client, actionSetName); client.IgnoreNotFound(err) != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			actionSets[actionSetName] = actionSet
+		}
+		windows = append(windows, dputils.BuildRecoverableWindows(backup, backupList.Items, dputils.IsBaseBackupRequired(actionSet))...)
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].Start.Before(windows[j].Start)
+	})
+	if reflect.DeepEqual(windows, backupPolicy.Status.RecoverableWindows) {
+		return intctrlutil.Reconciled()
+	}
+	patch := client.MergeFrom(backupPolicy.DeepCopy())
+	backupPolicy.Status.RecoverableWindows = windows
+	if err := r.Status().Patch(reqCtx.Ctx, backupPolicy, patch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
 }
 
 func (r *BackupPolicyReconciler) validateBackupPolicy(backupPolicy *dpv1alpha1.BackupPolicy) error {
@@ -126,9 +177,38 @@ func (r *BackupPolicyReconciler) validateBackupPolicy(backupPolicy *dpv1alpha1.B
 func (r *BackupPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
 		For(&dpv1alpha1.BackupPolicy{}).
+		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.mapBackupToPolicies)).
 		Complete(r)
 }
 
+// mapBackupToPolicies maps the backup to the BackupPolicies whose recoverable windows may depend on it,
+// the full backup may be the base backup of the continuous backups taken by other BackupPolicies.
+func (r *BackupPolicyReconciler) mapBackupToPolicies(ctx context.Context, obj client.Object) []reconcile.Request {
+	backup := obj.(*dpv1alpha1.Backup)
+	policyNames := sets.New(backup.Spec.BackupPolicyName)
+	if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeFull) {
+		backupList := &dpv1alpha1.BackupList{}
+		if err := r.Client.List(ctx, backupList, client.InNamespace(backup.Namespace), client.MatchingLabels{
+			dptypes.BackupTypeLabelKey: string(dpv1alpha1.BackupTypeContinuous),
+		}); err != nil {
+			return nil
+		}
+		for _, item := range backupList.Items {
+			policyNames.Insert(item.Spec.BackupPolicyName)
+		}
+	}
+	var requests []reconcile.Request
+	for _, name := range sets.List(policyNames) {
+		if name == "" {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: backup.Namespace, Name: name},
+		})
+	}
+	return requests
+}
+
 func (r *BackupPolicyReconciler) deleteExternalResources(
 	_ intctrlutil.RequestCtx,
 	_ *dpv1alpha1.BackupPolicy) error {
@@ -59,6 +59,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	appsv1alpha1 "github.com/apecloud/kubeblocks/apis/apps/v1alpha1"
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
@@ -149,6 +150,9 @@ type BackupRepoReconciler struct {
 // watch or update Restores
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch;update;patch
 
+// watch or update OpsRequests
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=opsrequests,verbs=get;list;watch;update;patch
+
 // create or delete StorageClasses
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch;create;delete
 
@@ -286,6 +290,19 @@ func (r *BackupRepoReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			return checkedRequeueWithError(err, reqCtx.Log,
 				"check associated restores failed")
 		}
+
+		// check associated OpsRequests, to create PVC in their namespaces
+		if err = r.prepareForAssociatedOpsRequests(reconCtx); err != nil {
+			return checkedRequeueWithError(err, reqCtx.Log,
+				"check associated OpsRequests failed")
+		}
+
+		// import the backups stored in the repo
+		res, err := r.syncBackups(reconCtx)
+		if err != nil {
+			return checkedRequeueWithError(err, reqCtx.Log, "failed to sync backups")
+		}
+		return res, nil
 	}
 
 	return ctrl.Result{}, nil
@@ -1130,6 +1147,39 @@ func (r *BackupRepoReconciler) prepareForAssociatedRestores(reconCtx *reconcileC
 	return retErr
 }
 
+func (r *BackupRepoReconciler) prepareForAssociatedOpsRequests(reconCtx *reconcileContext) error {
+	opsList := &appsv1alpha1.OpsRequestList{}
+	if err := r.Client.List(reconCtx.Ctx, opsList, client.MatchingLabels{
+		dataProtectionBackupRepoKey:          reconCtx.repo.Name,
+		dataProtectionWaitRepoPreparationKey: trueVal,
+	}, multicluster.InControlContext()); err != nil {
+		return err
+	}
+	// return any error to reconcile the repo
+	var retErr error
+	for i := range opsList.Items {
+		opsRequest := &opsList.Items[i]
+		if opsRequest.IsComplete() {
+			continue
+		}
+		err := r.prepareBackupRepoInNamespace(reconCtx, opsRequest.Namespace)
+		if retErr == nil {
+			retErr = err
+		}
+		if err != nil {
+			continue
+		}
+		patch := client.MergeFrom(opsRequest.DeepCopy())
+		delete(opsRequest.Labels, dataProtectionWaitRepoPreparationKey)
+		if err = r.Client.Patch(reconCtx.Ctx, opsRequest, patch, multicluster.InControlContext()); err != nil {
+			reconCtx.Log.Error(err, "failed to patch OpsRequest",
+				"opsRequest", client.ObjectKeyFromObject(opsRequest))
+			retErr = err
+		}
+	}
+	return retErr
+}
+
 func (r *BackupRepoReconciler) createRepoPVC(reconCtx *reconcileContext,
 	name, namespace string, extraAnnos map[string]string, mcOpt *multicluster.ClientOption) (*corev1.PersistentVolumeClaim, error) {
 
@@ -1465,6 +1515,22 @@ func (r *BackupRepoReconciler) mapRestoreToRepo(ctx context.Context, obj client.
 	return nil
 }
 
+func (r *BackupRepoReconciler) mapOpsRequestToRepo(ctx context.Context, obj client.Object) []ctrl.Request {
+	opsRequest := obj.(*appsv1alpha1.OpsRequest)
+	repoName, ok := opsRequest.Labels[dataProtectionBackupRepoKey]
+	if !ok {
+		return nil
+	}
+	// we should reconcile the BackupRepo when the OpsRequest needs to use the BackupRepo,
+	// but it's not ready for the namespace.
+	if opsRequest.Labels[dataProtectionWaitRepoPreparationKey] == trueVal && !opsRequest.IsComplete() {
+		return []ctrl.Request{{
+			NamespacedName: client.ObjectKey{Name: repoName},
+		}}
+	}
+	return nil
+}
+
 func (r *BackupRepoReconciler) mapProviderToRepos(ctx context.Context, obj client.Object) []ctrl.Request {
 	return r.providerRefMapper.mapToRequests(obj)
 }
@@ -1502,6 +1568,7 @@ func (r *BackupRepoReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&dpv1alpha1.StorageProvider{}, handler.EnqueueRequestsFromMapFunc(r.mapProviderToRepos)).
 		Watches(&dpv1alpha1.Backup{}, handler.EnqueueRequestsFromMapFunc(r.mapBackupToRepo)).
 		Watches(&dpv1alpha1.Restore{}, handler.EnqueueRequestsFromMapFunc(r.mapRestoreToRepo)).
+		Watches(&appsv1alpha1.OpsRequest{}, handler.EnqueueRequestsFromMapFunc(r.mapOpsRequestToRepo)).
 		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.mapSecretToRepos)).
 		Owns(&storagev1.StorageClass{}).
 		Owns(&corev1.PersistentVolumeClaim{}).
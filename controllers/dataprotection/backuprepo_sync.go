@@ -0,0 +1,207 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	"github.com/apecloud/kubeblocks/pkg/controller/multicluster"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// syncBackupsDelay returns the duration to wait before the next sync of the backups.
+// It returns 0 if the backups should be synced now, and a negative value if no sync is needed.
+func syncBackupsDelay(repo *dpv1alpha1.BackupRepo, now time.Time) time.Duration {
+	status := repo.Status.Sync
+	if status == nil || status.ObservedGeneration != repo.Generation || status.LastSyncTime == nil {
+		return 0
+	}
+	if repo.Spec.Sync.Interval == nil || repo.Spec.Sync.Interval.Duration <= 0 {
+		return -1
+	}
+	delay := status.LastSyncTime.Add(repo.Spec.Sync.Interval.Duration).Sub(now)
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// syncBackups imports the backups stored in the repo as Backup objects. The metadata of the backups
+// is collected by a job running in the controller manager namespace.
+func (r *BackupRepoReconciler) syncBackups(reconCtx *reconcileContext) (ctrl.Result, error) {
+	repo := reconCtx.repo
+	if repo.Spec.Sync == nil {
+		return ctrl.Result{}, nil
+	}
+	delay := syncBackupsDelay(repo, wallClock.Now())
+	switch {
+	case delay < 0:
+		return ctrl.Result{}, nil
+	case delay > 0:
+		return ctrl.Result{RequeueAfter: delay}, nil
+	}
+
+	namespace := viper.GetString(constant.CfgKeyCtrlrMgrNS)
+	jobKey := client.ObjectKey{
+		Namespace: namespace,
+		Name:      cutName(fmt.Sprintf("sync-backups-%s-%s", repo.UID[:8], repo.Name)),
+	}
+	job := &batchv1.Job{}
+	if err := r.Client.Get(reconCtx.Ctx, jobKey, job, multicluster.InControlContext()); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.createSyncBackupsJob(reconCtx, jobKey)
+		}
+		return ctrl.Result{}, err
+	}
+	finished, jobStatus, failureReason := utils.IsJobFinished(job)
+	if !finished {
+		// the repo will be reconciled when the job is finished.
+		return ctrl.Result{}, nil
+	}
+
+	var (
+		imported int32
+		message  string
+		backups  []dpv1alpha1.Backup
+		err      error
+	)
+	if jobStatus == batchv1.JobFailed {
+		message = fmt.Sprintf("sync backups job failed: %s", failureReason)
+	} else {
+		var output []byte
+		output, err = utils.GetJobOutput(reconCtx.Ctx, r.Client, r.RestConfig, job,
+			dpbackup.CatalogContainerName, multicluster.InControlContext())
+		if err == nil {
+			backups, err = dpbackup.ParseBackupCatalog(output)
+		}
+		if err != nil {
+			message = fmt.Sprintf("failed to read the backup metadata: %s", err.Error())
+		} else if imported, err = r.importBackups(reconCtx, backups); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if err = intctrlutil.BackgroundDeleteObject(r.Client, reconCtx.Ctx, job,
+		multicluster.InControlContext()); client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+
+	patch := client.MergeFrom(repo.DeepCopy())
+	repo.Status.Sync = &dpv1alpha1.BackupRepoSyncStatus{
+		ObservedGeneration: repo.Generation,
+		LastSyncTime:       &metav1.Time{Time: wallClock.Now()},
+		ImportedBackups:    imported,
+		Message:            message,
+	}
+	if err = r.Client.Status().Patch(reconCtx.Ctx, repo, patch, multicluster.InControlContext()); err != nil {
+		return ctrl.Result{}, err
+	}
+	if message != "" {
+		r.Recorder.Event(repo, corev1.EventTypeWarning, ReasonSyncBackupsFailed, message)
+	} else {
+		r.Recorder.Eventf(repo, corev1.EventTypeNormal, ReasonSyncBackupsCompleted, "Imported %d backups", imported)
+	}
+	if repo.Spec.Sync.Interval != nil && repo.Spec.Sync.Interval.Duration > 0 {
+		return ctrl.Result{RequeueAfter: repo.Spec.Sync.Interval.Duration}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *BackupRepoReconciler) createSyncBackupsJob(reconCtx *reconcileContext, jobKey client.ObjectKey) error {
+	if err := r.prepareBackupRepoInNamespace(reconCtx, jobKey.Namespace); err != nil {
+		return err
+	}
+	saName, err := EnsureWorkerServiceAccount(reconCtx.RequestCtx, r.Client, jobKey.Namespace, r.MultiClusterMgr)
+	if err != nil {
+		return err
+	}
+	job, err := dpbackup.BuildSyncBackupsJob(reconCtx.repo, jobKey, map[string]string{
+		dataProtectionBackupRepoKey: reconCtx.repo.Name,
+	}, saName)
+	if err != nil {
+		return err
+	}
+	if err = controllerutil.SetControllerReference(reconCtx.repo, job, r.Scheme); err != nil {
+		return err
+	}
+	reconCtx.Log.Info("create a job to sync backups", "job", jobKey)
+	return client.IgnoreAlreadyExists(r.Client.Create(reconCtx.Ctx, job, multicluster.InControlContext()))
+}
+
+// importBackups creates the Backup objects from the metadata of the backups, and returns the number
+// of the imported backups. The backups which already exist are skipped.
+func (r *BackupRepoReconciler) importBackups(reconCtx *reconcileContext, metadataList []dpv1alpha1.Backup) (int32, error) {
+	var imported int32
+	repo := reconCtx.repo
+	preparedNamespaces := map[string]bool{}
+	for i := range metadataList {
+		metadata := &metadataList[i]
+		if len(repo.Spec.Sync.SourceNamespaces) > 0 && !slices.Contains(repo.Spec.Sync.SourceNamespaces, metadata.Namespace) {
+			continue
+		}
+		backup := dpbackup.BuildImportedBackup(metadata, repo, repo.Spec.Sync.TargetNamespace, wallClock.Now())
+		if backup == nil {
+			continue
+		}
+		err := r.Client.Get(reconCtx.Ctx, client.ObjectKeyFromObject(backup), &dpv1alpha1.Backup{},
+			multicluster.InControlContext())
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsNotFound(err) {
+			return imported, err
+		}
+		if !preparedNamespaces[backup.Namespace] {
+			if err = r.prepareBackupRepoInNamespace(reconCtx, backup.Namespace); err != nil {
+				return imported, err
+			}
+			preparedNamespaces[backup.Namespace] = true
+		}
+		status := backup.Status
+		if err = r.Client.Create(reconCtx.Ctx, backup, multicluster.InControlContext()); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				continue
+			}
+			return imported, err
+		}
+		patch := client.MergeFrom(backup.DeepCopy())
+		backup.Status = status
+		if err = r.Client.Status().Patch(reconCtx.Ctx, backup, patch, multicluster.InControlContext()); err != nil {
+			return imported, err
+		}
+		reconCtx.Log.Info("imported backup", "backup", client.ObjectKeyFromObject(backup))
+		imported++
+	}
+	return imported, nil
+}
@@ -0,0 +1,584 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dprestore "github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+const (
+	verifyContainerName = "verify"
+
+	// defaultVerificationRunTimeout is the maximum duration of a verification run if it is not specified.
+	defaultVerificationRunTimeout = 2 * time.Hour
+
+	reasonBackupVerificationInvalid = "InvalidBackupVerification"
+	reasonBackupVerificationStarted = "StartBackupVerification"
+	reasonBackupVerificationPassed  = "BackupVerificationPassed"
+	reasonBackupVerificationFailed  = "BackupVerificationFailed"
+)
+
+// BackupVerificationReconciler reconciles a BackupVerification object
+type BackupVerificationReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupverifications,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupverifications/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupverifications/finalizers,verbs=update
+// +kubebuilder:rbac:groups=apps.kubeblocks.io,resources=clusters,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+
+// Reconcile restores the backup into a temporary Cluster in a scratch namespace, and runs the verification job
+// against the Cluster. When the verification completes, the result is recorded in the status and the annotations
+// of the backup, and the scratch namespace is deleted with all the resources in it.
+func (r *BackupVerificationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqCtx := intctrlutil.RequestCtx{
+		Ctx:      ctx,
+		Req:      req,
+		Log:      log.FromContext(ctx).WithValues("backupVerification", req.NamespacedName),
+		Recorder: r.Recorder,
+	}
+
+	verification := &dpv1alpha1.BackupVerification{}
+	if err := r.Client.Get(reqCtx.Ctx, reqCtx.Req.NamespacedName, verification); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	// handle finalizer
+	res, err := intctrlutil.HandleCRDeletion(reqCtx, r, verification, dptypes.DataProtectionFinalizerName, func() (*ctrl.Result, error) {
+		return nil, r.deleteExternalResources(reqCtx, verification)
+	})
+	if res != nil {
+		return *res, err
+	}
+
+	if verification.Status.RunName != "" {
+		return r.checkVerificationRun(reqCtx, verification)
+	}
+	return r.scheduleVerificationRun(reqCtx, verification)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BackupVerificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return intctrlutil.NewNamespacedControllerManagedBy(mgr).
+		For(&dpv1alpha1.BackupVerification{}).
+		Watches(&appsv1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.parseVerificationObject)).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.parseVerificationObject)).
+		Complete(r)
+}
+
+// parseVerificationObject maps the Cluster and the Job created for the verification to the BackupVerification,
+// which is in a different namespace.
+func (r *BackupVerificationReconciler) parseVerificationObject(ctx context.Context, object client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	name := object.GetLabels()[dptypes.BackupVerificationLabelKey]
+	namespace := object.GetLabels()[dptypes.BackupVerificationNamespaceLabelKey]
+	if name != "" && namespace != "" {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: namespace,
+				Name:      name,
+			},
+		})
+	}
+	return requests
+}
+
+// scheduleVerificationRun starts a verification run if it is due. A verification without the cron expression
+// runs once for each generation of the spec, otherwise it runs at each scheduled time.
+func (r *BackupVerificationReconciler) scheduleVerificationRun(reqCtx intctrlutil.RequestCtx,
+	verification *dpv1alpha1.BackupVerification) (ctrl.Result, error) {
+	statusPatch := client.MergeFrom(verification.DeepCopy())
+	now := time.Now()
+	var requeueAfter time.Duration
+	if verification.Spec.CronExpression == "" {
+		if verification.Status.ObservedGeneration == verification.Generation &&
+			verification.Status.Phase != "" && verification.Status.Phase != dpv1alpha1.BackupVerificationPhasePending {
+			return intctrlutil.Reconciled()
+		}
+	} else {
+		cronSchedule, err := common.ParseCronSchedule(verification.Spec.CronExpression)
+		if err != nil {
+			r.Recorder.Event(verification, corev1.EventTypeWarning, reasonBackupVerificationInvalid, err.Error())
+			verification.Status.ObservedGeneration = verification.Generation
+			verification.Status.Phase = dpv1alpha1.BackupVerificationPhaseFailed
+			verification.Status.FailureReason = err.Error()
+			verification.Status.NextScheduleTime = nil
+			if err = r.Client.Status().Patch(reqCtx.Ctx, verification, statusPatch); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			return intctrlutil.Reconciled()
+		}
+		verification.Status.NextScheduleTime = nil
+		if next := cronSchedule.Next(now); !next.IsZero() {
+			verification.Status.NextScheduleTime = &metav1.Time{Time: next}
+			requeueAfter = next.Sub(now)
+		}
+		scheduledTime := getMostRecentVerificationTime(verification, cronSchedule, now)
+		if scheduledTime.IsZero() {
+			if verification.Status.Phase == "" {
+				verification.Status.Phase = dpv1alpha1.BackupVerificationPhasePending
+			}
+			verification.Status.ObservedGeneration = verification.Generation
+			if err = r.Client.Status().Patch(reqCtx.Ctx, verification, statusPatch); err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			return r.requeueForNextSchedule(reqCtx, requeueAfter)
+		}
+		verification.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+	}
+
+	backup, err := r.getBackupToVerify(reqCtx, verification)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	verification.Status.ObservedGeneration = verification.Generation
+	if backup == nil {
+		// wait for the backup to complete, a scheduled time without any completed backup is skipped.
+		if verification.Spec.CronExpression == "" || verification.Status.Phase == "" {
+			verification.Status.Phase = dpv1alpha1.BackupVerificationPhasePending
+		}
+		if err = r.Client.Status().Patch(reqCtx.Ctx, verification, statusPatch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if verification.Spec.CronExpression != "" {
+			return r.requeueForNextSchedule(reqCtx, requeueAfter)
+		}
+		return intctrlutil.RequeueAfter(reconcileInterval, reqCtx.Log, "wait for the backup to complete")
+	}
+
+	runName := fmt.Sprintf("%s-%d", common.CutString(verification.Name, 52), now.Unix()/60)
+	cluster, err := r.buildScratchCluster(verification, backup, runName)
+	if err != nil {
+		// the backup can not be restored into a cluster, e.g. it has no snapshot of the cluster
+		r.Recorder.Event(verification, corev1.EventTypeWarning, reasonBackupVerificationFailed, err.Error())
+		verification.Status.Phase = dpv1alpha1.BackupVerificationPhaseFailed
+		verification.Status.BackupName = backup.Name
+		verification.Status.FailureReason = err.Error()
+		if err = r.Client.Status().Patch(reqCtx.Ctx, verification, statusPatch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		return r.requeueForNextSchedule(reqCtx, requeueAfter)
+	}
+	if err = r.createScratchCluster(reqCtx, verification, cluster); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	r.Recorder.Eventf(verification, corev1.EventTypeNormal, reasonBackupVerificationStarted,
+		"Start to verify the backup %s in the namespace %s", backup.Name, cluster.Namespace)
+	verification.Status.Phase = dpv1alpha1.BackupVerificationPhaseRunning
+	verification.Status.BackupName = backup.Name
+	verification.Status.RunName = runName
+	verification.Status.ScratchNamespace = cluster.Namespace
+	verification.Status.ClusterName = cluster.Name
+	verification.Status.StartTimestamp = &metav1.Time{Time: now}
+	verification.Status.CompletionTimestamp = nil
+	verification.Status.Duration = nil
+	verification.Status.FailureReason = ""
+	if err = r.Client.Status().Patch(reqCtx.Ctx, verification, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+func (r *BackupVerificationReconciler) requeueForNextSchedule(reqCtx intctrlutil.RequestCtx,
+	requeueAfter time.Duration) (ctrl.Result, error) {
+	if requeueAfter <= 0 {
+		return intctrlutil.Reconciled()
+	}
+	return intctrlutil.RequeueAfter(requeueAfter, reqCtx.Log, "wait for the next scheduled time")
+}
+
+// getMostRecentVerificationTime gets the most recent scheduled time which is not after now and has not been handled.
+// It returns the zero time if there is no such time.
+func getMostRecentVerificationTime(verification *dpv1alpha1.BackupVerification,
+	cronSchedule *common.CronSchedule, now time.Time) time.Time {
+	earliestTime := verification.CreationTimestamp.Time
+	if verification.Status.LastScheduleTime != nil {
+		earliestTime = verification.Status.LastScheduleTime.Time
+	}
+	var scheduledTime time.Time
+	for t := cronSchedule.Next(earliestTime); !t.IsZero() && !t.After(now); t = cronSchedule.Next(t) {
+		scheduledTime = t
+	}
+	return scheduledTime
+}
+
+// getBackupToVerify returns the specified backup, or the latest completed backup of the backup policy.
+// It returns nil if there is no completed backup to verify.
+func (r *BackupVerificationReconciler) getBackupToVerify(reqCtx intctrlutil.RequestCtx,
+	verification *dpv1alpha1.BackupVerification) (*dpv1alpha1.Backup, error) {
+	if verification.Spec.BackupName != "" {
+		backup := &dpv1alpha1.Backup{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: verification.Namespace,
+			Name: verification.Spec.BackupName}, backup); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			return nil, nil
+		}
+		return backup, nil
+	}
+
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.Client.List(reqCtx.Ctx, backupList, client.InNamespace(verification.Namespace),
+		client.MatchingLabels{dptypes.BackupPolicyLabelKey: verification.Spec.BackupPolicyName}); err != nil {
+		return nil, err
+	}
+	var latest *dpv1alpha1.Backup
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		if backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted || backup.GetEndTime() == nil {
+			continue
+		}
+		// the continuous backups can not be restored without the restore time, skip them
+		if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+			continue
+		}
+		if verification.Spec.BackupMethod != "" && backup.Spec.BackupMethod != verification.Spec.BackupMethod {
+			continue
+		}
+		if latest == nil || backup.GetEndTime().After(latest.GetEndTime().Time) {
+			latest = backup
+		}
+	}
+	return latest, nil
+}
+
+func (r *BackupVerificationReconciler) buildLabels(verification *dpv1alpha1.BackupVerification) map[string]string {
+	return map[string]string{
+		dptypes.BackupVerificationLabelKey:          verification.Name,
+		dptypes.BackupVerificationNamespaceLabelKey: verification.Namespace,
+	}
+}
+
+// buildScratchCluster builds the temporary Cluster of the verification run from the snapshot of the cluster
+// saved in the backup, it is restored from the backup in the scratch namespace of the run.
+func (r *BackupVerificationReconciler) buildScratchCluster(verification *dpv1alpha1.BackupVerification,
+	backup *dpv1alpha1.Backup, runName string) (*appsv1.Cluster, error) {
+	clusterSnapshot, ok := backup.Annotations[constant.ClusterSnapshotAnnotationKey]
+	if !ok {
+		return nil, fmt.Errorf("missing the cluster snapshot annotation %s in backup %s",
+			constant.ClusterSnapshotAnnotationKey, backup.Name)
+	}
+	snapshot := &appsv1.Cluster{}
+	if err := json.Unmarshal([]byte(clusterSnapshot), snapshot); err != nil {
+		return nil, err
+	}
+	restoreAnnotation, err := dprestore.GetRestoreFromBackupAnnotation(backup,
+		string(dpv1alpha1.VolumeClaimRestorePolicyParallel), "", false)
+	if err != nil {
+		return nil, err
+	}
+	// the cluster keeps the name of the backed up cluster, so that the names of the secrets generated for
+	// the cluster, including the connection credential of the backup target, are the same.
+	cluster := &appsv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        snapshot.Name,
+			Namespace:   buildScratchNamespaceName(verification, runName),
+			Labels:      r.buildLabels(verification),
+			Annotations: map[string]string{constant.RestoreFromBackupAnnotationKey: restoreAnnotation},
+		},
+		Spec: snapshot.Spec,
+	}
+	cluster.Spec.TerminationPolicy = appsv1.WipeOut
+	// the services exposed out of the kubernetes cluster are not required for the verification
+	cluster.Spec.Services = nil
+	for i := range cluster.Spec.ComponentSpecs {
+		cluster.Spec.ComponentSpecs[i].OfflineInstances = nil
+	}
+	return cluster, nil
+}
+
+// buildScratchNamespaceName builds the name of the scratch namespace of the verification run, the namespace
+// is unique across the verifications with the same name in different namespaces.
+func buildScratchNamespaceName(verification *dpv1alpha1.BackupVerification, runName string) string {
+	uid := string(verification.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	return fmt.Sprintf("%s-%s", common.CutString("verify-"+runName, 54), uid)
+}
+
+// createScratchCluster creates the scratch namespace and the temporary Cluster in it.
+func (r *BackupVerificationReconciler) createScratchCluster(reqCtx intctrlutil.RequestCtx,
+	verification *dpv1alpha1.BackupVerification, cluster *appsv1.Cluster) error {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   cluster.Namespace,
+			Labels: r.buildLabels(verification),
+		},
+	}
+	if err := r.Client.Create(reqCtx.Ctx, namespace); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return client.IgnoreAlreadyExists(r.Client.Create(reqCtx.Ctx, cluster))
+}
+
+// checkVerificationRun checks the temporary Cluster and the verification job of the running verification.
+func (r *BackupVerificationReconciler) checkVerificationRun(reqCtx intctrlutil.RequestCtx,
+	verification *dpv1alpha1.BackupVerification) (ctrl.Result, error) {
+	// the restore may get stuck without failing the cluster, so the whole run is bounded by a deadline
+	runTimeout := getVerificationRunTimeout(verification)
+	var remaining time.Duration
+	if verification.Status.StartTimestamp != nil {
+		remaining = time.Until(verification.Status.StartTimestamp.Add(runTimeout))
+		if remaining <= 0 {
+			return r.completeVerificationRun(reqCtx, verification, dpv1alpha1.BackupVerificationPhaseFailed,
+				fmt.Sprintf("verification run %s does not complete within %s", verification.Status.RunName, runTimeout))
+		}
+	}
+	waitForRun := func() (ctrl.Result, error) {
+		if remaining <= 0 {
+			return intctrlutil.Reconciled()
+		}
+		return intctrlutil.RequeueAfter(remaining, reqCtx.Log, "check the deadline of the verification run")
+	}
+
+	clusterKey := client.ObjectKey{Namespace: verification.Status.ScratchNamespace, Name: verification.Status.ClusterName}
+	cluster := &appsv1.Cluster{}
+	if err := r.Client.Get(reqCtx.Ctx, clusterKey, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.completeVerificationRun(reqCtx, verification, dpv1alpha1.BackupVerificationPhaseFailed,
+				fmt.Sprintf("cluster %s is not found in the namespace %s", clusterKey.Name, clusterKey.Namespace))
+		}
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	switch cluster.Status.Phase {
+	case appsv1.FailedClusterPhase, appsv1.AbnormalClusterPhase:
+		message := fmt.Sprintf("failed to restore the backup %s into the cluster, the cluster is %s",
+			verification.Status.BackupName, cluster.Status.Phase)
+		if cluster.Status.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, cluster.Status.Message)
+		}
+		return r.completeVerificationRun(reqCtx, verification, dpv1alpha1.BackupVerificationPhaseFailed, message)
+	case appsv1.RunningClusterPhase:
+	default:
+		return waitForRun()
+	}
+
+	runKey := client.ObjectKey{Namespace: verification.Status.ScratchNamespace, Name: verification.Status.RunName}
+	job := &batchv1.Job{}
+	exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client, runKey, job)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !exists {
+		env, err := r.buildDBEnv(reqCtx, verification, cluster)
+		if err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+		if err = r.Client.Create(reqCtx.Ctx, r.buildVerifyJob(verification, runKey, env)); err != nil {
+			return intctrlutil.CheckedRequeueWithError(client.IgnoreAlreadyExists(err), reqCtx.Log, "")
+		}
+		return waitForRun()
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return r.completeVerificationRun(reqCtx, verification, dpv1alpha1.BackupVerificationPhasePassed, "")
+		case batchv1.JobFailed:
+			return r.completeVerificationRun(reqCtx, verification, dpv1alpha1.BackupVerificationPhaseFailed,
+				fmt.Sprintf("verification job %s failed: %s", job.Name, cond.Message))
+		}
+	}
+	return waitForRun()
+}
+
+// getVerificationRunTimeout returns the maximum duration of a verification run.
+func getVerificationRunTimeout(verification *dpv1alpha1.BackupVerification) time.Duration {
+	if verification.Spec.RunTimeoutSeconds == nil {
+		return defaultVerificationRunTimeout
+	}
+	return time.Duration(*verification.Spec.RunTimeoutSeconds) * time.Second
+}
+
+// buildDBEnv builds the environment variables to connect to a replica of the restored component of the temporary
+// Cluster, with the connection credential of the backup target.
+func (r *BackupVerificationReconciler) buildDBEnv(reqCtx intctrlutil.RequestCtx,
+	verification *dpv1alpha1.BackupVerification, cluster *appsv1.Cluster) ([]corev1.EnvVar, error) {
+	backup := &dpv1alpha1.Backup{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: verification.Namespace,
+		Name: verification.Status.BackupName}, backup); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	podLabels := client.MatchingLabels{constant.AppInstanceLabelKey: cluster.Name}
+	if compName := backup.Labels[constant.KBAppComponentLabelKey]; compName != "" {
+		podLabels[constant.KBAppComponentLabelKey] = compName
+	}
+	podList := &corev1.PodList{}
+	if err := r.Client.List(reqCtx.Ctx, podList, client.InNamespace(cluster.Namespace), podLabels); err != nil {
+		return nil, err
+	}
+	if len(podList.Items) == 0 {
+		return nil, nil
+	}
+	var (
+		credential    *dpv1alpha1.ConnectionCredential
+		containerPort *dpv1alpha1.ContainerPort
+	)
+	if target := backup.Status.Target; target != nil {
+		credential = target.ConnectionCredential
+		containerPort = target.ContainerPort
+	}
+	return dputils.BuildEnvByTarget(&podList.Items[0], credential, containerPort)
+}
+
+// buildVerifyJob builds the job which runs the verify action against the temporary Cluster.
+func (r *BackupVerificationReconciler) buildVerifyJob(verification *dpv1alpha1.BackupVerification,
+	runKey client.ObjectKey, dbEnv []corev1.EnvVar) *batchv1.Job {
+	action := verification.Spec.VerifyAction
+	env := []corev1.EnvVar{
+		{Name: dptypes.DPBackupName, Value: verification.Status.BackupName},
+		{Name: dptypes.DPBackupNamespace, Value: verification.Namespace},
+	}
+	env = append(env, dbEnv...)
+	container := corev1.Container{
+		Name:            verifyContainerName,
+		Image:           action.Image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         action.Command,
+		Env:             append(env, action.Env...),
+	}
+	labels := r.buildLabels(verification)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      runKey.Name,
+			Namespace: runKey.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32(0),
+			ActiveDeadlineSeconds: action.TimeoutSeconds,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{container},
+				},
+			},
+		},
+	}
+}
+
+// completeVerificationRun records the result of the verification run in the status and the annotations
+// of the backup, and deletes the resources created for the run.
+func (r *BackupVerificationReconciler) completeVerificationRun(reqCtx intctrlutil.RequestCtx,
+	verification *dpv1alpha1.BackupVerification,
+	phase dpv1alpha1.BackupVerificationPhase,
+	failureReason string) (ctrl.Result, error) {
+	now := metav1.Now()
+	var duration time.Duration
+	if verification.Status.StartTimestamp != nil {
+		duration = now.Sub(verification.Status.StartTimestamp.Time).Round(time.Second)
+	}
+
+	// record the result in the backup, which may have been deleted in the meantime
+	backup := &dpv1alpha1.Backup{}
+	if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Namespace: verification.Namespace,
+		Name: verification.Status.BackupName}, backup); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	} else {
+		patch := client.MergeFrom(backup.DeepCopy())
+		if backup.Annotations == nil {
+			backup.Annotations = map[string]string{}
+		}
+		backup.Annotations[dptypes.BackupVerificationResultAnnotationKey] = string(phase)
+		backup.Annotations[dptypes.BackupVerificationDurationAnnotationKey] = duration.String()
+		backup.Annotations[dptypes.BackupVerificationTimeAnnotationKey] = now.UTC().Format(time.RFC3339)
+		if err = r.Client.Patch(reqCtx.Ctx, backup, patch); err != nil {
+			return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+		}
+	}
+
+	if err := r.deleteExternalResources(reqCtx, verification); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+
+	if phase == dpv1alpha1.BackupVerificationPhasePassed {
+		r.Recorder.Eventf(verification, corev1.EventTypeNormal, reasonBackupVerificationPassed,
+			"Backup %s is verified in %s", verification.Status.BackupName, duration)
+	} else {
+		r.Recorder.Event(verification, corev1.EventTypeWarning, reasonBackupVerificationFailed, failureReason)
+	}
+	statusPatch := client.MergeFrom(verification.DeepCopy())
+	verification.Status.Phase = phase
+	verification.Status.FailureReason = failureReason
+	verification.Status.CompletionTimestamp = &now
+	verification.Status.Duration = &metav1.Duration{Duration: duration}
+	verification.Status.RunName = ""
+	verification.Status.ScratchNamespace = ""
+	verification.Status.ClusterName = ""
+	if err := r.Client.Status().Patch(reqCtx.Ctx, verification, statusPatch); err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// deleteExternalResources deletes the scratch namespaces created for the verification, the temporary Clusters
+// and the verification jobs in them are deleted with the namespaces.
+func (r *BackupVerificationReconciler) deleteExternalResources(reqCtx intctrlutil.RequestCtx,
+	verification *dpv1alpha1.BackupVerification) error {
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.Client.List(reqCtx.Ctx, namespaceList, client.MatchingLabels(r.buildLabels(verification))); err != nil {
+		return err
+	}
+	for i := range namespaceList.Items {
+		if err := intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, &namespaceList.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
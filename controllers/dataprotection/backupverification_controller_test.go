@@ -0,0 +1,269 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1 "github.com/apecloud/kubeblocks/apis/apps/v1"
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/generics"
+	testapps "github.com/apecloud/kubeblocks/pkg/testutil/apps"
+	testdp "github.com/apecloud/kubeblocks/pkg/testutil/dataprotection"
+)
+
+var _ = Describe("BackupVerification Controller test", func() {
+	const verificationName = "test-backup-verification"
+
+	cleanEnv := func() {
+		// must wait till resources deleted and no longer existed before the testcases start,
+		// otherwise if later it needs to create some new resource objects with the same name,
+		// in race conditions, it will find the existence of old objects, resulting failure to
+		// create the new objects.
+		By("clean resources")
+
+		inNS := client.InNamespace(testCtx.DefaultNamespace)
+		ml := client.HasLabels{testCtx.TestObjLabelKey}
+
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupVerificationSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS)
+
+		// non-namespaced
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.ActionSetSignature, true, ml)
+	}
+
+	BeforeEach(cleanEnv)
+
+	AfterEach(cleanEnv)
+
+	newVerification := func(backupName string) *dpv1alpha1.BackupVerification {
+		return &dpv1alpha1.BackupVerification{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      verificationName,
+				Namespace: testCtx.DefaultNamespace,
+				Labels:    map[string]string{testCtx.TestObjLabelKey: "true"},
+			},
+			Spec: dpv1alpha1.BackupVerificationSpec{
+				BackupName: backupName,
+				VerifyAction: dpv1alpha1.VerifyAction{
+					Image:          "busybox",
+					Command:        []string{"sh", "-c", "test -n \"$DP_DB_HOST\""},
+					TimeoutSeconds: pointer.Int64(60),
+				},
+			},
+		}
+	}
+
+	Context("verify a backup", func() {
+		var backupKey client.ObjectKey
+
+		BeforeEach(func() {
+			By("creating an actionSet")
+			testdp.NewFakeActionSet(&testCtx)
+
+			By("creating a backup")
+			backup := testdp.NewFakeBackup(&testCtx, nil)
+			backupKey = client.ObjectKeyFromObject(backup)
+		})
+
+		mockBackupCompleted := func(withClusterSnapshot bool) {
+			if withClusterSnapshot {
+				By("mock the cluster snapshot of the backup")
+				cluster := testapps.NewClusterFactory(testCtx.DefaultNamespace, testdp.ClusterName, "").
+					AddComponent(testdp.ComponentName, "test-compdef").
+					SetReplicas(1).
+					GetObject()
+				clusterSnapshot, err := json.Marshal(cluster)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(testapps.GetAndChangeObj(&testCtx, backupKey, func(backup *dpv1alpha1.Backup) {
+					if backup.Annotations == nil {
+						backup.Annotations = map[string]string{}
+					}
+					backup.Annotations[constant.ClusterSnapshotAnnotationKey] = string(clusterSnapshot)
+					if backup.Labels == nil {
+						backup.Labels = map[string]string{}
+					}
+					backup.Labels[constant.KBAppComponentLabelKey] = testdp.ComponentName
+				})()).Should(Succeed())
+			}
+
+			By("mock the backup completed")
+			now := time.Now()
+			testdp.PatchBackupStatus(&testCtx, backupKey, dpv1alpha1.BackupStatus{
+				Phase:               dpv1alpha1.BackupPhaseCompleted,
+				StartTimestamp:      &metav1.Time{Time: now.Add(-time.Minute)},
+				CompletionTimestamp: &metav1.Time{Time: now},
+			})
+		}
+
+		It("should wait for the backup to complete", func() {
+			verification := newVerification(backupKey.Name)
+			Expect(testCtx.CreateObj(testCtx.Ctx, verification)).Should(Succeed())
+
+			By("the verification is pending and no scratch namespace is created")
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(verification),
+				func(g Gomega, fetched *dpv1alpha1.BackupVerification) {
+					g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupVerificationPhasePending))
+					g.Expect(fetched.Status.RunName).Should(BeEmpty())
+				})).Should(Succeed())
+			Consistently(testapps.List(&testCtx, generics.NamespaceSignature,
+				client.MatchingLabels{dptypes.BackupVerificationLabelKey: verification.Name})).Should(HaveLen(0))
+		})
+
+		It("should fail the verification if the backup has no cluster snapshot", func() {
+			mockBackupCompleted(false)
+
+			verification := newVerification(backupKey.Name)
+			Expect(testCtx.CreateObj(testCtx.Ctx, verification)).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKeyFromObject(verification),
+				func(g Gomega, fetched *dpv1alpha1.BackupVerification) {
+					g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupVerificationPhaseFailed))
+					g.Expect(fetched.Status.FailureReason).Should(ContainSubstring(constant.ClusterSnapshotAnnotationKey))
+					g.Expect(fetched.Status.RunName).Should(BeEmpty())
+				})).Should(Succeed())
+		})
+
+		It("should fail the verification and tear down if the cluster fails", func() {
+			mockBackupCompleted(true)
+
+			verification := newVerification(backupKey.Name)
+			Expect(testCtx.CreateObj(testCtx.Ctx, verification)).Should(Succeed())
+			verificationKey := client.ObjectKeyFromObject(verification)
+
+			By("the temporary cluster of the verification run is created in the scratch namespace")
+			var scratchNamespace string
+			Eventually(testapps.CheckObj(&testCtx, verificationKey, func(g Gomega, fetched *dpv1alpha1.BackupVerification) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupVerificationPhaseRunning))
+				g.Expect(fetched.Status.BackupName).Should(Equal(backupKey.Name))
+				g.Expect(fetched.Status.RunName).ShouldNot(BeEmpty())
+				g.Expect(fetched.Status.ScratchNamespace).ShouldNot(Equal(testCtx.DefaultNamespace))
+				g.Expect(fetched.Status.ClusterName).Should(Equal(testdp.ClusterName))
+				scratchNamespace = fetched.Status.ScratchNamespace
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, client.ObjectKey{Name: scratchNamespace}, func(g Gomega, ns *corev1.Namespace) {
+				g.Expect(ns.Labels[dptypes.BackupVerificationLabelKey]).Should(Equal(verification.Name))
+			})).Should(Succeed())
+			clusterKey := client.ObjectKey{Namespace: scratchNamespace, Name: testdp.ClusterName}
+			Eventually(testapps.CheckObj(&testCtx, clusterKey, func(g Gomega, cluster *appsv1.Cluster) {
+				g.Expect(cluster.Spec.TerminationPolicy).Should(Equal(appsv1.WipeOut))
+				g.Expect(cluster.Annotations[constant.RestoreFromBackupAnnotationKey]).Should(ContainSubstring(backupKey.Name))
+			})).Should(Succeed())
+
+			By("mock the cluster failed")
+			Eventually(testapps.GetAndChangeObjStatus(&testCtx, clusterKey, func(cluster *appsv1.Cluster) {
+				cluster.Status.Phase = appsv1.FailedClusterPhase
+			})).Should(Succeed())
+
+			By("the verification fails and the result is recorded in the backup")
+			Eventually(testapps.CheckObj(&testCtx, verificationKey, func(g Gomega, fetched *dpv1alpha1.BackupVerification) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupVerificationPhaseFailed))
+				g.Expect(fetched.Status.RunName).Should(BeEmpty())
+				g.Expect(fetched.Status.ScratchNamespace).Should(BeEmpty())
+				g.Expect(fetched.Status.CompletionTimestamp).ShouldNot(BeNil())
+			})).Should(Succeed())
+			Eventually(testapps.CheckObj(&testCtx, backupKey, func(g Gomega, backup *dpv1alpha1.Backup) {
+				g.Expect(backup.Annotations[dptypes.BackupVerificationResultAnnotationKey]).
+					Should(Equal(string(dpv1alpha1.BackupVerificationPhaseFailed)))
+			})).Should(Succeed())
+
+			By("the scratch namespace is deleted")
+			Eventually(func(g Gomega) {
+				ns := &corev1.Namespace{}
+				err := testCtx.Cli.Get(testCtx.Ctx, client.ObjectKey{Name: scratchNamespace}, ns)
+				if err == nil {
+					g.Expect(ns.DeletionTimestamp).ShouldNot(BeNil())
+				} else {
+					g.Expect(client.IgnoreNotFound(err)).Should(Succeed())
+				}
+			}).Should(Succeed())
+		})
+
+		It("should fail the verification and tear down if the run does not complete in time", func() {
+			mockBackupCompleted(true)
+
+			verification := newVerification(backupKey.Name)
+			verification.Spec.RunTimeoutSeconds = pointer.Int64(1)
+			Expect(testCtx.CreateObj(testCtx.Ctx, verification)).Should(Succeed())
+			verificationKey := client.ObjectKeyFromObject(verification)
+
+			By("the verification run starts")
+			var scratchNamespace string
+			Eventually(testapps.CheckObj(&testCtx, verificationKey, func(g Gomega, fetched *dpv1alpha1.BackupVerification) {
+				g.Expect(fetched.Status.ScratchNamespace).ShouldNot(BeEmpty())
+				scratchNamespace = fetched.Status.ScratchNamespace
+			})).Should(Succeed())
+
+			By("the verification fails once the deadline of the run is exceeded")
+			Eventually(testapps.CheckObj(&testCtx, verificationKey, func(g Gomega, fetched *dpv1alpha1.BackupVerification) {
+				g.Expect(fetched.Status.Phase).Should(Equal(dpv1alpha1.BackupVerificationPhaseFailed))
+				g.Expect(fetched.Status.FailureReason).Should(ContainSubstring("does not complete within"))
+				g.Expect(fetched.Status.RunName).Should(BeEmpty())
+			})).Should(Succeed())
+
+			By("the scratch namespace is deleted")
+			Eventually(func(g Gomega) {
+				ns := &corev1.Namespace{}
+				err := testCtx.Cli.Get(testCtx.Ctx, client.ObjectKey{Name: scratchNamespace}, ns)
+				if err == nil {
+					g.Expect(ns.DeletionTimestamp).ShouldNot(BeNil())
+				} else {
+					g.Expect(client.IgnoreNotFound(err)).Should(Succeed())
+				}
+			}).Should(Succeed())
+		})
+	})
+
+	It("should build the verification job with the connection environment variables", func() {
+		verification := newVerification(testdp.BackupName)
+		verification.Status.BackupName = testdp.BackupName
+		runKey := client.ObjectKey{Namespace: "scratch", Name: "run"}
+		dbEnv := []corev1.EnvVar{{Name: dptypes.DPDBHost, Value: "pod-0.headless.scratch.svc"}}
+		job := (&BackupVerificationReconciler{}).buildVerifyJob(verification, runKey, dbEnv)
+		Expect(job.Name).Should(Equal(runKey.Name))
+		Expect(job.Namespace).Should(Equal(runKey.Namespace))
+		Expect(*job.Spec.BackoffLimit).Should(BeEquivalentTo(0))
+		Expect(*job.Spec.ActiveDeadlineSeconds).Should(BeEquivalentTo(60))
+		env := job.Spec.Template.Spec.Containers[0].Env
+		Expect(env).Should(ContainElement(corev1.EnvVar{Name: dptypes.DPBackupName, Value: testdp.BackupName}))
+		Expect(env).Should(ContainElement(dbEnv[0]))
+	})
+
+	It("should build the scratch namespace name within the length limit", func() {
+		verification := newVerification(testdp.BackupName)
+		verification.Name = strings.Repeat("a", 63)
+		verification.UID = "0123456789abcdef"
+		name := buildScratchNamespaceName(verification, verification.Name+"-12345678")
+		Expect(len(name)).Should(BeNumerically("<=", 63))
+		Expect(name).Should(HaveSuffix("-01234567"))
+	})
+})
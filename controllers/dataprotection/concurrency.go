@@ -0,0 +1,254 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dataprotection
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
+	dprestore "github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+// workloadAdmitter admits the backups and restores reconciled by the backup and restore controllers.
+var workloadAdmitter = dputils.NewWorkloadAdmitter()
+
+// listWorkloads lists the running and pending backups and restores, which are limited by the concurrency limits.
+// The continuous backups are not taken into account, since they keep running until they are stopped.
+func listWorkloads(ctx context.Context, cli client.Client, limits dputils.ConcurrencyLimits) (*dputils.Workloads, error) {
+	workloads := &dputils.Workloads{NotStarted: sets.New[string]()}
+	backupList := &dpv1alpha1.BackupList{}
+	if err := cli.List(ctx, backupList); err != nil {
+		return nil, err
+	}
+	for i := range backupList.Items {
+		backup := &backupList.Items[i]
+		if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+			continue
+		}
+		switch backup.Status.Phase {
+		case dpv1alpha1.BackupPhaseRunning:
+			workloads.Running = append(workloads.Running, buildBackupWorkload(backup, backup.Status.BackupRepoName))
+		case dpv1alpha1.BackupPhasePending:
+			w := buildBackupWorkload(backup, backup.Labels[dataProtectionBackupRepoKey])
+			workloads.Pending = append(workloads.Pending, w)
+			workloads.NotStarted.Insert(w.Key())
+		case "", dpv1alpha1.BackupPhaseNew:
+			w := buildBackupWorkload(backup, backup.Labels[dataProtectionBackupRepoKey])
+			workloads.NotStarted.Insert(w.Key())
+		}
+	}
+
+	restoreList := &dpv1alpha1.RestoreList{}
+	if err := cli.List(ctx, restoreList); err != nil {
+		return nil, err
+	}
+	for i := range restoreList.Items {
+		restore := &restoreList.Items[i]
+		switch restore.Status.Phase {
+		case dpv1alpha1.RestorePhaseRunning:
+			w, err := buildRestoreWorkload(ctx, cli, restore, limits)
+			if err != nil {
+				return nil, err
+			}
+			workloads.Running = append(workloads.Running, w)
+		case "":
+			w, err := buildRestoreWorkload(ctx, cli, restore, limits)
+			if err != nil {
+				return nil, err
+			}
+			if dprestore.IsRestorePending(restore) {
+				workloads.Pending = append(workloads.Pending, w)
+			}
+			workloads.NotStarted.Insert(w.Key())
+		}
+	}
+	return workloads, nil
+}
+
+func buildBackupWorkload(backup *dpv1alpha1.Backup, repoName string) dputils.Workload {
+	var nodeNames []string
+	if v := backup.Annotations[dptypes.BackupTargetNodesAnnotationKey]; v != "" {
+		nodeNames = strings.Split(v, ",")
+	}
+	return dputils.Workload{
+		Kind:              dptypes.BackupKind,
+		Namespace:         backup.Namespace,
+		Name:              backup.Name,
+		CreationTimestamp: backup.CreationTimestamp,
+		RepoName:          repoName,
+		NodeNames:         nodeNames,
+	}
+}
+
+// buildRestoreWorkload builds the workload of the restore. The restore is limited on the nodes of its target pods
+// and the nodes where its jobs run, the nodes of the jobs which are scheduled by the scheduler are known once the jobs
+// are created.
+func buildRestoreWorkload(ctx context.Context,
+	cli client.Client,
+	restore *dpv1alpha1.Restore,
+	limits dputils.ConcurrencyLimits) (dputils.Workload, error) {
+	w := dputils.Workload{
+		Kind:              dptypes.RestoreKind,
+		Namespace:         restore.Namespace,
+		Name:              restore.Name,
+		CreationTimestamp: restore.CreationTimestamp,
+		RepoName:          restore.Labels[dataProtectionBackupRepoKey],
+	}
+	if limits.PerNode <= 0 {
+		return w, nil
+	}
+	nodeNames := sets.New[string]()
+	if restore.Spec.PrepareDataConfig != nil && restore.Spec.PrepareDataConfig.SchedulingSpec.NodeName != "" {
+		nodeNames.Insert(restore.Spec.PrepareDataConfig.SchedulingSpec.NodeName)
+	}
+	var selectors []*metav1.LabelSelector
+	if restore.Spec.ReadyConfig != nil {
+		if jobAction := restore.Spec.ReadyConfig.JobAction; jobAction != nil && jobAction.Target.PodSelector.LabelSelector != nil {
+			selectors = append(selectors, jobAction.Target.PodSelector.LabelSelector)
+		}
+		if execAction := restore.Spec.ReadyConfig.ExecAction; execAction != nil &&
+			(len(execAction.Target.PodSelector.MatchLabels) > 0 || len(execAction.Target.PodSelector.MatchExpressions) > 0) {
+			selectors = append(selectors, &execAction.Target.PodSelector)
+		}
+	}
+	selectors = append(selectors, &metav1.LabelSelector{MatchLabels: dprestore.BuildRestoreLabels(restore.Name)})
+	for _, labelSelector := range selectors {
+		selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return w, err
+		}
+		podList := &corev1.PodList{}
+		if err = cli.List(ctx, podList, client.InNamespace(restore.Namespace),
+			client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return w, err
+		}
+		for _, pod := range podList.Items {
+			if pod.Spec.NodeName != "" {
+				nodeNames.Insert(pod.Spec.NodeName)
+			}
+		}
+	}
+	w.NodeNames = sets.List(nodeNames)
+	return w, nil
+}
+
+// setBackupTargetNodes records the nodes of the target pods in the annotation of the backup,
+// which are used to limit the concurrent backups on each node.
+func (r *BackupReconciler) setBackupTargetNodes(
+	reqCtx intctrlutil.RequestCtx,
+	request *dpbackup.Request) error {
+	if dputils.GetConcurrencyLimits().PerNode <= 0 {
+		return nil
+	}
+	var podNames []string
+	if request.Status.Target != nil {
+		podNames = append(podNames, request.Status.Target.SelectedTargetPods...)
+	}
+	for _, target := range request.Status.Targets {
+		podNames = append(podNames, target.SelectedTargetPods...)
+	}
+	nodeNames := sets.New[string]()
+	for _, podName := range podNames {
+		pod := &corev1.Pod{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: podName, Namespace: request.Namespace}, pod); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if pod.Spec.NodeName != "" {
+			nodeNames.Insert(pod.Spec.NodeName)
+		}
+	}
+	request.Annotations[dptypes.BackupTargetNodesAnnotationKey] = strings.Join(sets.List(nodeNames), ",")
+	return nil
+}
+
+// admitBackup checks whether the backup can be started under the concurrency limits.
+// If not, the backup waits in the Pending phase until the other backups and restores finish.
+func (r *BackupReconciler) admitBackup(
+	reqCtx intctrlutil.RequestCtx,
+	original *dpv1alpha1.Backup,
+	request *dpbackup.Request) (bool, error) {
+	limits := dputils.GetConcurrencyLimits()
+	if !limits.Enabled() || request.GetBackupType() == string(dpv1alpha1.BackupTypeContinuous) {
+		return true, nil
+	}
+	var repoName string
+	if request.BackupRepo != nil {
+		repoName = request.BackupRepo.Name
+	}
+	message, err := workloadAdmitter.Admit(limits, func() (*dputils.Workloads, error) {
+		return listWorkloads(reqCtx.Ctx, r.Client, limits)
+	}, buildBackupWorkload(request.Backup, repoName))
+	if err != nil {
+		return false, err
+	}
+	if message == "" {
+		return true, nil
+	}
+	if request.Status.Phase != dpv1alpha1.BackupPhasePending {
+		request.Status.Phase = dpv1alpha1.BackupPhasePending
+		if err = r.Client.Status().Patch(reqCtx.Ctx, request.Backup, client.MergeFrom(original)); err != nil {
+			return false, err
+		}
+	}
+	r.Recorder.Event(request.Backup, corev1.EventTypeNormal, ReasonPending, message)
+	return false, nil
+}
+
+// admitRestore checks whether the restore can be started under the concurrency limits.
+// If not, the restore waits with the Pending reason in its Scheduled condition.
+func (r *RestoreReconciler) admitRestore(
+	reqCtx intctrlutil.RequestCtx,
+	restore *dpv1alpha1.Restore) (bool, error) {
+	limits := dputils.GetConcurrencyLimits()
+	if !limits.Enabled() {
+		return true, nil
+	}
+	candidate, err := buildRestoreWorkload(reqCtx.Ctx, r.Client, restore, limits)
+	if err != nil {
+		return false, err
+	}
+	message, err := workloadAdmitter.Admit(limits, func() (*dputils.Workloads, error) {
+		return listWorkloads(reqCtx.Ctx, r.Client, limits)
+	}, candidate)
+	if err != nil {
+		return false, err
+	}
+	if message == "" {
+		dprestore.SetRestoreScheduledCondition(restore, dprestore.ReasonScheduled, "")
+		return true, nil
+	}
+	dprestore.SetRestoreScheduledCondition(restore, dprestore.ReasonPending, message)
+	r.Recorder.Event(restore, corev1.EventTypeNormal, ReasonPending, message)
+	return false, nil
+}
@@ -21,10 +21,12 @@ package dataprotection
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -35,6 +37,7 @@ import (
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	dputils "github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
 	viper "github.com/apecloud/kubeblocks/pkg/viperx"
@@ -70,9 +73,11 @@ func (r *GCReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backups/status,verbs=get
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=backupschedules,verbs=get;list;watch
+// +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
-// delete expired backups.
+// delete expired backups and the backups exceeding the retention policy of their schedule.
 func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	reqCtx := intctrlutil.RequestCtx{
 		Ctx:      ctx,
@@ -102,8 +107,8 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 
 	now := r.clock.Now()
 	if backup.Status.Expiration == nil || backup.Status.Expiration.After(now) {
-		reqCtx.Log.V(1).Info("backup is not expired yet, skipping")
-		return intctrlutil.Reconciled()
+		reqCtx.Log.V(1).Info("backup is not expired yet")
+		return r.deleteBackupExceedingRetention(reqCtx, backup)
 	}
 
 	reqCtx.Log.Info("backup has expired, delete it", "backup", req.String())
@@ -116,6 +121,181 @@ func (r *GCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Re
 	return intctrlutil.Reconciled()
 }
 
+// deleteBackupExceedingRetention deletes the backup if it is not retained by the retention policy of its schedule,
+// and no other backups or restores depend on it.
+func (r *GCReconciler) deleteBackupExceedingRetention(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (ctrl.Result, error) {
+	exceeded, err := r.exceedsRetentionPolicy(reqCtx, backup)
+	if err != nil {
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	if !exceeded {
+		return intctrlutil.Reconciled()
+	}
+
+	reqCtx.Log.Info("backup exceeds the retention policy, delete it", "backup", reqCtx.Req.String())
+	if err = intctrlutil.BackgroundDeleteObject(r.Client, reqCtx.Ctx, backup); err != nil {
+		reqCtx.Log.Error(err, "failed to delete backup")
+		r.Recorder.Event(backup, corev1.EventTypeWarning, "RemoveBackupsExceedingRetentionFailed", err.Error())
+		return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+	}
+	return intctrlutil.Reconciled()
+}
+
+// exceedsRetentionPolicy checks whether the completed backup created by a schedule is not retained by
+// the retention policy of the schedule, and can be deleted safely.
+func (r *GCReconciler) exceedsRetentionPolicy(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup) (bool, error) {
+	scheduleName := backup.Labels[dptypes.BackupScheduleLabelKey]
+	if scheduleName == "" || backup.Status.Phase != dpv1alpha1.BackupPhaseCompleted ||
+		backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) {
+		return false, nil
+	}
+
+	backupSchedule := &dpv1alpha1.BackupSchedule{}
+	exists, err := intctrlutil.CheckResourceExists(reqCtx.Ctx, r.Client,
+		client.ObjectKey{Name: scheduleName, Namespace: backup.Namespace}, backupSchedule)
+	if err != nil || !exists {
+		return false, err
+	}
+	var retentionPolicy *dpv1alpha1.BackupRetentionPolicy
+	for _, schedulePolicy := range backupSchedule.Spec.Schedules {
+		if schedulePolicy.BackupMethod == backup.Spec.BackupMethod {
+			retentionPolicy = schedulePolicy.RetentionPolicy
+			break
+		}
+	}
+	if retentionPolicy == nil {
+		return false, nil
+	}
+
+	// only the backups of the schedule are listed here, all the backups and restores are listed only if
+	// the backup exceeds the retention policy, to avoid listing them for every backup.
+	scheduledBackupList := &dpv1alpha1.BackupList{}
+	if err = r.List(reqCtx.Ctx, scheduledBackupList, client.InNamespace(backup.Namespace),
+		client.MatchingLabels{dptypes.BackupScheduleLabelKey: scheduleName}); err != nil {
+		return false, err
+	}
+	var scheduledBackups []*dpv1alpha1.Backup
+	for i := range scheduledBackupList.Items {
+		item := &scheduledBackupList.Items[i]
+		if item.Spec.BackupMethod == backup.Spec.BackupMethod &&
+			item.Status.Phase == dpv1alpha1.BackupPhaseCompleted &&
+			item.DeletionTimestamp.IsZero() {
+			scheduledBackups = append(scheduledBackups, item)
+		}
+	}
+	retained := dpbackup.GetBackupsToRetain(retentionPolicy, scheduledBackups)
+	if retained.Has(backup.Name) {
+		return false, nil
+	}
+
+	// the backups that will be deleted by the retention policy
+	exceeded := sets.New[string]()
+	for _, item := range scheduledBackups {
+		if !retained.Has(item.Name) {
+			exceeded.Insert(item.Name)
+		}
+	}
+	inUse, err := r.isBackupInUse(reqCtx, backup, exceeded)
+	if err != nil {
+		return false, err
+	}
+	if inUse {
+		reqCtx.Log.V(1).Info("backup exceeds the retention policy, but it is still in use, skipping")
+		return false, nil
+	}
+	return true, nil
+}
+
+// isBackupInUse checks whether the backup is still depended on by an incremental backup,
+// a continuous backup, a running consolidation or a running restore. The @exceeded backups will be deleted by the retention policy,
+// so that they are not taken into account.
+func (r *GCReconciler) isBackupInUse(reqCtx intctrlutil.RequestCtx, backup *dpv1alpha1.Backup, exceeded sets.Set[string]) (bool, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := r.List(reqCtx.Ctx, backupList, client.InNamespace(backup.Namespace)); err != nil {
+		return false, err
+	}
+	backups := backupList.Items
+
+	// the backup and all its descendants, which depend on the backup
+	children := map[string][]string{}
+	for i := range backups {
+		if parent := dputils.GetParentBackupName(&backups[i]); parent != "" {
+			children[parent] = append(children[parent], backups[i].Name)
+		}
+	}
+	dependents := sets.New[string]()
+	for queue := []string{backup.Name}; len(queue) > 0; queue = queue[1:] {
+		for _, child := range children[queue[0]] {
+			if !dependents.Has(child) && child != backup.Name {
+				dependents.Insert(child)
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	backupMap := map[string]*dpv1alpha1.Backup{}
+	for i := range backups {
+		item := &backups[i]
+		backupMap[item.Name] = item
+
+		// 1. the backup is the ancestor of an incremental backup that will be kept
+		if dependents.Has(item.Name) && item.DeletionTimestamp.IsZero() && !exceeded.Has(item.Name) {
+			return true, nil
+		}
+
+		// 2. the backup is the base full backup of the recoverable time range of a continuous backup
+		if backup.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeFull) &&
+			item.Labels[dptypes.BackupTypeLabelKey] == string(dpv1alpha1.BackupTypeContinuous) && item.DeletionTimestamp.IsZero() {
+			if base := dputils.GetBaseFullBackupForContinuous(item, backups); base != nil && base.Name == backup.Name {
+				return true, nil
+			}
+		}
+
+		// 3. the backup is being consolidated into a synthetic full backup
+		if dpbackup.IsConsolidationBackup(item) && item.DeletionTimestamp.IsZero() &&
+			item.Status.Phase != dpv1alpha1.BackupPhaseCompleted && item.Status.Phase != dpv1alpha1.BackupPhaseFailed &&
+			slices.Contains(dpbackup.GetConsolidatedBackupNames(item), backup.Name) {
+			return true, nil
+		}
+	}
+
+	// 4. the backup is being restored, or it is the base full backup chosen by the restore of a continuous backup
+	restoreList := &dpv1alpha1.RestoreList{}
+	if err := r.List(reqCtx.Ctx, restoreList, client.InNamespace(backup.Namespace)); err != nil {
+		return false, err
+	}
+	for _, restore := range restoreList.Items {
+		if restore.Status.Phase == dpv1alpha1.RestorePhaseCompleted || restore.Status.Phase == dpv1alpha1.RestorePhaseFailed ||
+			restore.Spec.Backup.Namespace != backup.Namespace {
+			continue
+		}
+		if restore.Spec.Backup.Name == backup.Name || dependents.Has(restore.Spec.Backup.Name) {
+			return true, nil
+		}
+		if isBaseBackupOfRestore(backup, &restore, backupMap[restore.Spec.Backup.Name], backups) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isBaseBackupOfRestore checks whether the full backup is the base backup which the restore of the continuous
+// backup uses, it is the latest full backup before the restore time, the same as the restore manager resolves.
+func isBaseBackupOfRestore(backup *dpv1alpha1.Backup, restore *dpv1alpha1.Restore,
+	restoreBackup *dpv1alpha1.Backup, backups []dpv1alpha1.Backup) bool {
+	if restoreBackup == nil || restore.Spec.RestoreTime == "" ||
+		backup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeFull) ||
+		restoreBackup.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeContinuous) {
+		return false
+	}
+	restoreTime, err := time.Parse(time.RFC3339, restore.Spec.RestoreTime)
+	if err != nil {
+		return false
+	}
+	base := dputils.GetLatestFullBackupForContinuous(restoreBackup, backups, restoreTime)
+	return base != nil && base.Name == backup.Name
+}
+
 func getGCFrequency() time.Duration {
 	gcFrequencySeconds := viper.GetInt(dptypes.CfgKeyGCFrequencySeconds)
 	if gcFrequencySeconds > 0 {
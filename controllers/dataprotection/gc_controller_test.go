@@ -27,6 +27,7 @@ import (
 
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
@@ -52,6 +53,7 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 		testapps.ClearResources(&testCtx, generics.PodSignature, inNS, ml)
 		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupPolicySignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupScheduleSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.BackupRepoSignature, true, ml)
 
@@ -62,6 +64,7 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.JobSignature, true, inNS)
 		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.PersistentVolumeClaimSignature, true, inNS)
+		testapps.ClearResourcesWithRemoveFinalizerOption(&testCtx, generics.CronJobSignature, true, inNS)
 		testapps.ClearResources(&testCtx, generics.SecretSignature, inNS, ml)
 
 		// non-namespaced
@@ -172,5 +175,55 @@ var _ = Describe("Data Protection Garbage Collection Controller", func() {
 			Eventually(testapps.CheckObjExists(&testCtx, backup1Key, &dpv1alpha1.Backup{}, true)).Should(Succeed())
 			Eventually(testapps.CheckObjExists(&testCtx, expiredKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
 		})
+
+		It("delete backups exceeding the retention policy", func() {
+			By("create a backup schedule which keeps the last backup")
+			testdp.NewBackupScheduleFactory(testCtx.DefaultNamespace, testdp.BackupScheduleName).
+				SetBackupPolicyName(testdp.BackupPolicyName).
+				AddSchedulePolicy(dpv1alpha1.SchedulePolicy{
+					Enabled:        pointer.Bool(false),
+					BackupMethod:   testdp.BackupMethodName,
+					CronExpression: "0 0 * * *",
+					RetentionPolicy: &dpv1alpha1.BackupRetentionPolicy{
+						KeepLast: pointer.Int32(1),
+					},
+				}).
+				Create(&testCtx)
+
+			scheduleLabels := map[string]string{
+				dptypes.AutoBackupLabelKey:     "true",
+				dptypes.BackupScheduleLabelKey: testdp.BackupScheduleName,
+			}
+			createCompletedBackup := func(name string, stopTime time.Time) client.ObjectKey {
+				backup := testdp.NewBackupFactory(testCtx.DefaultNamespace, name).
+					WithRandomName().AddLabelsInMap(scheduleLabels).
+					SetBackupPolicyName(testdp.BackupPolicyName).
+					SetBackupMethod(testdp.BackupMethodName).
+					Create(&testCtx).GetObject()
+				key := client.ObjectKeyFromObject(backup)
+				testdp.PatchK8sJobStatus(&testCtx, getJobKey(backup), batchv1.JobComplete)
+				Eventually(testapps.CheckObj(&testCtx, key,
+					func(g Gomega, fetched *dpv1alpha1.Backup) {
+						g.Expect(fetched.Status.Phase).To(Equal(dpv1alpha1.BackupPhaseCompleted))
+					})).Should(Succeed())
+
+				By("mock the stop time of the backup")
+				backup.Status = dpv1alpha1.BackupStatus{
+					Phase:               dpv1alpha1.BackupPhaseCompleted,
+					StartTimestamp:      &metav1.Time{Time: stopTime.Add(-time.Minute)},
+					CompletionTimestamp: &metav1.Time{Time: stopTime},
+				}
+				testdp.PatchBackupStatus(&testCtx, key, backup.Status)
+				return key
+			}
+
+			By("create an old backup and a new backup")
+			oldKey := createCompletedBackup(backupNamePrefix+"old", fakeClock.Now().Add(-time.Hour*24))
+			newKey := createCompletedBackup(backupNamePrefix+"new", fakeClock.Now().Add(-time.Hour))
+
+			By("retain the new backup only")
+			Eventually(testapps.CheckObjExists(&testCtx, oldKey, &dpv1alpha1.Backup{}, false)).Should(Succeed())
+			Consistently(testapps.CheckObjExists(&testCtx, newKey, &dpv1alpha1.Backup{}, true)).Should(Succeed())
+		})
 	})
 })
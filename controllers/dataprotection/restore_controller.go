@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -44,6 +45,7 @@ import (
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
 	"github.com/apecloud/kubeblocks/pkg/constant"
 	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dpbackup "github.com/apecloud/kubeblocks/pkg/dataprotection/backup"
 	dperrors "github.com/apecloud/kubeblocks/pkg/dataprotection/errors"
 	dprestore "github.com/apecloud/kubeblocks/pkg/dataprotection/restore"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
@@ -54,8 +56,9 @@ import (
 // RestoreReconciler reconciles a Restore object
 type RestoreReconciler struct {
 	client.Client
-	Scheme   *runtime.Scheme
-	Recorder record.EventRecorder
+	Scheme     *runtime.Scheme
+	Recorder   record.EventRecorder
+	RestConfig *rest.Config
 }
 
 // +kubebuilder:rbac:groups=dataprotection.kubeblocks.io,resources=restores,verbs=get;list;watch;create;update;patch;delete
@@ -229,6 +232,10 @@ func (r *RestoreReconciler) newAction(reqCtx intctrlutil.RequestCtx, restore *dp
 		return intctrlutil.RequeueWithError(err, reqCtx.Log, "")
 	default:
 		dprestore.SetRestoreCheckBackupRepoCondition(restore, dprestore.ReasonCheckBackupRepoSuccessfully, "")
+		// the backup repo label is used to limit the concurrent restores of the backup repo.
+		if repoName != "" {
+			restore.Labels[dataProtectionBackupRepoKey] = repoName
+		}
 	}
 	if !reflect.DeepEqual(restore.ObjectMeta, oldRestore.ObjectMeta) {
 		if err := r.Client.Patch(reqCtx.Ctx, restore, patch); err != nil {
@@ -259,6 +266,17 @@ func (r *RestoreReconciler) newAction(reqCtx intctrlutil.RequestCtx, restore *dp
 		case err != nil:
 			return RecorderEventAndRequeue(reqCtx, r.Recorder, restore, err)
 		default:
+			// wait with the Pending reason if the concurrency limits are reached.
+			admitted, err := r.admitRestore(reqCtx, restore)
+			if err != nil {
+				return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+			}
+			if !admitted {
+				if err = r.Client.Status().Patch(reqCtx.Ctx, restore, patch); err != nil {
+					return intctrlutil.CheckedRequeueWithError(err, reqCtx.Log, "")
+				}
+				return intctrlutil.RequeueAfter(pendingCheckInterval, reqCtx.Log, "restore is pending")
+			}
 			restore.Status.StartTimestamp = &metav1.Time{Time: time.Now()}
 			restore.Status.Phase = dpv1alpha1.RestorePhaseRunning
 			r.Recorder.Event(restore, corev1.EventTypeNormal, dprestore.ReasonRestoreStarting, "start to restore")
@@ -306,9 +324,15 @@ func (r *RestoreReconciler) handleRunningPhase(reqCtx intctrlutil.RequestCtx, re
 }
 
 func (r *RestoreReconciler) HandleRestoreActions(reqCtx intctrlutil.RequestCtx, restoreMgr *dprestore.RestoreManager) error {
+	reqCtx.Log.V(1).Info("start to restore kubernetes resources", "restore", reqCtx.Req.NamespacedName)
+	// 1. restore the secrets and configmaps referenced by the clusters.
+	isCompleted, err := r.restoreKubeResources(reqCtx, restoreMgr, false)
+	if err != nil || !isCompleted {
+		return err
+	}
 	reqCtx.Log.V(1).Info("start to prepare data", "restore", reqCtx.Req.NamespacedName)
-	// 1. handle the prepareData stage.
-	isCompleted, err := r.prepareData(reqCtx, restoreMgr)
+	// 2. handle the prepareData stage.
+	isCompleted, err = r.prepareData(reqCtx, restoreMgr)
 	if err != nil {
 		return err
 	}
@@ -316,8 +340,14 @@ func (r *RestoreReconciler) HandleRestoreActions(reqCtx intctrlutil.RequestCtx,
 	if !isCompleted {
 		return nil
 	}
+	reqCtx.Log.V(1).Info("start to restore clusters", "restore", reqCtx.Req.NamespacedName)
+	// 3. restore the clusters after the data is prepared.
+	isCompleted, err = r.restoreKubeResources(reqCtx, restoreMgr, true)
+	if err != nil || !isCompleted {
+		return err
+	}
 	reqCtx.Log.V(1).Info("start to restore data after ready", "restore", reqCtx.Req.NamespacedName)
-	// 2. handle the postReady stage.
+	// 4. handle the postReady stage.
 	isCompleted, err = r.postReady(reqCtx, restoreMgr)
 	if err != nil {
 		return err
@@ -345,6 +375,37 @@ func (r *RestoreReconciler) validateAndBuildMGR(reqCtx intctrlutil.RequestCtx, r
 	return err
 }
 
+// restoreKubeResources restores the secrets and configmaps referenced by the clusters before the prepareData
+// stage, and restores the clusters after the prepareData stage if restoreClusters is true.
+func (r *RestoreReconciler) restoreKubeResources(reqCtx intctrlutil.RequestCtx,
+	restoreMgr *dprestore.RestoreManager, restoreClusters bool) (bool, error) {
+	if restoreMgr.Restore.Spec.Resources == nil {
+		return true, nil
+	}
+	condition := meta.FindStatusCondition(restoreMgr.Restore.Status.Conditions, dprestore.ConditionTypeRestoreKubeResources)
+	if condition != nil && (condition.Status == metav1.ConditionTrue ||
+		(!restoreClusters && condition.Reason == dprestore.ReasonClusterPending)) {
+		return true, nil
+	}
+	isCompleted, err := restoreMgr.RestoreKubeResources(reqCtx, r.Client, r.RestConfig, restoreClusters)
+	switch {
+	case intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal):
+		dprestore.SetRestoreKubeResourcesCondition(restoreMgr.Restore, dprestore.ReasonFailed, err.Error())
+		return false, err
+	case err != nil:
+		return false, err
+	case !isCompleted:
+		dprestore.SetRestoreKubeResourcesCondition(restoreMgr.Restore, dprestore.ReasonProcessing, "restoring kubernetes resources")
+		return false, nil
+	case !restoreClusters:
+		dprestore.SetRestoreKubeResourcesCondition(restoreMgr.Restore, dprestore.ReasonClusterPending,
+			"restore the secrets and configmaps successfully, the clusters are restored after the data is prepared")
+		return true, nil
+	}
+	dprestore.SetRestoreKubeResourcesCondition(restoreMgr.Restore, dprestore.ReasonSucceed, "restore kubernetes resources successfully")
+	return true, nil
+}
+
 // prepareData handles the prepareData stage of the backups.
 func (r *RestoreReconciler) prepareData(reqCtx intctrlutil.RequestCtx, restoreMgr *dprestore.RestoreManager) (bool, error) {
 	if len(restoreMgr.PrepareDataBackupSets) == 0 {
@@ -366,6 +427,10 @@ func (r *RestoreReconciler) prepareData(reqCtx intctrlutil.RequestCtx, restoreMg
 	}()
 	// set processing prepare data condition
 	dprestore.SetRestoreStageCondition(restoreMgr.Restore, dpv1alpha1.PrepareData, dprestore.ReasonProcessing, "processing prepareData stage.")
+	// verify the backup data once before the restore jobs read it.
+	if isCompleted, err = r.verifyIntegrity(reqCtx, restoreMgr); err != nil || !isCompleted {
+		return false, err
+	}
 	for i, v := range restoreMgr.PrepareDataBackupSets {
 		isCompleted, err = r.handleBackupActionSet(reqCtx, restoreMgr, v, dpv1alpha1.PrepareData, i)
 		if err != nil {
@@ -381,6 +446,56 @@ func (r *RestoreReconciler) prepareData(reqCtx intctrlutil.RequestCtx, restoreMg
 	return true, nil
 }
 
+// verifyIntegrity checks the backup data of the prepareData stage against the integrity manifests, so that the
+// restore fails fast if the backup data is missing or corrupted. Each backup is checked by a single job for the
+// whole restore, and the backups without the integrity manifests are not checked.
+func (r *RestoreReconciler) verifyIntegrity(reqCtx intctrlutil.RequestCtx, restoreMgr *dprestore.RestoreManager) (bool, error) {
+	restore := restoreMgr.Restore
+	if meta.IsStatusConditionTrue(restore.Status.Conditions, dprestore.ConditionTypeRestoreIntegrity) {
+		return true, nil
+	}
+	startTime := restore.CreationTimestamp
+	if restore.Status.StartTimestamp != nil {
+		startTime = *restore.Status.StartTimestamp
+	}
+	for _, v := range restoreMgr.PrepareDataBackupSets {
+		backup := dprestore.BuildBackupToVerify(restore, v.Backup)
+		if v.UseVolumeSnapshot || backup == nil || backup.Status.BackupRepoName == "" {
+			continue
+		}
+		repo := &dpv1alpha1.BackupRepo{}
+		if err := r.Client.Get(reqCtx.Ctx, client.ObjectKey{Name: backup.Status.BackupRepoName}, repo); err != nil {
+			return false, err
+		}
+		saName, err := EnsureWorkerServiceAccount(reqCtx, r.Client, backup.Namespace, nil)
+		if err != nil {
+			return false, err
+		}
+		checker := &dpbackup.IntegrityChecker{
+			RequestCtx:           reqCtx,
+			Client:               r.Client,
+			Scheme:               r.Scheme,
+			WorkerServiceAccount: saName,
+			Labels: map[string]string{
+				dprestore.DataProtectionRestoreLabelKey:          restore.Name,
+				dprestore.DataProtectionRestoreNamespaceLabelKey: restore.Namespace,
+			},
+		}
+		finished, err := checker.CheckIntegrity(backup, repo, startTime)
+		if intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal) {
+			dprestore.SetRestoreIntegrityCondition(restore, dprestore.ReasonFailed, err.Error())
+			return false, err
+		}
+		if err != nil || !finished {
+			dprestore.SetRestoreIntegrityCondition(restore, dprestore.ReasonProcessing,
+				fmt.Sprintf("verifying the integrity of backup %s", backup.Name))
+			return false, err
+		}
+	}
+	dprestore.SetRestoreIntegrityCondition(restore, dprestore.ReasonSucceed, "the backup data matches the integrity manifests")
+	return true, nil
+}
+
 func (r *RestoreReconciler) postReady(reqCtx intctrlutil.RequestCtx, restoreMgr *dprestore.RestoreManager) (bool, error) {
 	readyConfig := restoreMgr.Restore.Spec.ReadyConfig
 	if len(restoreMgr.PostReadyBackupSets) == 0 || readyConfig == nil {
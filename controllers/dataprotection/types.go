@@ -23,6 +23,8 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 )
 
 const (
@@ -32,8 +34,8 @@ const (
 const (
 
 	// label keys
-	dataProtectionBackupRepoKey          = "dataprotection.kubeblocks.io/backup-repo-name"
-	dataProtectionWaitRepoPreparationKey = "dataprotection.kubeblocks.io/wait-repo-preparation"
+	dataProtectionBackupRepoKey          = dptypes.BackupRepoNameLabelKey
+	dataProtectionWaitRepoPreparationKey = dptypes.WaitRepoPreparationLabelKey
 	dataProtectionIsToolConfigKey        = "dataprotection.kubeblocks.io/is-tool-config"
 
 	// annotation keys
@@ -71,6 +73,13 @@ const (
 	ReasonDigestChanged             = "DigestChanged"
 	ReasonUnknownError              = "UnknownError"
 	ReasonSkipped                   = "Skipped"
+
+	// event reasons
+	ReasonSyncBackupsCompleted = "SyncBackupsCompleted"
+	ReasonSyncBackupsFailed    = "SyncBackupsFailed"
+	ReasonPending              = "Pending"
+	ReasonIntegrityCheckPassed = "IntegrityCheckPassed"
+	ReasonIntegrityCheckFailed = "IntegrityCheckFailed"
 )
 
 // constant  for volume populator
@@ -95,3 +104,6 @@ const (
 )
 
 var reconcileInterval = time.Second
+
+// pendingCheckInterval is the interval to check whether the pending backups and restores can be started.
+var pendingCheckInterval = 30 * time.Second
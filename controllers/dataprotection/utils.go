@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -172,6 +173,9 @@ func GetTargetPods(reqCtx intctrlutil.RequestCtx,
 			}
 		case dpv1alpha1.PodSelectionStrategyAll:
 			if len(selectedPodNames) == 0 || backupType == dpv1alpha1.BackupTypeContinuous {
+				// sort the pods by ordinal, so that the selected target pods can be mapped to
+				// the instances with the same ordinals when restoring.
+				sort.Sort(intctrlutil.ByPodOrdinal(pods.Items))
 				for i := range pods.Items {
 					targetPods = append(targetPods, &pods.Items[i])
 				}
@@ -298,7 +302,7 @@ func getDefaultBackupRepo(ctx context.Context, cli client.Client) (*dpv1alpha1.B
 }
 
 type objectList interface {
-	*appsv1.StatefulSetList | *batchv1.JobList
+	*appsv1.StatefulSetList | *batchv1.JobList | *corev1.PersistentVolumeClaimList | *dpv1alpha1.RestoreList
 	client.ObjectList
 }
 
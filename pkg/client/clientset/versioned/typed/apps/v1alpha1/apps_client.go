@@ -32,12 +32,18 @@ type AppsV1alpha1Interface interface {
 	ClustersGetter
 	ClusterDefinitionsGetter
 	ComponentsGetter
+	ComponentAutoscalersGetter
 	ComponentDefinitionsGetter
 	ComponentVersionsGetter
 	ConfigConstraintsGetter
+	OpsApprovalPoliciesGetter
 	OpsDefinitionsGetter
 	OpsRequestsGetter
+	OpsSchedulesGetter
+	OpsTemplatesGetter
+	ResourceRecommendersGetter
 	ServiceDescriptorsGetter
+	StorageAutoscalingPoliciesGetter
 }
 
 // AppsV1alpha1Client is used to interact with features provided by the apps.kubeblocks.io group.
@@ -61,6 +67,10 @@ func (c *AppsV1alpha1Client) Components(namespace string) ComponentInterface {
 	return newComponents(c, namespace)
 }
 
+func (c *AppsV1alpha1Client) ComponentAutoscalers(namespace string) ComponentAutoscalerInterface {
+	return newComponentAutoscalers(c, namespace)
+}
+
 func (c *AppsV1alpha1Client) ComponentDefinitions() ComponentDefinitionInterface {
 	return newComponentDefinitions(c)
 }
@@ -73,6 +83,10 @@ func (c *AppsV1alpha1Client) ConfigConstraints() ConfigConstraintInterface {
 	return newConfigConstraints(c)
 }
 
+func (c *AppsV1alpha1Client) OpsApprovalPolicies() OpsApprovalPolicyInterface {
+	return newOpsApprovalPolicies(c)
+}
+
 func (c *AppsV1alpha1Client) OpsDefinitions() OpsDefinitionInterface {
 	return newOpsDefinitions(c)
 }
@@ -81,10 +95,26 @@ func (c *AppsV1alpha1Client) OpsRequests(namespace string) OpsRequestInterface {
 	return newOpsRequests(c, namespace)
 }
 
+func (c *AppsV1alpha1Client) OpsSchedules(namespace string) OpsScheduleInterface {
+	return newOpsSchedules(c, namespace)
+}
+
+func (c *AppsV1alpha1Client) OpsTemplates(namespace string) OpsTemplateInterface {
+	return newOpsTemplates(c, namespace)
+}
+
+func (c *AppsV1alpha1Client) ResourceRecommenders(namespace string) ResourceRecommenderInterface {
+	return newResourceRecommenders(c, namespace)
+}
+
 func (c *AppsV1alpha1Client) ServiceDescriptors(namespace string) ServiceDescriptorInterface {
 	return newServiceDescriptors(c, namespace)
 }
 
+func (c *AppsV1alpha1Client) StorageAutoscalingPolicies(namespace string) StorageAutoscalingPolicyInterface {
+	return newStorageAutoscalingPolicies(c, namespace)
+}
+
 // NewForConfig creates a new AppsV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -44,6 +44,10 @@ func (c *FakeAppsV1alpha1) Components(namespace string) v1alpha1.ComponentInterf
 	return &FakeComponents{c, namespace}
 }
 
+func (c *FakeAppsV1alpha1) ComponentAutoscalers(namespace string) v1alpha1.ComponentAutoscalerInterface {
+	return &FakeComponentAutoscalers{c, namespace}
+}
+
 func (c *FakeAppsV1alpha1) ComponentDefinitions() v1alpha1.ComponentDefinitionInterface {
 	return &FakeComponentDefinitions{c}
 }
@@ -56,6 +60,10 @@ func (c *FakeAppsV1alpha1) ConfigConstraints() v1alpha1.ConfigConstraintInterfac
 	return &FakeConfigConstraints{c}
 }
 
+func (c *FakeAppsV1alpha1) OpsApprovalPolicies() v1alpha1.OpsApprovalPolicyInterface {
+	return &FakeOpsApprovalPolicies{c}
+}
+
 func (c *FakeAppsV1alpha1) OpsDefinitions() v1alpha1.OpsDefinitionInterface {
 	return &FakeOpsDefinitions{c}
 }
@@ -64,10 +72,26 @@ func (c *FakeAppsV1alpha1) OpsRequests(namespace string) v1alpha1.OpsRequestInte
 	return &FakeOpsRequests{c, namespace}
 }
 
+func (c *FakeAppsV1alpha1) OpsSchedules(namespace string) v1alpha1.OpsScheduleInterface {
+	return &FakeOpsSchedules{c, namespace}
+}
+
+func (c *FakeAppsV1alpha1) OpsTemplates(namespace string) v1alpha1.OpsTemplateInterface {
+	return &FakeOpsTemplates{c, namespace}
+}
+
+func (c *FakeAppsV1alpha1) ResourceRecommenders(namespace string) v1alpha1.ResourceRecommenderInterface {
+	return &FakeResourceRecommenders{c, namespace}
+}
+
 func (c *FakeAppsV1alpha1) ServiceDescriptors(namespace string) v1alpha1.ServiceDescriptorInterface {
 	return &FakeServiceDescriptors{c, namespace}
 }
 
+func (c *FakeAppsV1alpha1) StorageAutoscalingPolicies(namespace string) v1alpha1.StorageAutoscalingPolicyInterface {
+	return &FakeStorageAutoscalingPolicies{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeAppsV1alpha1) RESTClient() rest.Interface {
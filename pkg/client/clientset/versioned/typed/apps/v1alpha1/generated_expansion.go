@@ -26,14 +26,26 @@ type ClusterDefinitionExpansion interface{}
 
 type ComponentExpansion interface{}
 
+type ComponentAutoscalerExpansion interface{}
+
 type ComponentDefinitionExpansion interface{}
 
 type ComponentVersionExpansion interface{}
 
 type ConfigConstraintExpansion interface{}
 
+type OpsApprovalPolicyExpansion interface{}
+
 type OpsDefinitionExpansion interface{}
 
 type OpsRequestExpansion interface{}
 
+type OpsScheduleExpansion interface{}
+
+type OpsTemplateExpansion interface{}
+
+type ResourceRecommenderExpansion interface{}
+
 type ServiceDescriptorExpansion interface{}
+
+type StorageAutoscalingPolicyExpansion interface{}
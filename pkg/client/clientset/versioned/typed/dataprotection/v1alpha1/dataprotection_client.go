@@ -33,6 +33,7 @@ type DataprotectionV1alpha1Interface interface {
 	BackupPoliciesGetter
 	BackupReposGetter
 	BackupSchedulesGetter
+	BackupVerificationsGetter
 	RestoresGetter
 	StorageProvidersGetter
 }
@@ -62,6 +63,10 @@ func (c *DataprotectionV1alpha1Client) BackupSchedules(namespace string) BackupS
 	return newBackupSchedules(c, namespace)
 }
 
+func (c *DataprotectionV1alpha1Client) BackupVerifications(namespace string) BackupVerificationInterface {
+	return newBackupVerifications(c, namespace)
+}
+
 func (c *DataprotectionV1alpha1Client) Restores(namespace string) RestoreInterface {
 	return newRestores(c, namespace)
 }
@@ -32,18 +32,30 @@ type Interface interface {
 	ClusterDefinitions() ClusterDefinitionInformer
 	// Components returns a ComponentInformer.
 	Components() ComponentInformer
+	// ComponentAutoscalers returns a ComponentAutoscalerInformer.
+	ComponentAutoscalers() ComponentAutoscalerInformer
 	// ComponentDefinitions returns a ComponentDefinitionInformer.
 	ComponentDefinitions() ComponentDefinitionInformer
 	// ComponentVersions returns a ComponentVersionInformer.
 	ComponentVersions() ComponentVersionInformer
 	// ConfigConstraints returns a ConfigConstraintInformer.
 	ConfigConstraints() ConfigConstraintInformer
+	// OpsApprovalPolicies returns a OpsApprovalPolicyInformer.
+	OpsApprovalPolicies() OpsApprovalPolicyInformer
 	// OpsDefinitions returns a OpsDefinitionInformer.
 	OpsDefinitions() OpsDefinitionInformer
 	// OpsRequests returns a OpsRequestInformer.
 	OpsRequests() OpsRequestInformer
+	// OpsSchedules returns a OpsScheduleInformer.
+	OpsSchedules() OpsScheduleInformer
+	// OpsTemplates returns a OpsTemplateInformer.
+	OpsTemplates() OpsTemplateInformer
+	// ResourceRecommenders returns a ResourceRecommenderInformer.
+	ResourceRecommenders() ResourceRecommenderInformer
 	// ServiceDescriptors returns a ServiceDescriptorInformer.
 	ServiceDescriptors() ServiceDescriptorInformer
+	// StorageAutoscalingPolicies returns a StorageAutoscalingPolicyInformer.
+	StorageAutoscalingPolicies() StorageAutoscalingPolicyInformer
 }
 
 type version struct {
@@ -77,6 +89,11 @@ func (v *version) Components() ComponentInformer {
 	return &componentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ComponentAutoscalers returns a ComponentAutoscalerInformer.
+func (v *version) ComponentAutoscalers() ComponentAutoscalerInformer {
+	return &componentAutoscalerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ComponentDefinitions returns a ComponentDefinitionInformer.
 func (v *version) ComponentDefinitions() ComponentDefinitionInformer {
 	return &componentDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -92,6 +109,11 @@ func (v *version) ConfigConstraints() ConfigConstraintInformer {
 	return &configConstraintInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// OpsApprovalPolicies returns a OpsApprovalPolicyInformer.
+func (v *version) OpsApprovalPolicies() OpsApprovalPolicyInformer {
+	return &opsApprovalPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // OpsDefinitions returns a OpsDefinitionInformer.
 func (v *version) OpsDefinitions() OpsDefinitionInformer {
 	return &opsDefinitionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -102,7 +124,27 @@ func (v *version) OpsRequests() OpsRequestInformer {
 	return &opsRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// OpsSchedules returns a OpsScheduleInformer.
+func (v *version) OpsSchedules() OpsScheduleInformer {
+	return &opsScheduleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// OpsTemplates returns a OpsTemplateInformer.
+func (v *version) OpsTemplates() OpsTemplateInformer {
+	return &opsTemplateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ResourceRecommenders returns a ResourceRecommenderInformer.
+func (v *version) ResourceRecommenders() ResourceRecommenderInformer {
+	return &resourceRecommenderInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ServiceDescriptors returns a ServiceDescriptorInformer.
 func (v *version) ServiceDescriptors() ServiceDescriptorInformer {
 	return &serviceDescriptorInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// StorageAutoscalingPolicies returns a StorageAutoscalingPolicyInformer.
+func (v *version) StorageAutoscalingPolicies() StorageAutoscalingPolicyInformer {
+	return &storageAutoscalingPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
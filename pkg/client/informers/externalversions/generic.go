@@ -81,18 +81,30 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ClusterDefinitions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("components"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().Components().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("componentautoscalers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ComponentAutoscalers().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("componentdefinitions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ComponentDefinitions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("componentversions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ComponentVersions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("configconstraints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ConfigConstraints().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("opsapprovalpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsApprovalPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("opsdefinitions"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsDefinitions().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("opsrequests"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsRequests().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("opsschedules"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsSchedules().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("opstemplates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().OpsTemplates().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("resourcerecommenders"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ResourceRecommenders().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("servicedescriptors"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().ServiceDescriptors().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("storageautoscalingpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Apps().V1alpha1().StorageAutoscalingPolicies().Informer()}, nil
 
 		// Group=apps.kubeblocks.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("configconstraints"):
@@ -109,6 +121,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dataprotection().V1alpha1().BackupRepos().Informer()}, nil
 	case dataprotectionv1alpha1.SchemeGroupVersion.WithResource("backupschedules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dataprotection().V1alpha1().BackupSchedules().Informer()}, nil
+	case dataprotectionv1alpha1.SchemeGroupVersion.WithResource("backupverifications"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dataprotection().V1alpha1().BackupVerifications().Informer()}, nil
 	case dataprotectionv1alpha1.SchemeGroupVersion.WithResource("restores"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dataprotection().V1alpha1().Restores().Informer()}, nil
 	case dataprotectionv1alpha1.SchemeGroupVersion.WithResource("storageproviders"):
@@ -42,6 +42,14 @@ type ComponentListerExpansion interface{}
 // ComponentNamespaceLister.
 type ComponentNamespaceListerExpansion interface{}
 
+// ComponentAutoscalerListerExpansion allows custom methods to be added to
+// ComponentAutoscalerLister.
+type ComponentAutoscalerListerExpansion interface{}
+
+// ComponentAutoscalerNamespaceListerExpansion allows custom methods to be added to
+// ComponentAutoscalerNamespaceLister.
+type ComponentAutoscalerNamespaceListerExpansion interface{}
+
 // ComponentDefinitionListerExpansion allows custom methods to be added to
 // ComponentDefinitionLister.
 type ComponentDefinitionListerExpansion interface{}
@@ -54,6 +62,10 @@ type ComponentVersionListerExpansion interface{}
 // ConfigConstraintLister.
 type ConfigConstraintListerExpansion interface{}
 
+// OpsApprovalPolicyListerExpansion allows custom methods to be added to
+// OpsApprovalPolicyLister.
+type OpsApprovalPolicyListerExpansion interface{}
+
 // OpsDefinitionListerExpansion allows custom methods to be added to
 // OpsDefinitionLister.
 type OpsDefinitionListerExpansion interface{}
@@ -66,6 +78,30 @@ type OpsRequestListerExpansion interface{}
 // OpsRequestNamespaceLister.
 type OpsRequestNamespaceListerExpansion interface{}
 
+// OpsScheduleListerExpansion allows custom methods to be added to
+// OpsScheduleLister.
+type OpsScheduleListerExpansion interface{}
+
+// OpsScheduleNamespaceListerExpansion allows custom methods to be added to
+// OpsScheduleNamespaceLister.
+type OpsScheduleNamespaceListerExpansion interface{}
+
+// OpsTemplateListerExpansion allows custom methods to be added to
+// OpsTemplateLister.
+type OpsTemplateListerExpansion interface{}
+
+// OpsTemplateNamespaceListerExpansion allows custom methods to be added to
+// OpsTemplateNamespaceLister.
+type OpsTemplateNamespaceListerExpansion interface{}
+
+// ResourceRecommenderListerExpansion allows custom methods to be added to
+// ResourceRecommenderLister.
+type ResourceRecommenderListerExpansion interface{}
+
+// ResourceRecommenderNamespaceListerExpansion allows custom methods to be added to
+// ResourceRecommenderNamespaceLister.
+type ResourceRecommenderNamespaceListerExpansion interface{}
+
 // ServiceDescriptorListerExpansion allows custom methods to be added to
 // ServiceDescriptorLister.
 type ServiceDescriptorListerExpansion interface{}
@@ -73,3 +109,11 @@ type ServiceDescriptorListerExpansion interface{}
 // ServiceDescriptorNamespaceListerExpansion allows custom methods to be added to
 // ServiceDescriptorNamespaceLister.
 type ServiceDescriptorNamespaceListerExpansion interface{}
+
+// StorageAutoscalingPolicyListerExpansion allows custom methods to be added to
+// StorageAutoscalingPolicyLister.
+type StorageAutoscalingPolicyListerExpansion interface{}
+
+// StorageAutoscalingPolicyNamespaceListerExpansion allows custom methods to be added to
+// StorageAutoscalingPolicyNamespaceLister.
+type StorageAutoscalingPolicyNamespaceListerExpansion interface{}
@@ -50,6 +50,14 @@ type BackupScheduleListerExpansion interface{}
 // BackupScheduleNamespaceLister.
 type BackupScheduleNamespaceListerExpansion interface{}
 
+// BackupVerificationListerExpansion allows custom methods to be added to
+// BackupVerificationLister.
+type BackupVerificationListerExpansion interface{}
+
+// BackupVerificationNamespaceListerExpansion allows custom methods to be added to
+// BackupVerificationNamespaceLister.
+type BackupVerificationNamespaceListerExpansion interface{}
+
 // RestoreListerExpansion allows custom methods to be added to
 // RestoreLister.
 type RestoreListerExpansion interface{}
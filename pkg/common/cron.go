@@ -0,0 +1,224 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed standard cron expression with five fields:
+// minute, hour, day of month, month and day of week.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	location                      *time.Location
+}
+
+type cronField struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	cronFields = []cronField{
+		{name: "minute", min: 0, max: 59},
+		{name: "hour", min: 0, max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: map[string]uint{
+			"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+			"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+		}},
+		{name: "day of week", min: 0, max: 7, names: map[string]uint{
+			"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+		}},
+	}
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// starBit marks that the field is specified by "*" or "?".
+const starBit = 1 << 63
+
+// ParseCronSchedule parses a standard cron expression, e.g. "0 2 * * 1-5".
+// The expression can be prefixed by "CRON_TZ=<zone>" or "TZ=<zone>" to specify the time zone, which is UTC by default,
+// and the descriptors "@yearly", "@monthly", "@weekly", "@daily" and "@hourly" are supported.
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	location := time.UTC
+	if strings.HasPrefix(expr, "TZ=") || strings.HasPrefix(expr, "CRON_TZ=") {
+		i := strings.Index(expr, " ")
+		if i == -1 {
+			return nil, fmt.Errorf("invalid cron expression %q: missing fields after the time zone", expr)
+		}
+		zone := expr[strings.Index(expr, "=")+1 : i]
+		var err error
+		if location, err = time.LoadLocation(zone); err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %s", zone, err.Error())
+		}
+		expr = strings.TrimSpace(expr[i:])
+	}
+	if strings.HasPrefix(expr, "@") {
+		descriptor, ok := cronDescriptors[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported cron descriptor %q", expr)
+		}
+		expr = descriptor
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q: expected %d fields, found %d", expr, len(cronFields), len(fields))
+	}
+	bits := make([]uint64, len(fields))
+	for i := range fields {
+		var err error
+		if bits[i], err = parseCronField(fields[i], cronFields[i]); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %s", expr, err.Error())
+		}
+	}
+	// both 0 and 7 mean Sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = (bits[4] | 1) &^ (1 << 7)
+	}
+	return &CronSchedule{
+		minute:   bits[0],
+		hour:     bits[1],
+		dom:      bits[2],
+		month:    bits[3],
+		dow:      bits[4],
+		location: location,
+	}, nil
+}
+
+func parseCronField(field string, spec cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		var (
+			start, end uint
+			step       uint = 1
+			err        error
+		)
+		rangeAndStep := strings.SplitN(item, "/", 2)
+		lowAndHigh := strings.SplitN(rangeAndStep[0], "-", 2)
+		switch {
+		case lowAndHigh[0] == "*" || lowAndHigh[0] == "?":
+			if len(lowAndHigh) > 1 {
+				return 0, fmt.Errorf("invalid %s %q", spec.name, item)
+			}
+			start, end = spec.min, spec.max
+			if len(rangeAndStep) == 1 {
+				bits |= starBit
+			}
+		default:
+			if start, err = parseCronValue(lowAndHigh[0], spec); err != nil {
+				return 0, err
+			}
+			end = start
+			if len(lowAndHigh) > 1 {
+				if end, err = parseCronValue(lowAndHigh[1], spec); err != nil {
+					return 0, err
+				}
+			} else if len(rangeAndStep) > 1 {
+				// "N/step" means from N to the max value
+				end = spec.max
+			}
+		}
+		if len(rangeAndStep) > 1 {
+			s, err := strconv.ParseUint(rangeAndStep[1], 10, 32)
+			if err != nil || s == 0 {
+				return 0, fmt.Errorf("invalid step of %s %q", spec.name, item)
+			}
+			step = uint(s)
+		}
+		if start > end {
+			return 0, fmt.Errorf("invalid range of %s %q", spec.name, item)
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, spec cronField) (uint, error) {
+	if v, ok := spec.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", spec.name, value)
+	}
+	if uint(v) < spec.min || uint(v) > spec.max {
+		return 0, fmt.Errorf("%s %d is out of range [%d, %d]", spec.name, v, spec.min, spec.max)
+	}
+	return uint(v), nil
+}
+
+// Next returns the next time matching the schedule after the given time,
+// or the zero time if no time matches the schedule in five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	origLocation := t.Location()
+	t = t.In(s.location)
+	// the schedule has a granularity of minutes
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	yearLimit := t.Year() + 5
+
+	for t.Year() <= yearLimit {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t.In(origLocation)
+	}
+	return time.Time{}
+}
+
+// matchDay checks if the day matches the day of month and the day of week.
+// If both fields are restricted, the day matches if either field matches, as the standard cron does.
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.dom&starBit != 0 || s.dow&starBit != 0 {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
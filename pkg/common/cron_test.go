@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	mustParse := func(layout string) time.Time {
+		v, err := time.Parse(time.RFC3339, layout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		expr     string
+		from     string
+		expected string
+	}{
+		{"*/15 * * * *", "2024-05-01T10:07:30Z", "2024-05-01T10:15:00Z"},
+		{"0 2 * * *", "2024-05-01T10:07:00Z", "2024-05-02T02:00:00Z"},
+		{"@hourly", "2024-05-01T10:00:00Z", "2024-05-01T11:00:00Z"},
+		{"30 3 * * sun", "2024-05-01T00:00:00Z", "2024-05-05T03:30:00Z"},
+		{"0 0 * * 7", "2024-05-01T00:00:00Z", "2024-05-05T00:00:00Z"},
+		{"0 0 1 */3 *", "2024-05-01T00:00:00Z", "2024-07-01T00:00:00Z"},
+		{"0 9 1-7 * 1", "2024-05-01T10:00:00Z", "2024-05-02T09:00:00Z"},
+		{"0 0 29 feb *", "2023-03-01T00:00:00Z", "2024-02-29T00:00:00Z"},
+		{"CRON_TZ=Asia/Shanghai 0 2 * * *", "2024-05-01T00:00:00Z", "2024-05-01T18:00:00Z"},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseCronSchedule(tt.expr)
+		if err != nil {
+			t.Errorf("failed to parse %q: %v", tt.expr, err)
+			continue
+		}
+		next := schedule.Next(mustParse(tt.from))
+		if !next.Equal(mustParse(tt.expected)) {
+			t.Errorf("%q: expected the next time after %s is %s, but got %s", tt.expr, tt.from, tt.expected, next.UTC().Format(time.RFC3339))
+		}
+	}
+}
+
+func TestParseInvalidCronSchedule(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@every 1h",
+		"TZ=Invalid/Zone * * * * *",
+	} {
+		if _, err := ParseCronSchedule(expr); err == nil {
+			t.Errorf("expected an error when parsing %q", expr)
+		}
+	}
+}
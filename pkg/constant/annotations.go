@@ -41,10 +41,18 @@ const (
 	KubeBlocksGenerationKey                  = "kubeblocks.io/generation"
 	ExtraEnvAnnotationKey                    = "kubeblocks.io/extra-env"
 	LastRoleSnapshotVersionAnnotationKey     = "apps.kubeblocks.io/last-role-snapshot-version"
-	ComponentScaleInAnnotationKey            = "apps.kubeblocks.io/component-scale-in" // ComponentScaleInAnnotationKey specifies whether the component is scaled in
+	ComponentScaleInAnnotationKey            = "apps.kubeblocks.io/component-scale-in"    // ComponentScaleInAnnotationKey specifies whether the component is scaled in
+	PreScaleInProgressAnnotationKey          = "apps.kubeblocks.io/pre-scale-in-progress" // PreScaleInProgressAnnotationKey records the progress of the preScaleIn action on the leaving pod
 	DisableHAAnnotationKey                   = "kubeblocks.io/disable-ha"
 	OpsDependentOnSuccessfulOpsAnnoKey       = "ops.kubeblocks.io/dependent-on-successful-ops" // OpsDependentOnSuccessfulOpsAnnoKey wait for the dependent ops to succeed before executing the current ops. If it fails, this ops will also fail.
 	RelatedOpsAnnotationKey                  = "ops.kubeblocks.io/related-ops"
+	OpsResumeAnnotationKey                   = "ops.kubeblocks.io/resume"             // OpsResumeAnnotationKey resumes the opsRequest which is paused by the health gate.
+	OpsCanaryDecisionAnnotationKey           = "ops.kubeblocks.io/canary-decision"    // OpsCanaryDecisionAnnotationKey promotes or rolls back the canary upgrade, the value is "Promote" or "Rollback".
+	OpsGatedRolloutAnnotationKey             = "ops.kubeblocks.io/gated-rollout"      // OpsGatedRolloutAnnotationKey records the opsRequest which rolls out the InstanceSet through its partition.
+	OpsApprovedByAnnotationKey               = "ops.kubeblocks.io/approved-by"        // OpsApprovedByAnnotationKey specifies the user who approves the opsRequest.
+	OpsApprovedByGroupsAnnotationKey         = "ops.kubeblocks.io/approved-by-groups" // OpsApprovedByGroupsAnnotationKey specifies the comma-separated groups of the approver.
+	OpsRequestedByAnnotationKey              = "ops.kubeblocks.io/requested-by"       // OpsRequestedByAnnotationKey records the user who creates the opsRequest.
+	OpsScheduledTimeAnnotationKey            = "ops.kubeblocks.io/scheduled-time"     // OpsScheduledTimeAnnotationKey records the scheduled time of the opsRequest created by the OpsSchedule.
 
 	// SkipImmutableCheckAnnotationKey specifies to skip the mutation check for the object.
 	// The mutation check is only applied to the fields that are declared as immutable.
@@ -38,14 +38,16 @@ const (
 	KBToolsImage         = "KUBEBLOCKS_TOOLS_IMAGE"
 	KBImagePullPolicy    = "KUBEBLOCKS_IMAGE_PULL_POLICY"
 	KBImagePullSecrets   = "KUBEBLOCKS_IMAGE_PULL_SECRETS"
+	EnableWebhooks       = "ENABLE_WEBHOOKS"
 )
 
 const (
-	StatefulSetKind    = "StatefulSet"
-	PodKind            = "Pod"
-	JobKind            = "Job"
-	VolumeSnapshotKind = "VolumeSnapshot"
-	ServiceKind        = "Service"
+	StatefulSetKind           = "StatefulSet"
+	PodKind                   = "Pod"
+	PersistentVolumeClaimKind = "PersistentVolumeClaim"
+	JobKind                   = "Job"
+	VolumeSnapshotKind        = "VolumeSnapshot"
+	ServiceKind               = "Service"
 )
 
 // username and password are keys in created secrets for others to refer to.
@@ -78,3 +80,6 @@ const (
 const InvalidContainerPort int32 = 0
 
 const EmptyInsTemplateName = ""
+
+// CanaryInsTemplateName is the name of the temporary instance template which upgrades the canary instances in place.
+const CanaryInsTemplateName = "canary"
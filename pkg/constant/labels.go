@@ -56,8 +56,12 @@ const (
 	ConsensusSetAccessModeLabelKey         = "cs.apps.kubeblocks.io/access-mode"
 	AddonNameLabelKey                      = "extensions.kubeblocks.io/addon-name"
 	OpsRequestTypeLabelKey                 = "ops.kubeblocks.io/ops-type"
+	ComponentAutoscalerLabelKey            = "ops.kubeblocks.io/component-autoscaler"
+	ResourceRecommenderLabelKey            = "ops.kubeblocks.io/resource-recommender"
 	OpsRequestNameLabelKey                 = "ops.kubeblocks.io/ops-name"
 	OpsRequestNamespaceLabelKey            = "ops.kubeblocks.io/ops-namespace"
+	OpsScheduleNameLabelKey                = "ops.kubeblocks.io/ops-schedule-name"
+	StorageAutoscalingPolicyLabelKey       = "ops.kubeblocks.io/storage-autoscaling-policy"
 	ServiceDescriptorNameLabelKey          = "servicedescriptor.kubeblocks.io/name"
 )
 
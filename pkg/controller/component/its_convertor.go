@@ -163,7 +163,9 @@ func (c *itsInstancesConvertor) convert(args ...any) (any, error) {
 
 	var instances []workloads.InstanceTemplate
 	for _, instance := range synthesizedComp.Instances {
-		instances = append(instances, *AppsInstanceToWorkloadInstance(&instance))
+		template := AppsInstanceToWorkloadInstance(&instance)
+		template.Images = synthesizedComp.InstanceImages[instance.Name]
+		instances = append(instances, *template)
 	}
 	return instances, nil
 }
@@ -198,9 +200,16 @@ func AppsInstanceToWorkloadInstance(instance *kbappsv1.InstanceTemplate) *worklo
 		}
 	}
 
+	var ordinals workloads.Ordinals
+	if len(instance.Ordinals) > 0 {
+		ordinals = workloads.Ordinals{Discrete: instance.Ordinals}
+	}
+
 	return &workloads.InstanceTemplate{
 		Name:                 instance.Name,
 		Replicas:             instance.Replicas,
+		Ordinals:             ordinals,
+		FlatInstanceOrdinal:  len(instance.Ordinals) > 0,
 		Annotations:          instance.Annotations,
 		Labels:               instance.Labels,
 		Image:                instance.Image,
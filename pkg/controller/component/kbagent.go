@@ -162,7 +162,11 @@ func mergedActionEnv4KBAgent(synthesizedComp *SynthesizedComponent) []corev1.Env
 		synthesizedComp.LifecycleActions.PostProvision,
 		synthesizedComp.LifecycleActions.PreTerminate,
 		synthesizedComp.LifecycleActions.Switchover,
+		synthesizedComp.LifecycleActions.ReplicationLag,
+		synthesizedComp.LifecycleActions.HealthCheck,
+		synthesizedComp.LifecycleActions.VolumeUsage,
 		synthesizedComp.LifecycleActions.MemberJoin,
+		synthesizedComp.LifecycleActions.PreScaleIn,
 		synthesizedComp.LifecycleActions.MemberLeave,
 		synthesizedComp.LifecycleActions.Readonly,
 		synthesizedComp.LifecycleActions.Readwrite,
@@ -195,9 +199,21 @@ func buildKBAgentStartupEnvs(synthesizedComp *SynthesizedComponent) ([]corev1.En
 	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.Switchover, "switchover"); a != nil {
 		actions = append(actions, *a)
 	}
+	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.ReplicationLag, "replicationLag"); a != nil {
+		actions = append(actions, *a)
+	}
+	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.HealthCheck, "healthCheck"); a != nil {
+		actions = append(actions, *a)
+	}
+	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.VolumeUsage, "volumeUsage"); a != nil {
+		actions = append(actions, *a)
+	}
 	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.MemberJoin, "memberJoin"); a != nil {
 		actions = append(actions, *a)
 	}
+	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.PreScaleIn, "preScaleIn"); a != nil {
+		actions = append(actions, *a)
+	}
 	if a := buildAction4KBAgent(synthesizedComp.LifecycleActions.MemberLeave, "memberLeave"); a != nil {
 		actions = append(actions, *a)
 	}
@@ -298,7 +314,11 @@ func customExecActionImageNContainer(synthesizedComp *SynthesizedComponent) (str
 		synthesizedComp.LifecycleActions.PostProvision,
 		synthesizedComp.LifecycleActions.PreTerminate,
 		synthesizedComp.LifecycleActions.Switchover,
+		synthesizedComp.LifecycleActions.ReplicationLag,
+		synthesizedComp.LifecycleActions.HealthCheck,
+		synthesizedComp.LifecycleActions.VolumeUsage,
 		synthesizedComp.LifecycleActions.MemberJoin,
+		synthesizedComp.LifecycleActions.PreScaleIn,
 		synthesizedComp.LifecycleActions.MemberLeave,
 		synthesizedComp.LifecycleActions.Readonly,
 		synthesizedComp.LifecycleActions.Readwrite,
@@ -88,6 +88,36 @@ func (a *kbagent) Switchover(ctx context.Context, cli client.Reader, opts *Optio
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.Switchover, lfa, opts))
 }
 
+func (a *kbagent) ReplicationLag(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error) {
+	lfa := &replicationLag{
+		namespace:   a.synthesizedComp.Namespace,
+		clusterName: a.synthesizedComp.ClusterName,
+		compName:    a.synthesizedComp.Name,
+		pod:         a.pod,
+	}
+	return a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.ReplicationLag, lfa, opts)
+}
+
+func (a *kbagent) HealthCheck(ctx context.Context, cli client.Reader, opts *Options) error {
+	lfa := &healthCheck{
+		namespace:   a.synthesizedComp.Namespace,
+		clusterName: a.synthesizedComp.ClusterName,
+		compName:    a.synthesizedComp.Name,
+		pod:         a.pod,
+	}
+	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.HealthCheck, lfa, opts))
+}
+
+func (a *kbagent) VolumeUsage(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error) {
+	lfa := &volumeUsage{
+		namespace:   a.synthesizedComp.Namespace,
+		clusterName: a.synthesizedComp.ClusterName,
+		compName:    a.synthesizedComp.Name,
+		pod:         a.pod,
+	}
+	return a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.VolumeUsage, lfa, opts)
+}
+
 func (a *kbagent) MemberJoin(ctx context.Context, cli client.Reader, opts *Options) error {
 	lfa := &memberJoin{
 		namespace:   a.synthesizedComp.Namespace,
@@ -98,6 +128,16 @@ func (a *kbagent) MemberJoin(ctx context.Context, cli client.Reader, opts *Optio
 	return a.ignoreOutput(a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.MemberJoin, lfa, opts))
 }
 
+func (a *kbagent) PreScaleIn(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error) {
+	lfa := &preScaleIn{
+		namespace:   a.synthesizedComp.Namespace,
+		clusterName: a.synthesizedComp.ClusterName,
+		compName:    a.synthesizedComp.Name,
+		pod:         a.pod,
+	}
+	return a.checkedCallAction(ctx, cli, a.synthesizedComp.LifecycleActions.PreScaleIn, lfa, opts)
+}
+
 func (a *kbagent) MemberLeave(ctx context.Context, cli client.Reader, opts *Options) error {
 	lfa := &memberLeave{
 		namespace:   a.synthesizedComp.Namespace,
@@ -237,6 +277,9 @@ func (a *kbagent) buildActionRequest(ctx context.Context, cli client.Reader, lfa
 				RetryInterval: opts.RetryPolicy.RetryInterval,
 			}
 		}
+		if opts.ReportProgress != nil {
+			req.ReportProgress = opts.ReportProgress
+		}
 	}
 	return req, nil
 }
@@ -298,7 +341,8 @@ func (a *kbagent) callActionWithSelector(ctx context.Context, spec *appsv1.Actio
 			return nil, errors.Wrapf(err, "http error occurred when executing action %s at pod %s", lfa.name(), pod.Name)
 		}
 		if len(rsp.Error) > 0 {
-			return nil, a.formatError(lfa, rsp)
+			// the output of an in-progress action is the progress reported so far
+			return rsp.Output, a.formatError(lfa, rsp)
 		}
 		// take first non-nil output
 		if output == nil && rsp.Output != nil {
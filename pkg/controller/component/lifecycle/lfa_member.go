@@ -83,6 +83,75 @@ func (a *switchover) parameters(ctx context.Context, cli client.Reader) (map[str
 	return m, nil
 }
 
+type replicationLag struct {
+	namespace   string
+	clusterName string
+	compName    string
+	pod         *corev1.Pod
+}
+
+var _ lifecycleAction = &replicationLag{}
+
+func (a *replicationLag) name() string {
+	return "replicationLag"
+}
+
+func (a *replicationLag) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_POD_FQDN: The FQDN of the replica pod whose replication lag is being queried.
+	compName := constant.GenerateClusterComponentName(a.clusterName, a.compName)
+	return map[string]string{
+		constant.KBEnvPodFQDN: component.PodFQDN(a.namespace, compName, a.pod.Name),
+	}, nil
+}
+
+type healthCheck struct {
+	namespace   string
+	clusterName string
+	compName    string
+	pod         *corev1.Pod
+}
+
+var _ lifecycleAction = &healthCheck{}
+
+func (a *healthCheck) name() string {
+	return "healthCheck"
+}
+
+func (a *healthCheck) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_POD_FQDN: The FQDN of the replica pod being checked.
+	compName := constant.GenerateClusterComponentName(a.clusterName, a.compName)
+	return map[string]string{
+		constant.KBEnvPodFQDN: component.PodFQDN(a.namespace, compName, a.pod.Name),
+	}, nil
+}
+
+type volumeUsage struct {
+	namespace   string
+	clusterName string
+	compName    string
+	pod         *corev1.Pod
+}
+
+var _ lifecycleAction = &volumeUsage{}
+
+func (a *volumeUsage) name() string {
+	return "volumeUsage"
+}
+
+func (a *volumeUsage) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_POD_FQDN: The FQDN of the replica pod whose volume usage is being queried.
+	compName := constant.GenerateClusterComponentName(a.clusterName, a.compName)
+	return map[string]string{
+		constant.KBEnvPodFQDN: component.PodFQDN(a.namespace, compName, a.pod.Name),
+	}, nil
+}
+
 type memberJoin struct {
 	namespace   string
 	clusterName string
@@ -108,6 +177,31 @@ func (a *memberJoin) parameters(ctx context.Context, cli client.Reader) (map[str
 	}, nil
 }
 
+type preScaleIn struct {
+	namespace   string
+	clusterName string
+	compName    string
+	pod         *corev1.Pod
+}
+
+var _ lifecycleAction = &preScaleIn{}
+
+func (a *preScaleIn) name() string {
+	return "preScaleIn"
+}
+
+func (a *preScaleIn) parameters(ctx context.Context, cli client.Reader) (map[string]string, error) {
+	// The container executing this action has access to following variables:
+	//
+	// - KB_LEAVE_MEMBER_POD_FQDN: The pod FQDN of the replica being removed from the group.
+	// - KB_LEAVE_MEMBER_POD_NAME: The pod name of the replica being removed from the group.
+	compName := constant.GenerateClusterComponentName(a.clusterName, a.compName)
+	return map[string]string{
+		leaveMemberPodFQDNVar: component.PodFQDN(a.namespace, compName, a.pod.Name),
+		leaveMemberPodNameVar: a.pod.Name,
+	}, nil
+}
+
 type memberLeave struct {
 	namespace   string
 	clusterName string
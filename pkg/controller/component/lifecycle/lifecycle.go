@@ -34,6 +34,7 @@ type Options struct {
 	NonBlocking    *bool
 	TimeoutSeconds *int32
 	RetryPolicy    *appsv1.RetryPolicy
+	ReportProgress *bool
 }
 
 type Lifecycle interface {
@@ -45,8 +46,17 @@ type Lifecycle interface {
 
 	Switchover(ctx context.Context, cli client.Reader, opts *Options, candidate string) error
 
+	ReplicationLag(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error)
+
+	HealthCheck(ctx context.Context, cli client.Reader, opts *Options) error
+
+	VolumeUsage(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error)
+
 	MemberJoin(ctx context.Context, cli client.Reader, opts *Options) error
 
+	// PreScaleIn returns the progress reported by the action, along with ErrActionInProgress if it is still running.
+	PreScaleIn(ctx context.Context, cli client.Reader, opts *Options) ([]byte, error)
+
 	MemberLeave(ctx context.Context, cli client.Reader, opts *Options) error
 
 	// Readonly(ctx context.Context, cli client.Reader, opts *Options) error
@@ -197,6 +197,11 @@ func buildSynthesizedComponent(reqCtx intctrlutil.RequestCtx,
 	// build runtimeClassName
 	buildRuntimeClassName(synthesizeComp, comp)
 
+	// resolve images of the instance templates which specify the serviceVersion or componentDefinition
+	if err = buildInstanceImages(reqCtx.Ctx, cli, synthesizeComp, compDef, comp); err != nil {
+		return nil, err
+	}
+
 	if err = buildKBAgentContainer(synthesizeComp); err != nil {
 		reqCtx.Log.Error(err, "build kb-agent container failed")
 		return nil, err
@@ -471,6 +476,41 @@ func buildAndUpdateResources(synthesizeComp *SynthesizedComponent, comp *appsv1.
 	}
 }
 
+func buildInstanceImages(ctx context.Context, cli client.Reader,
+	synthesizeComp *SynthesizedComponent, compDef *appsv1.ComponentDefinition, comp *appsv1.Component) error {
+	for _, instance := range comp.Spec.Instances {
+		if len(instance.ServiceVersion) == 0 && len(instance.CompDef) == 0 {
+			continue
+		}
+		instanceCompDef := compDef.DeepCopy()
+		if len(instance.CompDef) > 0 && instance.CompDef != compDef.Name {
+			instanceCompDef = &appsv1.ComponentDefinition{}
+			if err := cli.Get(ctx, client.ObjectKey{Name: instance.CompDef}, instanceCompDef); err != nil {
+				return err
+			}
+		}
+		serviceVersion := instance.ServiceVersion
+		if len(serviceVersion) == 0 {
+			serviceVersion = comp.Spec.ServiceVersion
+		}
+		if err := UpdateCompDefinitionImages4ServiceVersion(ctx, cli, instanceCompDef, serviceVersion); err != nil {
+			return err
+		}
+		images := make(map[string]string)
+		for _, c := range instanceCompDef.Spec.Runtime.InitContainers {
+			images[c.Name] = c.Image
+		}
+		for _, c := range instanceCompDef.Spec.Runtime.Containers {
+			images[c.Name] = c.Image
+		}
+		if synthesizeComp.InstanceImages == nil {
+			synthesizeComp.InstanceImages = make(map[string]map[string]string)
+		}
+		synthesizeComp.InstanceImages[instance.Name] = images
+	}
+	return nil
+}
+
 func buildComponentServices(synthesizeComp *SynthesizedComponent, comp *appsv1.Component) {
 	if len(synthesizeComp.ComponentServices) == 0 || len(comp.Spec.Services) == 0 {
 		return
@@ -55,6 +55,7 @@ type SynthesizedComponent struct {
 	EnvVars                          []corev1.EnvVar                     `json:"envVars,omitempty"`
 	EnvFromSources                   []corev1.EnvFromSource              `json:"envFromSources,omitempty"`
 	Instances                        []kbappsv1.InstanceTemplate         `json:"instances,omitempty"`
+	InstanceImages                   map[string]map[string]string        `json:"instanceImages,omitempty"` // {templateName: {containerName: image}}
 	OfflineInstances                 []string                            `json:"offlineInstances,omitempty"`
 	Roles                            []kbappsv1.ReplicaRole              `json:"roles,omitempty"`
 	Labels                           map[string]string                   `json:"labels,omitempty"`
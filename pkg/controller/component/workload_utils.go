@@ -136,10 +136,7 @@ func GenerateAllPodNames(
 	workloadName := constant.GenerateWorkloadNamePattern(clusterName, fullCompName)
 	var templates []instanceset.InstanceTemplate
 	for i := range instances {
-		templates = append(templates, &workloads.InstanceTemplate{
-			Name:     instances[i].Name,
-			Replicas: instances[i].Replicas,
-		})
+		templates = append(templates, AppsInstanceToWorkloadInstance(&instances[i]))
 	}
 	return instanceset.GenerateAllInstanceNames(workloadName, compReplicas, templates, offlineInstances, workloads.Ordinals{})
 }
@@ -298,19 +298,15 @@ func getPodUpdatePolicy(its *workloads.InstanceSet, pod *corev1.Pod) (PodUpdateP
 	if err != nil {
 		return NoOpsPolicy, err
 	}
-	templateList := buildInstanceTemplateExts(itsExt)
-	parentName, _ := ParseParentNameAndOrdinal(pod.Name)
-	templateName, _ := strings.CutPrefix(parentName, its.Name)
-	if len(templateName) > 0 {
-		templateName, _ = strings.CutPrefix(templateName, "-")
+	nameToTemplateMap, err := buildInstanceName2TemplateMap(itsExt)
+	if err != nil {
+		return NoOpsPolicy, err
 	}
-	index := slices.IndexFunc(templateList, func(templateExt *instanceTemplateExt) bool {
-		return templateName == templateExt.Name
-	})
-	if index < 0 {
+	template, ok := nameToTemplateMap[pod.Name]
+	if !ok {
 		return NoOpsPolicy, fmt.Errorf("no corresponding template found for instance %s", pod.Name)
 	}
-	inst, err := buildInstanceByTemplate(pod.Name, templateList[index], its, getPodRevision(pod))
+	inst, err := buildInstanceByTemplate(pod.Name, template, its, getPodRevision(pod))
 	if err != nil {
 		return NoOpsPolicy, err
 	}
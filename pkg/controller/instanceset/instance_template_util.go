@@ -37,8 +37,9 @@ type InstanceSetExt struct {
 // InstanceTemplateExt serves as a Public Struct,
 // used as the type for the construction results returned by BuildInstanceTemplateExts.
 type InstanceTemplateExt struct {
-	Name     string
-	Replicas int32
+	Name                string
+	Replicas            int32
+	FlatInstanceOrdinal bool
 	corev1.PodTemplateSpec
 	VolumeClaimTemplates []corev1.PersistentVolumeClaim
 }
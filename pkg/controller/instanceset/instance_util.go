@@ -51,11 +51,13 @@ type InstanceTemplate interface {
 	GetName() string
 	GetReplicas() int32
 	GetOrdinals() workloads.Ordinals
+	IsFlatInstanceOrdinal() bool
 }
 
 type instanceTemplateExt struct {
-	Name     string
-	Replicas int32
+	Name                string
+	Replicas            int32
+	FlatInstanceOrdinal bool
 	corev1.PodTemplateSpec
 	VolumeClaimTemplates []corev1.PersistentVolumeClaim
 }
@@ -208,19 +210,15 @@ func ValidateDupInstanceNames[T any](instances []T, getNameFunc func(item T) str
 
 func buildInstanceName2TemplateMap(itsExt *instanceSetExt) (map[string]*instanceTemplateExt, error) {
 	instanceTemplateList := buildInstanceTemplateExts(itsExt)
+	templateNames, err := generateInstanceNamesOfTemplateExts(itsExt.its, instanceTemplateList)
+	if err != nil {
+		return nil, err
+	}
 	allNameTemplateMap := make(map[string]*instanceTemplateExt)
 	var instanceNameList []string
-	for _, template := range instanceTemplateList {
-		ordinalList, err := GetOrdinalListByTemplateName(itsExt.its, template.Name)
-		if err != nil {
-			return nil, err
-		}
-		instanceNames, err := GenerateInstanceNamesFromTemplate(itsExt.its.Name, template.Name, template.Replicas, itsExt.its.Spec.OfflineInstances, ordinalList)
-		if err != nil {
-			return nil, err
-		}
-		instanceNameList = append(instanceNameList, instanceNames...)
-		for _, name := range instanceNames {
+	for i, template := range instanceTemplateList {
+		instanceNameList = append(instanceNameList, templateNames[i]...)
+		for _, name := range templateNames[i] {
 			allNameTemplateMap[name] = template
 		}
 	}
@@ -235,31 +233,96 @@ func buildInstanceName2TemplateMap(itsExt *instanceSetExt) (map[string]*instance
 	return allNameTemplateMap, nil
 }
 
-func GenerateAllInstanceNames(parentName string, replicas int32, templates []InstanceTemplate, offlineInstances []string, defaultTemplateOrdinals workloads.Ordinals) ([]string, error) {
-	totalReplicas := int32(0)
-	instanceNameList := make([]string, 0)
+// generateInstanceNamesOfTemplateExts generates the instance names of the templates of the InstanceSet in order.
+func generateInstanceNamesOfTemplateExts(its *workloads.InstanceSet, templates []*instanceTemplateExt) ([][]string, error) {
+	var templateSpecs []instanceNameTemplate
 	for _, template := range templates {
-		replicas := template.GetReplicas()
-		ordinalList, err := ConvertOrdinalsToSortedList(template.GetOrdinals())
+		ordinalList, err := GetOrdinalListByTemplateName(its, template.Name)
 		if err != nil {
 			return nil, err
 		}
-		names, err := GenerateInstanceNamesFromTemplate(parentName, template.GetName(), replicas, offlineInstances, ordinalList)
+		templateSpecs = append(templateSpecs, instanceNameTemplate{
+			name:     template.Name,
+			replicas: template.Replicas,
+			ordinals: ordinalList,
+			flat:     template.FlatInstanceOrdinal,
+		})
+	}
+	return generateInstanceNamesOfTemplates(its.Name, templateSpecs, its.Spec.OfflineInstances)
+}
+
+type instanceNameTemplate struct {
+	name     string
+	replicas int32
+	ordinals []int32
+	flat     bool
+}
+
+// generateInstanceNamesOfTemplates generates the instance names of the templates in order.
+// The instances of the templates with the flat instance ordinal are named with the pattern of the default template,
+// so they are generated first and excluded from the default template.
+func generateInstanceNamesOfTemplates(parentName string, templates []instanceNameTemplate, offlineInstances []string) ([][]string, error) {
+	templateNames := make([][]string, len(templates))
+	usedNames := slices.Clone(offlineInstances)
+	for i, template := range templates {
+		if !template.flat {
+			continue
+		}
+		if len(template.ordinals) == 0 {
+			return nil, fmt.Errorf("the ordinals of the template '%s' with the flat instance ordinal are not specified", template.name)
+		}
+		names, err := GenerateInstanceNamesFromTemplate(parentName, "", template.replicas, offlineInstances, template.ordinals)
 		if err != nil {
 			return nil, err
 		}
-		instanceNameList = append(instanceNameList, names...)
-		totalReplicas += replicas
+		templateNames[i] = names
+		usedNames = append(usedNames, names...)
 	}
-	if totalReplicas < replicas {
-		ordinalList, err := ConvertOrdinalsToSortedList(defaultTemplateOrdinals)
+	for i, template := range templates {
+		if template.flat {
+			continue
+		}
+		names, err := GenerateInstanceNamesFromTemplate(parentName, template.name, template.replicas, usedNames, template.ordinals)
 		if err != nil {
 			return nil, err
 		}
-		names, err := GenerateInstanceNamesFromTemplate(parentName, "", replicas-totalReplicas, offlineInstances, ordinalList)
+		templateNames[i] = names
+	}
+	return templateNames, nil
+}
+
+func GenerateAllInstanceNames(parentName string, replicas int32, templates []InstanceTemplate, offlineInstances []string, defaultTemplateOrdinals workloads.Ordinals) ([]string, error) {
+	totalReplicas := int32(0)
+	var templateSpecs []instanceNameTemplate
+	for _, template := range templates {
+		ordinalList, err := ConvertOrdinalsToSortedList(template.GetOrdinals())
 		if err != nil {
 			return nil, err
 		}
+		templateSpecs = append(templateSpecs, instanceNameTemplate{
+			name:     template.GetName(),
+			replicas: template.GetReplicas(),
+			ordinals: ordinalList,
+			flat:     template.IsFlatInstanceOrdinal(),
+		})
+		totalReplicas += template.GetReplicas()
+	}
+	if totalReplicas < replicas {
+		ordinalList, err := ConvertOrdinalsToSortedList(defaultTemplateOrdinals)
+		if err != nil {
+			return nil, err
+		}
+		templateSpecs = append(templateSpecs, instanceNameTemplate{
+			replicas: replicas - totalReplicas,
+			ordinals: ordinalList,
+		})
+	}
+	templateNames, err := generateInstanceNamesOfTemplates(parentName, templateSpecs, offlineInstances)
+	if err != nil {
+		return nil, err
+	}
+	instanceNameList := make([]string, 0)
+	for _, names := range templateNames {
 		instanceNameList = append(instanceNameList, names...)
 	}
 	getNameNOrdinalFunc := func(i int) (string, int) {
@@ -466,7 +529,7 @@ func buildInstanceByTemplate(name string, template *instanceTemplateExt, parent
 			AddLabels(constant.VolumeClaimTemplateNameLabelKey, claimTemplate.Name).
 			SetSpec(*claimTemplate.Spec.DeepCopy()).
 			GetObject()
-		if template.Name != "" {
+		if template.Name != "" && !template.FlatInstanceOrdinal {
 			pvc.Labels[constant.KBAppComponentInstanceTemplateLabelKey] = template.Name
 		}
 		pvcMap[pvcName] = pvc
@@ -519,7 +582,7 @@ func buildInstancePVCByTemplate(name string, template *instanceTemplateExt, pare
 			AddAnnotationsInMap(claimTemplate.Annotations).
 			SetSpec(*claimTemplate.Spec.DeepCopy()).
 			GetObject()
-		if template.Name != "" {
+		if template.Name != "" && !template.FlatInstanceOrdinal {
 			pvc.Labels[constant.KBAppComponentInstanceTemplateLabelKey] = template.Name
 		}
 		pvcs = append(pvcs, pvc)
@@ -792,6 +855,7 @@ func findTemplateObject(its *workloads.InstanceSet, tree *kubebuilderx.ObjectTre
 
 func buildInstanceTemplateExt(template workloads.InstanceTemplate, templateExt *instanceTemplateExt) {
 	templateExt.Name = template.Name
+	templateExt.FlatInstanceOrdinal = template.FlatInstanceOrdinal
 	replicas := int32(1)
 	if template.Replicas != nil {
 		replicas = *template.Replicas
@@ -809,6 +873,16 @@ func buildInstanceTemplateExt(template workloads.InstanceTemplate, templateExt *
 		if template.Image != nil {
 			templateExt.Spec.Containers[0].Image = *template.Image
 		}
+		for i, container := range templateExt.Spec.InitContainers {
+			if image, ok := template.Images[container.Name]; ok {
+				templateExt.Spec.InitContainers[i].Image = image
+			}
+		}
+		for i, container := range templateExt.Spec.Containers {
+			if image, ok := template.Images[container.Name]; ok {
+				templateExt.Spec.Containers[i].Image = image
+			}
+		}
 		if template.Resources != nil {
 			src := template.Resources
 			dst := &templateExt.Spec.Containers[0].Resources
@@ -538,6 +538,31 @@ var _ = Describe("instance util test", func() {
 			podNamesExpected := []string{"foo-1", "foo-2", "foo-bar-0", "foo-bar-2", "foo-foo-0"}
 			Expect(instanceNameList).Should(Equal(podNamesExpected))
 		})
+		It("with the flat instance ordinal", func() {
+			parentName := "foo"
+			templateCanary := &workloads.InstanceTemplate{
+				Name:                "canary",
+				Replicas:            pointer.Int32(1),
+				Ordinals:            workloads.Ordinals{Discrete: []int32{1}},
+				FlatInstanceOrdinal: true,
+			}
+			templateBar := &workloads.InstanceTemplate{
+				Name:     "bar",
+				Replicas: pointer.Int32(1),
+			}
+			var templates []InstanceTemplate
+			templates = append(templates, templateCanary, templateBar)
+			instanceNameList, err := GenerateAllInstanceNames(parentName, 4, templates, nil, workloads.Ordinals{})
+			Expect(err).Should(BeNil())
+
+			podNamesExpected := []string{"foo-0", "foo-1", "foo-2", "foo-bar-0"}
+			Expect(instanceNameList).Should(Equal(podNamesExpected))
+
+			By("the ordinals are required")
+			templateCanary.Ordinals = workloads.Ordinals{}
+			_, err = GenerateAllInstanceNames(parentName, 4, templates, nil, workloads.Ordinals{})
+			Expect(err).ShouldNot(BeNil())
+		})
 		It("with Ordinals, without offlineInstances", func() {
 			parentName := "foo"
 			defaultTemplateOrdinals := workloads.Ordinals{
@@ -63,15 +63,12 @@ func (r *revisionUpdateReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kub
 
 	// build instance revision list from instance templates
 	var instanceRevisionList []instanceRevision
-	for _, template := range instanceTemplateList {
-		ordinalList, err := GetOrdinalListByTemplateName(itsExt.its, template.Name)
-		if err != nil {
-			return kubebuilderx.Continue, err
-		}
-		instanceNames, err := GenerateInstanceNamesFromTemplate(its.Name, template.Name, template.Replicas, itsExt.its.Spec.OfflineInstances, ordinalList)
-		if err != nil {
-			return kubebuilderx.Continue, err
-		}
+	templateNames, err := generateInstanceNamesOfTemplateExts(its, instanceTemplateList)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+	for i, template := range instanceTemplateList {
+		instanceNames := templateNames[i]
 		revision, err := BuildInstanceTemplateRevision(&template.PodTemplateSpec, its)
 		if err != nil {
 			return kubebuilderx.Continue, err
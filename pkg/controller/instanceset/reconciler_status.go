@@ -89,11 +89,25 @@ func (r *statusReconciler) Reconcile(tree *kubebuilderx.ObjectTree) (kubebuilder
 		return kubebuilderx.Continue, err
 	}
 
+	itsExt, err := buildInstanceSetExt(its, tree)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+	nameToTemplateMap, err := buildInstanceName2TemplateMap(itsExt)
+	if err != nil {
+		return kubebuilderx.Continue, err
+	}
+
 	for _, pod := range podList {
-		parentName, _ := ParseParentNameAndOrdinal(pod.Name)
-		templateName, _ := strings.CutPrefix(parentName, its.Name)
-		if len(templateName) > 0 {
-			templateName, _ = strings.CutPrefix(templateName, "-")
+		var templateName string
+		if template, ok := nameToTemplateMap[pod.Name]; ok {
+			templateName = template.Name
+		} else {
+			parentName, _ := ParseParentNameAndOrdinal(pod.Name)
+			templateName, _ = strings.CutPrefix(parentName, its.Name)
+			if len(templateName) > 0 {
+				templateName, _ = strings.CutPrefix(templateName, "-")
+			}
 		}
 		if template2TemplatesStatus[templateName] == nil {
 			template2TemplatesStatus[templateName] = &workloads.InstanceTemplateStatus{
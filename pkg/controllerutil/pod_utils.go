@@ -383,6 +383,44 @@ func (c ByPodName) Less(i, j int) bool {
 	return c[i].Name < c[j].Name
 }
 
+// ByPodOrdinal sorts a list of pods by the pod name prefix and then the ordinal suffix,
+// e.g. pod-2 is ahead of pod-10.
+type ByPodOrdinal []corev1.Pod
+
+// Len returns the length of byPodOrdinal for sort.Sort
+func (c ByPodOrdinal) Len() int {
+	return len(c)
+}
+
+// Swap swaps the items for sort.Sort
+func (c ByPodOrdinal) Swap(i, j int) {
+	c[i], c[j] = c[j], c[i]
+}
+
+// Less defines compare method for sort.Sort
+func (c ByPodOrdinal) Less(i, j int) bool {
+	prefixI, ordinalI := parsePodNameAndOrdinal(c[i].Name)
+	prefixJ, ordinalJ := parsePodNameAndOrdinal(c[j].Name)
+	if prefixI != prefixJ || ordinalI < 0 || ordinalJ < 0 {
+		return c[i].Name < c[j].Name
+	}
+	return ordinalI < ordinalJ
+}
+
+// parsePodNameAndOrdinal parses the name prefix and the ordinal suffix from the pod name,
+// -1 is returned if the pod name has no ordinal suffix.
+func parsePodNameAndOrdinal(name string) (string, int) {
+	index := strings.LastIndex(name, "-")
+	if index < 0 {
+		return name, -1
+	}
+	ordinal, err := strconv.Atoi(name[index+1:])
+	if err != nil {
+		return name, -1
+	}
+	return name[:index], ordinal
+}
+
 // BuildPodHostDNS builds the host dns of pod.
 // ref: https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/
 func BuildPodHostDNS(pod *corev1.Pod) string {
@@ -607,6 +607,24 @@ var _ = Describe("pod utils", func() {
 			Expect(pods[3].Name).Should(Equal("pod-3"))
 		})
 	})
+	Context("test sort by pod ordinal", func() {
+		It("Should succeed with no error", func() {
+			pods := []corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-10"},
+			}, {
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-2"},
+			}, {
+				ObjectMeta: metav1.ObjectMeta{Name: "pod-1"},
+			}, {
+				ObjectMeta: metav1.ObjectMeta{Name: "other-0"},
+			}}
+			sort.Sort(ByPodOrdinal(pods))
+			Expect(pods[0].Name).Should(Equal("other-0"))
+			Expect(pods[1].Name).Should(Equal("pod-1"))
+			Expect(pods[2].Name).Should(Equal("pod-2"))
+			Expect(pods[3].Name).Should(Equal("pod-10"))
+		})
+	})
 })
 
 func TestBuildImagePullSecretsByEnv(t *testing.T) {
@@ -0,0 +1,215 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package action
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+const (
+	clustersGroupResource = "clusters.apps.kubeblocks.io"
+
+	// KubeResourcesSecretKey is the key of the secret storing the gzipped kubernetes resources.
+	KubeResourcesSecretKey = dptypes.KubeResourcesFileName + ".gz"
+
+	// maxKubeResourcesSecretDataSize is the max size of the gzipped kubernetes resources, the secret
+	// can not exceed 1MiB and some space is left for the metadata.
+	maxKubeResourcesSecretDataSize = 1<<20 - 16<<10
+)
+
+// defaultKubeResources are the resource types backed up if no resource type is included.
+var defaultKubeResources = []string{
+	clustersGroupResource,
+	"secrets",
+	"configmaps",
+}
+
+// BackupKubeResourcesAction is an action that backs up the kubernetes resources into the backup repo.
+// The resources are serialized and gzipped into a secret by the controller, and the job of the action
+// mounts the secret and pushes the resources into the backup repo.
+type BackupKubeResourcesAction struct {
+	JobAction
+
+	// Resources specifies the kubernetes resources to back up.
+	Resources *dpv1alpha1.KubeResources
+}
+
+func (k *BackupKubeResourcesAction) Execute(actCtx ActionContext) (*dpv1alpha1.ActionStatus, error) {
+	sb := newStatusBuilder(k)
+	handleErr := func(err error) (*dpv1alpha1.ActionStatus, error) {
+		return sb.withErr(err).build(), err
+	}
+
+	if err := k.validate(); err != nil {
+		return handleErr(err)
+	}
+	if k.Resources == nil || k.Resources.Selector == nil {
+		return handleErr(fmt.Errorf("the selector of the kubernetes resources is required"))
+	}
+
+	// the secret storing the serialized resources shares the name of the job.
+	secretKey := client.ObjectKey{Namespace: k.ObjectMeta.Namespace, Name: k.ObjectMeta.Name}
+	jobExists, err := ctrlutil.CheckResourceExists(actCtx.Ctx, actCtx.Client, secretKey, &batchv1.Job{})
+	if err != nil {
+		return handleErr(err)
+	}
+	if !jobExists {
+		if err = k.ensureResourcesSecret(actCtx, secretKey); err != nil {
+			return handleErr(err)
+		}
+	}
+
+	status, err := k.JobAction.Execute(actCtx)
+	if err != nil {
+		return status, err
+	}
+	if status.Phase == dpv1alpha1.ActionPhaseCompleted || status.Phase == dpv1alpha1.ActionPhaseFailed {
+		// the resources have been pushed into the backup repo, delete the secret.
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: secretKey.Namespace, Name: secretKey.Name}}
+		if err = client.IgnoreNotFound(actCtx.Client.Delete(actCtx.Ctx, secret)); err != nil {
+			return handleErr(err)
+		}
+	}
+	return status, nil
+}
+
+func (k *BackupKubeResourcesAction) ensureResourcesSecret(actCtx ActionContext, secretKey client.ObjectKey) error {
+	exists, err := ctrlutil.CheckResourceExists(actCtx.Ctx, actCtx.Client, secretKey, &corev1.Secret{})
+	if err != nil || exists {
+		return err
+	}
+	data, err := CollectKubeResources(actCtx.Ctx, actCtx.Client, secretKey.Namespace, k.Resources)
+	if err != nil {
+		return err
+	}
+	if data, err = gzipKubeResources(data); err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: secretKey.Namespace,
+			Name:      secretKey.Name,
+			Labels:    k.ObjectMeta.Labels,
+		},
+		Data: map[string][]byte{KubeResourcesSecretKey: data},
+	}
+	if err = utils.SetControllerReference(k.Owner, secret, actCtx.Scheme); err != nil {
+		return err
+	}
+	return client.IgnoreAlreadyExists(actCtx.Client.Create(actCtx.Ctx, secret))
+}
+
+func gzipKubeResources(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	writer := gzip.NewWriter(buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() > maxKubeResourcesSecretDataSize {
+		return nil, fmt.Errorf("the gzipped kubernetes resources are %d bytes, exceeding the limit %d bytes, "+
+			"please narrow down the selector or the included resources", buf.Len(), maxKubeResourcesSecretDataSize)
+	}
+	return buf.Bytes(), nil
+}
+
+// CollectKubeResources lists the kubernetes resources selected by the KubeResources in the namespace,
+// and serializes them into a list. The server-populated fields, such as the uid and the status,
+// are removed, so that the resources can be created again.
+func CollectKubeResources(ctx context.Context, cli client.Client,
+	namespace string, resources *dpv1alpha1.KubeResources) ([]byte, error) {
+	selector, err := metav1.LabelSelectorAsSelector(resources.Selector)
+	if err != nil {
+		return nil, err
+	}
+	groupResources := resources.Included
+	if len(groupResources) == 0 {
+		groupResources = defaultKubeResources
+	}
+
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "v1", "kind": "List"}}
+	for _, gr := range groupResources {
+		if slices.Contains(resources.Excluded, gr) {
+			continue
+		}
+		gvk, err := cli.RESTMapper().KindFor(schema.ParseGroupResource(gr).WithVersion(""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the kind of resource %s: %w", gr, err)
+		}
+		objList := &unstructured.UnstructuredList{}
+		objList.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err = cli.List(ctx, objList, client.InNamespace(namespace),
+			client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		// the cluster is usually not labeled by its own name.
+		if clusterName := resources.Selector.MatchLabels[constant.AppInstanceLabelKey]; gr == clustersGroupResource && clusterName != "" &&
+			!slices.ContainsFunc(objList.Items, func(obj unstructured.Unstructured) bool { return obj.GetName() == clusterName }) {
+			cluster := &unstructured.Unstructured{}
+			cluster.SetGroupVersionKind(gvk)
+			err = cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, cluster)
+			if client.IgnoreNotFound(err) != nil {
+				return nil, err
+			}
+			if err == nil {
+				objList.Items = append(objList.Items, *cluster)
+			}
+		}
+		for i := range objList.Items {
+			obj := &objList.Items[i]
+			if obj.GetDeletionTimestamp() != nil {
+				continue
+			}
+			sanitizeKubeResource(obj)
+			list.Items = append(list.Items, *obj)
+		}
+	}
+	return json.Marshal(list)
+}
+
+// sanitizeKubeResource removes the fields populated by the server and the references to other objects
+// that will not exist when the resource is restored.
+func sanitizeKubeResource(obj *unstructured.Unstructured) {
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp",
+		"managedFields", "ownerReferences", "finalizers", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+}
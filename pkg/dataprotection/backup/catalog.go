@@ -0,0 +1,209 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	// CatalogContainerName is the name of the container collecting the metadata of the backups.
+	CatalogContainerName = "catalog"
+
+	envSyncPaths = "DP_SYNC_PATHS"
+)
+
+// BuildBackupMetadata builds the metadata of the backup written into the backup repo. The fields
+// populated by the server and the status of the actions are removed.
+func BuildBackupMetadata(backup *dpv1alpha1.Backup) ([]byte, error) {
+	metadata := &dpv1alpha1.Backup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: dpv1alpha1.GroupVersion.String(),
+			Kind:       dptypes.BackupKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        backup.Name,
+			Namespace:   backup.Namespace,
+			Labels:      backup.Labels,
+			Annotations: map[string]string{},
+		},
+		Spec:   backup.Spec,
+		Status: *backup.Status.DeepCopy(),
+	}
+	for k, v := range backup.Annotations {
+		if k == corev1.LastAppliedConfigAnnotation {
+			continue
+		}
+		metadata.Annotations[k] = v
+	}
+	metadata.Status.Actions = nil
+	return json.Marshal(metadata)
+}
+
+// BuildSyncBackupsJob builds the job to collect the metadata of the backups stored in the backup repo.
+// The metadata files are returned in the output of the job, see utils.GetJobOutput.
+func BuildSyncBackupsJob(repo *dpv1alpha1.BackupRepo, jobKey client.ObjectKey,
+	labels map[string]string, serviceAccount string) (*batchv1.Job, error) {
+	var syncPaths []string
+	repoPathPrefix := strings.Trim(repo.Spec.PathPrefix, "/")
+	if repo.Spec.Sync != nil && len(repo.Spec.Sync.SourceNamespaces) > 0 {
+		for _, ns := range repo.Spec.Sync.SourceNamespaces {
+			syncPaths = append(syncPaths, filepath.Join("/", repoPathPrefix, ns))
+		}
+	} else {
+		syncPaths = append(syncPaths, filepath.Join("/", repoPathPrefix))
+	}
+	script := fmt.Sprintf(`set -e
+export PATH="$PATH:$%s"
+catalog=/tmp/backups
+: > "${catalog}"
+for path in ${%s}; do
+  for file in $(datasafed list -f -r "${path}" | grep "/%s$" || true); do
+    datasafed pull "${file}" - >> "${catalog}"
+    echo >> "${catalog}"
+  done
+done
+%s < "${catalog}"`, dptypes.DPDatasafedBinPath, envSyncPaths, dptypes.BackupMetadataFileName, utils.EncodeJobOutputCmd)
+	container := buildCatalogContainer(script, []corev1.EnvVar{
+		{Name: envSyncPaths, Value: strings.Join(syncPaths, " ")},
+	})
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: serviceAccount,
+	}
+	if err := utils.AddTolerations(&podSpec); err != nil {
+		return nil, err
+	}
+	utils.InjectDatasafed(&podSpec, repo, RepoVolumeMountPath, nil, "")
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}, nil
+}
+
+func buildCatalogContainer(script string, env []corev1.EnvVar) corev1.Container {
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            CatalogContainerName,
+		Command:         []string{"sh", "-c"},
+		Args:            []string{script},
+		Env:             env,
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	return container
+}
+
+// ParseBackupCatalog parses the metadata of the backups collected by the sync job.
+func ParseBackupCatalog(data []byte) ([]dpv1alpha1.Backup, error) {
+	var backups []dpv1alpha1.Backup
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		backup := dpv1alpha1.Backup{}
+		if err := decoder.Decode(&backup); err != nil {
+			if errors.Is(err, io.EOF) {
+				return backups, nil
+			}
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+}
+
+// BuildImportedBackup builds the Backup object imported from the metadata into the namespace. It returns
+// nil if the backup should not be imported, e.g. it is not completed or has expired. The status of the
+// returned backup should be updated after it is created.
+func BuildImportedBackup(metadata *dpv1alpha1.Backup, repo *dpv1alpha1.BackupRepo, namespace string, now time.Time) *dpv1alpha1.Backup {
+	if metadata.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+		return nil
+	}
+	// the expired backup will be deleted as soon as it is imported.
+	if metadata.Status.Expiration != nil && metadata.Status.Expiration.Time.Before(now) {
+		return nil
+	}
+	if namespace == "" {
+		namespace = metadata.Namespace
+	}
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        metadata.Name,
+			Namespace:   namespace,
+			Labels:      map[string]string{},
+			Annotations: metadata.Annotations,
+		},
+		Spec:   metadata.Spec,
+		Status: metadata.Status,
+	}
+	for k, v := range metadata.Labels {
+		backup.Labels[k] = v
+	}
+	backup.Labels[dptypes.BackupRepoNameLabelKey] = repo.Name
+	backup.Labels[dptypes.BackupImportedFromRepoLabelKey] = repo.Name
+	// the copy of a backup is a complete backup after it is imported.
+	delete(backup.Labels, dptypes.BackupCopySourceLabelKey)
+	// the imported backup is read-only, deleting it does not delete the backup data in the repo,
+	// which may be still referenced by the source cluster or imported by other namespaces.
+	backup.Spec.DeletionPolicy = dpv1alpha1.BackupDeletionPolicyRetain
+	controllerutil.AddFinalizer(backup, dptypes.DataProtectionFinalizerName)
+
+	backup.Status.BackupRepoName = repo.Name
+	backup.Status.PersistentVolumeClaimName = ""
+	if repo.AccessByMount() {
+		backup.Status.PersistentVolumeClaimName = repo.Status.BackupPVCName
+	}
+	return backup
+}
@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestBackupCatalog(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newBackup := func(name string, phase dpv1alpha1.BackupPhase, expiration time.Time) *dpv1alpha1.Backup {
+		return &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{dptypes.BackupMethodLabelKey: "xtrabackup"},
+				Annotations: map[string]string{
+					corev1.LastAppliedConfigAnnotation: "{}",
+				},
+				UID:             "8a41d19b-7f0e-4d3c-9d6f-8b1a9e5c3f21",
+				ResourceVersion: "1024",
+			},
+			Spec: dpv1alpha1.BackupSpec{BackupPolicyName: "policy", BackupMethod: "xtrabackup"},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:          phase,
+				Path:           "/default/mycluster-8a41d19b/policy/" + name,
+				BackupRepoName: "source-repo",
+				Expiration:     &metav1.Time{Time: expiration},
+				Actions:        []dpv1alpha1.ActionStatus{{Name: "dp-backup-0"}},
+				TimeRange: &dpv1alpha1.BackupTimeRange{
+					Start: &metav1.Time{Time: now.Add(-2 * time.Hour)},
+					End:   &metav1.Time{Time: now.Add(-time.Hour)},
+				},
+			},
+		}
+	}
+
+	// build the catalog as the sync job does
+	var catalog bytes.Buffer
+	for _, b := range []*dpv1alpha1.Backup{
+		newBackup("completed", dpv1alpha1.BackupPhaseCompleted, now.Add(time.Hour)),
+		newBackup("failed", dpv1alpha1.BackupPhaseFailed, now.Add(time.Hour)),
+		newBackup("expired", dpv1alpha1.BackupPhaseCompleted, now.Add(-time.Minute)),
+	} {
+		data, err := BuildBackupMetadata(b)
+		assert.NoError(t, err)
+		catalog.Write(append(data, '\n'))
+	}
+
+	metadataList, err := ParseBackupCatalog(catalog.Bytes())
+	assert.NoError(t, err)
+	assert.Len(t, metadataList, 3)
+	metadata := metadataList[0]
+	assert.Equal(t, "completed", metadata.Name)
+	assert.Empty(t, metadata.UID)
+	assert.Empty(t, metadata.Status.Actions)
+	assert.NotContains(t, metadata.Annotations, corev1.LastAppliedConfigAnnotation)
+	assert.True(t, metadata.Status.TimeRange.End.Time.Equal(now.Add(-time.Hour)))
+
+	repo := &dpv1alpha1.BackupRepo{ObjectMeta: metav1.ObjectMeta{Name: "target-repo"}}
+	imported := BuildImportedBackup(&metadata, repo, "restored", now)
+	assert.NotNil(t, imported)
+	assert.Equal(t, "restored", imported.Namespace)
+	assert.Equal(t, "xtrabackup", imported.Spec.BackupMethod)
+	assert.Equal(t, dpv1alpha1.BackupDeletionPolicyRetain, imported.Spec.DeletionPolicy)
+	assert.Equal(t, "target-repo", imported.Status.BackupRepoName)
+	assert.Equal(t, metadata.Status.Path, imported.Status.Path)
+	assert.Equal(t, map[string]string{
+		dptypes.BackupMethodLabelKey:           "xtrabackup",
+		dptypes.BackupRepoNameLabelKey:         "target-repo",
+		dptypes.BackupImportedFromRepoLabelKey: "target-repo",
+	}, imported.Labels)
+	assert.Contains(t, imported.Finalizers, dptypes.DataProtectionFinalizerName)
+
+	// the backups not completed or expired are not imported
+	assert.Nil(t, BuildImportedBackup(&metadataList[1], repo, "", now))
+	assert.Nil(t, BuildImportedBackup(&metadataList[2], repo, "", now))
+}
+
+func TestManagerWritesBackupMetadata(t *testing.T) {
+	backup := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec:       dpv1alpha1.BackupSpec{BackupPolicyName: "policy", BackupMethod: "xtrabackup"},
+		Status:     dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseRunning, Path: "/default/backup"},
+	}
+	for _, strategy := range []dpv1alpha1.PodSelectionStrategy{dpv1alpha1.PodSelectionStrategyAny, dpv1alpha1.PodSelectionStrategyAll} {
+		r := &Request{
+			Backup: backup,
+			Target: &dpv1alpha1.BackupTarget{PodSelector: &dpv1alpha1.PodSelector{Strategy: strategy}},
+		}
+		env, err := r.buildBackupMetadataEnv()
+		assert.NoError(t, err)
+		podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: BackupDataContainerName}}}
+		r.InjectManagerContainer(podSpec, nil, r.buildSyncProgressCommand(), env)
+
+		// the metadata is written by the manager container instead of a separate job
+		assert.Len(t, podSpec.Containers, 2)
+		manager := podSpec.Containers[1]
+		assert.Contains(t, manager.Env, env)
+		metadata, err := ParseBackupCatalog([]byte(env.Value))
+		assert.NoError(t, err)
+		assert.Len(t, metadata, 1)
+		assert.Equal(t, backup.Status.Path, metadata[0].Status.Path)
+
+		script := manager.Args[0]
+		assert.Contains(t, script, `.status.phase = "Completed"`)
+		assert.Contains(t, script, `--arg now "$(date -u +%Y-%m-%dT%H:%M:%SZ)"`)
+		assert.Contains(t, script, fmt.Sprintf(`env -u %s -u %s datasafed push - "/%s"`,
+			dptypes.DPDatasafedEncryptionAlgorithm, dptypes.DPDatasafedEncryptionPassPhrase, dptypes.BackupMetadataFileName))
+		if strategy == dpv1alpha1.PodSelectionStrategyAll {
+			assert.Contains(t, script, `--argjson info "{}"`)
+		} else {
+			assert.Contains(t, script, `--argjson info "${backup_info}"`)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+)
+
+// IsConsolidationBackup checks whether the backup is a synthetic full backup consolidated
+// from an incremental backup chain.
+func IsConsolidationBackup(backup *dpv1alpha1.Backup) bool {
+	return backup.Labels[dptypes.BackupConsolidationLabelKey] == "true"
+}
+
+// GetConsolidatedBackupNames returns the names of the backups consolidated into the synthetic full backup,
+// ordered from the full backup to the latest incremental backup.
+func GetConsolidatedBackupNames(backup *dpv1alpha1.Backup) []string {
+	value := backup.Annotations[dptypes.ConsolidatedBackupsAnnotationKey]
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// GetConsolidationChain returns the incremental backup chain to be consolidated into the synthetic full backup,
+// ordered from the full backup to the latest incremental backup.
+// The chain ends at the latest completed incremental backup of the same backup policy and backup method,
+// and starts at its first ancestor without parent. If the latest incremental backup has been consolidated
+// by another synthetic full backup, there is nothing to consolidate. All the backups of the chain must be
+// completed and stored in the backup repo of the synthetic full backup.
+func GetConsolidationChain(backup *dpv1alpha1.Backup,
+	backups []dpv1alpha1.Backup,
+	backupRepoName string) ([]*dpv1alpha1.Backup, error) {
+	var (
+		tip          *dpv1alpha1.Backup
+		backupMap    = map[string]*dpv1alpha1.Backup{}
+		consolidated = sets.New[string]()
+	)
+	for i := range backups {
+		item := &backups[i]
+		backupMap[item.Name] = item
+		if item.Name == backup.Name || !IsConsolidationBackup(item) || item.Status.Phase == dpv1alpha1.BackupPhaseFailed {
+			continue
+		}
+		if names := GetConsolidatedBackupNames(item); len(names) > 0 {
+			consolidated.Insert(names[len(names)-1])
+		}
+	}
+	for i := range backups {
+		item := &backups[i]
+		if item.Spec.BackupPolicyName != backup.Spec.BackupPolicyName ||
+			item.Spec.BackupMethod != backup.Spec.BackupMethod ||
+			utils.GetParentBackupName(item) == "" ||
+			item.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeIncremental) ||
+			item.Status.Phase != dpv1alpha1.BackupPhaseCompleted ||
+			!item.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if tip == nil || tip.GetEndTime().Before(item.GetEndTime()) {
+			tip = item
+		}
+	}
+	if tip == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`no completed incremental backup of backup method "%s" to consolidate`,
+			backup.Spec.BackupMethod))
+	}
+	if consolidated.Has(tip.Name) {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`the latest incremental backup "%s" has been consolidated`, tip.Name))
+	}
+
+	chain := []*dpv1alpha1.Backup{tip}
+	visited := sets.New(tip.Name)
+	for current := tip; utils.GetParentBackupName(current) != ""; {
+		parentName := utils.GetParentBackupName(current)
+		parent, ok := backupMap[parentName]
+		if !ok {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`parent backup "%s" of backup "%s" not found`,
+				parentName, current.Name))
+		}
+		if visited.Has(parent.Name) {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`circular parent reference found at backup "%s"`, parent.Name))
+		}
+		visited.Insert(parent.Name)
+		chain = append([]*dpv1alpha1.Backup{parent}, chain...)
+		current = parent
+	}
+	for _, item := range chain {
+		if item.Status.Phase != dpv1alpha1.BackupPhaseCompleted || !item.DeletionTimestamp.IsZero() {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`backup "%s" of the chain is not completed`, item.Name))
+		}
+		if item.Status.BackupRepoName != backupRepoName {
+			return nil, intctrlutil.NewFatalError(fmt.Sprintf(`backup "%s" of the chain is not stored in backup repo "%s"`,
+				item.Name, backupRepoName))
+		}
+	}
+	return chain, nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestGetConsolidationChain(t *testing.T) {
+	start := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	newBackup := func(name, method, parent string, backupType dpv1alpha1.BackupType, hours int) dpv1alpha1.Backup {
+		stopTime := metav1.NewTime(start.Add(time.Duration(hours) * time.Hour))
+		return dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{dptypes.BackupTypeLabelKey: string(backupType)},
+			},
+			Spec: dpv1alpha1.BackupSpec{
+				BackupPolicyName: "policy",
+				BackupMethod:     method,
+				ParentBackupName: parent,
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:               dpv1alpha1.BackupPhaseCompleted,
+				BackupRepoName:      "repo",
+				CompletionTimestamp: &stopTime,
+			},
+		}
+	}
+	synthetic := &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "synthetic",
+			Labels: map[string]string{dptypes.BackupConsolidationLabelKey: "true"},
+		},
+		Spec: dpv1alpha1.BackupSpec{
+			BackupPolicyName: "policy",
+			BackupMethod:     "incremental",
+		},
+	}
+	names := func(chain []*dpv1alpha1.Backup) []string {
+		var result []string
+		for _, b := range chain {
+			result = append(result, b.Name)
+		}
+		return result
+	}
+
+	backups := []dpv1alpha1.Backup{
+		newBackup("full", "full", "", dpv1alpha1.BackupTypeFull, 0),
+		newBackup("inc-1", "incremental", "full", dpv1alpha1.BackupTypeIncremental, 1),
+		newBackup("inc-2", "incremental", "inc-1", dpv1alpha1.BackupTypeIncremental, 2),
+		newBackup("inc-3", "incremental", "inc-2", dpv1alpha1.BackupTypeIncremental, 3),
+		// the incremental backup of another backup method is ignored
+		newBackup("other-inc", "other", "full", dpv1alpha1.BackupTypeIncremental, 4),
+		*synthetic,
+	}
+	chain, err := GetConsolidationChain(synthetic, backups, "repo")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"full", "inc-1", "inc-2", "inc-3"}, names(chain))
+
+	// the running incremental backup is not consolidated
+	running := newBackup("inc-4", "incremental", "inc-3", dpv1alpha1.BackupTypeIncremental, 4)
+	running.Status.Phase = dpv1alpha1.BackupPhaseRunning
+	chain, err = GetConsolidationChain(synthetic, append(backups, running), "repo")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"full", "inc-1", "inc-2", "inc-3"}, names(chain))
+
+	// the chain stored in another backup repo can not be consolidated
+	_, err = GetConsolidationChain(synthetic, backups, "another-repo")
+	assert.True(t, intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal))
+
+	// the latest incremental backup has been consolidated by another synthetic full backup
+	consolidated := newBackup("synthetic-0", "incremental", "", dpv1alpha1.BackupTypeFull, 3)
+	consolidated.Labels[dptypes.BackupConsolidationLabelKey] = "true"
+	consolidated.Annotations = map[string]string{dptypes.ConsolidatedBackupsAnnotationKey: "full,inc-1,inc-2,inc-3"}
+	_, err = GetConsolidationChain(synthetic, append(backups, consolidated), "repo")
+	assert.True(t, intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal))
+
+	// the incremental backups re-parented to the synthetic full backup form a new chain,
+	// the new parent is recorded in the status.
+	reparented := newBackup("inc-5", "incremental", "inc-3", dpv1alpha1.BackupTypeIncremental, 5)
+	reparented.Status.ParentBackupName = "synthetic-0"
+	backups = append(backups, consolidated, reparented)
+	chain, err = GetConsolidationChain(synthetic, backups, "repo")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"synthetic-0", "inc-5"}, names(chain))
+
+	// the chain is broken if a parent backup is missing
+	_, err = GetConsolidationChain(synthetic, []dpv1alpha1.Backup{backups[2], backups[3]}, "repo")
+	assert.True(t, intctrlutil.IsTargetError(err, intctrlutil.ErrorTypeFatal))
+}
@@ -0,0 +1,265 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/common"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	copyBackupFilesJobNamePrefix = "copy-"
+	backupCopyNameSuffix         = "-copy"
+
+	copySourceVolumePrefix    = "src-"
+	copySourceRepoMountPath   = "/backupdata-src"
+	copySourceConfigMountPath = "/etc/datasafed-src"
+)
+
+type CopyStatus string
+
+const (
+	CopyStatusCopying   CopyStatus = "Copying"
+	CopyStatusFailed    CopyStatus = "Failed"
+	CopyStatusSucceeded CopyStatus = "Succeeded"
+	CopyStatusUnknown   CopyStatus = "Unknown"
+)
+
+type Copier struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+}
+
+// CopyBackupFiles builds a job to copy the backup files from the source backup repo to the target
+// backup repo, and returns the copy status. If the copy job exists, it will check the job status and
+// return the corresponding copy status.
+func (c *Copier) CopyBackupFiles(source, backupCopy *dpv1alpha1.Backup,
+	sourceRepo, targetRepo *dpv1alpha1.BackupRepo) (CopyStatus, error) {
+	jobKey := BuildCopyBackupFilesJobKey(backupCopy)
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(c.Ctx, c.Client, jobKey, job)
+	if err != nil {
+		return CopyStatusUnknown, err
+	}
+
+	// if copy job exists, check its status
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			return CopyStatusSucceeded, nil
+		case batchv1.JobFailed:
+			return CopyStatusFailed,
+				fmt.Errorf("copy backup files job \"%s\" failed, %s", job.Name, msg)
+		}
+		return CopyStatusCopying, nil
+	}
+
+	if source.Status.Path == "" {
+		return CopyStatusFailed, fmt.Errorf("backup %s has no files to copy", source.Name)
+	}
+	return CopyStatusCopying, c.createCopyBackupFilesJob(jobKey, source, backupCopy, sourceRepo, targetRepo)
+}
+
+// BuildCopyBackupFilesScript builds the script to stream the backup files from the source repo to the
+// target repo, the files are not staged on the node.
+// The files in the backup path are copied as they are. The kopia repository is shared by the backups
+// of the same path prefix, its blobs are immutable and named by the content, so only the blobs missing
+// in the target repo are copied, and the callers must make sure only one copy job writes the kopia
+// repository in the target repo at a time.
+func BuildCopyBackupFilesScript(backup *dpv1alpha1.Backup, sourceRepo *dpv1alpha1.BackupRepo) string {
+	var copyCmds []string
+	addCopyCmd := func(path string, skipExisting bool) {
+		// make sure the path has a leading slash
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path
+		}
+		copyCmds = append(copyCmds, fmt.Sprintf("copy_files %q %t", path, skipExisting))
+	}
+	if backup.Status.KopiaRepoPath != "" {
+		addCopyCmd(backup.Status.KopiaRepoPath, true)
+		addCopyCmd(backup.Status.KopiaRepoPath+".meta", false)
+	}
+	addCopyCmd(backup.Status.Path, false)
+
+	return fmt.Sprintf(`
+set -e
+set -o pipefail
+export PATH="$PATH:$%s"
+
+# access the source repo, the datasafed without options accesses the target repo.
+src_datasafed() {
+	%s "$@"
+}
+
+copy_files() {
+	filePath="$1"
+	skipExisting="$2"
+	existing="/tmp/existing-files"
+	: > "${existing}"
+	if [ "${skipExisting}" = "true" ]; then
+		# the kopia repository may not exist in the target repo yet
+		datasafed list -r -f "${filePath}" > "${existing}" || :
+	fi
+	src_datasafed list -r -f "${filePath}" | while read -r file; do
+		if grep -qxF "${file}" "${existing}"; then
+			continue
+		fi
+		echo "copying ${file}"
+		src_datasafed pull "${file}" - | datasafed push - "${file}"
+	done
+}
+
+%s
+`, dptypes.DPDatasafedBinPath, buildSourceDatasafedCmd(sourceRepo), strings.Join(copyCmds, "\n"))
+}
+
+// buildSourceDatasafedCmd builds the datasafed command to access the source repo, which is
+// mounted besides the target repo.
+func buildSourceDatasafedCmd(sourceRepo *dpv1alpha1.BackupRepo) string {
+	if sourceRepo.AccessByMount() {
+		return fmt.Sprintf("%s=%s datasafed", dptypes.DPDatasafedLocalBackendPath, copySourceRepoMountPath)
+	}
+	// the local backend path of the target repo must not take effect on the source repo.
+	return fmt.Sprintf("env -u %s datasafed -c %s/datasafed.conf",
+		dptypes.DPDatasafedLocalBackendPath, copySourceConfigMountPath)
+}
+
+// injectSourceRepo mounts the source repo into the pod, the volumes are prefixed to avoid conflicts
+// with the target repo injected by utils.InjectDatasafed.
+func injectSourceRepo(podSpec *corev1.PodSpec, sourceRepo *dpv1alpha1.BackupRepo) {
+	var (
+		volume      corev1.Volume
+		volumeMount corev1.VolumeMount
+	)
+	switch {
+	case sourceRepo.AccessByMount():
+		volume = corev1.Volume{
+			Name: copySourceVolumePrefix + "dp-backup-data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: sourceRepo.Status.BackupPVCName,
+					ReadOnly:  true,
+				},
+			},
+		}
+		volumeMount = corev1.VolumeMount{Name: volume.Name, ReadOnly: true, MountPath: copySourceRepoMountPath}
+	case sourceRepo.AccessByTool():
+		volume = corev1.Volume{
+			Name: copySourceVolumePrefix + "dp-datasafed-config",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: sourceRepo.Status.ToolConfigSecretName},
+			},
+		}
+		volumeMount = corev1.VolumeMount{Name: volume.Name, ReadOnly: true, MountPath: copySourceConfigMountPath}
+	default:
+		return
+	}
+	podSpec.Volumes = append(podSpec.Volumes, volume)
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, volumeMount)
+	}
+}
+
+func (c *Copier) createCopyBackupFilesJob(
+	jobKey client.ObjectKey,
+	source, backupCopy *dpv1alpha1.Backup,
+	sourceRepo, targetRepo *dpv1alpha1.BackupRepo) error {
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            "copy",
+		Command:         []string{"sh", "-c"},
+		Args:            []string{BuildCopyBackupFilesScript(source, sourceRepo)},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	// build pod, the files are copied as they are, the encrypted files and the kopia repository
+	// are not decrypted, so the encryption config is not injected.
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: c.WorkerServiceAccount,
+	}
+	utils.InjectDatasafed(&podSpec, targetRepo, RepoVolumeMountPath, nil, "")
+	injectSourceRepo(&podSpec, sourceRepo)
+	if err := utils.AddTolerations(&podSpec); err != nil {
+		return err
+	}
+
+	// build job
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: jobKey.Namespace,
+			Name:      jobKey.Name,
+			Labels:    BuildBackupWorkloadLabels(backupCopy),
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobKey.Namespace,
+					Name:      jobKey.Name,
+				},
+				Spec: podSpec,
+			},
+			BackoffLimit: &dptypes.DefaultBackOffLimit,
+		},
+	}
+	if err := utils.SetControllerReference(backupCopy, job, c.Scheme); err != nil {
+		return err
+	}
+	c.Log.V(1).Info("create a job to copy backup files", "job", job)
+	return client.IgnoreAlreadyExists(c.Client.Create(c.Ctx, job))
+}
+
+// GetBackupCopyName returns the name of the linked Backup which refers to the copy of the backup.
+func GetBackupCopyName(backupName string) string {
+	return common.CutString(backupName, 63-len(backupCopyNameSuffix)) + backupCopyNameSuffix
+}
+
+func BuildCopyBackupFilesJobKey(backup *dpv1alpha1.Backup) client.ObjectKey {
+	jobName := fmt.Sprintf("%s-%s%s", backup.UID[:8], copyBackupFilesJobNamePrefix, backup.Name)
+	if len(jobName) > 63 {
+		jobName = strings.TrimSuffix(jobName[:63], "-")
+	}
+	return client.ObjectKey{Namespace: backup.Namespace, Name: jobName}
+}
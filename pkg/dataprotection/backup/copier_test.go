@@ -0,0 +1,77 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+func TestBuildCopyBackupFilesScript(t *testing.T) {
+	backup := &dpv1alpha1.Backup{}
+	backup.Status.Path = "ns/cluster/backup"
+	repo := &dpv1alpha1.BackupRepo{}
+	repo.Spec.AccessMethod = dpv1alpha1.AccessMethodMount
+
+	script := BuildCopyBackupFilesScript(backup, repo)
+	assert.Contains(t, script, `copy_files "/ns/cluster/backup" false`)
+	assert.NotContains(t, script, `copy_files "/ns/cluster/kopia`)
+	assert.Contains(t, script, "DATASAFED_LOCAL_BACKEND_PATH=/backupdata-src datasafed")
+	assert.Contains(t, script, `src_datasafed pull "${file}" - | datasafed push - "${file}"`)
+
+	backup.Status.KopiaRepoPath = "/ns/cluster/kopia"
+	repo.Spec.AccessMethod = dpv1alpha1.AccessMethodTool
+	script = BuildCopyBackupFilesScript(backup, repo)
+	assert.Contains(t, script, "copy_files \"/ns/cluster/kopia\" true\n"+
+		"copy_files \"/ns/cluster/kopia.meta\" false\n"+
+		"copy_files \"/ns/cluster/backup\" false")
+	assert.Contains(t, script, "env -u DATASAFED_LOCAL_BACKEND_PATH datasafed -c /etc/datasafed-src/datasafed.conf")
+}
+
+func TestInjectSourceRepo(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{
+		Name:         "copy",
+		VolumeMounts: []corev1.VolumeMount{{Name: "dp-datasafed-config"}},
+	}}}
+	repo := &dpv1alpha1.BackupRepo{}
+	repo.Spec.AccessMethod = dpv1alpha1.AccessMethodTool
+	repo.Status.ToolConfigSecretName = "source-config"
+	injectSourceRepo(podSpec, repo)
+
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "src-dp-datasafed-config", podSpec.Volumes[0].Name)
+	assert.Equal(t, "source-config", podSpec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "dp-datasafed-config"},
+		{Name: "src-dp-datasafed-config", ReadOnly: true, MountPath: copySourceConfigMountPath},
+	}, podSpec.Containers[0].VolumeMounts)
+}
+
+func TestGetBackupCopyName(t *testing.T) {
+	assert.Equal(t, "backup-copy", GetBackupCopyName("backup"))
+	name := GetBackupCopyName(strings.Repeat("a", 70))
+	assert.Len(t, name, 63)
+	assert.True(t, strings.HasSuffix(name, backupCopyNameSuffix))
+}
@@ -0,0 +1,153 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	ctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	integrityCheckJobNamePrefix = "dp-verify"
+	integrityCheckContainerName = "verify-integrity"
+)
+
+// IntegrityChecker checks the backup data in the backup repo against the integrity manifests
+// recorded in the backup status, without restoring the backup data.
+type IntegrityChecker struct {
+	ctrlutil.RequestCtx
+	Client               client.Client
+	Scheme               *runtime.Scheme
+	WorkerServiceAccount string
+	// Labels are added to the check job, e.g. to notify the controller which waits for the check.
+	Labels map[string]string
+}
+
+// CheckIntegrity builds a job to check the backup data against the integrity manifests, and returns true
+// if the check is finished. If the backup data is missing or corrupted, or the check can not be performed,
+// a fatal error with the reason is returned. Each check started at a different time runs in a new job.
+func (c *IntegrityChecker) CheckIntegrity(backup *dpv1alpha1.Backup,
+	repo *dpv1alpha1.BackupRepo,
+	startTime metav1.Time) (bool, error) {
+	if backup.Status.Integrity == nil || len(backup.Status.Integrity.Manifests) == 0 {
+		return false, ctrlutil.NewFatalError("the backup has no integrity manifests to check")
+	}
+	prefix := fmt.Sprintf("%s-%d", integrityCheckJobNamePrefix, startTime.Unix())
+	jobKey := client.ObjectKey{Namespace: backup.Namespace, Name: GenerateBackupJobName(backup, prefix)}
+	job := &batchv1.Job{}
+	exists, err := ctrlutil.CheckResourceExists(c.Ctx, c.Client, jobKey, job)
+	if err != nil {
+		return false, err
+	}
+
+	// if the job exists, check its status
+	if exists {
+		_, finishedType, msg := utils.IsJobFinished(job)
+		switch finishedType {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			if reason, err := c.getFailureReason(job); err != nil {
+				return false, err
+			} else if reason != "" {
+				msg = reason
+			}
+			return false, ctrlutil.NewErrorf(ctrlutil.ErrorTypeFatal, "integrity check job \"%s\" failed, %s", job.Name, msg)
+		}
+		return false, nil
+	}
+
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:            integrityCheckContainerName,
+		Command:         utils.BuildVerifyIntegrityCommand(),
+		Env:             []corev1.EnvVar{utils.BuildIntegrityManifestsEnv(backup.Status.Integrity.Manifests)},
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	ctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		RestartPolicy:      corev1.RestartPolicyNever,
+		ServiceAccountName: c.WorkerServiceAccount,
+	}
+	if err = utils.AddTolerations(&podSpec); err != nil {
+		return false, err
+	}
+	utils.InjectDatasafed(&podSpec, repo, RepoVolumeMountPath,
+		backup.Status.EncryptionConfig, backup.Status.KopiaRepoPath)
+
+	backoffLimit := int32(0)
+	job = &batchv1.Job{
+		ObjectMeta: *buildBackupJobObjMeta(backup, prefix),
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: jobKey.Namespace,
+					Name:      jobKey.Name,
+				},
+				Spec: podSpec,
+			},
+			// the check is not retried, since the backup data does not change.
+			BackoffLimit: &backoffLimit,
+		},
+	}
+	for k, v := range c.Labels {
+		job.Labels[k] = v
+	}
+	if err = utils.SetControllerReference(backup, job, c.Scheme); err != nil {
+		return false, err
+	}
+	c.Log.V(1).Info("create a job to check the integrity of the backup", "job", jobKey)
+	return false, client.IgnoreAlreadyExists(c.Client.Create(c.Ctx, job))
+}
+
+// getFailureReason returns the reason written to the termination message of the failed check.
+func (c *IntegrityChecker) getFailureReason(job *batchv1.Job) (string, error) {
+	podList := &corev1.PodList{}
+	if err := c.Client.List(c.Ctx, podList, client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return "", err
+	}
+	for _, pod := range podList.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == integrityCheckContainerName && cs.State.Terminated != nil && cs.State.Terminated.Message != "" {
+				return cs.State.Terminated.Message, nil
+			}
+		}
+	}
+	return "", nil
+}
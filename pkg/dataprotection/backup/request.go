@@ -20,9 +20,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package backup
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
@@ -40,13 +43,16 @@ import (
 )
 
 const (
-	BackupDataJobNamePrefix = "dp-backup"
-	prebackupJobNamePrefix  = "dp-prebackup"
-	postbackupJobNamePrefix = "dp-postbackup"
-	BackupDataContainerName = "backupdata"
-	managerContainerName    = "manager"
-	managerSharedVolumeName = "manager-shared-volume"
-	managerSharedMountPath  = "/dp-manager"
+	BackupDataJobNamePrefix    = "dp-backup"
+	prebackupJobNamePrefix     = "dp-prebackup"
+	postbackupJobNamePrefix    = "dp-postbackup"
+	kubeResourcesJobNamePrefix = "dp-kube-resources"
+	kubeResourcesVolumeName    = "dp-kube-resources"
+	kubeResourcesMountPath     = "/dp-kube-resources"
+	BackupDataContainerName    = "backupdata"
+	managerContainerName       = "manager"
+	managerSharedVolumeName    = "manager-shared-volume"
+	managerSharedMountPath     = "/dp-manager"
 )
 
 // Request is a request for a backup, with all references to other objects.
@@ -65,9 +71,15 @@ type Request struct {
 	WorkerServiceAccount string
 	SnapshotVolumes      bool
 	Target               *dpv1alpha1.BackupTarget
+	// ConsolidatedBackups is the incremental backup chain consolidated by the synthetic full backup,
+	// ordered from the full backup to the latest incremental backup.
+	ConsolidatedBackups []*dpv1alpha1.Backup
 }
 
 func (r *Request) GetBackupType() string {
+	if IsConsolidationBackup(r.Backup) {
+		return string(dpv1alpha1.BackupTypeFull)
+	}
 	if r.ActionSet != nil {
 		return string(r.ActionSet.Spec.BackupType)
 	}
@@ -91,6 +103,10 @@ func (r *Request) BuildActions() (map[string][]action.Action, error) {
 		return podActions
 	}
 
+	if IsConsolidationBackup(r.Backup) {
+		return r.buildConsolidateActions()
+	}
+
 	for i := range r.TargetPods {
 		var podActions []action.Action
 
@@ -120,10 +136,70 @@ func (r *Request) BuildActions() (map[string][]action.Action, error) {
 		actions[r.TargetPods[i].Name] = podActions
 	}
 
-	// TODO: build backup kubernetes resources action
+	// 5. build backup kubernetes resources action, it is not bound to any target pod.
+	backupKubeResourcesAction, err := r.buildBackupKubeResourcesAction(
+		strings.TrimSuffix(fmt.Sprintf("%s-%s", kubeResourcesJobNamePrefix, r.getActionTargetPrefix()), "-"))
+	if err != nil {
+		return nil, err
+	}
+	if backupKubeResourcesAction != nil {
+		actions[""] = append(actions[""], backupKubeResourcesAction)
+	}
+	return actions, nil
+}
+
+// buildConsolidateActions builds the actions of the synthetic full backup, which are only the consolidate
+// actions of the target pods, the synthetic full backup is built from the backups in the backup repo.
+func (r *Request) buildConsolidateActions() (map[string][]action.Action, error) {
+	if !r.backupActionSetExists() || r.ActionSet.Spec.Backup.Consolidate == nil {
+		return nil, intctrlutil.NewFatalError(fmt.Sprintf(`actionSet "%s" does not define the consolidate action`,
+			r.BackupMethod.ActionSetName))
+	}
+	var actions = map[string][]action.Action{}
+	for i := range r.TargetPods {
+		consolidateAction, err := r.buildConsolidateAction(r.TargetPods[i],
+			fmt.Sprintf("%s-%s%d", BackupDataJobNamePrefix, r.getActionTargetPrefix(), i))
+		if err != nil {
+			return nil, err
+		}
+		actions[r.TargetPods[i].Name] = []action.Action{consolidateAction}
+	}
 	return actions, nil
 }
 
+func (r *Request) buildConsolidateAction(targetPod *corev1.Pod, name string) (action.Action, error) {
+	podSpec, err := r.BuildJobActionPodSpec(targetPod, BackupDataContainerName, r.ActionSet.Spec.Backup.Consolidate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build job action pod spec: %w", err)
+	}
+	var names, paths []string
+	for _, b := range r.ConsolidatedBackups {
+		names = append(names, b.Name)
+		paths = append(paths, buildTargetBackupPath(b.Status.Path, r.Target, targetPod.Name))
+	}
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env,
+		corev1.EnvVar{Name: dptypes.DPConsolidateBackupNames, Value: strings.Join(names, " ")},
+		corev1.EnvVar{Name: dptypes.DPConsolidateBackupPaths, Value: strings.Join(paths, " ")},
+	)
+	var syncProgress *dpv1alpha1.SyncProgress
+	if r.ActionSet.Spec.Backup.BackupData != nil {
+		syncProgress = r.ActionSet.Spec.Backup.BackupData.SyncProgress
+	}
+	metadataEnv, err := r.buildBackupMetadataEnv()
+	if err != nil {
+		return nil, err
+	}
+	utils.InjectIntegrityRecorder(podSpec)
+	r.InjectManagerContainer(podSpec, syncProgress, r.buildSyncProgressCommand(), metadataEnv)
+	return &action.JobAction{
+		Name:         name,
+		ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
+		Owner:        r.Backup,
+		PodSpec:      podSpec,
+		BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
+	}, nil
+}
+
 func (r *Request) getActionTargetPrefix() string {
 	if r.Target != nil && r.Target.Name != "" {
 		return r.Target.Name + "-"
@@ -175,7 +251,13 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 		if err != nil {
 			return nil, fmt.Errorf("failed to build job action pod spec: %w", err)
 		}
-		r.InjectManagerContainer(podSpec, backupDataAct.SyncProgress, r.buildSyncProgressCommand())
+		metadataEnv, err := r.buildBackupMetadataEnv()
+		if err != nil {
+			return nil, err
+		}
+		// record the checksums of the backup data as it is pushed, which are written into the integrity manifest.
+		utils.InjectIntegrityRecorder(podSpec)
+		r.InjectManagerContainer(podSpec, backupDataAct.SyncProgress, r.buildSyncProgressCommand(), metadataEnv)
 		return &action.JobAction{
 			Name:         name,
 			ObjectMeta:   *buildBackupJobObjMeta(r.Backup, name),
@@ -184,6 +266,9 @@ func (r *Request) buildBackupDataAction(targetPod *corev1.Pod, name string) (act
 			BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
 		}, nil
 	case dpv1alpha1.BackupTypeContinuous:
+		if r.Target.PodSelector.Strategy == dpv1alpha1.PodSelectionStrategyAll {
+			return nil, intctrlutil.NewFatalError("the pod selection strategy All is not supported by the continuous backup")
+		}
 		podSpec, err := r.BuildJobActionPodSpec(r.TargetPods[0], BackupDataContainerName, &backupDataAct.JobActionSpec)
 		if err != nil {
 			return nil, err
@@ -245,6 +330,83 @@ func (r *Request) buildCreateVolumeSnapshotAction(targetPod *corev1.Pod, name st
 	}, nil
 }
 
+// buildBackupKubeResourcesAction builds the action to back up the kubernetes resources of the target
+// into the backup repo.
+func (r *Request) buildBackupKubeResourcesAction(name string) (action.Action, error) {
+	if r.Target == nil || r.Target.Resources == nil || r.Target.Resources.Selector == nil {
+		return nil, nil
+	}
+	// the kubernetes resources are not backed up by the continuous backup.
+	if r.GetBackupType() == string(dpv1alpha1.BackupTypeContinuous) {
+		return nil, nil
+	}
+	if r.BackupRepo == nil {
+		return nil, fmt.Errorf("the backup repo is required to back up the kubernetes resources")
+	}
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:    name,
+		Image:   viper.GetString(constant.KBToolsImage),
+		Command: []string{"sh", "-c"},
+		Args: []string{fmt.Sprintf(`set -e
+set -o pipefail
+export PATH="$PATH:$%s"
+export DATASAFED_BACKEND_BASE_PATH="$%s"
+gunzip -c "%s/%s" | datasafed push - "/%s"`, dptypes.DPDatasafedBinPath, dptypes.DPBackupBasePath, kubeResourcesMountPath,
+			action.KubeResourcesSecretKey, dptypes.KubeResourcesFileName)},
+		Env: []corev1.EnvVar{
+			{
+				Name: dptypes.DPBackupBasePath,
+				Value: BuildBackupPathByTarget(r.Backup, r.Target,
+					r.BackupRepo.Spec.PathPrefix, r.BackupPolicy.Spec.PathPrefix, ""),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      kubeResourcesVolumeName,
+				MountPath: kubeResourcesMountPath,
+				ReadOnly:  true,
+			},
+		},
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	objMeta := buildBackupJobObjMeta(r.Backup, name)
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{container},
+		Volumes: []corev1.Volume{
+			{
+				Name: kubeResourcesVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					// the secret is created by the action with the same name as the job.
+					Secret: &corev1.SecretVolumeSource{SecretName: objMeta.Name},
+				},
+			},
+		},
+		ServiceAccountName: r.WorkerServiceAccount,
+		RestartPolicy:      corev1.RestartPolicyNever,
+	}
+	if err := utils.AddTolerations(podSpec); err != nil {
+		return nil, err
+	}
+	utils.InjectDatasafed(podSpec, r.BackupRepo, RepoVolumeMountPath, r.Status.EncryptionConfig, "")
+	return &action.BackupKubeResourcesAction{
+		JobAction: action.JobAction{
+			Name:         name,
+			ObjectMeta:   *objMeta,
+			Owner:        r.Backup,
+			PodSpec:      podSpec,
+			BackOffLimit: r.BackupPolicy.Spec.BackoffLimit,
+		},
+		Resources: r.Target.Resources,
+	}, nil
+}
+
 func (r *Request) buildAction(targetPod *corev1.Pod,
 	name string,
 	act *dpv1alpha1.ActionSpec) (action.Action, error) {
@@ -457,6 +619,20 @@ func (r *Request) BuildJobActionPodSpec(targetPod *corev1.Pod,
 }
 
 func (r *Request) buildSyncProgressCommand() string {
+	// when all the target pods are backed up, the backup info of each target pod is written to
+	// the termination message of the container instead of patching the backup status, to avoid
+	// overwriting the backup info of each other. It is collected by the backup controller later,
+	// as well as the integrity manifest of each target pod.
+	syncStatusCommand := `status="{\"status\":${backup_info}}"
+kubectl -n "$namespace" patch backups.dataprotection.kubeblocks.io "$backup_name" --subresource=status --type=merge --patch "${status}"`
+	terminationCommand := `echo "${integrity}" > /dev/termination-log`
+	// the backup info of a single target pod is not the backup info of the whole backup.
+	metadataInfo := "${backup_info}"
+	if r.Target.PodSelector.Strategy == dpv1alpha1.PodSelectionStrategyAll {
+		syncStatusCommand = ""
+		metadataInfo = "{}"
+		terminationCommand = `echo "${backup_info}" | jq -c --argjson integrity "${integrity}" '. * $integrity' > /dev/termination-log`
+	}
 	// sync progress script will wait for the backup info file to be created,
 	// if the file is created, it will update the backup status and exit.
 	// If an exit file named with the backup info file with .exit suffix exists,
@@ -494,12 +670,35 @@ done
 backup_info=$(cat "$backup_info_file")
 echo "backupInfo:${backup_info}"
 
-status="{\"status\":${backup_info}}"
-kubectl -n "$namespace" patch backups.dataprotection.kubeblocks.io "$backup_name" --subresource=status --type=merge --patch "${status}"
+%s
 
 # save the backup CR object to the backup repo
 kubectl -n "$namespace" get backups.dataprotection.kubeblocks.io "$backup_name" -o json | datasafed push - "/kubeblocks-backup.json"
-`, dptypes.DPBackupInfoFile, dptypes.DPCheckInterval, r.Backup.Namespace, r.Backup.Name)
+
+# write the integrity manifest of the backup data
+%s
+
+# write the metadata of the completed backup, which is used to import the backup from the backup repo.
+# The metadata is not encrypted, so that it can be read without the encryption key, and the failure
+# of writing it does not fail the backup.
+echo "${%s}" | jq -c --argjson info "%s" --arg now "$(date -u +%%Y-%%m-%%dT%%H:%%M:%%SZ)" \
+  '.status = (.status * $info) | .status.phase = "Completed" | .status.completionTimestamp = $now' \
+  | env -u %s -u %s datasafed push - "/%s" || echo "failed to write the backup metadata"
+%s
+`, dptypes.DPBackupInfoFile, dptypes.DPCheckInterval, r.Backup.Namespace, r.Backup.Name, syncStatusCommand,
+		utils.BuildIntegrityManifestScript(), dptypes.DPBackupMetadata, metadataInfo,
+		dptypes.DPDatasafedEncryptionAlgorithm, dptypes.DPDatasafedEncryptionPassPhrase,
+		dptypes.BackupMetadataFileName, terminationCommand)
+}
+
+// buildBackupMetadataEnv builds the environment variable of the backup metadata, which is completed
+// with the backup info and written into the backup repo by the manager container.
+func (r *Request) buildBackupMetadataEnv() (corev1.EnvVar, error) {
+	metadata, err := BuildBackupMetadata(r.Backup)
+	if err != nil {
+		return corev1.EnvVar{}, err
+	}
+	return corev1.EnvVar{Name: dptypes.DPBackupMetadata, Value: string(metadata)}, nil
 }
 
 func (r *Request) buildContinuousSyncProgressCommand() string {
@@ -543,10 +742,81 @@ done
 `, dptypes.DPBackupInfoFile, dptypes.DPCheckInterval, r.Backup.Namespace, r.Backup.Name)
 }
 
+// RecordTargetPodBackupInfo records the backup info written to the termination message of the manager container
+// when the action of backing up data is completed. The integrity manifest of the target pod is recorded into
+// the backup status, and if all the target pods are backed up, the backup info of the target pod is recorded
+// into the action status.
+func (r *Request) RecordTargetPodBackupInfo(status *dpv1alpha1.ActionStatus) error {
+	if status.Phase != dpv1alpha1.ActionPhaseCompleted ||
+		status.ActionType != dpv1alpha1.ActionTypeJob ||
+		status.ObjectRef == nil ||
+		!strings.HasPrefix(status.Name, BackupDataJobNamePrefix) {
+		return nil
+	}
+	allPods := r.Target.PodSelector.Strategy == dpv1alpha1.PodSelectionStrategyAll
+	backupInfoRecorded := !allPods
+	if allPods {
+		for _, act := range r.Status.Actions {
+			if act.Name == status.Name && (act.TotalSize != "" || act.TimeRange != nil) {
+				status.TotalSize = act.TotalSize
+				status.TimeRange = act.TimeRange
+				backupInfoRecorded = true
+			}
+		}
+	}
+	backupPath := BuildBackupPathByTarget(r.Backup, r.Target,
+		r.BackupRepo.Spec.PathPrefix, r.BackupPolicy.Spec.PathPrefix, status.TargetPodName)
+	manifestRecorded := utils.GetIntegrityManifest(r.Backup, backupPath) != nil
+	if backupInfoRecorded && manifestRecorded {
+		return nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := r.Client.List(r.Ctx, podList, client.InNamespace(status.ObjectRef.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: status.ObjectRef.Name}); err != nil {
+		return err
+	}
+	for _, pod := range podList.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != managerContainerName || cs.State.Terminated == nil ||
+				cs.State.Terminated.ExitCode != 0 || cs.State.Terminated.Message == "" {
+				continue
+			}
+			backupInfo := &dpv1alpha1.BackupStatus{}
+			if err := json.Unmarshal([]byte(cs.State.Terminated.Message), backupInfo); err != nil {
+				return fmt.Errorf("failed to parse the backup info of target pod %s: %w", status.TargetPodName, err)
+			}
+			if !backupInfoRecorded {
+				status.TotalSize = backupInfo.TotalSize
+				status.TimeRange = backupInfo.TimeRange
+			}
+			if !manifestRecorded && backupInfo.Integrity != nil && len(backupInfo.Integrity.Manifests) > 0 {
+				r.setIntegrityManifest(dpv1alpha1.BackupIntegrityManifest{
+					Path:   backupPath,
+					Digest: backupInfo.Integrity.Manifests[0].Digest,
+				})
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *Request) setIntegrityManifest(manifest dpv1alpha1.BackupIntegrityManifest) {
+	if r.Status.Integrity == nil {
+		r.Status.Integrity = &dpv1alpha1.BackupIntegrity{}
+	}
+	if m := utils.GetIntegrityManifest(r.Backup, manifest.Path); m != nil {
+		m.Digest = manifest.Digest
+		return
+	}
+	r.Status.Integrity.Manifests = append(r.Status.Integrity.Manifests, manifest)
+}
+
 // InjectManagerContainer injects a sidecar that will sync the backup status
 // or push the backup CR object to the backup repo.
 func (r *Request) InjectManagerContainer(podSpec *corev1.PodSpec,
-	sync *dpv1alpha1.SyncProgress, command string) {
+	sync *dpv1alpha1.SyncProgress, command string, env ...corev1.EnvVar) {
 
 	// build container to sync backup progress that will update the backup status
 	container := podSpec.Containers[0].DeepCopy()
@@ -567,6 +837,7 @@ func (r *Request) InjectManagerContainer(podSpec *corev1.PodSpec,
 			Name:  dptypes.DPCheckInterval,
 			Value: fmt.Sprintf("%d", checkIntervalSeconds)},
 	)
+	container.Env = append(container.Env, env...)
 	container.Args = []string{command}
 	podSpec.Containers = append(podSpec.Containers, *container)
 }
@@ -0,0 +1,114 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+type retentionRule struct {
+	count  int32
+	bucket func(backup *dpv1alpha1.Backup) string
+	last   string
+}
+
+// GetBackupsToRetain returns the names of the backups retained by the count-based retention policy.
+// The backups are sorted by their stop time in descending order, and each rule of the policy retains
+// the latest backup of each time period, until the count of the rule is reached.
+func GetBackupsToRetain(policy *dpv1alpha1.BackupRetentionPolicy, backups []*dpv1alpha1.Backup) sets.Set[string] {
+	retained := sets.New[string]()
+	if policy == nil {
+		return retained
+	}
+
+	count := func(keep *int32) int32 {
+		if keep == nil {
+			return 0
+		}
+		return *keep
+	}
+	rules := []*retentionRule{
+		{
+			count: count(policy.KeepLast),
+			// each backup is in a period of its own
+			bucket: func(backup *dpv1alpha1.Backup) string { return backup.Name },
+		},
+		{
+			count:  count(policy.KeepDaily),
+			bucket: func(backup *dpv1alpha1.Backup) string { return backupStopTime(backup).Format("2006-01-02") },
+		},
+		{
+			count: count(policy.KeepWeekly),
+			bucket: func(backup *dpv1alpha1.Backup) string {
+				year, week := backupStopTime(backup).ISOWeek()
+				return fmt.Sprintf("%04d-%02d", year, week)
+			},
+		},
+		{
+			count:  count(policy.KeepMonthly),
+			bucket: func(backup *dpv1alpha1.Backup) string { return backupStopTime(backup).Format("2006-01") },
+		},
+		{
+			count:  count(policy.KeepYearly),
+			bucket: func(backup *dpv1alpha1.Backup) string { return backupStopTime(backup).Format("2006") },
+		},
+	}
+
+	// the backups without the stop time are not completed, skip them
+	sorted := make([]*dpv1alpha1.Backup, 0, len(backups))
+	for i := range backups {
+		if backups[i].GetEndTime() != nil {
+			sorted = append(sorted, backups[i])
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, tj := sorted[i].GetEndTime(), sorted[j].GetEndTime()
+		if ti.Equal(tj) {
+			return sorted[i].Name > sorted[j].Name
+		}
+		return ti.After(tj.Time)
+	})
+
+	for _, backup := range sorted {
+		for _, rule := range rules {
+			if rule.count <= 0 {
+				continue
+			}
+			if bucket := rule.bucket(backup); bucket != rule.last {
+				retained.Insert(backup.Name)
+				rule.last = bucket
+				rule.count--
+			}
+		}
+	}
+	return retained
+}
+
+// backupStopTime returns the stop time of the backup in UTC, the time periods of the retention rules are
+// calculated in UTC.
+func backupStopTime(backup *dpv1alpha1.Backup) time.Time {
+	return backup.GetEndTime().UTC()
+}
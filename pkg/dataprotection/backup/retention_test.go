@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+func TestGetBackupsToRetain(t *testing.T) {
+	// one backup per day at 01:00 UTC, from 2024-01-01 (Monday) to 2024-03-31
+	start := time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)
+	var backups []*dpv1alpha1.Backup
+	for i := 0; i < 91; i++ {
+		stopTime := metav1.NewTime(start.AddDate(0, 0, i))
+		backups = append(backups, &dpv1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("backup-%s", stopTime.Format("20060102")),
+			},
+			Status: dpv1alpha1.BackupStatus{
+				Phase:               dpv1alpha1.BackupPhaseCompleted,
+				CompletionTimestamp: &stopTime,
+			},
+		})
+	}
+	// a running backup has no stop time, which is never retained or counted
+	backups = append(backups, &dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup-running"},
+		Status:     dpv1alpha1.BackupStatus{Phase: dpv1alpha1.BackupPhaseRunning},
+	})
+
+	tests := []struct {
+		name     string
+		policy   *dpv1alpha1.BackupRetentionPolicy
+		expected []string
+	}{
+		{
+			name:     "nil policy",
+			policy:   nil,
+			expected: []string{},
+		},
+		{
+			name:     "keep last",
+			policy:   &dpv1alpha1.BackupRetentionPolicy{KeepLast: pointer.Int32(2)},
+			expected: []string{"backup-20240331", "backup-20240330"},
+		},
+		{
+			name:     "keep daily",
+			policy:   &dpv1alpha1.BackupRetentionPolicy{KeepDaily: pointer.Int32(3)},
+			expected: []string{"backup-20240331", "backup-20240330", "backup-20240329"},
+		},
+		{
+			name:   "keep weekly",
+			policy: &dpv1alpha1.BackupRetentionPolicy{KeepWeekly: pointer.Int32(3)},
+			// 2024-03-31 is Sunday, the latest backup of the previous weeks are on Sunday too
+			expected: []string{"backup-20240331", "backup-20240324", "backup-20240317"},
+		},
+		{
+			name:     "keep monthly",
+			policy:   &dpv1alpha1.BackupRetentionPolicy{KeepMonthly: pointer.Int32(12)},
+			expected: []string{"backup-20240331", "backup-20240229", "backup-20240131"},
+		},
+		{
+			name:     "keep yearly",
+			policy:   &dpv1alpha1.BackupRetentionPolicy{KeepYearly: pointer.Int32(1)},
+			expected: []string{"backup-20240331"},
+		},
+		{
+			name: "grandfather-father-son",
+			policy: &dpv1alpha1.BackupRetentionPolicy{
+				KeepDaily:   pointer.Int32(2),
+				KeepWeekly:  pointer.Int32(2),
+				KeepMonthly: pointer.Int32(3),
+			},
+			expected: []string{"backup-20240331", "backup-20240330", "backup-20240324", "backup-20240229", "backup-20240131"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, sets.New(tt.expected...), GetBackupsToRetain(tt.policy, backups))
+		})
+	}
+}
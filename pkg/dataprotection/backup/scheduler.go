@@ -79,12 +79,35 @@ func (s *Scheduler) validate() error {
 	}
 
 	for _, sp := range s.BackupSchedule.Spec.Schedules {
-		if methodInBackupPolicy(sp.BackupMethod) {
-			continue
+		if !methodInBackupPolicy(sp.BackupMethod) {
+			// backup method name is not in backup policy
+			return fmt.Errorf("backup method %s is not in backup policy %s/%s",
+				sp.BackupMethod, s.BackupPolicy.Namespace, s.BackupPolicy.Name)
+		}
+		if err := s.validateConsolidation(&sp); err != nil {
+			return err
 		}
-		// backup method name is not in backup policy
-		return fmt.Errorf("backup method %s is not in backup policy %s/%s",
-			sp.BackupMethod, s.BackupPolicy.Namespace, s.BackupPolicy.Name)
+	}
+	return nil
+}
+
+// validateConsolidation validates that the backup method of the schedule policy supports the consolidation.
+func (s *Scheduler) validateConsolidation(schedulePolicy *dpv1alpha1.SchedulePolicy) error {
+	if schedulePolicy.Consolidation == nil {
+		return nil
+	}
+	method := dputils.GetBackupMethodByName(schedulePolicy.BackupMethod, s.BackupPolicy)
+	if boolptr.IsSetToTrue(method.SnapshotVolumes) || method.ActionSetName == "" {
+		return fmt.Errorf("backup method %s does not support the consolidation", schedulePolicy.BackupMethod)
+	}
+	actionSet, err := dputils.GetActionSetByName(s.RequestCtx, s.Client, method.ActionSetName)
+	if err != nil {
+		return err
+	}
+	if actionSet.Spec.BackupType != dpv1alpha1.BackupTypeIncremental ||
+		actionSet.Spec.Backup == nil || actionSet.Spec.Backup.Consolidate == nil {
+		return fmt.Errorf("backup method %s does not support the consolidation, actionSet %s should be incremental and define the consolidate action",
+			schedulePolicy.BackupMethod, actionSet.Name)
 	}
 	return nil
 }
@@ -114,21 +137,28 @@ func (s *Scheduler) handleSchedulePolicy(index int) error {
 	}
 
 	// create/delete/patch cronjob workload
-	return s.reconcileCronJob(schedulePolicy)
+	if err := s.reconcileCronJob(schedulePolicy, false); err != nil {
+		return err
+	}
+	// create/delete/patch cronjob workload for the consolidation of the incremental backups
+	return s.reconcileCronJob(schedulePolicy, true)
 }
 
-// buildCronJob builds cronjob from backup schedule.
-func (s *Scheduler) buildCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy, cronJobName string) (*batchv1.CronJob, error) {
+// buildCronJob builds cronjob from backup schedule. If @consolidation is true, the cronjob creates
+// the synthetic full backups which consolidate the incremental backups.
+func (s *Scheduler) buildCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy,
+	cronJobName string,
+	consolidation bool) (*batchv1.CronJob, error) {
 	var (
 		successfulJobsHistoryLimit int32 = 0
 		failedJobsHistoryLimit     int32 = 1
 	)
 
 	if cronJobName == "" {
-		cronJobName = GenerateCRNameByBackupSchedule(s.BackupSchedule, schedulePolicy.BackupMethod)
+		cronJobName = GenerateCRNameByBackupSchedule(s.BackupSchedule, getCronJobMethodName(schedulePolicy, consolidation))
 	}
 
-	podSpec, err := s.buildPodSpec(schedulePolicy)
+	podSpec, err := s.buildPodSpec(schedulePolicy, consolidation)
 	if err != nil {
 		return nil, err
 	}
@@ -154,9 +184,13 @@ func (s *Scheduler) buildCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy, cron
 		},
 	}
 
-	timeZone, cronExpression := BuildCronJobSchedule(schedulePolicy.CronExpression)
+	scheduleExpression := schedulePolicy.CronExpression
+	if consolidation {
+		scheduleExpression = schedulePolicy.Consolidation.CronExpression
+	}
+	timeZone, cronExpression := BuildCronJobSchedule(scheduleExpression)
 	if timeZone != nil {
-		cronjob.Spec.Schedule = schedulePolicy.CronExpression
+		cronjob.Spec.Schedule = scheduleExpression
 		cronjob.Spec.TimeZone = timeZone
 	} else {
 		cronjob.Spec.Schedule = cronExpression
@@ -170,10 +204,17 @@ func (s *Scheduler) buildCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy, cron
 	cronjob.Labels[dptypes.BackupScheduleLabelKey] = s.BackupSchedule.Name
 	cronjob.Labels[dptypes.BackupMethodLabelKey] = schedulePolicy.BackupMethod
 	cronjob.Labels[constant.AppManagedByLabelKey] = dptypes.AppName
+	if consolidation {
+		cronjob.Labels[dptypes.BackupConsolidationLabelKey] = "true"
+	}
 	return cronjob, nil
 }
 
-func (s *Scheduler) buildPodSpec(schedulePolicy *dpv1alpha1.SchedulePolicy) (*corev1.PodSpec, error) {
+func (s *Scheduler) buildPodSpec(schedulePolicy *dpv1alpha1.SchedulePolicy, consolidation bool) (*corev1.PodSpec, error) {
+	var consolidationLabel string
+	if consolidation {
+		consolidationLabel = fmt.Sprintf("\n    %s: \"true\"", dptypes.BackupConsolidationLabelKey)
+	}
 	// TODO(ldm): add backup deletionPolicy
 	createBackupCmd := fmt.Sprintf(`
 kubectl create -f - <<EOF
@@ -182,17 +223,17 @@ kind: Backup
 metadata:
   labels:
     dataprotection.kubeblocks.io/autobackup: "true"
-    dataprotection.kubeblocks.io/backup-schedule: "%s"
+    dataprotection.kubeblocks.io/backup-schedule: "%s"%s
   name: %s
   namespace: %s
 spec:
   backupPolicyName: %s
   backupMethod: %s
-  retentionPeriod: %s
+  retentionPeriod: "%s"
 EOF
-`, s.BackupSchedule.Name, s.generateBackupName(schedulePolicy), s.BackupSchedule.Namespace,
+`, s.BackupSchedule.Name, consolidationLabel, s.generateBackupName(schedulePolicy, consolidation), s.BackupSchedule.Namespace,
 		s.BackupPolicy.Name, schedulePolicy.BackupMethod,
-		schedulePolicy.RetentionPeriod)
+		getRetentionPeriod(schedulePolicy))
 
 	container := corev1.Container{
 		Name:            "backup-schedule",
@@ -214,8 +255,27 @@ EOF
 	return podSpec, nil
 }
 
+// getRetentionPeriod returns the retention period of the backups created by the schedule policy.
+func getRetentionPeriod(schedulePolicy *dpv1alpha1.SchedulePolicy) dpv1alpha1.RetentionPeriod {
+	// the backups are kept until they are not retained by the count-based retention policy,
+	// which is handled by the garbage collection controller.
+	if schedulePolicy.RetentionPolicy != nil {
+		return ""
+	}
+	return schedulePolicy.RetentionPeriod
+}
+
+// getCronJobMethodName returns the backup method name used to generate the name of the cronjob.
+func getCronJobMethodName(schedulePolicy *dpv1alpha1.SchedulePolicy, consolidation bool) string {
+	if consolidation {
+		return schedulePolicy.BackupMethod + "-consolidation"
+	}
+	return schedulePolicy.BackupMethod
+}
+
 // reconcileCronJob will create/delete/patch cronjob according to cronExpression and policy changes.
-func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy) error {
+// If @consolidation is true, it reconciles the cronjob for the consolidation of the incremental backups.
+func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy, consolidation bool) error {
 	// get cronjob from labels
 	cronJob := &batchv1.CronJob{}
 	cronJobList := &batchv1.CronJobList{}
@@ -227,12 +287,16 @@ func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy)
 		},
 	); err != nil {
 		return err
-	} else if len(cronJobList.Items) > 0 {
-		cronJob = &cronJobList.Items[0]
+	}
+	for i := range cronJobList.Items {
+		if (cronJobList.Items[i].Labels[dptypes.BackupConsolidationLabelKey] == "true") == consolidation {
+			cronJob = &cronJobList.Items[i]
+			break
+		}
 	}
 
 	// schedule is disabled, delete cronjob if exists
-	if !boolptr.IsSetToTrue(schedulePolicy.Enabled) {
+	if !boolptr.IsSetToTrue(schedulePolicy.Enabled) || (consolidation && schedulePolicy.Consolidation == nil) {
 		if len(cronJob.Name) != 0 {
 			// delete the old cronjob.
 			if err := dputils.RemoveDataProtectionFinalizer(s.Ctx, s.Client, cronJob); err != nil {
@@ -244,7 +308,7 @@ func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy)
 		return nil
 	}
 
-	cronjobProto, err := s.buildCronJob(schedulePolicy, cronJob.Name)
+	cronjobProto, err := s.buildCronJob(schedulePolicy, cronJob.Name, consolidation)
 	if err != nil {
 		return err
 	}
@@ -273,7 +337,7 @@ func (s *Scheduler) reconcileCronJob(schedulePolicy *dpv1alpha1.SchedulePolicy)
 	return s.Client.Patch(s.Ctx, cronJob, patch)
 }
 
-func (s *Scheduler) generateBackupName(schedulePolicy *dpv1alpha1.SchedulePolicy) string {
+func (s *Scheduler) generateBackupName(schedulePolicy *dpv1alpha1.SchedulePolicy, consolidation bool) string {
 	var backupNamePrefix string
 	targets := dputils.GetBackupTargets(s.BackupPolicy, dputils.GetBackupMethodByName(schedulePolicy.BackupMethod, s.BackupPolicy))
 	if len(targets) > 0 {
@@ -285,6 +349,9 @@ func (s *Scheduler) generateBackupName(schedulePolicy *dpv1alpha1.SchedulePolicy
 	if backupNamePrefix == "" {
 		backupNamePrefix = s.BackupSchedule.Name
 	}
+	if consolidation {
+		backupNamePrefix += "-synthetic"
+	}
 	return backupNamePrefix + "-$(date -u +'%Y%m%d%H%M%S')"
 }
 
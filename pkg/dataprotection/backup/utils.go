@@ -205,7 +205,11 @@ func BuildBackupPathByTarget(backup *dpv1alpha1.Backup,
 	repoPathPrefix,
 	pathPrefix,
 	targetPodName string) string {
-	baseBackupPath := BuildBaseBackupPath(backup, repoPathPrefix, pathPrefix)
+	return buildTargetBackupPath(BuildBaseBackupPath(backup, repoPathPrefix, pathPrefix), target, targetPodName)
+}
+
+// buildTargetBackupPath builds the path of the target under the base path of the backup.
+func buildTargetBackupPath(baseBackupPath string, target *dpv1alpha1.BackupTarget, targetPodName string) string {
 	if target.Name != "" {
 		baseBackupPath = filepath.Join("/", baseBackupPath, target.Name)
 	}
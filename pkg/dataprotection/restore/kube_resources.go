@@ -0,0 +1,392 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	intctrlutil "github.com/apecloud/kubeblocks/pkg/controllerutil"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils"
+	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+const (
+	kubeResourcesJobNamePrefix = "restore-kube-resources"
+	kubeResourcesContainerName = "pull"
+)
+
+var (
+	clustersGroupResource   = schema.GroupResource{Group: "apps.kubeblocks.io", Resource: "clusters"}
+	secretsGroupResource    = schema.GroupResource{Resource: "secrets"}
+	configMapsGroupResource = schema.GroupResource{Resource: "configmaps"}
+)
+
+// RestoreKubeResources restores the kubernetes resources backed up with the data. A job is created to
+// pull the resources from the backup repo, and the resources are read from the output of the job,
+// then filtered, remapped and created.
+// Only the Clusters and the Secrets and ConfigMaps referenced by them are restored. The Secrets and
+// ConfigMaps are restored before the data is prepared, and the Clusters are restored after the data
+// is prepared if restoreClusters is true, so that the Clusters start with the restored data.
+// It returns true if the resources have been restored.
+func (r *RestoreManager) RestoreKubeResources(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	restConfig *rest.Config, restoreClusters bool) (bool, error) {
+	backupSet, err := r.getKubeResourcesBackupSet(reqCtx, cli)
+	if err != nil {
+		return false, err
+	}
+	target := utils.GetBackupStatusTarget(backupSet.Backup, r.Restore.Spec.Backup.SourceTargetName)
+	if target == nil || target.Resources == nil {
+		return false, intctrlutil.NewFatalError(fmt.Sprintf(`the kubernetes resources are not backed up in backup "%s"`, backupSet.Backup.Name))
+	}
+
+	jobName := cutJobName(fmt.Sprintf("%s-%s", kubeResourcesJobNamePrefix, r.Restore.UID[:8]))
+	job, err := r.buildKubeResourcesJob(reqCtx, cli, backupSet, target, jobName)
+	if err != nil {
+		return false, err
+	}
+	jobs, err := r.CreateJobsIfNotExist(reqCtx, cli, r.Restore, []*batchv1.Job{job})
+	if err != nil {
+		return false, err
+	}
+	done, _, errMsg := utils.IsJobFinished(jobs[0])
+	switch {
+	case errMsg != "":
+		return false, intctrlutil.NewFatalError(fmt.Sprintf(`failed to pull the kubernetes resources from backup "%s": %s`,
+			backupSet.Backup.Name, errMsg))
+	case !done:
+		return false, nil
+	}
+
+	data, err := utils.GetJobOutput(reqCtx.Ctx, cli, restConfig, jobs[0], kubeResourcesContainerName)
+	if err != nil {
+		return false, err
+	}
+	if err = r.createKubeResources(reqCtx, cli, data, restoreClusters); err != nil {
+		return false, err
+	}
+	if !restoreClusters {
+		// the job is kept to restore the clusters.
+		return true, nil
+	}
+	return true, deleteRestoreJob(reqCtx, cli, BuildJobKeyForActionStatus(jobName), r.Restore.Namespace)
+}
+
+// getKubeResourcesBackupSet gets the backup set which the kubernetes resources are restored from.
+// The continuous backup does not back up the kubernetes resources, so the full backup
+// it is based on is used.
+func (r *RestoreManager) getKubeResourcesBackupSet(reqCtx intctrlutil.RequestCtx, cli client.Client) (*BackupActionSet, error) {
+	backupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, r.Restore.Spec.Backup.Name, r.Restore.Spec.Backup.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if utils.GetBackupType(backupSet.ActionSet, &backupSet.UseVolumeSnapshot) != dpv1alpha1.BackupTypeContinuous {
+		return backupSet, nil
+	}
+	restoreTime, _ := time.Parse(time.RFC3339, r.Restore.Spec.RestoreTime)
+	return r.getFullBackupActionSetForContinuous(reqCtx, cli, backupSet.Backup, metav1.NewTime(restoreTime))
+}
+
+func (r *RestoreManager) buildKubeResourcesJob(reqCtx intctrlutil.RequestCtx,
+	cli client.Client,
+	backupSet *BackupActionSet,
+	target *dpv1alpha1.BackupStatusTarget,
+	jobName string) (*batchv1.Job, error) {
+	backupRepo, err := r.prepareBackupRepo(reqCtx, cli, *backupSet)
+	if err != nil {
+		return nil, err
+	}
+	backupPath := backupSet.Backup.Status.Path
+	if target.Name != "" {
+		backupPath = filepath.Join("/", backupPath, target.Name)
+	}
+	// the resources are returned by the output of the job, instead of being written by the job.
+	script := fmt.Sprintf(`set -e
+set -o pipefail
+export PATH="$PATH:$%s"
+export DATASAFED_BACKEND_BASE_PATH="$%s"
+datasafed pull "/%s" - | %s`, dptypes.DPDatasafedBinPath, dptypes.DPBackupBasePath,
+		dptypes.KubeResourcesFileName, utils.EncodeJobOutputCmd)
+
+	runAsUser := int64(0)
+	container := corev1.Container{
+		Name:    kubeResourcesContainerName,
+		Image:   viper.GetString(constant.KBToolsImage),
+		Command: []string{"sh", "-c"},
+		Args:    []string{script},
+		Env: []corev1.EnvVar{
+			{Name: dptypes.DPBackupBasePath, Value: backupPath},
+		},
+		Resources:       r.Restore.Spec.ContainerResources,
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: boolptr.False(),
+			RunAsUser:                &runAsUser,
+		},
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&container)
+
+	podSpec := corev1.PodSpec{
+		Containers:         []corev1.Container{container},
+		ServiceAccountName: r.WorkerServiceAccount,
+		RestartPolicy:      corev1.RestartPolicyNever,
+	}
+	if err = utils.AddTolerations(&podSpec); err != nil {
+		return nil, err
+	}
+	mountPath := "/backupdata"
+	if backupRepo != nil {
+		utils.InjectDatasafed(&podSpec, backupRepo, mountPath, backupSet.Backup.Status.EncryptionConfig, "")
+	} else if pvcName := backupSet.Backup.Status.PersistentVolumeClaimName; pvcName != "" {
+		utils.InjectDatasafedWithPVC(&podSpec, pvcName, mountPath, "")
+	}
+
+	backoffLimit := defaultBackoffLimit
+	if r.Restore.Spec.BackoffLimit != nil {
+		backoffLimit = *r.Restore.Spec.BackoffLimit
+	}
+	jobLabels := BuildRestoreLabels(r.Restore.Name)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: r.Restore.Namespace,
+			Labels:    jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: jobLabels},
+				Spec:       podSpec,
+			},
+			BackoffLimit: &backoffLimit,
+		},
+	}, nil
+}
+
+// createKubeResources creates the clusters if restoreClusters is true, otherwise creates the secrets and
+// configmaps referenced by the clusters. The resources that already exist are skipped.
+func (r *RestoreManager) createKubeResources(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	data []byte, restoreClusters bool) error {
+	list := &unstructured.UnstructuredList{}
+	if err := json.Unmarshal(data, list); err != nil {
+		return intctrlutil.NewFatalError(fmt.Sprintf("failed to parse the kubernetes resources: %s", err.Error()))
+	}
+	groupResourceFor := func(obj *unstructured.Unstructured) (schema.GroupResource, error) {
+		gvk := obj.GroupVersionKind()
+		mapping, err := cli.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return schema.GroupResource{}, err
+		}
+		return mapping.Resource.GroupResource(), nil
+	}
+	objs, err := filterKubeResources(list.Items, r.Restore.Spec.Resources, groupResourceFor, restoreClusters)
+	if err != nil {
+		return err
+	}
+	for i := range objs {
+		remapKubeResource(&objs[i], r.Restore.Spec.Resources, r.Restore.Namespace)
+		if err = cli.Create(reqCtx.Ctx, &objs[i]); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				reqCtx.Log.V(1).Info("skip restoring the existing resource", "kind", objs[i].GetKind(),
+					"namespace", objs[i].GetNamespace(), "name", objs[i].GetName())
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// filterKubeResources filters the resources by the included and excluded resources, and returns the
+// clusters if restoreClusters is true, otherwise returns the secrets and configmaps referenced by the
+// clusters, which are referred to by the cluster spec or labeled with the instance name of the clusters.
+func filterKubeResources(objs []unstructured.Unstructured,
+	resources *dpv1alpha1.RestoreKubeResources,
+	groupResourceFor func(obj *unstructured.Unstructured) (schema.GroupResource, error),
+	restoreClusters bool) ([]unstructured.Unstructured, error) {
+	matches := func(obj *unstructured.Unstructured, gr schema.GroupResource, selectors []dpv1alpha1.IncludeResource) (bool, error) {
+		for _, s := range selectors {
+			if schema.ParseGroupResource(s.GroupResource) != gr {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(&s.LabelSelector)
+			if err != nil {
+				return false, intctrlutil.NewFatalError(err.Error())
+			}
+			if selector.Matches(labels.Set(obj.GetLabels())) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	var (
+		filtered   []unstructured.Unstructured
+		grs        []schema.GroupResource
+		clusters   = sets.New[string]()
+		secrets    = sets.New[string]()
+		configMaps = sets.New[string]()
+	)
+	for i := range objs {
+		obj := &objs[i]
+		gr, err := groupResourceFor(obj)
+		if err != nil {
+			return nil, err
+		}
+		if gr != clustersGroupResource && gr != secretsGroupResource && gr != configMapsGroupResource {
+			continue
+		}
+		if len(resources.IncludeResources) > 0 {
+			included, err := matches(obj, gr, resources.IncludeResources)
+			if err != nil {
+				return nil, err
+			}
+			if !included {
+				continue
+			}
+		}
+		excluded, err := matches(obj, gr, resources.ExcludeResources)
+		if err != nil {
+			return nil, err
+		}
+		if excluded {
+			continue
+		}
+		if gr == clustersGroupResource {
+			clusters.Insert(obj.GetName())
+			visitResourceRefs(obj.Object, func(gr schema.GroupResource, ref map[string]interface{}, key string) {
+				name, _ := ref[key].(string)
+				if gr == secretsGroupResource {
+					secrets.Insert(name)
+				} else {
+					configMaps.Insert(name)
+				}
+			})
+		}
+		filtered = append(filtered, *obj)
+		grs = append(grs, gr)
+	}
+
+	var selected []unstructured.Unstructured
+	for i := range filtered {
+		obj := &filtered[i]
+		switch {
+		case grs[i] == clustersGroupResource:
+			if !restoreClusters {
+				continue
+			}
+		case restoreClusters:
+			continue
+		case !clusters.Has(obj.GetLabels()[constant.AppInstanceLabelKey]) &&
+			!(grs[i] == secretsGroupResource && secrets.Has(obj.GetName())) &&
+			!(grs[i] == configMapsGroupResource && configMaps.Has(obj.GetName())):
+			continue
+		}
+		selected = append(selected, *obj)
+	}
+	return selected, nil
+}
+
+// visitResourceRefs visits the references to the secrets and configmaps in the object, such as the
+// secretRef, the secretKeyRef and the volume sources. The key is the field of the referred name.
+func visitResourceRefs(obj interface{}, visit func(gr schema.GroupResource, ref map[string]interface{}, key string)) {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		for k, v := range o {
+			if ref, ok := v.(map[string]interface{}); ok {
+				switch k {
+				case "secret":
+					if _, ok = ref["secretName"].(string); ok {
+						visit(secretsGroupResource, ref, "secretName")
+					}
+				case "secretRef", "secretKeyRef":
+					if _, ok = ref["name"].(string); ok {
+						visit(secretsGroupResource, ref, "name")
+					}
+				case "configMap", "configMapRef", "configMapKeyRef":
+					if _, ok = ref["name"].(string); ok {
+						visit(configMapsGroupResource, ref, "name")
+					}
+				}
+			}
+			visitResourceRefs(v, visit)
+		}
+	case []interface{}:
+		for i := range o {
+			visitResourceRefs(o[i], visit)
+		}
+	}
+}
+
+// remapKubeResource renames the resource and moves it to the target namespace by the mappings,
+// the references to the secrets and configmaps are renamed as well.
+func remapKubeResource(obj *unstructured.Unstructured, resources *dpv1alpha1.RestoreKubeResources, defaultNamespace string) {
+	namespace, ok := resources.NamespaceMapping[obj.GetNamespace()]
+	if !ok {
+		namespace = defaultNamespace
+	}
+	obj.SetNamespace(namespace)
+	obj.SetName(mapResourceName(obj.GetName(), resources.NameMapping))
+	objLabels := obj.GetLabels()
+	for k, v := range objLabels {
+		if newValue, ok := resources.NameMapping[v]; ok {
+			objLabels[k] = newValue
+		}
+	}
+	obj.SetLabels(objLabels)
+	visitResourceRefs(obj.Object, func(_ schema.GroupResource, ref map[string]interface{}, key string) {
+		ref[key] = mapResourceName(ref[key].(string), resources.NameMapping)
+	})
+}
+
+// mapResourceName maps the name equal to a key or prefixed with a key followed by a hyphen,
+// the longest matched key takes precedence.
+func mapResourceName(name string, nameMapping map[string]string) string {
+	if newName, ok := nameMapping[name]; ok {
+		return newName
+	}
+	var matchedKey string
+	for k := range nameMapping {
+		if strings.HasPrefix(name, k+"-") && len(k) > len(matchedKey) {
+			matchedKey = k
+		}
+	}
+	if matchedKey == "" {
+		return name
+	}
+	return nameMapping[matchedKey] + strings.TrimPrefix(name, matchedKey)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+)
+
+func newKubeResource(apiVersion, kind, namespace, name string, labels map[string]string) unstructured.Unstructured {
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetLabels(labels)
+	return obj
+}
+
+func TestFilterKubeResources(t *testing.T) {
+	groupResources := map[string]schema.GroupResource{
+		"Secret":    {Resource: "secrets"},
+		"ConfigMap": {Resource: "configmaps"},
+		"Service":   {Resource: "services"},
+		"Cluster":   {Group: "apps.kubeblocks.io", Resource: "clusters"},
+	}
+	groupResourceFor := func(obj *unstructured.Unstructured) (schema.GroupResource, error) {
+		return groupResources[obj.GetKind()], nil
+	}
+	cluster := newKubeResource("apps.kubeblocks.io/v1", "Cluster", "default", "mycluster", nil)
+	assert.NoError(t, unstructured.SetNestedSlice(cluster.Object, []interface{}{
+		map[string]interface{}{
+			"name": "mysql",
+			"tls":  true,
+			"issuer": map[string]interface{}{
+				"name":      "UserProvided",
+				"secretRef": map[string]interface{}{"name": "tls-certs"},
+			},
+			"userResourceRefs": map[string]interface{}{
+				"configMapRefs": []interface{}{
+					map[string]interface{}{
+						"name":      "user-config",
+						"configMap": map[string]interface{}{"name": "user-config"},
+					},
+				},
+			},
+		},
+	}, "spec", "componentSpecs"))
+	instanceLabels := map[string]string{constant.AppInstanceLabelKey: "mycluster"}
+	objs := []unstructured.Unstructured{
+		cluster,
+		newKubeResource("v1", "ConfigMap", "default", "mycluster-config", instanceLabels),
+		newKubeResource("v1", "ConfigMap", "default", "user-config", nil),
+		newKubeResource("v1", "ConfigMap", "default", "unrelated-config", nil),
+		newKubeResource("v1", "Service", "default", "mycluster-svc", instanceLabels),
+		newKubeResource("v1", "Secret", "default", "tls-certs", nil),
+		newKubeResource("v1", "Secret", "default", "mycluster-account-root",
+			map[string]string{constant.AppInstanceLabelKey: "mycluster", "account": "root"}),
+		newKubeResource("v1", "Secret", "default", "mycluster-account-admin",
+			map[string]string{constant.AppInstanceLabelKey: "mycluster", "account": "admin"}),
+	}
+	names := func(objs []unstructured.Unstructured) []string {
+		var result []string
+		for _, obj := range objs {
+			result = append(result, obj.GetName())
+		}
+		return result
+	}
+
+	filtered, err := filterKubeResources(objs, &dpv1alpha1.RestoreKubeResources{}, groupResourceFor, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mycluster-config", "user-config", "tls-certs",
+		"mycluster-account-root", "mycluster-account-admin"}, names(filtered))
+
+	filtered, err = filterKubeResources(objs, &dpv1alpha1.RestoreKubeResources{}, groupResourceFor, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mycluster"}, names(filtered))
+
+	resources := &dpv1alpha1.RestoreKubeResources{
+		IncludeResources: []dpv1alpha1.IncludeResource{
+			{GroupResource: "secrets"},
+			{GroupResource: "clusters.apps.kubeblocks.io"},
+		},
+		ExcludeResources: []dpv1alpha1.IncludeResource{
+			{
+				GroupResource: "secrets",
+				LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"account": "admin"}},
+			},
+		},
+	}
+	filtered, err = filterKubeResources(objs, resources, groupResourceFor, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tls-certs", "mycluster-account-root"}, names(filtered))
+
+	// the resources of the excluded cluster are not restored.
+	resources.IncludeResources = []dpv1alpha1.IncludeResource{{GroupResource: "secrets"}}
+	filtered, err = filterKubeResources(objs, resources, groupResourceFor, false)
+	assert.NoError(t, err)
+	assert.Empty(t, filtered)
+}
+
+func TestRemapKubeResource(t *testing.T) {
+	resources := &dpv1alpha1.RestoreKubeResources{
+		NamespaceMapping: map[string]string{"prod": "staging"},
+		NameMapping:      map[string]string{"mycluster": "newcluster", "mycluster-mysql": "newmysql"},
+	}
+
+	obj := newKubeResource("v1", "Secret", "prod", "mycluster-conn-credential",
+		map[string]string{constant.AppInstanceLabelKey: "mycluster", "account": "root"})
+	remapKubeResource(&obj, resources, "default")
+	assert.Equal(t, "staging", obj.GetNamespace())
+	assert.Equal(t, "newcluster-conn-credential", obj.GetName())
+	assert.Equal(t, map[string]string{constant.AppInstanceLabelKey: "newcluster", "account": "root"}, obj.GetLabels())
+
+	// the longest matched key takes precedence.
+	obj = newKubeResource("v1", "ConfigMap", "test", "mycluster-mysql-config", nil)
+	remapKubeResource(&obj, resources, "default")
+	assert.Equal(t, "default", obj.GetNamespace())
+	assert.Equal(t, "newmysql-config", obj.GetName())
+
+	// the references to the secrets and configmaps are renamed.
+	obj = newKubeResource("apps.kubeblocks.io/v1", "Cluster", "prod", "mycluster", nil)
+	assert.NoError(t, unstructured.SetNestedField(obj.Object, "mycluster-tls", "spec", "tls", "secretRef", "name"))
+	remapKubeResource(&obj, resources, "default")
+	assert.Equal(t, "newcluster", obj.GetName())
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "tls", "secretRef", "name")
+	assert.Equal(t, "newcluster-tls", name)
+
+	// the name only sharing the prefix is not mapped.
+	assert.Equal(t, "myclusterx", mapResourceName("myclusterx", resources.NameMapping))
+	assert.Equal(t, "newcluster", mapResourceName("mycluster", resources.NameMapping))
+}
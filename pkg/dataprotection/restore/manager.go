@@ -110,9 +110,12 @@ func (r *RestoreManager) BuildDifferentialBackupActionSets(reqCtx intctrlutil.Re
 // BuildIncrementalBackupActionSets builds the backupActionSets for specified incremental backup.
 func (r *RestoreManager) BuildIncrementalBackupActionSets(reqCtx intctrlutil.RequestCtx, cli client.Client, sourceBackupSet BackupActionSet) error {
 	r.SetBackupSets(sourceBackupSet)
-	if sourceBackupSet.ActionSet != nil && sourceBackupSet.ActionSet.Spec.BackupType == dpv1alpha1.BackupTypeIncremental {
+	// the synthetic full backup consolidated from an incremental backup chain has no parent.
+	parentBackupName := utils.GetParentBackupName(sourceBackupSet.Backup)
+	if sourceBackupSet.ActionSet != nil && sourceBackupSet.ActionSet.Spec.BackupType == dpv1alpha1.BackupTypeIncremental &&
+		parentBackupName != "" {
 		// get the parent BackupActionSet for incremental.
-		backupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, sourceBackupSet.Backup.Spec.ParentBackupName, sourceBackupSet.Backup.Namespace)
+		backupSet, err := r.GetBackupActionSetByNamespaced(reqCtx, cli, parentBackupName, sourceBackupSet.Backup.Namespace)
 		if err != nil || backupSet == nil {
 			return err
 		}
@@ -153,6 +156,13 @@ func (r *RestoreManager) BuildContinuousRestoreManager(reqCtx intctrlutil.Reques
 		if restoreTime.Before(startTime.Time) || restoreTime.After(stopTime.Time) {
 			return intctrlutil.NewFatalError(fmt.Sprintf(`restore time out of the range for backup "%s"`, continuousBackup.Name))
 		}
+		windows, err := getRecoverableWindows(reqCtx, cli, continuousBackup, continuousBackupSet.ActionSet)
+		if err != nil {
+			return err
+		}
+		if err = validateRestoreTime(restoreTime, continuousBackup, windows); err != nil {
+			return intctrlutil.NewFatalError(err.Error())
+		}
 		return nil
 	}
 	// check if the restore time is valid.
@@ -190,27 +200,9 @@ func (r *RestoreManager) getFullBackupActionSetForContinuous(reqCtx intctrlutil.
 		return nil, err
 	}
 
-	// sort by completed time in descending order
-	sort.Slice(backupItems, func(i, j int) bool {
-		i, j = j, i
-		return CompareWithBackupStopTime(backupItems[i], backupItems[j])
-	})
-
-	// 2. get the latest backup object
-	var latestFullBackup *dpv1alpha1.Backup
-	for _, item := range backupItems {
-		fullBackupStopTime := item.GetEndTime()
-		// latest full backup rules:
-		// 1. Full backup's stopTime must after Continuous backup's startTime.
-		//    Even if the seconds are the same, the data may not be continuous.
-		// 2. RestoreTime should after the Full backup's stopTime.
-		if fullBackupStopTime != nil &&
-			!restoreTime.Before(fullBackupStopTime) &&
-			!fullBackupStopTime.Before(continuousBackup.GetStartTime()) {
-			latestFullBackup = &item
-			break
-		}
-	}
+	// 2. get the latest backup object, the full backup's stopTime must be after the continuous backup's startTime,
+	// and the restoreTime should be after the full backup's stopTime.
+	latestFullBackup := utils.GetLatestFullBackupForContinuous(continuousBackup, backupItems, restoreTime.Time)
 	if latestFullBackup == nil {
 		return notFoundLatestFullBackup()
 	}
@@ -571,7 +563,8 @@ func (r *RestoreManager) BuildPostReadyActionJobs(reqCtx intctrlutil.RequestCtx,
 		if err != nil {
 			return nil, err
 		}
-		sort.Sort(intctrlutil.ByPodName(targetPodList.Items))
+		// sort the pods by ordinal to map them to the source target pods with the same index.
+		sort.Sort(intctrlutil.ByPodOrdinal(targetPodList.Items))
 		buildJob := func(targetPod *corev1.Pod, sourceTargetPodName string, index int) *batchv1.Job {
 			if boolptr.IsSetToTrue(actionSpec.Job.RunOnTargetPodNode) {
 				jobBuilder.resetSpecificVolumesAndMounts()
@@ -29,6 +29,9 @@ const (
 	ConditionTypeReadinessProbe          = "ReadinessProbe"
 	ConditionTypeRestorePostReady        = "PostReady"
 	ConditionTypeRestoreCheckBackupRepo  = "CheckBackupRepo"
+	ConditionTypeRestoreKubeResources    = "RestoreKubeResources"
+	ConditionTypeRestoreScheduled        = "Scheduled"
+	ConditionTypeRestoreIntegrity        = "IntegrityVerified"
 	// condition reasons
 	ReasonRestoreStarting             = "RestoreStarting"
 	ReasonRestoreCompleted            = "RestoreCompleted"
@@ -41,6 +44,9 @@ const (
 	ReasonProcessing                  = "Processing"
 	ReasonFailed                      = "Failed"
 	ReasonSucceed                     = "Succeed"
+	ReasonPending                     = "Pending"
+	ReasonClusterPending              = "ClusterPending"
+	ReasonScheduled                   = "Scheduled"
 	reasonCreateRestoreJob            = "CreateRestoreJob"
 	reasonCreateRestorePVC            = "CreateRestorePVC"
 )
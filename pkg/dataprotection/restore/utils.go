@@ -22,6 +22,7 @@ package restore
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
@@ -81,6 +82,62 @@ func SetRestoreStageCondition(restore *dpv1alpha1.Restore, stage dpv1alpha1.Rest
 	SetRestoreCondition(restore, status, conditionType, reason, message)
 }
 
+// SetRestoreKubeResourcesCondition sets restore condition which type is ConditionTypeRestoreKubeResources.
+func SetRestoreKubeResourcesCondition(restore *dpv1alpha1.Restore, reason, message string) {
+	status := metav1.ConditionFalse
+	if reason == ReasonSucceed {
+		status = metav1.ConditionTrue
+	}
+	SetRestoreCondition(restore, status, ConditionTypeRestoreKubeResources, reason, message)
+}
+
+// SetRestoreIntegrityCondition sets restore condition which type is ConditionTypeRestoreIntegrity.
+func SetRestoreIntegrityCondition(restore *dpv1alpha1.Restore, reason, message string) {
+	status := metav1.ConditionFalse
+	if reason == ReasonSucceed {
+		status = metav1.ConditionTrue
+	}
+	SetRestoreCondition(restore, status, ConditionTypeRestoreIntegrity, reason, message)
+}
+
+// SetRestoreScheduledCondition sets restore condition which type is ConditionTypeRestoreScheduled.
+func SetRestoreScheduledCondition(restore *dpv1alpha1.Restore, reason, message string) {
+	status := metav1.ConditionFalse
+	if reason == ReasonScheduled {
+		status = metav1.ConditionTrue
+	}
+	SetRestoreCondition(restore, status, ConditionTypeRestoreScheduled, reason, message)
+}
+
+// IsRestorePending checks whether the restore is waiting for the other backups and restores to finish.
+func IsRestorePending(restore *dpv1alpha1.Restore) bool {
+	condition := meta.FindStatusCondition(restore.Status.Conditions, ConditionTypeRestoreScheduled)
+	return condition != nil && condition.Reason == ReasonPending
+}
+
+// BuildBackupToVerify returns a copy of the backup which keeps only the integrity manifests of the backup data
+// read by the restore, or nil if the backup data is not covered by any integrity manifest, e.g. the backup was
+// created by an old version.
+func BuildBackupToVerify(restore *dpv1alpha1.Restore, backup *dpv1alpha1.Backup) *dpv1alpha1.Backup {
+	if backup.Status.Integrity == nil || backup.Status.Path == "" {
+		return nil
+	}
+	backupPath := filepath.Join("/", backup.Status.Path, restore.Spec.Backup.SourceTargetName)
+	var manifests []dpv1alpha1.BackupIntegrityManifest
+	for _, m := range backup.Status.Integrity.Manifests {
+		manifestPath := filepath.Join("/", m.Path)
+		if manifestPath == backupPath || strings.HasPrefix(manifestPath, backupPath+"/") {
+			manifests = append(manifests, m)
+		}
+	}
+	if len(manifests) == 0 {
+		return nil
+	}
+	backup = backup.DeepCopy()
+	backup.Status.Integrity.Manifests = manifests
+	return backup
+}
+
 func FindRestoreStatusAction(actions []dpv1alpha1.RestoreStatusAction, key string) *dpv1alpha1.RestoreStatusAction {
 	for i := range actions {
 		if actions[i].ObjectKey == key {
@@ -289,7 +346,10 @@ func cutJobName(jobName string) string {
 	return jobName
 }
 
-func FormatRestoreTimeAndValidate(restoreTimeStr string, continuousBackup *dpv1alpha1.Backup) (string, error) {
+// FormatRestoreTimeAndValidate formats the restore time in RFC3339 and validates it against the recoverable
+// windows of the continuous backup.
+func FormatRestoreTimeAndValidate(restoreTimeStr string, continuousBackup *dpv1alpha1.Backup,
+	windows []dpv1alpha1.RecoverableWindow) (string, error) {
 	if restoreTimeStr == "" {
 		return restoreTimeStr, nil
 	}
@@ -305,20 +365,57 @@ func FormatRestoreTimeAndValidate(restoreTimeStr string, continuousBackup *dpv1a
 		}
 	}
 	restoreTimeStr = restoreTime.UTC().Format(time.RFC3339)
-	// TODO: check with Recoverable time
 
 	if continuousBackup.Status.TimeRange == nil || continuousBackup.Status.TimeRange.Start.IsZero() || continuousBackup.Status.TimeRange.End.IsZero() {
 		return restoreTimeStr, fmt.Errorf("invalid timeRange of the backup")
 	}
-	if !isTimeInRange(restoreTime, continuousBackup.Status.TimeRange.Start.Time, continuousBackup.Status.TimeRange.End.Time) {
-		return restoreTimeStr, fmt.Errorf("restore-to-time is out of time range, you can view the recoverable time: \n"+
-			"\tkbcli cluster describe %s -n %s", continuousBackup.Labels[constant.AppInstanceLabelKey], continuousBackup.Namespace)
+	if err = validateRestoreTime(restoreTime, continuousBackup, windows); err != nil {
+		return restoreTimeStr, fmt.Errorf("%s, you can view the recoverable time: \n"+
+			"\tkbcli cluster describe %s -n %s", err.Error(), continuousBackup.Labels[constant.AppInstanceLabelKey], continuousBackup.Namespace)
 	}
 	return restoreTimeStr, nil
 }
 
-func isTimeInRange(t time.Time, start time.Time, end time.Time) bool {
-	return !t.Before(start) && !t.After(end)
+// validateRestoreTime checks whether the data can be restored to the restore time by the recoverable windows
+// of the continuous backup.
+func validateRestoreTime(restoreTime time.Time, continuousBackup *dpv1alpha1.Backup, windows []dpv1alpha1.RecoverableWindow) error {
+	if len(windows) == 0 {
+		return fmt.Errorf(`backup "%s" has no recoverable window, a completed full backup which stops within its log ranges is required`,
+			continuousBackup.Name)
+	}
+	if !utils.IsTimeInRecoverableWindows(windows, restoreTime) {
+		ranges := make([]string, 0, len(windows))
+		for _, window := range windows {
+			ranges = append(ranges, fmt.Sprintf("[%s, %s]", window.Start.UTC().Format(time.RFC3339),
+				window.End.UTC().Format(time.RFC3339)))
+		}
+		return fmt.Errorf(`restore-to-time "%s" is out of the recoverable windows %s of backup "%s"`,
+			restoreTime.UTC().Format(time.RFC3339), strings.Join(ranges, ", "), continuousBackup.Name)
+	}
+	return nil
+}
+
+// GetRecoverableWindows gets the recoverable windows of the continuous backup, it returns nil if the data
+// can not be restored to any point in time by the continuous backup.
+func GetRecoverableWindows(reqCtx intctrlutil.RequestCtx, cli client.Client, continuousBackup *dpv1alpha1.Backup) ([]dpv1alpha1.RecoverableWindow, error) {
+	var actionSetName string
+	if continuousBackup.Status.BackupMethod != nil {
+		actionSetName = continuousBackup.Status.BackupMethod.ActionSetName
+	}
+	actionSet, err := utils.GetActionSetByName(reqCtx, cli, actionSetName)
+	if err != nil {
+		return nil, err
+	}
+	return getRecoverableWindows(reqCtx, cli, continuousBackup, actionSet)
+}
+
+func getRecoverableWindows(reqCtx intctrlutil.RequestCtx, cli client.Client,
+	continuousBackup *dpv1alpha1.Backup, actionSet *dpv1alpha1.ActionSet) ([]dpv1alpha1.RecoverableWindow, error) {
+	backupList := &dpv1alpha1.BackupList{}
+	if err := cli.List(reqCtx.Ctx, backupList, client.InNamespace(continuousBackup.Namespace)); err != nil {
+		return nil, err
+	}
+	return utils.BuildRecoverableWindows(continuousBackup, backupList.Items, utils.IsBaseBackupRequired(actionSet)), nil
 }
 
 func GetRestoreFromBackupAnnotation(backup *dpv1alpha1.Backup, volumeRestorePolicy, restoreTime string, doReadyRestoreAfterClusterRunning bool) (string, error) {
@@ -0,0 +1,60 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package restore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+)
+
+func TestBuildBackupToVerify(t *testing.T) {
+	restore := &dpv1alpha1.Restore{}
+	backup := &dpv1alpha1.Backup{}
+	backup.Status.Path = "/ns/backup"
+	assert.Nil(t, BuildBackupToVerify(restore, backup))
+
+	backup.Status.Integrity = &dpv1alpha1.BackupIntegrity{
+		Manifests: []dpv1alpha1.BackupIntegrityManifest{
+			{Path: "/ns/backup/target-0/pod-0", Digest: "sha256:aaa"},
+			{Path: "/ns/backup/target-0/pod-1", Digest: "sha256:bbb"},
+			{Path: "/ns/backup/target-1", Digest: "sha256:ccc"},
+			{Path: "/ns/backup/target-10", Digest: "sha256:ddd"},
+		},
+	}
+	// all the manifests are verified without the source target
+	assert.Len(t, BuildBackupToVerify(restore, backup).Status.Integrity.Manifests, 4)
+
+	// only the manifests of the source target are verified
+	restore.Spec.Backup.SourceTargetName = "target-0"
+	verified := BuildBackupToVerify(restore, backup)
+	assert.Len(t, verified.Status.Integrity.Manifests, 2)
+	assert.Len(t, backup.Status.Integrity.Manifests, 4)
+
+	restore.Spec.Backup.SourceTargetName = "target-1"
+	verified = BuildBackupToVerify(restore, backup)
+	assert.Equal(t, []dpv1alpha1.BackupIntegrityManifest{{Path: "/ns/backup/target-1", Digest: "sha256:ccc"}},
+		verified.Status.Integrity.Manifests)
+
+	restore.Spec.Backup.SourceTargetName = "target-2"
+	assert.Nil(t, BuildBackupToVerify(restore, backup))
+}
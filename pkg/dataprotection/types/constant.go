@@ -35,6 +35,14 @@ const (
 	CfgKeyWorkerClusterRoleName = "WORKER_CLUSTER_ROLE_NAME"
 	// CfgDataProtectionReconcileWorkers the max reconcile workers for MaxConcurrentReconciles
 	CfgDataProtectionReconcileWorkers = "DATAPROTECTION_RECONCILE_WORKERS"
+	// CfgKeyMaxConcurrentJobs is the key of the max number of the concurrent backups and restores
+	CfgKeyMaxConcurrentJobs = "MAX_CONCURRENT_JOBS"
+	// CfgKeyMaxConcurrentJobsPerNode is the key of the max number of the concurrent backups and restores on each node
+	CfgKeyMaxConcurrentJobsPerNode = "MAX_CONCURRENT_JOBS_PER_NODE"
+	// CfgKeyMaxConcurrentJobsPerRepo is the key of the max number of the concurrent backups and restores of each BackupRepo
+	CfgKeyMaxConcurrentJobsPerRepo = "MAX_CONCURRENT_JOBS_PER_REPO"
+	// CfgKeyBandwidthLimit is the key of the bandwidth limit of datasafed when transferring the data, such as 10Mi
+	CfgKeyBandwidthLimit = "BANDWIDTH_LIMIT"
 )
 
 // config default values
@@ -62,10 +70,29 @@ const (
 	ConnectionPasswordAnnotationKey = "dataprotection.kubeblocks.io/connection-password"
 	// GeminiAcknowledgedAnnotationKey indicates whether Gemini has acknowledged the backup.
 	GeminiAcknowledgedAnnotationKey = "dataprotection.kubeblocks.io/gemini-acknowledged"
+	// BackupVerificationResultAnnotationKey records the result of the last verification of the backup, Passed or Failed.
+	BackupVerificationResultAnnotationKey = "dataprotection.kubeblocks.io/verification-result"
+	// BackupVerificationDurationAnnotationKey records the duration of the last verification of the backup.
+	BackupVerificationDurationAnnotationKey = "dataprotection.kubeblocks.io/verification-duration"
+	// BackupVerificationTimeAnnotationKey records the time when the last verification of the backup completed.
+	BackupVerificationTimeAnnotationKey = "dataprotection.kubeblocks.io/verification-time"
+	// ConsolidatedBackupsAnnotationKey records the names of the backups consolidated into the synthetic full backup,
+	// separated by commas and ordered from the full backup to the latest incremental backup.
+	ConsolidatedBackupsAnnotationKey = "dataprotection.kubeblocks.io/consolidated-backups"
+	// BackupTargetNodesAnnotationKey records the names of the nodes where the target pods of the backup run,
+	// separated by commas, which is used to limit the concurrent backups on each node.
+	BackupTargetNodesAnnotationKey = "dataprotection.kubeblocks.io/target-nodes"
+	// VerifyIntegrityAnnotationKey requests to check the backup data in the backup repo against the integrity manifests,
+	// it is removed by the controller once the check completes.
+	VerifyIntegrityAnnotationKey = "dataprotection.kubeblocks.io/verify-integrity"
 )
 
 // label keys
 const (
+	// BackupRepoNameLabelKey specifies the name of the BackupRepo used by the object.
+	BackupRepoNameLabelKey = "dataprotection.kubeblocks.io/backup-repo-name"
+	// WaitRepoPreparationLabelKey indicates the object is waiting for the BackupRepo to be prepared in its namespace.
+	WaitRepoPreparationLabelKey = "dataprotection.kubeblocks.io/wait-repo-preparation"
 	// ClusterUIDLabelKey specifies the cluster UID label key.
 	ClusterUIDLabelKey = "dataprotection.kubeblocks.io/cluster-uid"
 	// BackupNameLabelKey specifies the backup name label key.
@@ -84,6 +111,16 @@ const (
 	AutoBackupLabelKey = "dataprotection.kubeblocks.io/autobackup"
 	// BackupTargetPodLabelKey specifies the backup target pod label key.
 	BackupTargetPodLabelKey = "dataprotection.kubeblocks.io/target-pod-name"
+	// BackupVerificationLabelKey specifies the name of the BackupVerification which creates the object.
+	BackupVerificationLabelKey = "dataprotection.kubeblocks.io/backup-verification"
+	// BackupVerificationNamespaceLabelKey specifies the namespace of the BackupVerification which creates the object.
+	BackupVerificationNamespaceLabelKey = "dataprotection.kubeblocks.io/backup-verification-namespace"
+	// BackupCopySourceLabelKey specifies the name of the source backup of a backup copy.
+	BackupCopySourceLabelKey = "dataprotection.kubeblocks.io/copy-source-backup"
+	// BackupImportedFromRepoLabelKey specifies the name of the BackupRepo which the backup is imported from.
+	BackupImportedFromRepoLabelKey = "dataprotection.kubeblocks.io/imported-from-repo"
+	// BackupConsolidationLabelKey indicates the backup is a synthetic full backup consolidated from an incremental backup chain.
+	BackupConsolidationLabelKey = "dataprotection.kubeblocks.io/consolidation"
 )
 
 // env names
@@ -106,6 +143,8 @@ const (
 	DPBackupBasePath = "DP_BACKUP_BASE_PATH"
 	// DPBackupName backup CR name
 	DPBackupName = "DP_BACKUP_NAME"
+	// DPBackupNamespace backup CR namespace
+	DPBackupNamespace = "DP_BACKUP_NAMESPACE"
 	// DPParentBackupName backup CR name
 	DPParentBackupName = "DP_PARENT_BACKUP_NAME"
 	// DPTTL backup time to live, reference the backup.spec.retentionPeriod
@@ -122,9 +161,21 @@ const (
 	DPBackupStopTime = "DP_BACKUP_STOP_TIME" // backup stop time
 	// DPDatasafedBinPath the path containing the datasafed binary
 	DPDatasafedBinPath = "DP_DATASAFED_BIN_PATH"
+	// DPConsolidateBackupNames the names of the backups to consolidate, from the full backup to the latest incremental backup
+	DPConsolidateBackupNames = "DP_CONSOLIDATE_BACKUP_NAMES"
+	// DPConsolidateBackupPaths the paths of the backups to consolidate, in the same order as DPConsolidateBackupNames
+	DPConsolidateBackupPaths = "DP_CONSOLIDATE_BACKUP_PATHS"
+	// DPIntegrityManifests the integrity manifests to verify, one manifest per line in the format of "<digest> <path>"
+	DPIntegrityManifests = "DP_INTEGRITY_MANIFESTS"
+	// DPIntegrityChecksumsFile the file recording the size and the SHA-256 checksum of each file pushed to the backup path
+	DPIntegrityChecksumsFile = "DP_INTEGRITY_CHECKSUMS_FILE"
+	// DPBackupMetadata the metadata of the backup written into the backup repo when the backup data is completed
+	DPBackupMetadata = "DP_BACKUP_METADATA"
 
 	// NOTE: do not add 'DP_' prefix to the value of the following constants, they are the datasafed built-in environment.
 
+	// DPDatasafedBackendBasePath specifies the base path of the files in the backup repo
+	DPDatasafedBackendBasePath = "DATASAFED_BACKEND_BASE_PATH"
 	// DPDatasafedLocalBackendPath force datasafed to use local backend with the path
 	DPDatasafedLocalBackendPath = "DATASAFED_LOCAL_BACKEND_PATH"
 	// DPDatasafedKopiaRepoRoot specifies the root of the Kopia repository
@@ -133,6 +184,9 @@ const (
 	DPDatasafedEncryptionAlgorithm = "DATASAFED_ENCRYPTION_ALGORITHM"
 	// DPDatasafedEncryptionPassPhrase specifies the encryption key
 	DPDatasafedEncryptionPassPhrase = "DATASAFED_ENCRYPTION_PASS_PHRASE"
+	// DPDatasafedBandwidthLimit specifies the bandwidth limit per second when transferring the data,
+	// it is honored by the datasafed proxy installed by the dpchecksum tool.
+	DPDatasafedBandwidthLimit = "DATASAFED_BW_LIMIT"
 
 	DPArchiveInterval      = "DP_ARCHIVE_INTERVAL"
 	DPContinuousTTLSeconds = "DP_TTL_SECONDS"
@@ -143,6 +197,15 @@ const (
 	RestoreKind            = "Restore"
 	DataprotectionAPIGroup = "dataprotection.kubeblocks.io"
 	KopiaRepoFolderName    = "kopia"
+	// KubeResourcesFileName is the name of the file storing the backed up kubernetes resources
+	// under the backup path of the target.
+	KubeResourcesFileName = "kube-resources.json"
+	// BackupMetadataFileName is the name of the file storing the metadata of the backup under the backup path
+	// of the target, which is used to import the backup from the backup repo.
+	BackupMetadataFileName = "backup-metadata.json"
+	// IntegrityManifestFileName is the name of the file storing the size and the SHA-256 checksum of each file
+	// under the backup path of the target.
+	IntegrityManifestFileName = "kubeblocks-manifest.sha256"
 )
 
 const (
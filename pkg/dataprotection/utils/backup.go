@@ -20,7 +20,14 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package utils
 
 import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
 	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
 	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
 	"github.com/apecloud/kubeblocks/pkg/dataprotection/utils/boolptr"
 )
@@ -53,3 +60,177 @@ func GetBackupMethodsFromBackupPolicy(backupPolicyList *dpv1alpha1.BackupPolicyL
 	}
 	return defaultBackupMethod, backupMethodsMap
 }
+
+// GetParentBackupName returns the parent backup name of the incremental or differential backup.
+// The parent recorded in the status by the controller when the backup is re-parented to a synthetic
+// full backup takes precedence over the one in the spec.
+func GetParentBackupName(backup *dpv1alpha1.Backup) string {
+	if backup.Status.ParentBackupName != "" {
+		return backup.Status.ParentBackupName
+	}
+	return backup.Spec.ParentBackupName
+}
+
+// GetBaseFullBackupForContinuous returns the earliest completed full backup which stops after the start time
+// of the continuous backup, the point-in-time recovery to any time after it depends on the full backup.
+func GetBaseFullBackupForContinuous(continuousBackup *dpv1alpha1.Backup, backups []dpv1alpha1.Backup) *dpv1alpha1.Backup {
+	var base *dpv1alpha1.Backup
+	for _, item := range getFullBackupCandidatesForContinuous(continuousBackup, backups) {
+		if base == nil || item.GetEndTime().Before(base.GetEndTime()) {
+			base = item
+		}
+	}
+	return base
+}
+
+// GetLatestFullBackupForContinuous returns the latest completed full backup which stops between the start time
+// of the continuous backup and the restore time, it is the base backup used to restore the continuous backup
+// to the restore time.
+func GetLatestFullBackupForContinuous(continuousBackup *dpv1alpha1.Backup, backups []dpv1alpha1.Backup, restoreTime time.Time) *dpv1alpha1.Backup {
+	var latest *dpv1alpha1.Backup
+	for _, item := range getFullBackupCandidatesForContinuous(continuousBackup, backups) {
+		stopTime := item.GetEndTime()
+		if restoreTime.Before(stopTime.Time) {
+			continue
+		}
+		// the backup with the larger name is preferred if the stop times are equal
+		if latest == nil || latest.GetEndTime().Before(stopTime) ||
+			(latest.GetEndTime().Equal(stopTime) && latest.Name < item.Name) {
+			latest = item
+		}
+	}
+	return latest
+}
+
+// getFullBackupCandidatesForContinuous returns the completed full backups of the same target as the continuous
+// backup, which stop after the start time of the continuous backup.
+func getFullBackupCandidatesForContinuous(continuousBackup *dpv1alpha1.Backup, backups []dpv1alpha1.Backup) []*dpv1alpha1.Backup {
+	startTime := continuousBackup.GetStartTime()
+	if startTime.IsZero() {
+		return nil
+	}
+	matchingLabels := map[string]string{}
+	for _, key := range []string{dptypes.ClusterUIDLabelKey, constant.AppInstanceLabelKey, constant.KBAppComponentLabelKey} {
+		if v := continuousBackup.Labels[key]; v != "" {
+			matchingLabels[key] = v
+		}
+	}
+	var candidates []*dpv1alpha1.Backup
+	for i := range backups {
+		item := &backups[i]
+		if item.Labels[dptypes.BackupTypeLabelKey] != string(dpv1alpha1.BackupTypeFull) ||
+			item.Status.Phase != dpv1alpha1.BackupPhaseCompleted {
+			continue
+		}
+		if len(matchingLabels) == 0 && item.Spec.BackupPolicyName != continuousBackup.Spec.BackupPolicyName {
+			continue
+		}
+		if !labels.SelectorFromSet(matchingLabels).Matches(labels.Set(item.Labels)) {
+			continue
+		}
+		stopTime := item.GetEndTime()
+		if stopTime == nil || stopTime.Before(startTime) {
+			continue
+		}
+		candidates = append(candidates, item)
+	}
+	return candidates
+}
+
+// IsBaseBackupRequired checks whether the continuous backup taken by the ActionSet requires a full backup
+// to restore. It is required by default.
+func IsBaseBackupRequired(actionSet *dpv1alpha1.ActionSet) bool {
+	if actionSet == nil || actionSet.Spec.Restore == nil {
+		return true
+	}
+	return !boolptr.IsSetToFalse(actionSet.Spec.Restore.BaseBackupRequired)
+}
+
+// BuildRecoverableWindows builds the recoverable windows of the continuous backup, one for each log range
+// split by the gaps, the backups are the candidates of the base full backups. The log ranges without
+// a base full backup have no recoverable window.
+func BuildRecoverableWindows(continuousBackup *dpv1alpha1.Backup,
+	backups []dpv1alpha1.Backup, baseBackupRequired bool) []dpv1alpha1.RecoverableWindow {
+	var (
+		windows    []dpv1alpha1.RecoverableWindow
+		candidates []*dpv1alpha1.Backup
+	)
+	if baseBackupRequired {
+		candidates = getFullBackupCandidatesForContinuous(continuousBackup, backups)
+	}
+	for _, lr := range splitTimeRangeByGaps(continuousBackup.Status.TimeRange) {
+		window := dpv1alpha1.RecoverableWindow{
+			ContinuousBackupName: continuousBackup.Name,
+			Start:                lr.start.DeepCopy(),
+			End:                  lr.end.DeepCopy(),
+		}
+		if baseBackupRequired {
+			// the logs can only be applied to the full backup which stops within the log range.
+			var base *dpv1alpha1.Backup
+			for _, item := range candidates {
+				stopTime := item.GetEndTime()
+				if stopTime.Before(&lr.start) || lr.end.Before(stopTime) {
+					continue
+				}
+				if base == nil || stopTime.Before(base.GetEndTime()) {
+					base = item
+				}
+			}
+			if base == nil {
+				continue
+			}
+			window.BaseBackupName = base.Name
+			window.Start = base.GetEndTime().DeepCopy()
+		}
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// logRange is a time range of the continuous backup without gaps.
+type logRange struct {
+	start, end metav1.Time
+}
+
+// splitTimeRangeByGaps splits the time range of the continuous backup into the log ranges without gaps.
+func splitTimeRangeByGaps(timeRange *dpv1alpha1.BackupTimeRange) []logRange {
+	if timeRange == nil || timeRange.Start.IsZero() || timeRange.End.IsZero() || timeRange.End.Before(timeRange.Start) {
+		return nil
+	}
+	gaps := make([]dpv1alpha1.BackupTimeGap, len(timeRange.Gaps))
+	copy(gaps, timeRange.Gaps)
+	sort.Slice(gaps, func(i, j int) bool {
+		return gaps[i].Start.Before(&gaps[j].Start)
+	})
+	var logRanges []logRange
+	start := *timeRange.Start
+	for _, gap := range gaps {
+		if !gap.End.After(start.Time) {
+			continue
+		}
+		if gap.Start.After(timeRange.End.Time) {
+			break
+		}
+		if gap.Start.After(start.Time) {
+			logRanges = append(logRanges, logRange{start: start, end: gap.Start})
+		}
+		start = gap.End
+	}
+	if !start.After(timeRange.End.Time) {
+		logRanges = append(logRanges, logRange{start: start, end: *timeRange.End})
+	}
+	return logRanges
+}
+
+// IsTimeInRecoverableWindows checks whether the data can be restored to the time by any of the recoverable windows.
+func IsTimeInRecoverableWindows(windows []dpv1alpha1.RecoverableWindow, t time.Time) bool {
+	for _, window := range windows {
+		if window.Start.IsZero() || window.End.IsZero() {
+			continue
+		}
+		if !t.Before(window.Start.Time) && !t.After(window.End.Time) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	"github.com/apecloud/kubeblocks/pkg/constant"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+var testBackupBaseTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestBackup(name string, backupType dpv1alpha1.BackupType, start, end time.Duration) dpv1alpha1.Backup {
+	return dpv1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				dptypes.BackupTypeLabelKey:   string(backupType),
+				constant.AppInstanceLabelKey: "mycluster",
+			},
+		},
+		Status: dpv1alpha1.BackupStatus{
+			Phase: dpv1alpha1.BackupPhaseCompleted,
+			TimeRange: &dpv1alpha1.BackupTimeRange{
+				Start: &metav1.Time{Time: testBackupBaseTime.Add(start)},
+				End:   &metav1.Time{Time: testBackupBaseTime.Add(end)},
+			},
+		},
+	}
+}
+
+func TestBuildRecoverableWindows(t *testing.T) {
+	base := testBackupBaseTime
+	newBackup := newTestBackup
+	continuous := newBackup("continuous", dpv1alpha1.BackupTypeContinuous, 2*time.Hour, 10*time.Hour)
+
+	// the full backups stopped before the continuous backup or after it are not the base backup.
+	backups := []dpv1alpha1.Backup{
+		newBackup("full-before", dpv1alpha1.BackupTypeFull, 0, time.Hour),
+		newBackup("full-after", dpv1alpha1.BackupTypeFull, 10*time.Hour, 11*time.Hour),
+	}
+	assert.Empty(t, BuildRecoverableWindows(&continuous, backups, true))
+
+	// the continuous backup which does not require a base backup is recoverable within its time range.
+	windows := BuildRecoverableWindows(&continuous, backups, false)
+	assert.Len(t, windows, 1)
+	assert.Equal(t, "", windows[0].BaseBackupName)
+	assert.True(t, windows[0].Start.Time.Equal(base.Add(2*time.Hour)))
+
+	// the earliest full backup stopped within the time range starts the window.
+	backups = append(backups,
+		newBackup("full-1", dpv1alpha1.BackupTypeFull, 3*time.Hour, 4*time.Hour),
+		newBackup("full-2", dpv1alpha1.BackupTypeFull, 5*time.Hour, 6*time.Hour))
+	windows = BuildRecoverableWindows(&continuous, backups, true)
+	assert.Len(t, windows, 1)
+	assert.Equal(t, "continuous", windows[0].ContinuousBackupName)
+	assert.Equal(t, "full-1", windows[0].BaseBackupName)
+	assert.True(t, windows[0].Start.Time.Equal(base.Add(4*time.Hour)))
+	assert.True(t, windows[0].End.Time.Equal(base.Add(10*time.Hour)))
+
+	assert.False(t, IsTimeInRecoverableWindows(windows, base.Add(3*time.Hour)))
+	assert.True(t, IsTimeInRecoverableWindows(windows, base.Add(4*time.Hour)))
+	assert.True(t, IsTimeInRecoverableWindows(windows, base.Add(10*time.Hour)))
+	assert.False(t, IsTimeInRecoverableWindows(windows, base.Add(11*time.Hour)))
+	assert.False(t, IsTimeInRecoverableWindows(nil, base.Add(5*time.Hour)))
+}
+
+func TestBuildRecoverableWindowsWithGaps(t *testing.T) {
+	base := testBackupBaseTime
+	newGap := func(start, end time.Duration) dpv1alpha1.BackupTimeGap {
+		return dpv1alpha1.BackupTimeGap{
+			Start: metav1.Time{Time: base.Add(start)},
+			End:   metav1.Time{Time: base.Add(end)},
+		}
+	}
+	continuous := newTestBackup("continuous", dpv1alpha1.BackupTypeContinuous, 2*time.Hour, 20*time.Hour)
+	// the log ranges are [2h, 5h], [7h, 12h] and [13h, 20h], the gaps out of the time range are ignored.
+	continuous.Status.TimeRange.Gaps = []dpv1alpha1.BackupTimeGap{
+		newGap(12*time.Hour, 13*time.Hour),
+		newGap(5*time.Hour, 7*time.Hour),
+		newGap(21*time.Hour, 22*time.Hour),
+	}
+	backups := []dpv1alpha1.Backup{
+		newTestBackup("full-1", dpv1alpha1.BackupTypeFull, 3*time.Hour, 4*time.Hour),
+		// stops within the gap, the logs after it are missing.
+		newTestBackup("full-2", dpv1alpha1.BackupTypeFull, 5*time.Hour, 6*time.Hour),
+		newTestBackup("full-3", dpv1alpha1.BackupTypeFull, 14*time.Hour, 15*time.Hour),
+		newTestBackup("full-4", dpv1alpha1.BackupTypeFull, 16*time.Hour, 17*time.Hour),
+	}
+
+	// the log range without a full backup has no recoverable window.
+	windows := BuildRecoverableWindows(&continuous, backups, true)
+	assert.Len(t, windows, 2)
+	assert.Equal(t, "full-1", windows[0].BaseBackupName)
+	assert.True(t, windows[0].Start.Time.Equal(base.Add(4*time.Hour)))
+	assert.True(t, windows[0].End.Time.Equal(base.Add(5*time.Hour)))
+	assert.Equal(t, "full-3", windows[1].BaseBackupName)
+	assert.True(t, windows[1].Start.Time.Equal(base.Add(15*time.Hour)))
+	assert.True(t, windows[1].End.Time.Equal(base.Add(20*time.Hour)))
+
+	assert.True(t, IsTimeInRecoverableWindows(windows, base.Add(5*time.Hour)))
+	assert.False(t, IsTimeInRecoverableWindows(windows, base.Add(6*time.Hour)))
+	assert.False(t, IsTimeInRecoverableWindows(windows, base.Add(10*time.Hour)))
+	assert.True(t, IsTimeInRecoverableWindows(windows, base.Add(18*time.Hour)))
+
+	// each log range is a window if no base backup is required.
+	windows = BuildRecoverableWindows(&continuous, backups, false)
+	assert.Len(t, windows, 3)
+	assert.True(t, windows[1].Start.Time.Equal(base.Add(7*time.Hour)))
+	assert.True(t, windows[1].End.Time.Equal(base.Add(12*time.Hour)))
+}
+
+func TestGetLatestFullBackupForContinuous(t *testing.T) {
+	continuous := newTestBackup("continuous", dpv1alpha1.BackupTypeContinuous, 2*time.Hour, 10*time.Hour)
+	backups := []dpv1alpha1.Backup{
+		newTestBackup("full-before", dpv1alpha1.BackupTypeFull, 0, time.Hour),
+		newTestBackup("full-1", dpv1alpha1.BackupTypeFull, 3*time.Hour, 4*time.Hour),
+		newTestBackup("full-2", dpv1alpha1.BackupTypeFull, 5*time.Hour, 6*time.Hour),
+		newTestBackup("full-3", dpv1alpha1.BackupTypeFull, 5*time.Hour, 6*time.Hour),
+	}
+	backups[3].Labels[constant.AppInstanceLabelKey] = "othercluster"
+
+	// no full backup stopped before the restore time within the time range of the continuous backup.
+	assert.Nil(t, GetLatestFullBackupForContinuous(&continuous, backups, testBackupBaseTime.Add(3*time.Hour)))
+
+	// the latest full backup stopped before the restore time is the base backup of the restore.
+	latest := GetLatestFullBackupForContinuous(&continuous, backups, testBackupBaseTime.Add(5*time.Hour))
+	assert.NotNil(t, latest)
+	assert.Equal(t, "full-1", latest.Name)
+	latest = GetLatestFullBackupForContinuous(&continuous, backups, testBackupBaseTime.Add(8*time.Hour))
+	assert.NotNil(t, latest)
+	assert.Equal(t, "full-2", latest.Name)
+
+	// the earliest one is the base backup of the recoverable window.
+	assert.Equal(t, "full-1", GetBaseFullBackupForContinuous(&continuous, backups).Name)
+}
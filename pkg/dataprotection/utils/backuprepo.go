@@ -32,10 +32,17 @@ import (
 )
 
 const (
-	datasafedImageEnv        = "DATASAFED_IMAGE"
-	defaultDatasafedImage    = "apecloud/datasafed:latest"
-	datasafedBinMountPath    = "/bin/datasafed"
-	datasafedConfigMountPath = "/etc/datasafed"
+	datasafedImageEnv         = "DATASAFED_IMAGE"
+	defaultDatasafedImage     = "apecloud/datasafed:latest"
+	datasafedBinMountPath     = "/bin/datasafed"
+	datasafedConfigMountPath  = "/etc/datasafed"
+	datasafedInstallerName    = "dp-copy-datasafed"
+	datasafedBinVolumeName    = "dp-datasafed-bin"
+	checksumToolInstallerName = "dp-copy-checksum-tool"
+	checksumToolName          = "dpchecksum"
+	// integrityChecksumsFileName is the name of the file in the datasafed bin path recording the checksums
+	// of the backup data, which is written by the datasafed proxy injected by InjectIntegrityRecorder.
+	integrityChecksumsFileName = "checksums"
 )
 
 func InjectDatasafed(podSpec *corev1.PodSpec, repo *dpv1alpha1.BackupRepo, repoVolumeMountPath string,
@@ -125,7 +132,7 @@ func InjectDatasafedWithConfig(podSpec *corev1.PodSpec, configSecretName string,
 }
 
 func injectDatasafedInstaller(podSpec *corev1.PodSpec) {
-	sharedVolumeName := "dp-datasafed-bin"
+	sharedVolumeName := datasafedBinVolumeName
 	sharedVolume := corev1.Volume{
 		Name: sharedVolumeName,
 		VolumeSource: corev1.VolumeSource{
@@ -147,7 +154,7 @@ func injectDatasafedInstaller(podSpec *corev1.PodSpec) {
 		datasafedImage = defaultDatasafedImage
 	}
 	initContainer := corev1.Container{
-		Name:            "dp-copy-datasafed",
+		Name:            datasafedInstallerName,
 		Image:           datasafedImage,
 		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
 		Command:         []string{"/bin/sh", "-c", fmt.Sprintf("/scripts/install-datasafed.sh %s", datasafedBinMountPath)},
@@ -156,6 +163,47 @@ func injectDatasafedInstaller(podSpec *corev1.PodSpec) {
 	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&initContainer)
 	podSpec.InitContainers = append(podSpec.InitContainers, initContainer)
 	injectElements(podSpec, toSlice(sharedVolume), toSlice(sharedVolumeMount), toSlice(env))
+	injectBandwidthLimitEnv(podSpec)
+}
+
+// injectBandwidthLimitEnv limits the bandwidth of datasafed when transferring the data.
+// The data is throttled by the datasafed proxy of the dpchecksum tool, so that the tool is injected as well.
+func injectBandwidthLimitEnv(podSpec *corev1.PodSpec) {
+	bandwidthLimit := viper.GetString(dptypes.CfgKeyBandwidthLimit)
+	if bandwidthLimit == "" {
+		return
+	}
+	env := corev1.EnvVar{
+		Name:  dptypes.DPDatasafedBandwidthLimit,
+		Value: bandwidthLimit,
+	}
+	injectElements(podSpec, nil, nil, toSlice(env))
+	InjectChecksumTool(podSpec)
+}
+
+// InjectChecksumTool injects the dpchecksum tool into the datasafed bin path of the pod,
+// it streams the data by datasafed and checksums the data as it is streamed. The tool is also
+// installed as the proxy of datasafed, which throttles the data streamed by datasafed.
+// It must be called after the datasafed is injected.
+func InjectChecksumTool(podSpec *corev1.PodSpec) {
+	for _, c := range podSpec.InitContainers {
+		if c.Name == checksumToolInstallerName {
+			return
+		}
+	}
+	// copy the dpchecksum binary from the tools image to the shared volume
+	initContainer := corev1.Container{
+		Name:            checksumToolInstallerName,
+		Image:           viper.GetString(constant.KBToolsImage),
+		ImagePullPolicy: corev1.PullPolicy(viper.GetString(constant.KBImagePullPolicy)),
+		Command:         []string{"/bin/" + checksumToolName, "install", datasafedBinMountPath},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      datasafedBinVolumeName,
+			MountPath: datasafedBinMountPath,
+		}},
+	}
+	intctrlutil.InjectZeroResourcesLimitsIfEmpty(&initContainer)
+	podSpec.InitContainers = append(podSpec.InitContainers, initContainer)
 }
 
 func injectElements(podSpec *corev1.PodSpec, volumes []corev1.Volume, volumeMounts []corev1.VolumeMount, envs []corev1.EnvVar) {
@@ -0,0 +1,51 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+func TestInjectBandwidthLimitEnv(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backupdata"}}}
+	injectDatasafedInstaller(podSpec)
+	assert.Len(t, podSpec.InitContainers, 1)
+	assert.Empty(t, podSpec.Containers[0].Env[1:])
+
+	viper.Set(dptypes.CfgKeyBandwidthLimit, "10Mi")
+	defer viper.Set(dptypes.CfgKeyBandwidthLimit, "")
+	podSpec = &corev1.PodSpec{Containers: []corev1.Container{{Name: "backupdata"}}}
+	injectDatasafedInstaller(podSpec)
+	// the datasafed proxy of the checksum tool is installed to throttle the data.
+	assert.Len(t, podSpec.InitContainers, 2)
+	assert.Equal(t, checksumToolInstallerName, podSpec.InitContainers[1].Name)
+	assert.Equal(t, []string{"/bin/dpchecksum", "install", datasafedBinMountPath}, podSpec.InitContainers[1].Command)
+	assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{Name: dptypes.DPDatasafedBandwidthLimit, Value: "10Mi"})
+
+	// the checksum tool is installed only once.
+	InjectChecksumTool(podSpec)
+	assert.Len(t, podSpec.InitContainers, 2)
+}
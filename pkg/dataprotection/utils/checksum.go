@@ -0,0 +1,72 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// ChecksumWriter calculates the size and the SHA-256 checksum of the data written to it,
+// it is used to checksum the data as it is streamed, so that the data is read only once.
+type ChecksumWriter struct {
+	hash hash.Hash
+	size int64
+}
+
+func NewChecksumWriter() *ChecksumWriter {
+	return &ChecksumWriter{hash: sha256.New()}
+}
+
+func (w *ChecksumWriter) Write(p []byte) (int, error) {
+	n, err := w.hash.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Size returns the size of the data written.
+func (w *ChecksumWriter) Size() int64 {
+	return w.size
+}
+
+// Checksum returns the hex encoded SHA-256 checksum of the data written.
+func (w *ChecksumWriter) Checksum() string {
+	return hex.EncodeToString(w.hash.Sum(nil))
+}
+
+// Digest returns the SHA-256 digest of the data written, in the format of "sha256:<hex>".
+func (w *ChecksumWriter) Digest() string {
+	return fmt.Sprintf("sha256:%s", w.Checksum())
+}
+
+// CopyWithChecksum copies the data from src to dst, and returns the checksum of the copied data.
+// If dst is nil, the data is only checksummed.
+func CopyWithChecksum(dst io.Writer, src io.Reader) (*ChecksumWriter, error) {
+	w := NewChecksumWriter()
+	var out io.Writer = w
+	if dst != nil {
+		out = io.MultiWriter(dst, w)
+	}
+	_, err := io.Copy(out, src)
+	return w, err
+}
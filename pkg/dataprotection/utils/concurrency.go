@@ -0,0 +1,238 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+	viper "github.com/apecloud/kubeblocks/pkg/viperx"
+)
+
+// ConcurrencyLimits defines the max numbers of the concurrent backups and restores, 0 means no limit.
+type ConcurrencyLimits struct {
+	Global  int
+	PerNode int
+	PerRepo int
+}
+
+// GetConcurrencyLimits returns the concurrency limits configured for the operator.
+func GetConcurrencyLimits() ConcurrencyLimits {
+	return ConcurrencyLimits{
+		Global:  viper.GetInt(dptypes.CfgKeyMaxConcurrentJobs),
+		PerNode: viper.GetInt(dptypes.CfgKeyMaxConcurrentJobsPerNode),
+		PerRepo: viper.GetInt(dptypes.CfgKeyMaxConcurrentJobsPerRepo),
+	}
+}
+
+// Enabled checks whether any of the limits is set.
+func (l ConcurrencyLimits) Enabled() bool {
+	return l.Global > 0 || l.PerNode > 0 || l.PerRepo > 0
+}
+
+// Workload is a backup or restore running the jobs which are limited by the ConcurrencyLimits.
+type Workload struct {
+	Kind              string
+	Namespace         string
+	Name              string
+	CreationTimestamp metav1.Time
+	// RepoName is the name of the BackupRepo which the workload reads from or writes to.
+	RepoName string
+	// NodeNames are the names of the nodes where the data of the workload is located.
+	NodeNames []string
+}
+
+// Key returns the unique key of the workload.
+func (w *Workload) Key() string {
+	return w.Kind + "/" + w.Namespace + "/" + w.Name
+}
+
+type workloadUsage struct {
+	total int
+	repos map[string]int
+	nodes map[string]int
+}
+
+func newWorkloadUsage(running []Workload) *workloadUsage {
+	usage := &workloadUsage{repos: map[string]int{}, nodes: map[string]int{}}
+	for i := range running {
+		usage.add(&running[i])
+	}
+	return usage
+}
+
+func (u *workloadUsage) add(w *Workload) {
+	u.total++
+	if w.RepoName != "" {
+		u.repos[w.RepoName]++
+	}
+	for _, node := range w.NodeNames {
+		u.nodes[node]++
+	}
+}
+
+// exceeded returns the message of the limit which the workload exceeds, or empty if it is not limited.
+func (u *workloadUsage) exceeded(limits ConcurrencyLimits, w *Workload) string {
+	if limits.Global > 0 && u.total >= limits.Global {
+		return fmt.Sprintf("the number of the concurrent backups and restores reaches the limit %d", limits.Global)
+	}
+	if limits.PerRepo > 0 && w.RepoName != "" && u.repos[w.RepoName] >= limits.PerRepo {
+		return fmt.Sprintf("the number of the concurrent backups and restores of backup repo %s reaches the limit %d",
+			w.RepoName, limits.PerRepo)
+	}
+	if limits.PerNode > 0 {
+		for _, node := range w.NodeNames {
+			if u.nodes[node] >= limits.PerNode {
+				return fmt.Sprintf("the number of the concurrent backups and restores on node %s reaches the limit %d",
+					node, limits.PerNode)
+			}
+		}
+	}
+	return ""
+}
+
+// SortWorkloadsByFairQueue sorts the pending workloads in a fair queue across the namespaces:
+// the workloads of each namespace are served in the order of creation, and the namespaces are
+// served in turn, the namespace with fewer running workloads goes first.
+func SortWorkloadsByFairQueue(running, pending []Workload) {
+	runningInNamespace := map[string]int{}
+	for _, w := range running {
+		runningInNamespace[w.Namespace]++
+	}
+	lessByCreation := func(a, b *Workload) bool {
+		if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+			return a.CreationTimestamp.Before(&b.CreationTimestamp)
+		}
+		return a.Key() < b.Key()
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return lessByCreation(&pending[i], &pending[j])
+	})
+	turns := make([]int, len(pending))
+	for i := range pending {
+		turns[i] = runningInNamespace[pending[i].Namespace]
+		runningInNamespace[pending[i].Namespace]++
+	}
+	indexes := make([]int, len(pending))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return turns[indexes[i]] < turns[indexes[j]]
+	})
+	sorted := make([]Workload, len(pending))
+	for i, index := range indexes {
+		sorted[i] = pending[index]
+	}
+	copy(pending, sorted)
+}
+
+// AdmitWorkload checks whether the workload can be started under the limits. The pending workloads
+// are admitted in the order of the fair queue, and the workloads ahead of the candidate take the
+// free slots first, unless they are limited by their own BackupRepos or nodes.
+// It returns empty if the workload is admitted, otherwise the reason why the workload has to wait.
+func AdmitWorkload(limits ConcurrencyLimits, running, pending []Workload, candidate Workload) string {
+	if !limits.Enabled() {
+		return ""
+	}
+	queue := make([]Workload, 0, len(pending)+1)
+	for _, w := range pending {
+		if w.Key() != candidate.Key() {
+			queue = append(queue, w)
+		}
+	}
+	queue = append(queue, candidate)
+	SortWorkloadsByFairQueue(running, queue)
+
+	usage := newWorkloadUsage(running)
+	for i := range queue {
+		w := &queue[i]
+		message := usage.exceeded(limits, w)
+		if w.Key() == candidate.Key() {
+			return message
+		}
+		if message == "" {
+			usage.add(w)
+		}
+	}
+	return ""
+}
+
+// Workloads are the backups and restores listed from the cache.
+type Workloads struct {
+	Running []Workload
+	Pending []Workload
+	// NotStarted are the keys of the workloads which are not started yet, including the pending ones.
+	NotStarted sets.Set[string]
+}
+
+// WorkloadAdmitter admits the workloads one at a time, so that a free slot is not taken by
+// several workloads reconciled concurrently. The admitted workloads are counted as running
+// until the cache observes that they are started, as the cache lags behind the status updates.
+type WorkloadAdmitter struct {
+	mu       sync.Mutex
+	admitted map[string]Workload
+}
+
+func NewWorkloadAdmitter() *WorkloadAdmitter {
+	return &WorkloadAdmitter{admitted: map[string]Workload{}}
+}
+
+// Admit lists the workloads and checks whether the candidate can be started under the limits,
+// the slot is reserved for the candidate if it is admitted.
+// It returns empty if the workload is admitted, otherwise the reason why the workload has to wait.
+func (a *WorkloadAdmitter) Admit(limits ConcurrencyLimits,
+	listWorkloads func() (*Workloads, error),
+	candidate Workload) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.admitted[candidate.Key()]; ok {
+		return "", nil
+	}
+	workloads, err := listWorkloads()
+	if err != nil {
+		return "", err
+	}
+	running := workloads.Running
+	for key, w := range a.admitted {
+		if !workloads.NotStarted.Has(key) {
+			// the admitted workload is observed started, finished or deleted.
+			delete(a.admitted, key)
+			continue
+		}
+		running = append(running, w)
+	}
+	var pending []Workload
+	for _, w := range workloads.Pending {
+		if _, ok := a.admitted[w.Key()]; !ok {
+			pending = append(pending, w)
+		}
+	}
+	message := AdmitWorkload(limits, running, pending, candidate)
+	if message == "" {
+		a.admitted[candidate.Key()] = candidate
+	}
+	return message, nil
+}
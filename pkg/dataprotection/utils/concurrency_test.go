@@ -0,0 +1,125 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestAdmitWorkload(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newWorkload := func(namespace, name string, minutes int, repoName string, nodeNames ...string) Workload {
+		return Workload{
+			Kind:              dptypes.BackupKind,
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(base.Add(time.Duration(minutes) * time.Minute)),
+			RepoName:          repoName,
+			NodeNames:         nodeNames,
+		}
+	}
+	names := func(workloads []Workload) []string {
+		var result []string
+		for _, w := range workloads {
+			result = append(result, w.Namespace+"/"+w.Name)
+		}
+		return result
+	}
+
+	// the namespaces are served in turn
+	running := []Workload{newWorkload("ns1", "r1", 0, "repo", "node1")}
+	pending := []Workload{
+		newWorkload("ns1", "p1", 1, "repo", "node1"),
+		newWorkload("ns1", "p2", 2, "repo", "node2"),
+		newWorkload("ns2", "p3", 3, "repo", "node2"),
+		newWorkload("ns3", "p4", 4, "repo", "node3"),
+		newWorkload("ns2", "p5", 5, "repo", "node3"),
+	}
+	queue := append([]Workload{}, pending...)
+	SortWorkloadsByFairQueue(running, queue)
+	assert.Equal(t, []string{"ns2/p3", "ns3/p4", "ns1/p1", "ns2/p5", "ns1/p2"}, names(queue))
+
+	// no limits
+	assert.Empty(t, AdmitWorkload(ConcurrencyLimits{}, running, pending, pending[0]))
+
+	// global limit
+	limits := ConcurrencyLimits{Global: 3}
+	assert.Empty(t, AdmitWorkload(limits, running, pending, pending[2]))
+	assert.Empty(t, AdmitWorkload(limits, running, pending, pending[3]))
+	assert.NotEmpty(t, AdmitWorkload(limits, running, pending, pending[0]))
+
+	// per repo limit
+	limits = ConcurrencyLimits{PerRepo: 1}
+	assert.NotEmpty(t, AdmitWorkload(limits, running, pending, pending[2]))
+	assert.Empty(t, AdmitWorkload(limits, running, nil, newWorkload("ns2", "p6", 6, "another-repo")))
+
+	// per node limit, the workloads ahead limited by the nodes do not block the candidate
+	limits = ConcurrencyLimits{PerNode: 1}
+	assert.NotEmpty(t, AdmitWorkload(limits, running, pending, pending[0]))
+	assert.Empty(t, AdmitWorkload(limits, running, pending, pending[2]))
+	assert.Empty(t, AdmitWorkload(limits, running, pending, pending[3]))
+	assert.NotEmpty(t, AdmitWorkload(limits, running, pending, pending[1]))
+	assert.NotEmpty(t, AdmitWorkload(limits, running, pending, pending[4]))
+}
+
+func TestWorkloadAdmitter(t *testing.T) {
+	newWorkload := func(name string) Workload {
+		return Workload{Kind: dptypes.BackupKind, Namespace: "ns", Name: name}
+	}
+	w1, w2 := newWorkload("w1"), newWorkload("w2")
+	limits := ConcurrencyLimits{Global: 1}
+	// the cache has not observed the status updates of the admitted workloads yet.
+	workloads := &Workloads{NotStarted: sets.New(w1.Key(), w2.Key())}
+	listWorkloads := func() (*Workloads, error) {
+		return workloads, nil
+	}
+
+	admitter := NewWorkloadAdmitter()
+	message, err := admitter.Admit(limits, listWorkloads, w1)
+	assert.NoError(t, err)
+	assert.Empty(t, message)
+	// the slot is reserved for the admitted workload
+	message, err = admitter.Admit(limits, listWorkloads, w2)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, message)
+	// the admitted workload is admitted again if its status is not updated
+	message, err = admitter.Admit(limits, listWorkloads, w1)
+	assert.NoError(t, err)
+	assert.Empty(t, message)
+
+	// the admitted workload is observed running
+	workloads = &Workloads{Running: []Workload{w1}, NotStarted: sets.New(w2.Key())}
+	message, err = admitter.Admit(limits, listWorkloads, w2)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, message)
+
+	// the admitted workload is observed finished
+	workloads = &Workloads{NotStarted: sets.New(w2.Key())}
+	message, err = admitter.Admit(limits, listWorkloads, w2)
+	assert.NoError(t, err)
+	assert.Empty(t, message)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+// InjectIntegrityRecorder injects the dpchecksum tool, whose datasafed proxy records the checksums of the backup
+// data pushed by the containers of the pod, the records are read by the script built by BuildIntegrityManifestScript.
+// It must be called after the datasafed is injected.
+func InjectIntegrityRecorder(podSpec *corev1.PodSpec) {
+	InjectChecksumTool(podSpec)
+	env := corev1.EnvVar{
+		Name:  dptypes.DPIntegrityChecksumsFile,
+		Value: filepath.Join(datasafedBinMountPath, integrityChecksumsFileName),
+	}
+	injectElements(podSpec, nil, nil, toSlice(env))
+}
+
+// BuildIntegrityManifestScript builds the script to write the integrity manifest of the backup path
+// specified by DP_BACKUP_BASE_PATH by the dpchecksum tool injected by InjectIntegrityRecorder.
+// Only the files whose checksums are recorded as they are pushed are listed in the manifest.
+// The script sets the variable "integrity" to the JSON of the backup status recording the manifest,
+// e.g. {"integrity":{"manifests":[{"path":"/path","digest":"sha256:<hex>"}]}}.
+func BuildIntegrityManifestScript() string {
+	return fmt.Sprintf(`integrity=$("$%s/%s" manifest)`, dptypes.DPDatasafedBinPath, checksumToolName)
+}
+
+// BuildVerifyIntegrityCommand builds the command of the dpchecksum tool in the tools image to verify the backup data
+// against the integrity manifests specified by DP_INTEGRITY_MANIFESTS. It fails once the digest of a manifest
+// mismatches, or a file listed in the manifest is missing or its size or checksum mismatches.
+func BuildVerifyIntegrityCommand() []string {
+	return []string{"/bin/" + checksumToolName, "verify"}
+}
+
+// BuildIntegrityManifestsEnv builds the environment variable of the integrity manifests to verify.
+func BuildIntegrityManifestsEnv(manifests []dpv1alpha1.BackupIntegrityManifest) corev1.EnvVar {
+	lines := make([]string, 0, len(manifests))
+	for _, m := range manifests {
+		lines = append(lines, fmt.Sprintf("%s %s", m.Digest, m.Path))
+	}
+	return corev1.EnvVar{Name: dptypes.DPIntegrityManifests, Value: strings.Join(lines, "\n")}
+}
+
+// GetIntegrityManifest returns the integrity manifest of the backup path, or nil if the backup path
+// is not covered by any manifest, e.g. the backup was created before the manifests are supported.
+func GetIntegrityManifest(backup *dpv1alpha1.Backup, path string) *dpv1alpha1.BackupIntegrityManifest {
+	if backup.Status.Integrity == nil {
+		return nil
+	}
+	path = filepath.Join("/", path)
+	for i := range backup.Status.Integrity.Manifests {
+		m := &backup.Status.Integrity.Manifests[i]
+		if filepath.Join("/", m.Path) == path {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	dpv1alpha1 "github.com/apecloud/kubeblocks/apis/dataprotection/v1alpha1"
+	dptypes "github.com/apecloud/kubeblocks/pkg/dataprotection/types"
+)
+
+func TestIntegrityManifests(t *testing.T) {
+	backup := &dpv1alpha1.Backup{}
+	assert.Nil(t, GetIntegrityManifest(backup, "/ns/backup"))
+
+	backup.Status.Integrity = &dpv1alpha1.BackupIntegrity{
+		Manifests: []dpv1alpha1.BackupIntegrityManifest{
+			{Path: "/ns/backup/pod-0", Digest: "sha256:aaa"},
+			{Path: "/ns/backup/pod-1/", Digest: "sha256:bbb"},
+		},
+	}
+	m := GetIntegrityManifest(backup, "ns/backup/pod-1")
+	assert.NotNil(t, m)
+	assert.Equal(t, "sha256:bbb", m.Digest)
+	assert.Nil(t, GetIntegrityManifest(backup, "/ns/backup/pod-2"))
+
+	env := BuildIntegrityManifestsEnv(backup.Status.Integrity.Manifests)
+	assert.Equal(t, dptypes.DPIntegrityManifests, env.Name)
+	assert.Equal(t, "sha256:aaa /ns/backup/pod-0\nsha256:bbb /ns/backup/pod-1/", env.Value)
+}
+
+func TestInjectIntegrityRecorder(t *testing.T) {
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "backupdata"}}}
+	injectDatasafedInstaller(podSpec)
+	InjectIntegrityRecorder(podSpec)
+
+	// the checksums are recorded by the datasafed proxy of the checksum tool.
+	assert.Len(t, podSpec.InitContainers, 2)
+	assert.Equal(t, checksumToolInstallerName, podSpec.InitContainers[1].Name)
+	assert.Contains(t, podSpec.Containers[0].Env, corev1.EnvVar{
+		Name:  dptypes.DPIntegrityChecksumsFile,
+		Value: "/bin/datasafed/checksums",
+	})
+	assert.Equal(t, `integrity=$("$DP_DATASAFED_BIN_PATH/dpchecksum" manifest)`, BuildIntegrityManifestScript())
+}
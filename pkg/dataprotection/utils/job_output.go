@@ -0,0 +1,85 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EncodeJobOutputCmd is the shell command for the workers to return the data read from the stdin
+// to the controller. The data is gzipped and base64 encoded into the last line of the container logs,
+// so that the workers are not required to write the kubernetes resources.
+const EncodeJobOutputCmd = `{ echo; gzip -c | base64 | tr -d '\n'; echo; }`
+
+// GetJobOutput returns the data written by EncodeJobOutputCmd in the container of the succeeded pod of the job.
+func GetJobOutput(ctx context.Context, cli client.Client, restConfig *rest.Config,
+	job *batchv1.Job, containerName string, opts ...client.ListOption) ([]byte, error) {
+	podList := &corev1.PodList{}
+	opts = append(opts, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name})
+	if err := cli.List(ctx, podList, opts...); err != nil {
+		return nil, err
+	}
+	var pod *corev1.Pod
+	for i := range podList.Items {
+		if podList.Items[i].Status.Phase == corev1.PodSucceeded {
+			pod = &podList.Items[i]
+			break
+		}
+	}
+	if pod == nil {
+		return nil, fmt.Errorf("the succeeded pod of job %s/%s is not found", job.Namespace, job.Name)
+	}
+	typedCli, err := corev1client.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := typedCli.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: containerName}).DoRaw(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeJobOutput(logs)
+}
+
+// DecodeJobOutput decodes the data from the last non-empty line of the logs.
+func DecodeJobOutput(logs []byte) ([]byte, error) {
+	lines := strings.Split(strings.TrimSpace(string(logs)), "\n")
+	encoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the job output: %s", err.Error())
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the job output: %s", err.Error())
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
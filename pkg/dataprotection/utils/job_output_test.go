@@ -0,0 +1,46 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeJobOutput(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := gzip.NewWriter(buf)
+	_, err := writer.Write([]byte(`{"kind":"List"}`))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	// the logs printed before the output are ignored.
+	logs := "pulling /kube-resources.json\n\n" + base64.StdEncoding.EncodeToString(buf.Bytes()) + "\n"
+	data, err := DecodeJobOutput([]byte(logs))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind":"List"}`, string(data))
+
+	_, err = DecodeJobOutput([]byte("failed to pull the file\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (C) 2022-2024 ApeCloud Co., Ltd
+
+This file is part of KubeBlocks project
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package utils
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBandwidthLimit(t *testing.T) {
+	limit, err := ParseBandwidthLimit("")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), limit)
+
+	limit, err = ParseBandwidthLimit("10Mi")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10*1024*1024), limit)
+
+	_, err = ParseBandwidthLimit("fast")
+	assert.Error(t, err)
+	_, err = ParseBandwidthLimit("0")
+	assert.Error(t, err)
+}
+
+func TestRateLimitedReader(t *testing.T) {
+	data := strings.Repeat("a", 300)
+	start := time.Now()
+	out, err := io.ReadAll(NewRateLimitedReader(context.Background(), strings.NewReader(data), 100))
+	assert.NoError(t, err)
+	assert.Equal(t, data, string(out))
+	// the first 100 bytes are read in burst, the rest 200 bytes take about 2 seconds.
+	assert.GreaterOrEqual(t, time.Since(start), 1500*time.Millisecond)
+
+	// the read is stopped once the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = io.ReadAll(NewRateLimitedReader(ctx, strings.NewReader(data), 100))
+	assert.Error(t, err)
+}
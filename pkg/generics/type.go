@@ -70,6 +70,7 @@ var PodSignature = func(_ corev1.Pod, _ *corev1.Pod, _ corev1.PodList, _ *corev1
 var EventSignature = func(_ corev1.Event, _ *corev1.Event, _ corev1.EventList, _ *corev1.EventList) {}
 var ConfigMapSignature = func(_ corev1.ConfigMap, _ *corev1.ConfigMap, _ corev1.ConfigMapList, _ *corev1.ConfigMapList) {}
 var EndpointsSignature = func(_ corev1.Endpoints, _ *corev1.Endpoints, _ corev1.EndpointsList, _ *corev1.EndpointsList) {}
+var NamespaceSignature = func(_ corev1.Namespace, _ *corev1.Namespace, _ corev1.NamespaceList, _ *corev1.NamespaceList) {}
 
 var InstanceSetSignature = func(_ workloads.InstanceSet, _ *workloads.InstanceSet, _ workloads.InstanceSetList, _ *workloads.InstanceSetList) {
 }
@@ -99,6 +100,18 @@ var ComponentVersionSignature = func(appsv1.ComponentVersion, *appsv1.ComponentV
 }
 var OpsDefinitionSignature = func(_ appsv1alpha1.OpsDefinition, _ *appsv1alpha1.OpsDefinition, _ appsv1alpha1.OpsDefinitionList, _ *appsv1alpha1.OpsDefinitionList) {
 }
+var OpsApprovalPolicySignature = func(_ appsv1alpha1.OpsApprovalPolicy, _ *appsv1alpha1.OpsApprovalPolicy, _ appsv1alpha1.OpsApprovalPolicyList, _ *appsv1alpha1.OpsApprovalPolicyList) {
+}
+var OpsTemplateSignature = func(_ appsv1alpha1.OpsTemplate, _ *appsv1alpha1.OpsTemplate, _ appsv1alpha1.OpsTemplateList, _ *appsv1alpha1.OpsTemplateList) {
+}
+var OpsScheduleSignature = func(_ appsv1alpha1.OpsSchedule, _ *appsv1alpha1.OpsSchedule, _ appsv1alpha1.OpsScheduleList, _ *appsv1alpha1.OpsScheduleList) {
+}
+var ComponentAutoscalerSignature = func(_ appsv1alpha1.ComponentAutoscaler, _ *appsv1alpha1.ComponentAutoscaler, _ appsv1alpha1.ComponentAutoscalerList, _ *appsv1alpha1.ComponentAutoscalerList) {
+}
+var ResourceRecommenderSignature = func(_ appsv1alpha1.ResourceRecommender, _ *appsv1alpha1.ResourceRecommender, _ appsv1alpha1.ResourceRecommenderList, _ *appsv1alpha1.ResourceRecommenderList) {
+}
+var StorageAutoscalingPolicySignature = func(_ appsv1alpha1.StorageAutoscalingPolicy, _ *appsv1alpha1.StorageAutoscalingPolicy, _ appsv1alpha1.StorageAutoscalingPolicyList, _ *appsv1alpha1.StorageAutoscalingPolicyList) {
+}
 var OpsRequestSignature = func(_ appsv1alpha1.OpsRequest, _ *appsv1alpha1.OpsRequest, _ appsv1alpha1.OpsRequestList, _ *appsv1alpha1.OpsRequestList) {
 }
 var ConfigConstraintSignature = func(_ appsv1beta1.ConfigConstraint, _ *appsv1beta1.ConfigConstraint, _ appsv1beta1.ConfigConstraintList, _ *appsv1beta1.ConfigConstraintList) {
@@ -114,6 +127,8 @@ var BackupScheduleSignature = func(_ dpv1alpha1.BackupSchedule, _ *dpv1alpha1.Ba
 }
 var RestoreSignature = func(_ dpv1alpha1.Restore, _ *dpv1alpha1.Restore, _ dpv1alpha1.RestoreList, _ *dpv1alpha1.RestoreList) {
 }
+var BackupVerificationSignature = func(_ dpv1alpha1.BackupVerification, _ *dpv1alpha1.BackupVerification, _ dpv1alpha1.BackupVerificationList, _ *dpv1alpha1.BackupVerificationList) {
+}
 var ActionSetSignature = func(_ dpv1alpha1.ActionSet, _ *dpv1alpha1.ActionSet, _ dpv1alpha1.ActionSetList, _ *dpv1alpha1.ActionSetList) {
 }
 var BackupRepoSignature = func(_ dpv1alpha1.BackupRepo, _ *dpv1alpha1.BackupRepo, _ dpv1alpha1.BackupRepoList, _ *dpv1alpha1.BackupRepoList) {
@@ -44,6 +44,9 @@ type ActionRequest struct {
 	NonBlocking    *bool             `json:"nonBlocking,omitempty"`
 	TimeoutSeconds *int32            `json:"timeoutSeconds,omitempty"`
 	RetryPolicy    *RetryPolicy      `json:"retryPolicy,omitempty"`
+	// ReportProgress indicates that the non-blocking action reports its progress by the last line of its output,
+	// which is returned along with the in-progress error before the action finishes.
+	ReportProgress *bool `json:"reportProgress,omitempty"`
 }
 
 type ActionResponse struct {
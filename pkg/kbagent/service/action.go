@@ -20,9 +20,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"sync"
 
@@ -59,6 +61,39 @@ type runningAction struct {
 	stdoutChan chan []byte
 	stderrChan chan []byte
 	errChan    chan error
+	progress   *lastLineWriter
+}
+
+// lastLineWriter keeps the last non-empty line written to it, which is taken as the progress reported by
+// a non-blocking action.
+type lastLineWriter struct {
+	mutex   sync.Mutex
+	partial []byte
+	last    []byte
+}
+
+func (w *lastLineWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if line := bytes.TrimSpace(w.partial[:i]); len(line) > 0 {
+			w.last = append([]byte{}, line...)
+		}
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *lastLineWriter) lastLine() []byte {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.last
 }
 
 var _ Service = &actionService{}
@@ -92,10 +127,11 @@ func (s *actionService) decode(payload []byte) (*proto.ActionRequest, error) {
 }
 
 func (s *actionService) encode(out []byte, err error) []byte {
-	rsp := &proto.ActionResponse{}
-	if err == nil {
-		rsp.Output = out
-	} else {
+	// the output is returned along with the error for the in-progress action, which is the progress reported so far
+	rsp := &proto.ActionResponse{
+		Output: out,
+	}
+	if err != nil {
 		rsp.Error = proto.Error2Type(err)
 		rsp.Message = err.Error()
 	}
@@ -127,7 +163,16 @@ func (s *actionService) handleExecActionNonBlocking(ctx context.Context, req *pr
 
 	running, ok := s.runningActions[req.Action]
 	if !ok {
-		stdoutChan, stderrChan, errChan, err := runCommandNonBlocking(ctx, action.Exec, req.Parameters, req.TimeoutSeconds)
+		var (
+			progress     *lastLineWriter
+			outputWriter io.Writer
+		)
+		if req.ReportProgress != nil && *req.ReportProgress {
+			// the action reports its progress by the last line of the output before it finishes
+			progress = &lastLineWriter{}
+			outputWriter = progress
+		}
+		stdoutChan, stderrChan, errChan, err := runCommandNonBlockingWithOutput(ctx, action.Exec, req.Parameters, req.TimeoutSeconds, outputWriter)
 		if err != nil {
 			return nil, err
 		}
@@ -135,11 +180,15 @@ func (s *actionService) handleExecActionNonBlocking(ctx context.Context, req *pr
 			stdoutChan: stdoutChan,
 			stderrChan: stderrChan,
 			errChan:    errChan,
+			progress:   progress,
 		}
 		s.runningActions[req.Action] = running
 	}
 	err := gather(running.errChan)
 	if err == nil {
+		if running.progress != nil {
+			return running.progress.lastLine(), proto.ErrInProgress
+		}
 		return nil, proto.ErrInProgress
 	}
 	delete(s.runningActions, req.Action)
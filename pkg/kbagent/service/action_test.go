@@ -20,10 +20,91 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>.
 package service
 
 import (
+	"errors"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+
+	"github.com/apecloud/kubeblocks/pkg/kbagent/proto"
 )
 
 var _ = Describe("action", func() {
 	Context("action", func() {
+		It("last line writer", func() {
+			w := &lastLineWriter{}
+			Expect(w.lastLine()).Should(BeNil())
+
+			_, _ = w.Write([]byte("10\n2"))
+			Expect(w.lastLine()).Should(Equal([]byte("10")))
+
+			_, _ = w.Write([]byte("0\n\n  \n"))
+			Expect(w.lastLine()).Should(Equal([]byte("20")))
+
+			_, _ = w.Write([]byte("30"))
+			Expect(w.lastLine()).Should(Equal([]byte("20")))
+		})
+
+		It("non-blocking with progress", func() {
+			service, err := newActionService(logr.Discard(), []proto.Action{
+				{
+					Name: "preScaleIn",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo 10; echo 50; sleep 1; echo 100"},
+					},
+				},
+			})
+			Expect(err).Should(BeNil())
+
+			nonBlocking, reportProgress := true, true
+			req := &proto.ActionRequest{
+				Action:         "preScaleIn",
+				NonBlocking:    &nonBlocking,
+				ReportProgress: &reportProgress,
+			}
+			output, err := service.handleRequest(ctx, req)
+			Expect(errors.Is(err, proto.ErrInProgress)).Should(BeTrue())
+
+			Eventually(func(g Gomega) {
+				output, err = service.handleRequest(ctx, req)
+				g.Expect(errors.Is(err, proto.ErrInProgress)).Should(BeTrue())
+				g.Expect(output).Should(Equal([]byte("50")))
+			}).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				output, err = service.handleRequest(ctx, req)
+				g.Expect(err).Should(BeNil())
+				g.Expect(output).Should(Equal([]byte("10\n50\n100\n")))
+			}).WithTimeout(5 * time.Second).Should(Succeed())
+		})
+
+		It("non-blocking without progress", func() {
+			service, err := newActionService(logr.Discard(), []proto.Action{
+				{
+					Name: "postProvision",
+					Exec: &proto.ExecAction{
+						Commands: []string{"/bin/bash", "-c", "echo 10; sleep 1; echo 100"},
+					},
+				},
+			})
+			Expect(err).Should(BeNil())
+
+			nonBlocking := true
+			req := &proto.ActionRequest{
+				Action:      "postProvision",
+				NonBlocking: &nonBlocking,
+			}
+			output, err := service.handleRequest(ctx, req)
+			Expect(errors.Is(err, proto.ErrInProgress)).Should(BeTrue())
+			Expect(output).Should(BeNil())
+
+			Eventually(func(g Gomega) {
+				output, err = service.handleRequest(ctx, req)
+				g.Expect(err).Should(BeNil())
+				g.Expect(output).Should(Equal([]byte("10\n100\n")))
+			}).WithTimeout(5 * time.Second).Should(Succeed())
+		})
 	})
 })
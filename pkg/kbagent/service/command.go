@@ -70,9 +70,20 @@ func runCommand(ctx context.Context, action *proto.ExecAction, parameters map[st
 }
 
 func runCommandNonBlocking(ctx context.Context, action *proto.ExecAction, parameters map[string]string, timeout *int32) (chan []byte, chan []byte, chan error, error) {
+	return runCommandNonBlockingWithOutput(ctx, action, parameters, timeout, nil)
+}
+
+// runCommandNonBlockingWithOutput is the same as runCommandNonBlocking, except that the stdout is also copied to
+// the @outputWriter, so the output can be inspected before the command finishes.
+func runCommandNonBlockingWithOutput(ctx context.Context, action *proto.ExecAction, parameters map[string]string, timeout *int32,
+	outputWriter io.Writer) (chan []byte, chan []byte, chan error, error) {
 	stdoutBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
 	stderrBuf := bytes.NewBuffer(make([]byte, 0, defaultBufferSize))
-	execErrorChan, err := runCommandX(ctx, action, parameters, timeout, nil, stdoutBuf, stderrBuf)
+	var stdoutWriter io.Writer = stdoutBuf
+	if outputWriter != nil {
+		stdoutWriter = io.MultiWriter(stdoutBuf, outputWriter)
+	}
+	execErrorChan, err := runCommandX(ctx, action, parameters, timeout, nil, stdoutWriter, stderrBuf)
 	if err != nil {
 		return nil, nil, nil, err
 	}